@@ -146,11 +146,19 @@ For Terminology refer to the [Scenario Description](https://www.project-piper.io
 				defer vaultClient.MustRevokeToken()
 			}
 
+			if GeneralConfig.DryRun {
+				PrintStepPlan(STEP_NAME, &metadata, stepConfig)
+				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
+				return
+			}
+
 			stepTelemetryData := telemetry.CustomData{}
 			stepTelemetryData.ErrorCode = "1"
 			handler := func() {
 				commonPipelineEnvironment.persist(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
@@ -2,10 +2,12 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	netHttp "net/http"
 	"os"
+	"path"
 	"strings"
 	"text/template"
 	"time"
@@ -42,6 +44,7 @@ type gitRepository interface {
 }
 
 type gitWorktree interface {
+	Add(string) (plumbing.Hash, error)
 	Checkout(*git.CheckoutOptions) error
 	Commit(string, *git.CommitOptions) (plumbing.Hash, error)
 }
@@ -108,6 +111,21 @@ func artifactPrepareVersion(config artifactPrepareVersionOptions, telemetryData
 
 var sshAgentAuth = ssh.NewSSHAgentAuth
 
+// versionTagLog provides the latest version tag and the commits since this tag, it can be replaced in tests
+var versionTagLog = latestVersionTagLog
+
+// moduleVersion contains the version information of a module of a monorepo
+type moduleVersion struct {
+	Name            string `json:"name"`
+	BuildTool       string `json:"buildTool"`
+	BuildDescriptor string `json:"buildDescriptor"`
+	OriginalVersion string `json:"originalVersion"`
+	Version         string `json:"version"`
+	Tag             string `json:"tag,omitempty"`
+	// released indicates that a new version has been created which needs to be tagged
+	released bool
+}
+
 func runArtifactPrepareVersion(config *artifactPrepareVersionOptions, telemetryData *telemetry.CustomData, commonPipelineEnvironment *artifactPrepareVersionCommonPipelineEnvironment, artifact versioning.Artifact, utils artifactPrepareVersionUtils, repository gitRepository, getWorktree func(gitRepository) (gitWorktree, error)) error {
 
 	telemetryData.BuildTool = config.BuildTool
@@ -134,6 +152,17 @@ func runArtifactPrepareVersion(config *artifactPrepareVersionOptions, telemetryD
 		}
 	}
 
+	var modules []versioning.Module
+	if len(config.ModuleDescriptors) > 0 {
+		moduleOpts := artifactOpts
+		modules, err = versioning.DiscoverModules(config.ModuleDescriptors, &moduleOpts, utils)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return errors.Wrap(err, "failed to discover modules")
+		}
+		log.Entry().Infof("%v module(s) found matching %v", len(modules), config.ModuleDescriptors)
+	}
+
 	// support former groovy versioning template and translate into new options
 	if len(config.VersioningTemplate) > 0 {
 		config.VersioningType, _, config.IncludeCommitID = templateCompatibility(config.VersioningTemplate)
@@ -159,6 +188,7 @@ func runArtifactPrepareVersion(config *artifactPrepareVersionOptions, telemetryD
 	commonPipelineEnvironment.git.headCommitID = gitCommitID
 	newVersion := version
 	now := time.Now()
+	var moduleVersions []moduleVersion
 
 	if config.VersioningType == "cloud" || config.VersioningType == "cloud_noTag" {
 		// make sure that versioning does not create tags (when set to "cloud")
@@ -207,6 +237,11 @@ func runArtifactPrepareVersion(config *artifactPrepareVersionOptions, telemetryD
 			}
 		}
 
+		moduleVersions, err = versionModules(config, modules, repository, nil, version, newVersion, gitCommitID, now, utils)
+		if err != nil {
+			return err
+		}
+
 		if config.VersioningType == "cloud" {
 			certs, err := certutils.CertificateDownload(config.CustomTLSCertificateLinks, utils)
 			if err != nil {
@@ -214,7 +249,7 @@ func runArtifactPrepareVersion(config *artifactPrepareVersionOptions, telemetryD
 			}
 
 			// commit changes and push to repository (including new version tag)
-			gitCommitID, err = pushChanges(config, newVersion, repository, worktree, now, certs)
+			gitCommitID, err = pushChanges(config, newVersion, repository, worktree, now, certs, moduleVersionTags(config.TagPrefix, moduleVersions)...)
 			if err != nil {
 				if strings.Contains(fmt.Sprint(err), "reference already exists") {
 					log.SetErrorCategory(log.ErrorCustom)
@@ -222,6 +257,11 @@ func runArtifactPrepareVersion(config *artifactPrepareVersionOptions, telemetryD
 				return errors.Wrapf(err, "failed to push changes for version '%v'", newVersion)
 			}
 		}
+	} else if config.VersioningType == "semantic-release" {
+		newVersion, gitCommitID, moduleVersions, err = runSemanticRelease(config, artifact, utils, &artifactOpts, modules, repository, getWorktree, gitCommit, version, now)
+		if err != nil {
+			return err
+		}
 	} else {
 		// propagate version information to additional descriptors
 		if len(config.AdditionalTargetTools) > 0 {
@@ -230,6 +270,11 @@ func runArtifactPrepareVersion(config *artifactPrepareVersionOptions, telemetryD
 				return err
 			}
 		}
+
+		moduleVersions, err = versionModules(config, modules, repository, nil, version, newVersion, gitCommitID, now, utils)
+		if err != nil {
+			return err
+		}
 	}
 
 	log.Entry().Infof("New version: '%v'", newVersion)
@@ -238,6 +283,17 @@ func runArtifactPrepareVersion(config *artifactPrepareVersionOptions, telemetryD
 	commonPipelineEnvironment.artifactVersion = newVersion
 	commonPipelineEnvironment.originalArtifactVersion = version
 
+	if len(moduleVersions) > 0 {
+		for _, module := range moduleVersions {
+			log.Entry().Infof("New version of module '%v': '%v'", module.Name, module.Version)
+		}
+		artifactVersions, err := json.Marshal(moduleVersions)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal module versions")
+		}
+		commonPipelineEnvironment.custom.artifactVersions = string(artifactVersions)
+	}
+
 	gitCommitMessages := strings.Split(gitCommitMessage, "\n")
 	commitMessage := truncateString(gitCommitMessages[0], 50) // Github recommends to keep commit message title less than 50 chars
 
@@ -359,27 +415,39 @@ func initializeWorktree(gitCommit plumbing.Hash, worktree gitWorktree) error {
 	return nil
 }
 
-func pushChanges(config *artifactPrepareVersionOptions, newVersion string, repository gitRepository, worktree gitWorktree, t time.Time, certs []byte) (string, error) {
+// pushChanges commits the changes and pushes the version tag together with the tags of the modules.
+// In case newVersion is empty only the module tags are pushed.
+func pushChanges(config *artifactPrepareVersionOptions, newVersion string, repository gitRepository, worktree gitWorktree, t time.Time, certs []byte, moduleTags ...string) (string, error) {
 
 	var commitID string
 
-	commit, err := addAndCommit(config, worktree, newVersion, t)
+	tags := []string{}
+	commitVersion := newVersion
+	if len(newVersion) > 0 {
+		tags = append(tags, fmt.Sprintf("%v%v", config.TagPrefix, newVersion))
+	} else {
+		commitVersion = strings.Join(moduleTags, ", ")
+	}
+	tags = append(tags, moduleTags...)
+
+	commit, err := addAndCommit(config, worktree, commitVersion, t)
 	if err != nil {
 		return commit.String(), err
 	}
 
 	commitID = commit.String()
 
-	tag := fmt.Sprintf("%v%v", config.TagPrefix, newVersion)
-	_, err = repository.CreateTag(tag, commit, nil)
-	if err != nil {
-		return commitID, err
+	refSpecs := []gitConfig.RefSpec{}
+	for _, tag := range tags {
+		_, err = repository.CreateTag(tag, commit, nil)
+		if err != nil {
+			return commitID, err
+		}
+		refSpecs = append(refSpecs, gitConfig.RefSpec(fmt.Sprintf("refs/tags/%v:refs/tags/%v", tag, tag)))
 	}
 
-	ref := gitConfig.RefSpec(fmt.Sprintf("refs/tags/%v:refs/tags/%v", tag, tag))
-
 	pushOptions := git.PushOptions{
-		RefSpecs: []gitConfig.RefSpec{gitConfig.RefSpec(ref)},
+		RefSpecs: refSpecs,
 		CABundle: certs,
 	}
 
@@ -567,3 +635,391 @@ func propagateVersion(config *artifactPrepareVersionOptions, utils artifactPrepa
 	}
 	return nil
 }
+
+// runSemanticRelease calculates the next version based on the Conventional Commits since the latest version tag,
+// writes it into the build descriptors, updates the changelog and pushes the changes together with the new version tag
+func runSemanticRelease(config *artifactPrepareVersionOptions, artifact versioning.Artifact, utils artifactPrepareVersionUtils, artifactOpts *versioning.Options, modules []versioning.Module, repository gitRepository, getWorktree func(gitRepository) (gitWorktree, error), gitCommit plumbing.Hash, version string, now time.Time) (string, string, []moduleVersion, error) {
+	gitCommitID := gitCommit.String()
+
+	newVersion, commits, release, err := nextSemanticVersion(repository, config.TagPrefix, "", nil, version, config.PreReleaseChannel)
+	if err != nil {
+		return "", gitCommitID, nil, err
+	}
+	independentModules := len(modules) > 0 && config.ModuleVersioning == "independent"
+	if !release && !independentModules {
+		moduleVersions, err := versionModules(config, modules, repository, nil, version, "", gitCommitID, now, utils)
+		return newVersion, gitCommitID, moduleVersions, err
+	}
+
+	createTag := true
+	provider, err := utils.GetConfigProvider()
+	if err != nil {
+		log.Entry().WithError(err).Warning("Cannot infer config from CI environment")
+	} else if provider.IsPullRequest() {
+		createTag = false
+	}
+	if config.IsOptimizedAndScheduled {
+		createTag = false
+	}
+
+	worktree, err := getWorktree(repository)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return "", gitCommitID, nil, errors.Wrap(err, "failed to retrieve git worktree")
+	}
+	err = initializeWorktree(gitCommit, worktree)
+	if err != nil {
+		return "", gitCommitID, nil, err
+	}
+	// changelog files only need to be staged in case they are committed
+	var stagingWorktree gitWorktree
+	if createTag {
+		stagingWorktree = worktree
+	}
+
+	releaseVersion := ""
+	if release {
+		releaseVersion = newVersion
+		if newVersion != version {
+			err = artifact.SetVersion(newVersion)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return "", gitCommitID, nil, errors.Wrap(err, "failed to write version")
+			}
+		}
+
+		// propagate version information to additional descriptors
+		if len(config.AdditionalTargetTools) > 0 {
+			err = propagateVersion(config, utils, artifactOpts, newVersion, gitCommitID, now)
+			if err != nil {
+				return "", gitCommitID, nil, err
+			}
+		}
+
+		err = updateChangelogFile(config.ChangelogFile, newVersion, commits, utils, stagingWorktree, now)
+		if err != nil {
+			return "", gitCommitID, nil, err
+		}
+	}
+
+	moduleVersions, err := versionModules(config, modules, repository, stagingWorktree, version, releaseVersion, gitCommitID, now, utils)
+	if err != nil {
+		return "", gitCommitID, nil, err
+	}
+
+	if createTag {
+		moduleTags := moduleVersionTags(config.TagPrefix, moduleVersions)
+		if len(releaseVersion) == 0 && len(moduleTags) == 0 {
+			return newVersion, gitCommitID, moduleVersions, nil
+		}
+
+		certs, err := certutils.CertificateDownload(config.CustomTLSCertificateLinks, utils)
+		if err != nil {
+			return "", gitCommitID, nil, err
+		}
+
+		// commit changes and push to repository (including new version tags)
+		gitCommitID, err = pushChanges(config, releaseVersion, repository, worktree, now, certs, moduleTags...)
+		if err != nil {
+			if strings.Contains(fmt.Sprint(err), "reference already exists") {
+				log.SetErrorCategory(log.ErrorCustom)
+			}
+			return "", gitCommitID, nil, errors.Wrapf(err, "failed to push changes for version '%v'", newVersion)
+		}
+	}
+	return newVersion, gitCommitID, moduleVersions, nil
+}
+
+// nextSemanticVersion calculates the next version based on the Conventional Commits since the latest tag with the prefix,
+// only commits changing files within the directory are considered. False is returned in case no release is required.
+// With a pre-release channel the next pre-release version of this channel is calculated, e.g. 1.3.0-rc.2.
+func nextSemanticVersion(repository gitRepository, tagPrefix, directory string, changes *moduleChanges, version, preReleaseChannel string) (string, []versioning.ConventionalCommit, bool, error) {
+	latestTag, commits, err := versionTagLog(repository, tagPrefix, directory, changes)
+	if err != nil {
+		return "", nil, false, errors.Wrap(err, "failed to retrieve commits since latest version tag")
+	}
+
+	conventionalCommits := []versioning.ConventionalCommit{}
+	for _, commit := range commits {
+		if conventionalCommit, ok := versioning.ParseConventionalCommit(commit.Hash.String(), commit.Message); ok {
+			conventionalCommits = append(conventionalCommits, conventionalCommit)
+		}
+	}
+
+	if len(latestTag) == 0 {
+		log.Entry().Infof("no version tag with prefix '%v' found, releasing initial version '%v'", tagPrefix, version)
+		return version, conventionalCommits, true, nil
+	}
+
+	latestVersion := strings.TrimPrefix(latestTag, tagPrefix)
+	release := versioning.NextReleaseType(conventionalCommits)
+	log.Entry().Infof("%v commit(s) since tag '%v' require a %v release", len(commits), latestTag, release)
+	if release == versioning.ReleaseNone {
+		log.Entry().Infof("no release relevant changes since tag '%v'", latestTag)
+		return latestVersion, conventionalCommits, false, nil
+	}
+	newVersion, err := versioning.NextVersion(latestVersion, release, preReleaseChannel)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return "", nil, false, errors.Wrapf(err, "failed to increment version of tag '%v'", latestTag)
+	}
+	return newVersion, conventionalCommits, true, nil
+}
+
+// versionModules versions the modules of a monorepo. With consistent versioning the modules get the version of the main artifact,
+// with independent versioning each module is versioned based on its own version. An empty newVersion indicates that no new version is released.
+func versionModules(config *artifactPrepareVersionOptions, modules []versioning.Module, repository gitRepository, stagingWorktree gitWorktree, version, newVersion, gitCommitID string, now time.Time, utils artifactPrepareVersionUtils) ([]moduleVersion, error) {
+	moduleVersions := []moduleVersion{}
+	independent := config.ModuleVersioning == "independent"
+	changes := newModuleChanges(modules)
+
+	for _, module := range modules {
+		originalVersion, err := module.Artifact.GetVersion()
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return nil, errors.Wrapf(err, "failed to retrieve version of module '%v'", module.Name)
+		}
+		current := moduleVersion{
+			Name:            module.Name,
+			BuildTool:       module.BuildTool,
+			BuildDescriptor: module.BuildDescriptor,
+			OriginalVersion: originalVersion,
+			Version:         originalVersion,
+		}
+
+		switch config.VersioningType {
+		case "cloud", "cloud_noTag":
+			baseVersion := version
+			if independent {
+				baseVersion = originalVersion
+			}
+			current.Version, err = calculateCloudVersion(module.Artifact, config, baseVersion, gitCommitID, now)
+			if err != nil {
+				return nil, err
+			}
+			current.released = true
+		case "semantic-release":
+			if !independent {
+				if len(newVersion) > 0 {
+					current.Version, current.released = newVersion, true
+				}
+				break
+			}
+			var commits []versioning.ConventionalCommit
+			current.Version, commits, current.released, err = nextSemanticVersion(repository, module.Tag(config.TagPrefix, ""), module.Name, changes, originalVersion, config.PreReleaseChannel)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to calculate version of module '%v'", module.Name)
+			}
+			if current.released && len(config.ChangelogFile) > 0 {
+				err = updateChangelogFile(path.Join(module.Name, config.ChangelogFile), current.Version, commits, utils, stagingWorktree, now)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if current.released && current.Version != originalVersion {
+			err = module.Artifact.SetVersion(current.Version)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return nil, errors.Wrapf(err, "failed to write version of module '%v'", module.Name)
+			}
+		}
+		moduleVersions = append(moduleVersions, current)
+	}
+	return moduleVersions, nil
+}
+
+// moduleVersionTags returns the tags of the released module versions, e.g. services/api/v1.2.3
+func moduleVersionTags(tagPrefix string, moduleVersions []moduleVersion) []string {
+	tags := []string{}
+	for i, module := range moduleVersions {
+		if !module.released {
+			continue
+		}
+		moduleVersions[i].Tag = fmt.Sprintf("%v/%v%v", module.Name, tagPrefix, module.Version)
+		tags = append(tags, moduleVersions[i].Tag)
+	}
+	return tags
+}
+
+func updateChangelogFile(changelogFile, version string, commits []versioning.ConventionalCommit, utils artifactPrepareVersionUtils, stagingWorktree gitWorktree, now time.Time) error {
+	if len(changelogFile) == 0 {
+		return nil
+	}
+	changelog := ""
+	if exists, _ := utils.FileExists(changelogFile); exists {
+		content, err := utils.FileRead(changelogFile)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read changelog %v", changelogFile)
+		}
+		changelog = string(content)
+	}
+	changelog = versioning.UpdateChangelog(changelog, versioning.Changelog(version, now, commits))
+	if err := utils.FileWrite(changelogFile, []byte(changelog), 0666); err != nil {
+		return errors.Wrapf(err, "failed to write changelog %v", changelogFile)
+	}
+	if stagingWorktree != nil {
+		if _, err := stagingWorktree.Add(changelogFile); err != nil {
+			return errors.Wrapf(err, "failed to add %v", changelogFile)
+		}
+	}
+	return nil
+}
+
+// latestVersionTagLog provides the tag with the highest semantic version reachable from HEAD and the commits since this tag.
+// Like with git describe, tags of other branches are not considered. In case no version tag exists, all commits reachable from HEAD are provided.
+// With a directory only the commits changing files within this directory are provided, the changes of the commits are
+// shared by all modules in order to determine them only once per commit.
+func latestVersionTagLog(repository gitRepository, tagPrefix, directory string, changes *moduleChanges) (string, []*object.Commit, error) {
+	repo, ok := repository.(*git.Repository)
+	if !ok {
+		return "", nil, fmt.Errorf("git history not available")
+	}
+
+	tags, err := repo.Tags()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to retrieve tags")
+	}
+	reachable, err := reachableFromHead(repo)
+	if err != nil {
+		return "", nil, err
+	}
+	latestTag, latestVersion := "", ""
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		tag := ref.Name().Short()
+		tagVersion := strings.TrimPrefix(tag, tagPrefix)
+		if !strings.HasPrefix(tag, tagPrefix) || !versioning.IsSemanticVersion(tagVersion) {
+			return nil
+		}
+		// annotated tags refer to a tag object instead of the commit
+		commitHash := ref.Hash()
+		if tagObject, err := repo.TagObject(commitHash); err == nil {
+			commitHash = tagObject.Target
+		}
+		if !reachable[commitHash] {
+			log.Entry().Debugf("Tag %v is not reachable from HEAD, ignoring it", tag)
+			return nil
+		}
+		if len(latestTag) > 0 {
+			if result, _ := versioning.CompareSemanticVersions(tagVersion, latestVersion); result <= 0 {
+				return nil
+			}
+		}
+		latestTag, latestVersion = tag, tagVersion
+		return nil
+	})
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to retrieve tags")
+	}
+
+	var commitIter object.CommitIter
+	if len(latestTag) > 0 {
+		commitIter, err = gitUtils.LogRange(repo, latestTag, "HEAD")
+	} else {
+		commitIter, err = repo.Log(&git.LogOptions{})
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	if len(directory) > 0 && changes == nil {
+		changes = &moduleChanges{directories: []string{directory}, changed: map[plumbing.Hash]map[string]bool{}}
+	}
+	commits := []*object.Commit{}
+	err = commitIter.ForEach(func(commit *object.Commit) error {
+		if len(directory) > 0 {
+			changed, err := changes.changes(commit, directory)
+			if err != nil {
+				return err
+			}
+			if !changed {
+				return nil
+			}
+		}
+		commits = append(commits, commit)
+		return nil
+	})
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to retrieve commits")
+	}
+	return latestTag, commits, nil
+}
+
+// reachableFromHead returns the hashes of all commits reachable from HEAD
+func reachableFromHead(repo *git.Repository) (map[plumbing.Hash]bool, error) {
+	commitIter, err := repo.Log(&git.LogOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve commits")
+	}
+	reachable := map[plumbing.Hash]bool{}
+	err = commitIter.ForEach(func(commit *object.Commit) error {
+		reachable[commit.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve commits")
+	}
+	return reachable, nil
+}
+
+// moduleChanges determines which module directories are changed by a commit. The module directories are compared
+// with the ones of the first parent once per commit, i.e. the whole tree is not diffed and the result is shared by all modules.
+type moduleChanges struct {
+	directories []string
+	changed     map[plumbing.Hash]map[string]bool
+}
+
+func newModuleChanges(modules []versioning.Module) *moduleChanges {
+	directories := []string{}
+	for _, module := range modules {
+		directories = append(directories, module.Name)
+	}
+	return &moduleChanges{directories: directories, changed: map[plumbing.Hash]map[string]bool{}}
+}
+
+// changes checks if the commit changes files within the directory
+func (m *moduleChanges) changes(commit *object.Commit, directory string) (bool, error) {
+	changed, ok := m.changed[commit.Hash]
+	if !ok {
+		var err error
+		if changed, err = changedDirectories(commit, m.directories); err != nil {
+			return false, err
+		}
+		m.changed[commit.Hash] = changed
+	}
+	return changed[directory], nil
+}
+
+func changedDirectories(commit *object.Commit, directories []string) (map[string]bool, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve tree of commit %v", commit.Hash)
+	}
+	var parentTree *object.Tree
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to retrieve parent of commit %v", commit.Hash)
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil, errors.Wrapf(err, "failed to retrieve tree of commit %v", parent.Hash)
+		}
+	}
+	changed := map[string]bool{}
+	for _, directory := range directories {
+		changed[directory] = directoryHash(tree, directory) != directoryHash(parentTree, directory)
+	}
+	return changed, nil
+}
+
+// directoryHash returns the hash of the directory within the tree, the zero hash in case it does not exist
+func directoryHash(tree *object.Tree, directory string) plumbing.Hash {
+	if tree == nil {
+		return plumbing.ZeroHash
+	}
+	entry, err := tree.FindEntry(directory)
+	if err != nil {
+		return plumbing.ZeroHash
+	}
+	return entry.Hash
+}
@@ -19,9 +19,10 @@ import (
 )
 
 type artifactPrepareVersionOptions struct {
-	AdditionalTargetTools       []string `json:"additionalTargetTools,omitempty" validate:"possible-values=custom docker dub golang gradle helm maven mta npm pip sbt yarn"`
+	AdditionalTargetTools       []string `json:"additionalTargetTools,omitempty" validate:"possible-values=cargo custom docker dotnet dub golang gradle helm maven mta npm pip sbt yarn"`
 	AdditionalTargetDescriptors []string `json:"additionalTargetDescriptors,omitempty"`
-	BuildTool                   string   `json:"buildTool,omitempty" validate:"possible-values=custom docker dub golang gradle helm maven mta npm pip sbt yarn CAP"`
+	BuildTool                   string   `json:"buildTool,omitempty" validate:"possible-values=cargo custom docker dotnet dub golang gradle helm maven mta npm pip sbt yarn CAP"`
+	ChangelogFile               string   `json:"changelogFile,omitempty"`
 	CommitUserName              string   `json:"commitUserName,omitempty"`
 	CustomVersionField          string   `json:"customVersionField,omitempty"`
 	CustomVersionSection        string   `json:"customVersionSection,omitempty"`
@@ -34,14 +35,17 @@ type artifactPrepareVersionOptions struct {
 	IncludeCommitID             bool     `json:"includeCommitId,omitempty"`
 	IsOptimizedAndScheduled     bool     `json:"isOptimizedAndScheduled,omitempty"`
 	M2Path                      string   `json:"m2Path,omitempty"`
+	ModuleDescriptors           []string `json:"moduleDescriptors,omitempty"`
+	ModuleVersioning            string   `json:"moduleVersioning,omitempty" validate:"possible-values=consistent independent"`
 	Password                    string   `json:"password,omitempty"`
+	PreReleaseChannel           string   `json:"preReleaseChannel,omitempty" validate:"possible-values=alpha beta rc"`
 	ProjectSettingsFile         string   `json:"projectSettingsFile,omitempty"`
 	ShortCommitID               bool     `json:"shortCommitId,omitempty"`
 	TagPrefix                   string   `json:"tagPrefix,omitempty"`
 	UnixTimestamp               bool     `json:"unixTimestamp,omitempty"`
 	Username                    string   `json:"username,omitempty"`
 	VersioningTemplate          string   `json:"versioningTemplate,omitempty"`
-	VersioningType              string   `json:"versioningType,omitempty" validate:"possible-values=cloud cloud_noTag library"`
+	VersioningType              string   `json:"versioningType,omitempty" validate:"possible-values=cloud cloud_noTag library semantic-release"`
 	CustomTLSCertificateLinks   []string `json:"customTlsCertificateLinks,omitempty"`
 }
 
@@ -56,6 +60,9 @@ type artifactPrepareVersionCommonPipelineEnvironment struct {
 		headCommitID  string
 		commitMessage string
 	}
+	custom struct {
+		artifactVersions string
+	}
 }
 
 func (p *artifactPrepareVersionCommonPipelineEnvironment) persist(path, resourceName string) {
@@ -72,6 +79,7 @@ func (p *artifactPrepareVersionCommonPipelineEnvironment) persist(path, resource
 		{category: "git", name: "commitId", value: p.git.commitID},
 		{category: "git", name: "headCommitId", value: p.git.headCommitID},
 		{category: "git", name: "commitMessage", value: p.git.commitMessage},
+		{category: "custom", name: "artifactVersions", value: p.custom.artifactVersions},
 	}
 
 	errCount := 0
@@ -140,6 +148,26 @@ Unlike for the _Continuous Deloyment_ pattern described above, in this case ther
 
 Configuration of this pattern is done via ` + "`" + `versioningType: library` + "`" + `.
 
+### 3. Semantic release based on Conventional Commits
+
+With ` + "`" + `versioningType: semantic-release` + "`" + ` the next ` + "`" + `<major>.<minor>.<patch>` + "`" + ` version is derived from the commit messages, following the idea of [semantic-release](https://semantic-release.gitbook.io/).
+The commits since the latest version tag (` + "`" + `<tagPrefix><major>.<minor>.<patch>` + "`" + `) reachable from the current commit are classified according to the [Conventional Commits](https://www.conventionalcommits.org/) specification:
+
+* a breaking change (` + "`" + `feat!: ...` + "`" + ` or a footer ` + "`" + `BREAKING CHANGE: ...` + "`" + `) increments the major version
+* a feature (` + "`" + `feat: ...` + "`" + `) increments the minor version
+* a fix or performance improvement (` + "`" + `fix: ...` + "`" + `, ` + "`" + `perf: ...` + "`" + `) increments the patch version
+
+The new version is written into the build descriptor of the ` + "`" + `buildTool` + "`" + ` as well as into the descriptors of the [` + "`" + `additionalTargetTools` + "`" + `](#additionaltargettools).
+The release notes are added to the [` + "`" + `changelogFile` + "`" + `](#changelogfile) and the changes are committed and pushed with the new version tag, just like for ` + "`" + `versioningType: cloud` + "`" + `.
+If no version tag exists yet, the version of the build descriptor is released as initial version.
+If none of the commits contains a release relevant change, the version of the latest tag is used and no tag is created.
+
+With a [` + "`" + `preReleaseChannel` + "`" + `](#prereleasechannel) pre-release versions according to [Semantic Versioning 2.0.0](https://semver.org) are created, e.g. for a release branch:
+
+* a feature on top of ` + "`" + `v1.2.3` + "`" + ` results in ` + "`" + `1.3.0-rc.1` + "`" + `, further changes increment the counter of the channel (` + "`" + `1.3.0-rc.2` + "`" + `)
+* switching to a channel with a higher precedence (` + "`" + `alpha` + "`" + ` → ` + "`" + `beta` + "`" + ` → ` + "`" + `rc` + "`" + `) restarts the counter (` + "`" + `1.3.0-beta.4` + "`" + ` → ` + "`" + `1.3.0-rc.1` + "`" + `)
+* without ` + "`" + `preReleaseChannel` + "`" + ` the latest pre-release is released as final version (` + "`" + `1.3.0-rc.2` + "`" + ` → ` + "`" + `1.3.0` + "`" + `)
+
 ### Support of additional build tools
 
 Besides the ` + "`" + `buildTools` + "`" + ` provided out of the box (like ` + "`" + `maven` + "`" + `, ` + "`" + `mta` + "`" + `, ` + "`" + `npm` + "`" + `, ...) it is possible to set ` + "`" + `buildTool: custom` + "`" + `.
@@ -230,11 +258,19 @@ Define ` + "`" + `buildTool: custom` + "`" + `, ` + "`" + `filePath: <path to yo
 				defer vaultClient.MustRevokeToken()
 			}
 
+			if GeneralConfig.DryRun {
+				PrintStepPlan(STEP_NAME, &metadata, stepConfig)
+				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
+				return
+			}
+
 			stepTelemetryData := telemetry.CustomData{}
 			stepTelemetryData.ErrorCode = "1"
 			handler := func() {
 				commonPipelineEnvironment.persist(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
@@ -287,7 +323,8 @@ func addArtifactPrepareVersionFlags(cmd *cobra.Command, stepConfig *artifactPrep
 	cmd.Flags().StringSliceVar(&stepConfig.AdditionalTargetTools, "additionalTargetTools", []string{}, "Additional buildTool targets where descriptors need to be updated besides the main `buildTool`.")
 	cmd.Flags().StringSliceVar(&stepConfig.AdditionalTargetDescriptors, "additionalTargetDescriptors", []string{}, "Defines patterns for build descriptors which should be used for option additionalTargetTools.")
 	cmd.Flags().StringVar(&stepConfig.BuildTool, "buildTool", os.Getenv("PIPER_buildTool"), "Defines the tool which is used for building the artifact.")
-	cmd.Flags().StringVar(&stepConfig.CommitUserName, "commitUserName", `Project Piper`, "Defines the user name which appears in version control for the versioning update (in case `versioningType: cloud`).")
+	cmd.Flags().StringVar(&stepConfig.ChangelogFile, "changelogFile", `CHANGELOG.md`, "Defines the changelog file which is updated with the release notes of the new version (only `versioningType: semantic-release`).")
+	cmd.Flags().StringVar(&stepConfig.CommitUserName, "commitUserName", `Project Piper`, "Defines the user name which appears in version control for the versioning update (in case `versioningType: cloud` or `semantic-release`).")
 	cmd.Flags().StringVar(&stepConfig.CustomVersionField, "customVersionField", os.Getenv("PIPER_customVersionField"), "For `buildTool: custom`: Defines the field which contains the version in the descriptor file.")
 	cmd.Flags().StringVar(&stepConfig.CustomVersionSection, "customVersionSection", os.Getenv("PIPER_customVersionSection"), "For `buildTool: custom`: Defines the section for version retrieval in vase a *.ini/*.cfg file is used.")
 	cmd.Flags().StringVar(&stepConfig.CustomVersioningScheme, "customVersioningScheme", `maven`, "For `buildTool: custom`: Defines the versioning scheme to be used.")
@@ -299,10 +336,13 @@ func addArtifactPrepareVersionFlags(cmd *cobra.Command, stepConfig *artifactPrep
 	cmd.Flags().BoolVar(&stepConfig.IncludeCommitID, "includeCommitId", true, "Defines if the automatically generated version (`versioningType: cloud`) should include the commit id hash.")
 	cmd.Flags().BoolVar(&stepConfig.IsOptimizedAndScheduled, "isOptimizedAndScheduled", false, "Whether the pipeline runs in optimized mode and the current execution is a scheduled one")
 	cmd.Flags().StringVar(&stepConfig.M2Path, "m2Path", os.Getenv("PIPER_m2Path"), "Maven only - Path to the location of the local repository that should be used.")
+	cmd.Flags().StringSliceVar(&stepConfig.ModuleDescriptors, "moduleDescriptors", []string{}, "Glob patterns of the build descriptors of the modules of a monorepo, e.g. `packages/*/package.json`. Each matching descriptor is versioned and tagged as a dedicated module.")
+	cmd.Flags().StringVar(&stepConfig.ModuleVersioning, "moduleVersioning", `consistent`, "Defines how the modules defined via [`moduleDescriptors`](#moduledescriptors) are versioned.")
 	cmd.Flags().StringVar(&stepConfig.Password, "password", os.Getenv("PIPER_password"), "Password/token for git authentication.")
+	cmd.Flags().StringVar(&stepConfig.PreReleaseChannel, "preReleaseChannel", os.Getenv("PIPER_preReleaseChannel"), "Defines the pre-release channel for which pre-release versions like `1.3.0-rc.1` are created (only `versioningType: semantic-release`).")
 	cmd.Flags().StringVar(&stepConfig.ProjectSettingsFile, "projectSettingsFile", os.Getenv("PIPER_projectSettingsFile"), "Maven only - Path to the mvn settings file that should be used as project settings file.")
 	cmd.Flags().BoolVar(&stepConfig.ShortCommitID, "shortCommitId", false, "Defines if a short version of the commitId should be used. GitHub format is used (first 7 characters).")
-	cmd.Flags().StringVar(&stepConfig.TagPrefix, "tagPrefix", `build_`, "Defines the prefix which is used for the git tag which is written during the versioning run (only `versioningType: cloud`).")
+	cmd.Flags().StringVar(&stepConfig.TagPrefix, "tagPrefix", `build_`, "Defines the prefix which is used for the git tag which is written during the versioning run (only `versioningType: cloud` and `semantic-release`). For `semantic-release` a prefix like `v` is recommended.")
 	cmd.Flags().BoolVar(&stepConfig.UnixTimestamp, "unixTimestamp", false, "Defines if the Unix timestamp number should be used as build number instead of the standard date format.")
 	cmd.Flags().StringVar(&stepConfig.Username, "username", os.Getenv("PIPER_username"), "User name for git authentication")
 	cmd.Flags().StringVar(&stepConfig.VersioningTemplate, "versioningTemplate", os.Getenv("PIPER_versioningTemplate"), "DEPRECATED: Defines the template for the automatic version which will be created")
@@ -354,6 +394,15 @@ func artifactPrepareVersionMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     os.Getenv("PIPER_buildTool"),
 					},
+					{
+						Name:        "changelogFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `CHANGELOG.md`,
+					},
 					{
 						Name:        "commitUserName",
 						ResourceRef: []config.ResourceReference{},
@@ -467,6 +516,24 @@ func artifactPrepareVersionMetadata() config.StepData {
 						Aliases:     []config.Alias{{Name: "maven/m2Path"}},
 						Default:     os.Getenv("PIPER_m2Path"),
 					},
+					{
+						Name:        "moduleDescriptors",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "moduleVersioning",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `consistent`,
+					},
 					{
 						Name: "password",
 						ResourceRef: []config.ResourceReference{
@@ -488,6 +555,15 @@ func artifactPrepareVersionMetadata() config.StepData {
 						Aliases:   []config.Alias{{Name: "access_token"}},
 						Default:   os.Getenv("PIPER_password"),
 					},
+					{
+						Name:        "preReleaseChannel",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_preReleaseChannel"),
+					},
 					{
 						Name:        "projectSettingsFile",
 						ResourceRef: []config.ResourceReference{},
@@ -593,6 +669,7 @@ func artifactPrepareVersionMetadata() config.StepData {
 							{"name": "git/commitId"},
 							{"name": "git/headCommitId"},
 							{"name": "git/commitMessage"},
+							{"name": "custom/artifactVersions"},
 						},
 					},
 				},
@@ -4,8 +4,10 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +17,8 @@ import (
 	"github.com/SAP/jenkins-library/pkg/versioning"
 
 	"github.com/ghodss/yaml"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
 	"github.com/stretchr/testify/assert"
 	"helm.sh/helm/v3/pkg/chart"
 
@@ -24,6 +28,7 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/object"
 	gitHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/memory"
 )
 
 type artifactVersioningMock struct {
@@ -78,6 +83,7 @@ type gitRepositoryMock struct {
 	revisionHash        plumbing.Hash
 	revisionError       string
 	tag                 string
+	tags                []string
 	tagHash             plumbing.Hash
 	tagError            string
 	worktree            *git.Worktree
@@ -95,6 +101,7 @@ func (r *gitRepositoryMock) CreateTag(name string, hash plumbing.Hash, opts *git
 		return nil, fmt.Errorf("%s", r.tagError)
 	}
 	r.tag = name
+	r.tags = append(r.tags, name)
 	r.tagHash = hash
 	return nil, nil
 }
@@ -149,6 +156,7 @@ func (r *gitRepositoryMock) Worktree() (*git.Worktree, error) {
 }
 
 type gitWorktreeMock struct {
+	addedFiles    []string
 	checkoutError string
 	checkoutOpts  *git.CheckoutOptions
 	commitHash    plumbing.Hash
@@ -157,6 +165,11 @@ type gitWorktreeMock struct {
 	commitError   string
 }
 
+func (w *gitWorktreeMock) Add(path string) (plumbing.Hash, error) {
+	w.addedFiles = append(w.addedFiles, path)
+	return plumbing.Hash{}, nil
+}
+
 func (w *gitWorktreeMock) Checkout(opts *git.CheckoutOptions) error {
 	if len(w.checkoutError) > 0 {
 		return fmt.Errorf("%s", w.checkoutError)
@@ -551,6 +564,450 @@ func TestRunArtifactPrepareVersion(t *testing.T) {
 	})
 }
 
+func TestRunArtifactPrepareVersionSemanticRelease(t *testing.T) {
+	newCommit := func(message string, data ...byte) *object.Commit {
+		return &object.Commit{Hash: plumbing.ComputeHash(plumbing.CommitObject, data), Message: message}
+	}
+	mockVersionTagLog := func(tag string, commits ...*object.Commit) func() {
+		versionTagLog = func(repository gitRepository, tagPrefix, directory string, changes *moduleChanges) (string, []*object.Commit, error) {
+			return tag, commits, nil
+		}
+		return func() { versionTagLog = latestVersionTagLog }
+	}
+	newConfig := func() artifactPrepareVersionOptions {
+		return artifactPrepareVersionOptions{
+			BuildTool:      "npm",
+			ChangelogFile:  "CHANGELOG.md",
+			Password:       "****",
+			TagPrefix:      "v",
+			Username:       "testUser",
+			VersioningType: "semantic-release",
+		}
+	}
+	conf := gitConfig.RemoteConfig{Name: "origin", URLs: []string{"https://my.test.server"}}
+
+	t.Run("success case - minor release", func(t *testing.T) {
+		defer mockVersionTagLog("v1.2.3",
+			newCommit("feat(api): add endpoint", 1),
+			newCommit("fix: handle timeout", 2),
+			newCommit("Merge pull request #12 from feature", 3),
+		)()
+
+		config := newConfig()
+		cpe := artifactPrepareVersionCommonPipelineEnvironment{}
+		versioningMock := artifactVersioningMock{originalVersion: "1.2.3", versioningScheme: "semver2"}
+		utils := newArtifactPrepareVersionMockUtils()
+		utils.AddFile("CHANGELOG.md", []byte("# Changelog\n\n## 1.2.3 (2024-05-01)\n"))
+		worktree := gitWorktreeMock{commitHash: plumbing.ComputeHash(plumbing.CommitObject, []byte{2, 3, 4})}
+		repo := gitRepositoryMock{
+			revisionHash: plumbing.ComputeHash(plumbing.CommitObject, []byte{1, 2, 3}),
+			remote:       git.NewRemote(nil, &conf),
+		}
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &cpe, &versioningMock, utils, &repo, func(r gitRepository) (gitWorktree, error) { return &worktree, nil })
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1.3.0", versioningMock.newVersion)
+		assert.Equal(t, "v1.3.0", repo.tag)
+		assert.True(t, repo.pushCalled)
+		assert.Equal(t, []string{"CHANGELOG.md"}, worktree.addedFiles)
+		assert.Equal(t, "update version 1.3.0", worktree.commitMsg)
+		assert.Equal(t, "1.3.0", cpe.artifactVersion)
+		assert.Equal(t, "1.2.3", cpe.originalArtifactVersion)
+		assert.Equal(t, worktree.commitHash.String(), cpe.git.commitID)
+
+		changelog, err := utils.FileRead("CHANGELOG.md")
+		assert.NoError(t, err)
+		assert.Contains(t, string(changelog), "# Changelog\n\n## 1.3.0 (")
+		assert.Contains(t, string(changelog), "### Features\n\n* **api:** add endpoint")
+		assert.Contains(t, string(changelog), "### Bug Fixes\n\n* handle timeout")
+		assert.NotContains(t, string(changelog), "Merge pull request")
+		assert.Contains(t, string(changelog), "## 1.2.3 (2024-05-01)")
+	})
+
+	t.Run("success case - breaking change", func(t *testing.T) {
+		defer mockVersionTagLog("v1.2.3", newCommit("refactor: rename config\n\nBREAKING CHANGE: option foo is now bar", 1))()
+
+		config := newConfig()
+		cpe := artifactPrepareVersionCommonPipelineEnvironment{}
+		versioningMock := artifactVersioningMock{originalVersion: "1.2.3", versioningScheme: "semver2"}
+		worktree := gitWorktreeMock{}
+		repo := gitRepositoryMock{remote: git.NewRemote(nil, &conf)}
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &cpe, &versioningMock, newArtifactPrepareVersionMockUtils(), &repo, func(r gitRepository) (gitWorktree, error) { return &worktree, nil })
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2.0.0", cpe.artifactVersion)
+		assert.Equal(t, "v2.0.0", repo.tag)
+	})
+
+	t.Run("success case - pre-release channel", func(t *testing.T) {
+		defer mockVersionTagLog("v1.3.0-rc.1", newCommit("fix: handle timeout", 1))()
+
+		config := newConfig()
+		config.PreReleaseChannel = "rc"
+		cpe := artifactPrepareVersionCommonPipelineEnvironment{}
+		versioningMock := artifactVersioningMock{originalVersion: "1.3.0-rc.1", versioningScheme: "semver2"}
+		worktree := gitWorktreeMock{}
+		repo := gitRepositoryMock{remote: git.NewRemote(nil, &conf)}
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &cpe, &versioningMock, newArtifactPrepareVersionMockUtils(), &repo, func(r gitRepository) (gitWorktree, error) { return &worktree, nil })
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1.3.0-rc.2", versioningMock.newVersion)
+		assert.Equal(t, "v1.3.0-rc.2", repo.tag)
+	})
+
+	t.Run("success case - promote pre-release", func(t *testing.T) {
+		defer mockVersionTagLog("v1.3.0-rc.2", newCommit("fix: handle timeout", 1))()
+
+		config := newConfig()
+		cpe := artifactPrepareVersionCommonPipelineEnvironment{}
+		versioningMock := artifactVersioningMock{originalVersion: "1.3.0-rc.2", versioningScheme: "semver2"}
+		worktree := gitWorktreeMock{}
+		repo := gitRepositoryMock{remote: git.NewRemote(nil, &conf)}
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &cpe, &versioningMock, newArtifactPrepareVersionMockUtils(), &repo, func(r gitRepository) (gitWorktree, error) { return &worktree, nil })
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1.3.0", cpe.artifactVersion)
+		assert.Equal(t, "v1.3.0", repo.tag)
+	})
+
+	t.Run("success case - initial release", func(t *testing.T) {
+		defer mockVersionTagLog("", newCommit("feat: initial implementation", 1))()
+
+		config := newConfig()
+		cpe := artifactPrepareVersionCommonPipelineEnvironment{}
+		versioningMock := artifactVersioningMock{originalVersion: "0.1.0", versioningScheme: "semver2"}
+		utils := newArtifactPrepareVersionMockUtils()
+		worktree := gitWorktreeMock{}
+		repo := gitRepositoryMock{remote: git.NewRemote(nil, &conf)}
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &cpe, &versioningMock, utils, &repo, func(r gitRepository) (gitWorktree, error) { return &worktree, nil })
+
+		assert.NoError(t, err)
+		assert.Equal(t, "0.1.0", cpe.artifactVersion)
+		assert.Empty(t, versioningMock.newVersion)
+		assert.Equal(t, "v0.1.0", repo.tag)
+		assert.True(t, utils.HasWrittenFile("CHANGELOG.md"))
+	})
+
+	t.Run("success case - no release relevant changes", func(t *testing.T) {
+		defer mockVersionTagLog("v1.2.3", newCommit("docs: update readme", 1), newCommit("chore: update dependencies", 2))()
+
+		config := newConfig()
+		cpe := artifactPrepareVersionCommonPipelineEnvironment{}
+		versioningMock := artifactVersioningMock{originalVersion: "1.0.0", versioningScheme: "semver2"}
+		utils := newArtifactPrepareVersionMockUtils()
+		repo := gitRepositoryMock{revisionHash: plumbing.ComputeHash(plumbing.CommitObject, []byte{1, 2, 3})}
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &cpe, &versioningMock, utils, &repo, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1.2.3", cpe.artifactVersion)
+		assert.Empty(t, versioningMock.newVersion)
+		assert.Empty(t, repo.tag)
+		assert.False(t, utils.HasWrittenFile("CHANGELOG.md"))
+		assert.Equal(t, repo.revisionHash.String(), cpe.git.commitID)
+	})
+
+	t.Run("success case - optimized and scheduled", func(t *testing.T) {
+		defer mockVersionTagLog("v1.2.3", newCommit("fix: handle timeout", 1))()
+
+		config := newConfig()
+		config.IsOptimizedAndScheduled = true
+		cpe := artifactPrepareVersionCommonPipelineEnvironment{}
+		versioningMock := artifactVersioningMock{originalVersion: "1.2.3", versioningScheme: "semver2"}
+		utils := newArtifactPrepareVersionMockUtils()
+		worktree := gitWorktreeMock{}
+		repo := gitRepositoryMock{}
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &cpe, &versioningMock, utils, &repo, func(r gitRepository) (gitWorktree, error) { return &worktree, nil })
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1.2.4", versioningMock.newVersion)
+		assert.False(t, repo.pushCalled)
+		assert.Empty(t, worktree.addedFiles)
+		assert.True(t, utils.HasWrittenFile("CHANGELOG.md"))
+	})
+
+	t.Run("error - retrieving commits", func(t *testing.T) {
+		versionTagLog = func(repository gitRepository, tagPrefix, directory string, changes *moduleChanges) (string, []*object.Commit, error) {
+			return "", nil, fmt.Errorf("log error")
+		}
+		defer func() { versionTagLog = latestVersionTagLog }()
+
+		config := newConfig()
+		versioningMock := artifactVersioningMock{originalVersion: "1.2.3", versioningScheme: "semver2"}
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &artifactPrepareVersionCommonPipelineEnvironment{}, &versioningMock, newArtifactPrepareVersionMockUtils(), &gitRepositoryMock{}, nil)
+
+		assert.EqualError(t, err, "failed to retrieve commits since latest version tag: log error")
+	})
+
+	t.Run("error - invalid tag version", func(t *testing.T) {
+		defer mockVersionTagLog("v1.2", newCommit("fix: handle timeout", 1))()
+
+		config := newConfig()
+		versioningMock := artifactVersioningMock{originalVersion: "1.2.3", versioningScheme: "semver2"}
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &artifactPrepareVersionCommonPipelineEnvironment{}, &versioningMock, newArtifactPrepareVersionMockUtils(), &gitRepositoryMock{}, nil)
+
+		assert.EqualError(t, err, "failed to increment version of tag 'v1.2': version '1.2' is not a semantic version <major>.<minor>.<patch>")
+	})
+}
+
+func TestRunArtifactPrepareVersionModules(t *testing.T) {
+	conf := gitConfig.RemoteConfig{Name: "origin", URLs: []string{"https://my.test.server"}}
+	newUtils := func() *artifactPrepareVersionMockUtils {
+		utils := newArtifactPrepareVersionMockUtils()
+		utils.AddFile("crates/parser/Cargo.toml", []byte("[package]\nname = \"parser\"\nversion = \"0.1.0\"\n"))
+		utils.AddFile("tools/cli/pyproject.toml", []byte("[project]\nname = \"cli\"\nversion = \"2.0.0\"\n"))
+		return utils
+	}
+	moduleVersions := func(t *testing.T, cpe artifactPrepareVersionCommonPipelineEnvironment) []moduleVersion {
+		versions := []moduleVersion{}
+		assert.NoError(t, json.Unmarshal([]byte(cpe.custom.artifactVersions), &versions))
+		return versions
+	}
+
+	t.Run("success case - cloud", func(t *testing.T) {
+		config := artifactPrepareVersionOptions{
+			BuildTool:         "maven",
+			ModuleDescriptors: []string{"crates/*/Cargo.toml", "tools/*/pyproject.toml"},
+			ModuleVersioning:  "consistent",
+			Password:          "****",
+			TagPrefix:         "v",
+			Username:          "testUser",
+			VersioningType:    "cloud",
+		}
+		cpe := artifactPrepareVersionCommonPipelineEnvironment{}
+		versioningMock := artifactVersioningMock{originalVersion: "1.2.3", versioningScheme: "maven"}
+		utils := newUtils()
+		worktree := gitWorktreeMock{commitHash: plumbing.ComputeHash(plumbing.CommitObject, []byte{2, 3, 4})}
+		repo := gitRepositoryMock{
+			revisionHash: plumbing.ComputeHash(plumbing.CommitObject, []byte{1, 2, 3}),
+			remote:       git.NewRemote(nil, &conf),
+		}
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &cpe, &versioningMock, utils, &repo, func(r gitRepository) (gitWorktree, error) { return &worktree, nil })
+
+		assert.NoError(t, err)
+		versions := moduleVersions(t, cpe)
+		if assert.Len(t, versions, 2) {
+			assert.Equal(t, "crates/parser", versions[0].Name)
+			assert.Equal(t, "cargo", versions[0].BuildTool)
+			assert.Equal(t, "0.1.0", versions[0].OriginalVersion)
+			assert.True(t, strings.HasPrefix(versions[0].Version, "1.2.3-"), versions[0].Version)
+			assert.Equal(t, "crates/parser/v"+versions[0].Version, versions[0].Tag)
+			assert.Equal(t, "tools/cli", versions[1].Name)
+			assert.True(t, strings.HasPrefix(versions[1].Version, "1.2.3."), versions[1].Version)
+
+			cargo, err := utils.FileRead("crates/parser/Cargo.toml")
+			assert.NoError(t, err)
+			assert.Contains(t, string(cargo), fmt.Sprintf("version = \"%v\"", versions[0].Version))
+			assert.Equal(t, []string{"v" + versioningMock.newVersion, versions[0].Tag, versions[1].Tag}, repo.tags)
+		}
+		assert.True(t, repo.pushCalled)
+	})
+
+	t.Run("success case - library", func(t *testing.T) {
+		config := artifactPrepareVersionOptions{
+			BuildTool:         "maven",
+			ModuleDescriptors: []string{"**/Cargo.toml", "**/pyproject.toml"},
+			VersioningType:    "library",
+		}
+		cpe := artifactPrepareVersionCommonPipelineEnvironment{}
+		versioningMock := artifactVersioningMock{originalVersion: "1.2.3", versioningScheme: "maven"}
+		utils := newUtils()
+		repo := gitRepositoryMock{revisionHash: plumbing.ComputeHash(plumbing.CommitObject, []byte{1, 2, 3})}
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &cpe, &versioningMock, utils, &repo, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []moduleVersion{
+			{Name: "crates/parser", BuildTool: "cargo", BuildDescriptor: "crates/parser/Cargo.toml", OriginalVersion: "0.1.0", Version: "0.1.0"},
+			{Name: "tools/cli", BuildTool: "pip", BuildDescriptor: "tools/cli/pyproject.toml", OriginalVersion: "2.0.0", Version: "2.0.0"},
+		}, moduleVersions(t, cpe))
+		assert.False(t, utils.HasWrittenFile("crates/parser/Cargo.toml"))
+	})
+
+	t.Run("success case - semantic-release independent", func(t *testing.T) {
+		versionTagLog = func(repository gitRepository, tagPrefix, directory string, changes *moduleChanges) (string, []*object.Commit, error) {
+			switch directory {
+			case "crates/parser":
+				return "crates/parser/v0.1.0", []*object.Commit{{Message: "feat(parser): support comments"}}, nil
+			case "tools/cli":
+				return "tools/cli/v2.0.0", []*object.Commit{{Message: "docs: update usage"}}, nil
+			}
+			return "v1.2.3", []*object.Commit{{Message: "chore: update dependencies"}}, nil
+		}
+		defer func() { versionTagLog = latestVersionTagLog }()
+
+		config := artifactPrepareVersionOptions{
+			BuildTool:         "npm",
+			ChangelogFile:     "CHANGELOG.md",
+			ModuleDescriptors: []string{"crates/*/Cargo.toml", "tools/*/pyproject.toml"},
+			ModuleVersioning:  "independent",
+			Password:          "****",
+			TagPrefix:         "v",
+			Username:          "testUser",
+			VersioningType:    "semantic-release",
+		}
+		cpe := artifactPrepareVersionCommonPipelineEnvironment{}
+		versioningMock := artifactVersioningMock{originalVersion: "1.2.3", versioningScheme: "semver2"}
+		utils := newUtils()
+		worktree := gitWorktreeMock{commitHash: plumbing.ComputeHash(plumbing.CommitObject, []byte{2, 3, 4})}
+		repo := gitRepositoryMock{
+			revisionHash: plumbing.ComputeHash(plumbing.CommitObject, []byte{1, 2, 3}),
+			remote:       git.NewRemote(nil, &conf),
+		}
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &cpe, &versioningMock, utils, &repo, func(r gitRepository) (gitWorktree, error) { return &worktree, nil })
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1.2.3", cpe.artifactVersion)
+		assert.Empty(t, versioningMock.newVersion)
+		assert.Equal(t, []string{"crates/parser/v0.2.0"}, repo.tags)
+		assert.Equal(t, "update version crates/parser/v0.2.0", worktree.commitMsg)
+		assert.Equal(t, []string{"crates/parser/CHANGELOG.md"}, worktree.addedFiles)
+		assert.Equal(t, worktree.commitHash.String(), cpe.git.commitID)
+		assert.False(t, utils.HasWrittenFile("CHANGELOG.md"))
+		assert.False(t, utils.HasWrittenFile("tools/cli/pyproject.toml"))
+
+		versions := moduleVersions(t, cpe)
+		if assert.Len(t, versions, 2) {
+			assert.Equal(t, "0.2.0", versions[0].Version)
+			assert.Equal(t, "crates/parser/v0.2.0", versions[0].Tag)
+			assert.Equal(t, "2.0.0", versions[1].Version)
+			assert.Empty(t, versions[1].Tag)
+		}
+		cargo, err := utils.FileRead("crates/parser/Cargo.toml")
+		assert.NoError(t, err)
+		assert.Contains(t, string(cargo), `version = "0.2.0"`)
+	})
+
+	t.Run("error - unsupported module descriptor", func(t *testing.T) {
+		config := artifactPrepareVersionOptions{
+			BuildTool:         "maven",
+			ModuleDescriptors: []string{"*/build.xml"},
+			VersioningType:    "library",
+		}
+		utils := newArtifactPrepareVersionMockUtils()
+		utils.AddFile("legacy/build.xml", []byte("<project/>"))
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &artifactPrepareVersionCommonPipelineEnvironment{}, &artifactVersioningMock{originalVersion: "1.2.3"}, utils, &gitRepositoryMock{}, nil)
+
+		assert.EqualError(t, err, "failed to discover modules: build descriptor 'legacy/build.xml' not supported")
+	})
+}
+
+func TestLatestVersionTagLog(t *testing.T) {
+	fs := memfs.New()
+	repo, err := git.Init(memory.NewStorage(), fs)
+	assert.NoError(t, err)
+	worktree, err := repo.Worktree()
+	assert.NoError(t, err)
+
+	commit := func(message string) plumbing.Hash {
+		hash, err := worktree.Commit(message, &git.CommitOptions{AllowEmptyCommits: true, Author: &object.Signature{Name: "Test", When: time.Now()}})
+		assert.NoError(t, err)
+		return hash
+	}
+
+	t.Run("no version tag", func(t *testing.T) {
+		commit("feat: initial implementation")
+
+		tag, commits, err := latestVersionTagLog(repo, "v", "", nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, tag)
+		assert.Len(t, commits, 1)
+	})
+
+	t.Run("commits since latest version tag", func(t *testing.T) {
+		_, err := repo.CreateTag("v1.0.0", commit("fix: first bug"), nil)
+		assert.NoError(t, err)
+		_, err = repo.CreateTag("v1.10.0", commit("feat: new feature"), nil)
+		assert.NoError(t, err)
+		_, err = repo.CreateTag("v1.9.0", commit("feat: unrelated"), nil)
+		assert.NoError(t, err)
+		_, err = repo.CreateTag("v1.10.0-rc.1", commit("feat: release candidate"), nil)
+		assert.NoError(t, err)
+		_, err = repo.CreateTag("vNext", commit("chore: prepare release"), nil)
+		assert.NoError(t, err)
+		commit("fix: second bug")
+
+		tag, commits, err := latestVersionTagLog(repo, "v", "", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "v1.10.0", tag)
+		if assert.Len(t, commits, 4) {
+			assert.Equal(t, "fix: second bug", commits[0].Message)
+		}
+	})
+
+	t.Run("tags not reachable from HEAD are ignored", func(t *testing.T) {
+		head, err := repo.Head()
+		assert.NoError(t, err)
+		assert.NoError(t, worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("maintenance"), Create: true}))
+		_, err = repo.CreateTag("v2.0.0", commit("feat: other branch"), &git.CreateTagOptions{Message: "v2.0.0", Tagger: &object.Signature{Name: "Test", When: time.Now()}})
+		assert.NoError(t, err)
+		assert.NoError(t, worktree.Checkout(&git.CheckoutOptions{Branch: head.Name()}))
+
+		tag, commits, err := latestVersionTagLog(repo, "v", "", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "v1.10.0", tag)
+		assert.Len(t, commits, 4)
+	})
+
+	t.Run("commits of module directory", func(t *testing.T) {
+		commitFile := func(file, message string) plumbing.Hash {
+			assert.NoError(t, util.WriteFile(fs, file, []byte(message), 0666))
+			_, err := worktree.Add(file)
+			assert.NoError(t, err)
+			return commit(message)
+		}
+		_, err := repo.CreateTag("services/api/v0.1.0", commitFile("services/api/main.go", "feat(api): initial implementation"), nil)
+		assert.NoError(t, err)
+		commitFile("services/api/handler.go", "fix(api): handle timeout")
+		commitFile("services/web/main.go", "feat(web): new page")
+		commit("chore: update pipeline")
+
+		changes := newModuleChanges([]versioning.Module{{Name: "services/api"}, {Name: "services/web"}})
+		tag, commits, err := latestVersionTagLog(repo, "services/api/v", "services/api", changes)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "services/api/v0.1.0", tag)
+		if assert.Len(t, commits, 1) {
+			assert.Equal(t, "fix(api): handle timeout", commits[0].Message)
+		}
+		// the changes of the commits are determined once for all modules
+		assert.Len(t, changes.changed, 3)
+
+		tag, commits, err = latestVersionTagLog(repo, "services/web/v", "services/web", changes)
+
+		assert.NoError(t, err)
+		assert.Empty(t, tag)
+		if assert.Len(t, commits, 1) {
+			assert.Equal(t, "feat(web): new page", commits[0].Message)
+		}
+
+		tag, commits, err = latestVersionTagLog(repo, "services/api/v", "services/api", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "services/api/v0.1.0", tag)
+		assert.Len(t, commits, 1)
+	})
+
+	t.Run("error - no git repository", func(t *testing.T) {
+		_, _, err := latestVersionTagLog(&gitRepositoryMock{}, "v", "", nil)
+		assert.EqualError(t, err, "git history not available")
+	})
+}
+
 func TestVersioningTemplate(t *testing.T) {
 	tt := []struct {
 		scheme      string
@@ -630,6 +1087,18 @@ func TestPushChanges(t *testing.T) {
 		assert.Equal(t, &git.PushOptions{RefSpecs: []gitConfig.RefSpec{"refs/tags/1.2.3:refs/tags/1.2.3"}, Auth: &gitHttp.BasicAuth{Username: config.Username, Password: config.Password}}, repo.pushOptions)
 	})
 
+	t.Run("success - module tags", func(t *testing.T) {
+		config := artifactPrepareVersionOptions{Username: "testUser", Password: "****", TagPrefix: "v"}
+		repo := gitRepositoryMock{remote: remote}
+		worktree := gitWorktreeMock{commitHash: plumbing.ComputeHash(plumbing.CommitObject, []byte{1, 2, 3})}
+
+		_, err := pushChanges(&config, "", &repo, &worktree, testTime, nil, "services/api/v1.1.0", "services/web/v2.0.1")
+		assert.NoError(t, err)
+		assert.Equal(t, "update version services/api/v1.1.0, services/web/v2.0.1", worktree.commitMsg)
+		assert.Equal(t, []string{"services/api/v1.1.0", "services/web/v2.0.1"}, repo.tags)
+		assert.Equal(t, []gitConfig.RefSpec{"refs/tags/services/api/v1.1.0:refs/tags/services/api/v1.1.0", "refs/tags/services/web/v2.0.1:refs/tags/services/web/v2.0.1"}, repo.pushOptions.RefSpecs)
+	})
+
 	t.Run("success - ssh fallback", func(t *testing.T) {
 		config := artifactPrepareVersionOptions{CommitUserName: "Project Piper"}
 		repo := gitRepositoryMock{remote: remote}
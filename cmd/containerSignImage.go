@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/SAP/jenkins-library/pkg/attestation"
+	"github.com/SAP/jenkins-library/pkg/docker"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+)
+
+type containerImageDigestResolver interface {
+	GetImageDigest(image string, dockerConfigJSON []byte) (string, error)
+}
+
+type containerSignImageUtils interface {
+	FileExists(filename string) (bool, error)
+	FileRead(path string) ([]byte, error)
+	FileWrite(path string, content []byte, perm os.FileMode) error
+	MkdirAll(path string, perm os.FileMode) error
+
+	containerImageDigestResolver
+	AttachSignature(repository, digest string, signature docker.ImageSignature, dockerConfigJSON []byte) (string, error)
+}
+
+type containerSignImageUtilsBundle struct {
+	*piperutils.Files
+}
+
+func (c *containerSignImageUtilsBundle) GetImageDigest(image string, dockerConfigJSON []byte) (string, error) {
+	keychain, err := docker.KeychainFromDockerConfigJSON(dockerConfigJSON)
+	if err != nil {
+		return "", err
+	}
+	return docker.GetImageDigest(image, keychain)
+}
+
+func (c *containerSignImageUtilsBundle) AttachSignature(repository, digest string, signature docker.ImageSignature, dockerConfigJSON []byte) (string, error) {
+	keychain, err := docker.KeychainFromDockerConfigJSON(dockerConfigJSON)
+	if err != nil {
+		return "", err
+	}
+	return docker.AttachSignature(repository, digest, signature, keychain)
+}
+
+func newContainerSignImageUtils() containerSignImageUtils {
+	utils := containerSignImageUtilsBundle{
+		Files: &piperutils.Files{},
+	}
+	return &utils
+}
+
+func containerSignImage(config containerSignImageOptions, telemetryData *telemetry.CustomData) {
+	utils := newContainerSignImageUtils()
+
+	err := runContainerSignImage(&config, telemetryData, utils)
+	if err != nil {
+		log.Entry().WithError(err).Fatal("step execution failed")
+	}
+}
+
+func runContainerSignImage(config *containerSignImageOptions, telemetryData *telemetry.CustomData, utils containerSignImageUtils) error {
+	keyContent, err := utils.FileRead(config.SigningKeyFile)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.Wrapf(err, "failed to read signing key %v", config.SigningKeyFile)
+	}
+	signer, err := attestation.LoadPrivateKey(keyContent)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.Wrapf(err, "invalid signing key %v", config.SigningKeyFile)
+	}
+
+	dockerConfig, err := readContainerDockerConfigJSON(config.DockerConfigJSON, utils.FileRead)
+	if err != nil {
+		return err
+	}
+	images, err := containerImagesWithDigests(config.ContainerRegistryURL, config.ContainerImageNameTags, config.ContainerImageDigests, dockerConfig, utils)
+	if err != nil {
+		return err
+	}
+
+	for _, image := range images {
+		payload, signature, err := attestation.SignImage(image.Repository, image.Digest, config.SignatureAnnotations, signer)
+		if err != nil {
+			return err
+		}
+		tag, err := utils.AttachSignature(image.Repository, image.Digest, docker.ImageSignature{Payload: payload, Signature: signature}, dockerConfig)
+		if err != nil {
+			return errors.Wrapf(err, "failed to attach signature to image %v", image.Reference())
+		}
+		log.Entry().Infof("image %v signed, signature stored as %v", image.Reference(), tag)
+	}
+	return nil
+}
+
+// containerImagesWithDigests returns the images, digests which are not provided are read from the registry
+func containerImagesWithDigests(registryURL string, imageNameTags, imageDigests []string, dockerConfigJSON []byte, utils containerImageDigestResolver) ([]docker.ContainerImage, error) {
+	images, err := docker.ContainerImagesFromNameTags(registryURL, imageNameTags, imageDigests)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return nil, err
+	}
+	if len(images) == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return nil, errors.New("no container images provided")
+	}
+	for i, image := range images {
+		if len(image.Digest) > 0 {
+			continue
+		}
+		digest, err := utils.GetImageDigest(image.Reference(), dockerConfigJSON)
+		if err != nil {
+			return nil, err
+		}
+		log.Entry().Debugf("digest of image %v: %v", image.Reference(), digest)
+		images[i].Digest = digest
+	}
+	return images, nil
+}
+
+// readContainerDockerConfigJSON returns the content of the Docker config.json, no content if no file is configured
+func readContainerDockerConfigJSON(path string, fileRead func(path string) ([]byte, error)) ([]byte, error) {
+	if len(path) == 0 {
+		return []byte{}, nil
+	}
+	content, err := fileRead(path)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return nil, errors.Wrapf(err, "failed to read Docker config.json %v", path)
+	}
+	return content, nil
+}
@@ -0,0 +1,171 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/jenkins-library/pkg/attestation"
+	"github.com/SAP/jenkins-library/pkg/docker"
+	"github.com/SAP/jenkins-library/pkg/mock"
+)
+
+// containerImageRegistryMockUtils works against a local registry and is shared by containerSignImage and containerVerifyImage tests
+type containerImageRegistryMockUtils struct {
+	*mock.FilesMock
+	dockerConfigJSON []byte
+	attachError      error
+}
+
+func (c *containerImageRegistryMockUtils) GetImageDigest(image string, dockerConfigJSON []byte) (string, error) {
+	c.dockerConfigJSON = dockerConfigJSON
+	return docker.GetImageDigest(image, authn.DefaultKeychain)
+}
+
+func (c *containerImageRegistryMockUtils) AttachSignature(repository, digest string, signature docker.ImageSignature, dockerConfigJSON []byte) (string, error) {
+	if c.attachError != nil {
+		return "", c.attachError
+	}
+	c.dockerConfigJSON = dockerConfigJSON
+	return docker.AttachSignature(repository, digest, signature, authn.DefaultKeychain)
+}
+
+func (c *containerImageRegistryMockUtils) GetSignatures(repository, digest string, dockerConfigJSON []byte) ([]docker.ImageSignature, error) {
+	return docker.GetSignatures(repository, digest, authn.DefaultKeychain)
+}
+
+func (c *containerImageRegistryMockUtils) GetAttestations(repository, digest string, dockerConfigJSON []byte) ([]attestation.Envelope, error) {
+	return docker.GetAttestations(repository, digest, authn.DefaultKeychain)
+}
+
+func newContainerImageRegistryMockUtils() *containerImageRegistryMockUtils {
+	return &containerImageRegistryMockUtils{FilesMock: &mock.FilesMock{}}
+}
+
+// newContainerImageTestRegistry starts a local registry containing the image app:1.0.0 and returns its url and the digest of the image
+func newContainerImageTestRegistry(t *testing.T) (string, string) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(server.Close)
+
+	image, err := random.Image(64, 1)
+	assert.NoError(t, err)
+	tag, err := name.NewTag(fmt.Sprintf("%v/app:1.0.0", server.Listener.Addr().String()))
+	assert.NoError(t, err)
+	assert.NoError(t, remote.Write(tag, image))
+	digest, err := image.Digest()
+	assert.NoError(t, err)
+	return server.URL, digest.String()
+}
+
+// newContainerImageTestKey returns a PEM encoded private key and the corresponding public key
+func newContainerImageTestKey(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	privateDER, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+}
+
+func TestRunContainerSignImage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success - digest read from registry", func(t *testing.T) {
+		t.Parallel()
+		registryURL, digest := newContainerImageTestRegistry(t)
+		privateKey, publicKey := newContainerImageTestKey(t)
+		config := containerSignImageOptions{
+			SigningKeyFile:         "signing-key.pem",
+			ContainerRegistryURL:   registryURL,
+			ContainerImageNameTags: []string{"app:1.0.0"},
+			SignatureAnnotations:   map[string]interface{}{"gitCommitId": "0123456789abcdef"},
+			DockerConfigJSON:       ".docker/config.json",
+		}
+		utils := newContainerImageRegistryMockUtils()
+		utils.AddFile("signing-key.pem", privateKey)
+		utils.AddFile(".docker/config.json", []byte(`{"auths":{}}`))
+
+		err := runContainerSignImage(&config, nil, utils)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(`{"auths":{}}`), utils.dockerConfigJSON)
+		images, _ := docker.ContainerImagesFromNameTags(registryURL, config.ContainerImageNameTags, nil)
+		signatures, err := docker.GetSignatures(images[0].Repository, digest, authn.DefaultKeychain)
+		assert.NoError(t, err)
+		if assert.Len(t, signatures, 1) {
+			key, _ := attestation.LoadPublicKey(publicKey)
+			signed, err := attestation.VerifyImageSignature(signatures[0].Payload, signatures[0].Signature, images[0].Repository, digest, key)
+			assert.NoError(t, err)
+			assert.Equal(t, images[0].Repository, signed.Critical.Identity.DockerReference)
+			assert.Equal(t, "0123456789abcdef", signed.Optional["gitCommitId"])
+		}
+	})
+
+	t.Run("error - invalid signing key", func(t *testing.T) {
+		t.Parallel()
+		config := containerSignImageOptions{SigningKeyFile: "signing-key.pem", ContainerImageNameTags: []string{"app:1.0.0"}}
+		utils := newContainerImageRegistryMockUtils()
+		utils.AddFile("signing-key.pem", []byte("no key"))
+
+		err := runContainerSignImage(&config, nil, utils)
+
+		assert.ErrorContains(t, err, "invalid signing key signing-key.pem")
+	})
+
+	t.Run("error - missing signing key", func(t *testing.T) {
+		t.Parallel()
+		config := containerSignImageOptions{SigningKeyFile: "signing-key.pem", ContainerImageNameTags: []string{"app:1.0.0"}}
+
+		err := runContainerSignImage(&config, nil, newContainerImageRegistryMockUtils())
+
+		assert.ErrorContains(t, err, "failed to read signing key signing-key.pem")
+	})
+
+	t.Run("error - no images", func(t *testing.T) {
+		t.Parallel()
+		privateKey, _ := newContainerImageTestKey(t)
+		config := containerSignImageOptions{SigningKeyFile: "signing-key.pem"}
+		utils := newContainerImageRegistryMockUtils()
+		utils.AddFile("signing-key.pem", privateKey)
+
+		err := runContainerSignImage(&config, nil, utils)
+
+		assert.EqualError(t, err, "no container images provided")
+	})
+
+	t.Run("error - attaching signature", func(t *testing.T) {
+		t.Parallel()
+		privateKey, _ := newContainerImageTestKey(t)
+		config := containerSignImageOptions{
+			SigningKeyFile:         "signing-key.pem",
+			ContainerRegistryURL:   "https://my.registry",
+			ContainerImageNameTags: []string{"app:1.0.0"},
+			ContainerImageDigests:  []string{"sha256:abc"},
+		}
+		utils := newContainerImageRegistryMockUtils()
+		utils.AddFile("signing-key.pem", privateKey)
+		utils.attachError = fmt.Errorf("unauthorized")
+
+		err := runContainerSignImage(&config, nil, utils)
+
+		assert.EqualError(t, err, "failed to attach signature to image my.registry/app@sha256:abc: unauthorized")
+	})
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/SAP/jenkins-library/pkg/attestation"
+	"github.com/SAP/jenkins-library/pkg/docker"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+)
+
+type containerVerifyImageUtils interface {
+	FileExists(filename string) (bool, error)
+	FileRead(path string) ([]byte, error)
+	FileWrite(path string, content []byte, perm os.FileMode) error
+	MkdirAll(path string, perm os.FileMode) error
+
+	containerImageDigestResolver
+	GetSignatures(repository, digest string, dockerConfigJSON []byte) ([]docker.ImageSignature, error)
+	GetAttestations(repository, digest string, dockerConfigJSON []byte) ([]attestation.Envelope, error)
+}
+
+type containerVerifyImageUtilsBundle struct {
+	*piperutils.Files
+}
+
+func (c *containerVerifyImageUtilsBundle) GetImageDigest(image string, dockerConfigJSON []byte) (string, error) {
+	keychain, err := docker.KeychainFromDockerConfigJSON(dockerConfigJSON)
+	if err != nil {
+		return "", err
+	}
+	return docker.GetImageDigest(image, keychain)
+}
+
+func (c *containerVerifyImageUtilsBundle) GetSignatures(repository, digest string, dockerConfigJSON []byte) ([]docker.ImageSignature, error) {
+	keychain, err := docker.KeychainFromDockerConfigJSON(dockerConfigJSON)
+	if err != nil {
+		return nil, err
+	}
+	return docker.GetSignatures(repository, digest, keychain)
+}
+
+func (c *containerVerifyImageUtilsBundle) GetAttestations(repository, digest string, dockerConfigJSON []byte) ([]attestation.Envelope, error) {
+	keychain, err := docker.KeychainFromDockerConfigJSON(dockerConfigJSON)
+	if err != nil {
+		return nil, err
+	}
+	return docker.GetAttestations(repository, digest, keychain)
+}
+
+func newContainerVerifyImageUtils() containerVerifyImageUtils {
+	utils := containerVerifyImageUtilsBundle{
+		Files: &piperutils.Files{},
+	}
+	return &utils
+}
+
+func containerVerifyImage(config containerVerifyImageOptions, telemetryData *telemetry.CustomData) {
+	utils := newContainerVerifyImageUtils()
+
+	err := runContainerVerifyImage(&config, telemetryData, utils)
+	if err != nil {
+		log.Entry().WithError(err).Fatal("step execution failed")
+	}
+}
+
+func runContainerVerifyImage(config *containerVerifyImageOptions, telemetryData *telemetry.CustomData, utils containerVerifyImageUtils) error {
+	keyContent, err := utils.FileRead(config.PublicKeyFile)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.Wrapf(err, "failed to read public key %v", config.PublicKeyFile)
+	}
+	publicKey, err := attestation.LoadPublicKey(keyContent)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.Wrapf(err, "invalid public key %v", config.PublicKeyFile)
+	}
+
+	dockerConfig, err := readContainerDockerConfigJSON(config.DockerConfigJSON, utils.FileRead)
+	if err != nil {
+		return err
+	}
+	images, err := docker.ContainerImagesFromNameTags(config.ContainerRegistryURL, config.ContainerImageNameTags, config.ContainerImageDigests)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+	if len(images) == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.New("no container images provided")
+	}
+
+	failed := []string{}
+	for _, image := range images {
+		reference := fmt.Sprintf("%v:%v", image.Repository, image.Tag)
+		verifiedImage, err := verifyRegistryContainerImage(image, publicKey, config.RequireProvenance, dockerConfig, utils)
+		if err != nil {
+			log.Entry().WithError(err).Errorf("verification of image %v failed", reference)
+			failed = append(failed, reference)
+			continue
+		}
+		log.Entry().Infof("image %v verified, deployments need to use the digest reference %v", reference, verifiedImage.Reference())
+	}
+	if len(failed) > 0 {
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("verification failed for %v image(s): %v", len(failed), failed)
+	}
+	return nil
+}
+
+// verifyRegistryContainerImage verifies the image the tag currently points to in the registry since the tag may have been moved
+// after the build. If the digest of the build is known, the image in the registry has to match it.
+func verifyRegistryContainerImage(image docker.ContainerImage, publicKey crypto.PublicKey, requireProvenance bool, dockerConfigJSON []byte, utils containerVerifyImageUtils) (docker.ContainerImage, error) {
+	registryImage := docker.ContainerImage{Repository: image.Repository, Tag: image.Tag}
+	digest, err := utils.GetImageDigest(registryImage.Reference(), dockerConfigJSON)
+	if err != nil {
+		return registryImage, err
+	}
+	log.Entry().Debugf("digest of image %v: %v", registryImage.Reference(), digest)
+	if len(image.Digest) > 0 && image.Digest != digest {
+		return registryImage, fmt.Errorf("image in the registry has digest %v but the built image has digest %v", digest, image.Digest)
+	}
+	registryImage.Digest = digest
+	return registryImage, verifyContainerImage(registryImage, publicKey, requireProvenance, dockerConfigJSON, utils)
+}
+
+func verifyContainerImage(image docker.ContainerImage, publicKey crypto.PublicKey, requireProvenance bool, dockerConfigJSON []byte, utils containerVerifyImageUtils) error {
+	signatures, err := utils.GetSignatures(image.Repository, image.Digest, dockerConfigJSON)
+	if err != nil {
+		return err
+	}
+	if len(signatures) == 0 {
+		return errors.New("image is not signed")
+	}
+	verified := false
+	for _, signature := range signatures {
+		if _, err := attestation.VerifyImageSignature(signature.Payload, signature.Signature, image.Repository, image.Digest, publicKey); err != nil {
+			log.Entry().Debugf("signature of image %v ignored: %v", image.Reference(), err)
+			continue
+		}
+		verified = true
+		break
+	}
+	if !verified {
+		return fmt.Errorf("none of the %v signature(s) has been created with the trusted key for repository %v and digest %v", len(signatures), image.Repository, image.Digest)
+	}
+
+	if requireProvenance {
+		return verifyContainerImageProvenance(image, publicKey, dockerConfigJSON, utils)
+	}
+	return nil
+}
+
+// verifyContainerImageProvenance checks that a signed provenance with the image as subject is attached to the image
+func verifyContainerImageProvenance(image docker.ContainerImage, publicKey crypto.PublicKey, dockerConfigJSON []byte, utils containerVerifyImageUtils) error {
+	algorithm, digest, _ := strings.Cut(image.Digest, ":")
+	envelopes, err := utils.GetAttestations(image.Repository, image.Digest, dockerConfigJSON)
+	if err != nil {
+		return err
+	}
+	for _, envelope := range envelopes {
+		payload, err := envelope.Verify(publicKey)
+		if err != nil {
+			continue
+		}
+		statement := attestation.Statement{}
+		if err := json.Unmarshal(payload, &statement); err != nil || statement.PredicateType != attestation.PredicateTypeSLSAProvenance {
+			continue
+		}
+		for _, subject := range statement.Subject {
+			if subject.Name == image.Repository && subject.Digest[algorithm] == digest {
+				return nil
+			}
+		}
+	}
+	return errors.New("no provenance attestation signed with the trusted key found")
+}
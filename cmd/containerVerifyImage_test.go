@@ -0,0 +1,161 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/jenkins-library/pkg/attestation"
+	"github.com/SAP/jenkins-library/pkg/docker"
+)
+
+func TestRunContainerVerifyImage(t *testing.T) {
+	t.Parallel()
+
+	// signImage signs the image app:1.0.0 in the registry with the given key
+	signImage := func(t *testing.T, registryURL string, privateKey []byte) {
+		config := containerSignImageOptions{
+			SigningKeyFile:         "signing-key.pem",
+			ContainerRegistryURL:   registryURL,
+			ContainerImageNameTags: []string{"app:1.0.0"},
+		}
+		utils := newContainerImageRegistryMockUtils()
+		utils.AddFile("signing-key.pem", privateKey)
+		assert.NoError(t, runContainerSignImage(&config, nil, utils))
+	}
+
+	// attachProvenance attaches a provenance for the image app:1.0.0 in the registry signed with the given key
+	attachProvenance := func(t *testing.T, registryURL, digest string, privateKey []byte) {
+		images, err := docker.ContainerImagesFromNameTags(registryURL, []string{"app:1.0.0"}, []string{digest})
+		assert.NoError(t, err)
+		subject, err := attestation.ImageSubject(images[0].Repository, digest)
+		assert.NoError(t, err)
+		signer, err := attestation.LoadPrivateKey(privateKey)
+		assert.NoError(t, err)
+		envelope, err := attestation.SignStatement(attestation.NewProvenanceStatement([]attestation.Subject{subject}, attestation.BuildInfo{}), signer)
+		assert.NoError(t, err)
+		_, err = docker.AttachAttestation(images[0].Repository, digest, envelope, attestation.PredicateTypeSLSAProvenance, authn.DefaultKeychain)
+		assert.NoError(t, err)
+	}
+
+	newConfig := func(registryURL string) containerVerifyImageOptions {
+		return containerVerifyImageOptions{
+			PublicKeyFile:          "public-key.pem",
+			ContainerRegistryURL:   registryURL,
+			ContainerImageNameTags: []string{"app:1.0.0"},
+		}
+	}
+
+	t.Run("success - signed image", func(t *testing.T) {
+		t.Parallel()
+		registryURL, digest := newContainerImageTestRegistry(t)
+		privateKey, publicKey := newContainerImageTestKey(t)
+		signImage(t, registryURL, privateKey)
+		config := newConfig(registryURL)
+		config.ContainerImageDigests = []string{digest}
+		utils := newContainerImageRegistryMockUtils()
+		utils.AddFile("public-key.pem", publicKey)
+
+		err := runContainerVerifyImage(&config, nil, utils)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("success - signed image with provenance", func(t *testing.T) {
+		t.Parallel()
+		registryURL, digest := newContainerImageTestRegistry(t)
+		privateKey, publicKey := newContainerImageTestKey(t)
+		signImage(t, registryURL, privateKey)
+		attachProvenance(t, registryURL, digest, privateKey)
+		config := newConfig(registryURL)
+		config.RequireProvenance = true
+		utils := newContainerImageRegistryMockUtils()
+		utils.AddFile("public-key.pem", publicKey)
+
+		err := runContainerVerifyImage(&config, nil, utils)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error - unsigned image", func(t *testing.T) {
+		t.Parallel()
+		registryURL, _ := newContainerImageTestRegistry(t)
+		_, publicKey := newContainerImageTestKey(t)
+		config := newConfig(registryURL)
+		utils := newContainerImageRegistryMockUtils()
+		utils.AddFile("public-key.pem", publicKey)
+
+		err := runContainerVerifyImage(&config, nil, utils)
+
+		assert.ErrorContains(t, err, "verification failed for 1 image(s)")
+	})
+
+	t.Run("error - tag moved after the build", func(t *testing.T) {
+		t.Parallel()
+		registryURL, digest := newContainerImageTestRegistry(t)
+		privateKey, publicKey := newContainerImageTestKey(t)
+		signImage(t, registryURL, privateKey)
+		config := newConfig(registryURL)
+		config.ContainerImageDigests = []string{"sha256:0000000000000000000000000000000000000000000000000000000000000000"}
+		utils := newContainerImageRegistryMockUtils()
+		utils.AddFile("public-key.pem", publicKey)
+
+		images, _ := docker.ContainerImagesFromNameTags(registryURL, config.ContainerImageNameTags, config.ContainerImageDigests)
+		key, _ := attestation.LoadPublicKey(publicKey)
+		_, err := verifyRegistryContainerImage(images[0], key, false, nil, utils)
+		assert.EqualError(t, err, "image in the registry has digest "+digest+" but the built image has digest sha256:0000000000000000000000000000000000000000000000000000000000000000")
+
+		err = runContainerVerifyImage(&config, nil, utils)
+
+		assert.ErrorContains(t, err, "verification failed for 1 image(s)")
+	})
+
+	t.Run("error - signed with other key", func(t *testing.T) {
+		t.Parallel()
+		registryURL, digest := newContainerImageTestRegistry(t)
+		privateKey, _ := newContainerImageTestKey(t)
+		_, publicKey := newContainerImageTestKey(t)
+		signImage(t, registryURL, privateKey)
+		config := newConfig(registryURL)
+		utils := newContainerImageRegistryMockUtils()
+		utils.AddFile("public-key.pem", publicKey)
+
+		images, _ := containerImagesWithDigests(registryURL, config.ContainerImageNameTags, nil, nil, utils)
+		key, _ := attestation.LoadPublicKey(publicKey)
+		assert.EqualError(t, verifyContainerImage(images[0], key, false, nil, utils), "none of the 1 signature(s) has been created with the trusted key for repository "+images[0].Repository+" and digest "+digest)
+
+		err := runContainerVerifyImage(&config, nil, utils)
+
+		assert.ErrorContains(t, err, "verification failed for 1 image(s)")
+	})
+
+	t.Run("error - provenance missing", func(t *testing.T) {
+		t.Parallel()
+		registryURL, _ := newContainerImageTestRegistry(t)
+		privateKey, publicKey := newContainerImageTestKey(t)
+		signImage(t, registryURL, privateKey)
+		config := newConfig(registryURL)
+		utils := newContainerImageRegistryMockUtils()
+		utils.AddFile("public-key.pem", publicKey)
+
+		images, _ := containerImagesWithDigests(registryURL, config.ContainerImageNameTags, nil, nil, utils)
+		key, _ := attestation.LoadPublicKey(publicKey)
+		assert.NoError(t, verifyContainerImage(images[0], key, false, nil, utils))
+		assert.EqualError(t, verifyContainerImage(images[0], key, true, nil, utils), "no provenance attestation signed with the trusted key found")
+	})
+
+	t.Run("error - invalid public key", func(t *testing.T) {
+		t.Parallel()
+		config := newConfig("https://my.registry")
+		utils := newContainerImageRegistryMockUtils()
+		utils.AddFile("public-key.pem", []byte("no key"))
+
+		err := runContainerVerifyImage(&config, nil, utils)
+
+		assert.ErrorContains(t, err, "invalid public key public-key.pem")
+	})
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/vault/api"
+
+	"github.com/SAP/jenkins-library/pkg/encryption"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
+	"github.com/SAP/jenkins-library/pkg/vault"
+)
+
+const (
+	cpeEncryptionPassword     = "password"
+	cpeEncryptionVaultTransit = "vaultTransit"
+	cpeEncryptionX25519       = "x25519"
+)
+
+// configureCPEEncryption sets the key provider used to keep the commonPipelineEnvironment encrypted at rest.
+// It needs to be called before the commonPipelineEnvironment is read in order to decrypt existing values.
+func configureCPEEncryption() error {
+	provider, err := cpeKeyProvider(GeneralConfig.CPEEncryption)
+	if err != nil {
+		return fmt.Errorf("failed to configure encryption of the commonPipelineEnvironment: %w", err)
+	}
+	if provider != nil {
+		log.Entry().Debugf("commonPipelineEnvironment is encrypted using key provider '%v'", provider.Name())
+	}
+	piperenv.SetKeyProvider(provider)
+	return nil
+}
+
+func cpeKeyProvider(name string) (piperenv.KeyProvider, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case cpeEncryptionPassword:
+		password := os.Getenv("PIPER_cpeEncryptionPassword")
+		if len(password) == 0 {
+			return nil, fmt.Errorf("environment variable PIPER_cpeEncryptionPassword is not set")
+		}
+		log.RegisterSecret(password)
+		provider, err := encryption.NewPasswordKeyProvider(password)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	case cpeEncryptionX25519:
+		if len(GeneralConfig.CPEEncryptionKeyFile) == 0 {
+			return nil, fmt.Errorf("no key file defined, please use flag cpeEncryptionKeyFile or environment variable PIPER_cpeEncryptionKeyFile")
+		}
+		keyFile, err := os.ReadFile(GeneralConfig.CPEEncryptionKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key file: %w", err)
+		}
+		provider, err := encryption.NewX25519KeyProvider(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	case cpeEncryptionVaultTransit:
+		client, err := cpeVaultClient()
+		if err != nil {
+			return nil, err
+		}
+		provider, err := client.NewTransitKeyProvider(GeneralConfig.CPETransitKey)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	default:
+		return nil, fmt.Errorf("key provider '%v' is not supported, supported are '%v', '%v' and '%v'", name, cpeEncryptionPassword, cpeEncryptionVaultTransit, cpeEncryptionX25519)
+	}
+}
+
+// cpeVaultClient creates a dedicated Vault client since the client for the step configuration
+// can only be created after the commonPipelineEnvironment has been read.
+func cpeVaultClient() (*vault.Client, error) {
+	if len(GeneralConfig.VaultServerURL) == 0 {
+		return nil, fmt.Errorf("no Vault server defined, please use flag vaultServerUrl")
+	}
+	clientConfig := &vault.ClientConfig{Config: &api.Config{Address: GeneralConfig.VaultServerURL}, Namespace: GeneralConfig.VaultNamespace}
+	token := GeneralConfig.VaultToken
+	if len(token) == 0 {
+		token = os.Getenv("PIPER_vaultToken")
+	}
+	if len(token) > 0 {
+		return vault.NewClientWithToken(clientConfig, token)
+	}
+	clientConfig.RoleID = GeneralConfig.VaultRoleID
+	if len(clientConfig.RoleID) == 0 {
+		clientConfig.RoleID = os.Getenv("PIPER_vaultAppRoleID")
+	}
+	clientConfig.SecretID = GeneralConfig.VaultRoleSecretID
+	if len(clientConfig.SecretID) == 0 {
+		clientConfig.SecretID = os.Getenv("PIPER_vaultAppRoleSecretID")
+	}
+	if len(clientConfig.RoleID) == 0 || len(clientConfig.SecretID) == 0 {
+		return nil, fmt.Errorf("no Vault credentials available, please provide a Vault token or AppRole credentials")
+	}
+	return vault.NewClient(clientConfig)
+}
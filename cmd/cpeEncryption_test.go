@@ -0,0 +1,98 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/jenkins-library/pkg/encryption"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
+)
+
+func TestCPEKeyProvider(t *testing.T) {
+	prepare := func(t *testing.T) {
+		generalConfig := GeneralConfig
+		t.Cleanup(func() {
+			GeneralConfig = generalConfig
+			piperenv.SetKeyProvider(nil)
+		})
+	}
+
+	t.Run("no encryption", func(t *testing.T) {
+		prepare(t)
+
+		assert.NoError(t, configureCPEEncryption())
+	})
+
+	t.Run("password", func(t *testing.T) {
+		prepare(t)
+		GeneralConfig.CPEEncryption = "password"
+		t.Setenv("PIPER_cpeEncryptionPassword", "secret")
+
+		provider, err := cpeKeyProvider(GeneralConfig.CPEEncryption)
+		assert.NoError(t, err)
+		assert.Equal(t, "password", provider.Name())
+		assert.NoError(t, configureCPEEncryption())
+	})
+
+	t.Run("password missing", func(t *testing.T) {
+		prepare(t)
+		GeneralConfig.CPEEncryption = "password"
+		t.Setenv("PIPER_cpeEncryptionPassword", "")
+
+		assert.EqualError(t, configureCPEEncryption(), "failed to configure encryption of the commonPipelineEnvironment: environment variable PIPER_cpeEncryptionPassword is not set")
+	})
+
+	t.Run("x25519", func(t *testing.T) {
+		prepare(t)
+		identity, recipient, err := encryption.GenerateX25519Identity()
+		assert.NoError(t, err)
+		keyFile := filepath.Join(t.TempDir(), "key.txt")
+		assert.NoError(t, os.WriteFile(keyFile, []byte(identity), 0o600))
+		GeneralConfig.CPEEncryptionKeyFile = keyFile
+
+		provider, err := cpeKeyProvider("x25519")
+		assert.NoError(t, err)
+		assert.Equal(t, recipient, provider.(*encryption.X25519KeyProvider).Recipient())
+	})
+
+	t.Run("x25519 without key file", func(t *testing.T) {
+		prepare(t)
+		GeneralConfig.CPEEncryptionKeyFile = ""
+
+		_, err := cpeKeyProvider("x25519")
+		assert.EqualError(t, err, "no key file defined, please use flag cpeEncryptionKeyFile or environment variable PIPER_cpeEncryptionKeyFile")
+	})
+
+	t.Run("vault transit without server", func(t *testing.T) {
+		prepare(t)
+		GeneralConfig.VaultServerURL = ""
+
+		_, err := cpeKeyProvider("vaultTransit")
+		assert.EqualError(t, err, "no Vault server defined, please use flag vaultServerUrl")
+	})
+
+	t.Run("vault transit without credentials", func(t *testing.T) {
+		prepare(t)
+		GeneralConfig.VaultServerURL = "https://vault.example.com"
+		GeneralConfig.VaultToken, GeneralConfig.VaultRoleID, GeneralConfig.VaultRoleSecretID = "", "", ""
+		t.Setenv("PIPER_vaultToken", "")
+		t.Setenv("PIPER_vaultAppRoleID", "")
+		t.Setenv("PIPER_vaultAppRoleSecretID", "")
+
+		_, err := cpeKeyProvider("vaultTransit")
+		assert.EqualError(t, err, "no Vault credentials available, please provide a Vault token or AppRole credentials")
+	})
+
+	t.Run("unsupported key provider", func(t *testing.T) {
+		prepare(t)
+
+		_, err := cpeKeyProvider("rot13")
+		assert.EqualError(t, err, "key provider 'rot13' is not supported, supported are 'password', 'vaultTransit' and 'x25519'")
+	})
+}
@@ -0,0 +1,85 @@
+// Code generated by piper's step-generator. DO NOT EDIT.
+
+package cmd
+
+import "github.com/SAP/jenkins-library/pkg/piperenv"
+
+// CPERegistry returns all keys of the commonPipelineEnvironment defined in the step metadata together with their type
+func CPERegistry() piperenv.Registry {
+	return piperenv.Registry{
+		"abap/addonDescriptor":                                        {Type: "string", WrittenBy: []string{"abapAddonAssemblyKitCheck", "abapAddonAssemblyKitCheckCVs", "abapAddonAssemblyKitCheckPV", "abapAddonAssemblyKitCreateTargetVector", "abapAddonAssemblyKitRegisterPackages", "abapAddonAssemblyKitReleasePackages", "abapAddonAssemblyKitReserveNextPackages", "abapEnvironmentAssembleConfirm", "abapEnvironmentAssemblePackages"}, ReadBy: []string{"abapAddonAssemblyKitCheck", "abapAddonAssemblyKitCheckCVs", "abapAddonAssemblyKitCheckPV", "abapAddonAssemblyKitCreateTargetVector", "abapAddonAssemblyKitPublishTargetVector", "abapAddonAssemblyKitRegisterPackages", "abapAddonAssemblyKitReleasePackages", "abapAddonAssemblyKitReserveNextPackages", "abapEnvironmentAssembleConfirm", "abapEnvironmentAssemblePackages", "abapEnvironmentBuild"}},
+		"abap/buildValues":                                            {Type: "string", WrittenBy: []string{"abapEnvironmentBuild"}, ReadBy: []string{"abapEnvironmentBuild"}},
+		"artifactId":                                                  {Type: "string", WrittenBy: []string{"artifactPrepareVersion"}, ReadBy: []string{"gradleExecuteBuild"}},
+		"artifactVersion":                                             {Type: "string", WrittenBy: []string{"artifactPrepareVersion"}, ReadBy: []string{"cloudFoundryDeploy", "cnbBuild", "detectExecuteScan", "fortifyExecuteScan", "githubPublishRelease", "golangBuild", "gradleExecuteBuild", "imagePushToRegistry", "kanikoExecute", "kubernetesDeploy", "mtaBuild", "protecodeExecuteScan", "sbomMerge", "sonarExecuteScan", "whitesourceExecuteScan"}},
+		"buildTool":                                                   {Type: "string", ReadBy: []string{"cloudFoundryDeploy", "detectExecuteScan", "malwareExecuteScan", "whitesourceExecuteScan"}},
+		"container/buildpacks":                                        {Type: "[]string", ReadBy: []string{"cnbBuild"}},
+		"container/imageDigest":                                       {Type: "string", WrittenBy: []string{"cnbBuild", "kanikoExecute"}},
+		"container/imageDigests":                                      {Type: "[]string", WrittenBy: []string{"cnbBuild", "kanikoExecute"}, ReadBy: []string{"containerSignImage", "containerVerifyImage", "kubernetesDeploy", "provenanceCreate"}},
+		"container/imageNameTag":                                      {Type: "string", WrittenBy: []string{"cnbBuild", "kanikoExecute"}, ReadBy: []string{"containerSaveImage", "gitopsUpdateDeployment", "helmExecute", "kubernetesDeploy", "malwareExecuteScan", "protecodeExecuteScan", "whitesourceExecuteScan"}},
+		"container/imageNameTags":                                     {Type: "[]string", WrittenBy: []string{"cnbBuild", "kanikoExecute"}, ReadBy: []string{"containerSignImage", "containerVerifyImage", "detectExecuteScan", "imagePushToRegistry", "kubernetesDeploy", "osvExecuteScan", "provenanceCreate", "whitesourceExecuteScan"}},
+		"container/imageNames":                                        {Type: "[]string", WrittenBy: []string{"cnbBuild", "kanikoExecute"}, ReadBy: []string{"imagePushToRegistry", "kubernetesDeploy"}},
+		"container/postBuildpacks":                                    {Type: "[]string", ReadBy: []string{"cnbBuild"}},
+		"container/preBuildpacks":                                     {Type: "[]string", ReadBy: []string{"cnbBuild"}},
+		"container/registryUrl":                                       {Type: "string", WrittenBy: []string{"cnbBuild", "kanikoExecute"}, ReadBy: []string{"cnbBuild", "containerSaveImage", "containerSignImage", "containerVerifyImage", "detectExecuteScan", "gitopsUpdateDeployment", "imagePushToRegistry", "kanikoExecute", "kubernetesDeploy", "malwareExecuteScan", "osvExecuteScan", "protecodeExecuteScan", "provenanceCreate", "whitesourceExecuteScan"}},
+		"container/repositoryPassword":                                {Type: "string", ReadBy: []string{"containerSaveImage", "detectExecuteScan", "imagePushToRegistry", "kanikoExecute", "kubernetesDeploy", "malwareExecuteScan", "protecodeExecuteScan", "whitesourceExecuteScan"}},
+		"container/repositoryUsername":                                {Type: "string", ReadBy: []string{"containerSaveImage", "detectExecuteScan", "imagePushToRegistry", "kanikoExecute", "kubernetesDeploy", "malwareExecuteScan", "protecodeExecuteScan", "whitesourceExecuteScan"}},
+		"custom/apiProviderList":                                      {Type: "string", WrittenBy: []string{"apiProviderList"}},
+		"custom/apiProxyList":                                         {Type: "string", WrittenBy: []string{"apiProxyList"}},
+		"custom/artifactVersions":                                     {Type: "string", WrittenBy: []string{"artifactPrepareVersion"}},
+		"custom/artifacts":                                            {Type: "piperenv.Artifacts", WrittenBy: []string{"golangBuild", "gradleExecuteBuild"}},
+		"custom/buildSettingsInfo":                                    {Type: "string", WrittenBy: []string{"cnbBuild", "golangBuild", "gradleExecuteBuild", "kanikoExecute", "mavenBuild", "mtaBuild", "npmExecuteScripts", "pythonBuild"}, ReadBy: []string{"cnbBuild", "golangBuild", "gradleExecuteBuild", "kanikoExecute", "mavenBuild", "mtaBuild", "npmExecuteScripts", "pythonBuild"}},
+		"custom/changeDocumentId":                                     {Type: "string", WrittenBy: []string{"transportRequestDocIDFromGit", "transportRequestUploadSOLMAN"}, ReadBy: []string{"isChangeInDevelopment", "transportRequestUploadSOLMAN"}},
+		"custom/dockerConfigJSON":                                     {Type: "string", ReadBy: []string{"cnbBuild", "containerSaveImage", "malwareExecuteScan", "protecodeExecuteScan", "whitesourceExecuteScan"}},
+		"custom/eventData":                                            {Type: "string", ReadBy: []string{"gcpPublishEvent"}},
+		"custom/helmChartUrl":                                         {Type: "string", WrittenBy: []string{"helmExecute"}},
+		"custom/helmRepositoryPassword":                               {Type: "string", ReadBy: []string{"helmExecute"}},
+		"custom/helmRepositoryURL":                                    {Type: "string", ReadBy: []string{"helmExecute"}},
+		"custom/helmRepositoryUsername":                               {Type: "string", ReadBy: []string{"helmExecute"}},
+		"custom/integrationFlowMplError":                              {Type: "string", WrittenBy: []string{"integrationArtifactGetMplStatus"}},
+		"custom/integrationFlowMplStatus":                             {Type: "string", WrittenBy: []string{"integrationArtifactGetMplStatus"}},
+		"custom/integrationFlowServiceEndpoint":                       {Type: "string", WrittenBy: []string{"integrationArtifactGetServiceEndpoint"}, ReadBy: []string{"integrationArtifactTriggerIntegrationTest"}},
+		"custom/integrationFlowTriggerIntegrationTestResponseBody":    {Type: "string", WrittenBy: []string{"integrationArtifactTriggerIntegrationTest"}},
+		"custom/integrationFlowTriggerIntegrationTestResponseHeaders": {Type: "string", WrittenBy: []string{"integrationArtifactTriggerIntegrationTest"}},
+		"custom/isChangeInDevelopment":                                {Type: "bool", WrittenBy: []string{"isChangeInDevelopment"}},
+		"custom/isOptimizedAndScheduled":                              {Type: "bool", ReadBy: []string{"artifactPrepareVersion", "checkmarxExecuteScan", "checkmarxOneExecuteScan", "detectExecuteScan", "fortifyExecuteScan", "whitesourceExecuteScan"}},
+		"custom/localHelmChartPath":                                   {Type: "string", ReadBy: []string{"kubernetesDeploy"}},
+		"custom/mavenBuildArtifacts":                                  {Type: "string", WrittenBy: []string{"mavenBuild"}},
+		"custom/mavenGlobalSettingsFile":                              {Type: "string", ReadBy: []string{"mavenBuild"}},
+		"custom/mavenRepositoryPassword":                              {Type: "string", ReadBy: []string{"mavenBuild", "mtaBuild"}},
+		"custom/mavenRepositoryURL":                                   {Type: "string", ReadBy: []string{"mavenBuild", "mtaBuild"}},
+		"custom/mavenRepositoryUsername":                              {Type: "string", ReadBy: []string{"mavenBuild", "mtaBuild"}},
+		"custom/mtaBuildArtifacts":                                    {Type: "string", WrittenBy: []string{"mtaBuild"}},
+		"custom/mtaBuildToolDesc":                                     {Type: "string", WrittenBy: []string{"mtaBuild"}},
+		"custom/mtarPublishedUrl":                                     {Type: "string", WrittenBy: []string{"mtaBuild"}},
+		"custom/npmBuildArtifacts":                                    {Type: "string", WrittenBy: []string{"npmExecuteScripts"}},
+		"custom/npmRepositoryPassword":                                {Type: "string", ReadBy: []string{"npmExecuteScripts"}},
+		"custom/npmRepositoryURL":                                     {Type: "string", ReadBy: []string{"npmExecuteScripts"}},
+		"custom/npmRepositoryUsername":                                {Type: "string", ReadBy: []string{"npmExecuteScripts"}},
+		"custom/rawRepositoryPassword":                                {Type: "string", ReadBy: []string{"golangBuild"}},
+		"custom/rawRepositoryURL":                                     {Type: "string", ReadBy: []string{"golangBuild"}},
+		"custom/rawRepositoryUsername":                                {Type: "string", ReadBy: []string{"golangBuild"}},
+		"custom/repositoryFormat":                                     {Type: "string", ReadBy: []string{"nexusUpload"}},
+		"custom/repositoryId":                                         {Type: "string", ReadBy: []string{"mavenBuild"}},
+		"custom/repositoryPassword":                                   {Type: "string", ReadBy: []string{"containerSaveImage", "golangBuild", "gradleExecuteBuild", "helmExecute", "kubernetesDeploy", "malwareExecuteScan", "mavenBuild", "mtaBuild", "nexusUpload", "npmExecuteScripts", "protecodeExecuteScan", "pythonBuild", "whitesourceExecuteScan"}},
+		"custom/repositoryUrl":                                        {Type: "string", ReadBy: []string{"golangBuild", "gradleExecuteBuild", "helmExecute", "mavenBuild", "mtaBuild", "nexusUpload", "npmExecuteScripts", "pythonBuild"}},
+		"custom/repositoryUsername":                                   {Type: "string", ReadBy: []string{"containerSaveImage", "golangBuild", "gradleExecuteBuild", "helmExecute", "kubernetesDeploy", "malwareExecuteScan", "mavenBuild", "mtaBuild", "nexusUpload", "npmExecuteScripts", "protecodeExecuteScan", "pythonBuild", "whitesourceExecuteScan"}},
+		"custom/terraformOutputs":                                     {Type: "map[string]interface{}", WrittenBy: []string{"terraformExecute"}},
+		"custom/transportRequestId":                                   {Type: "string", WrittenBy: []string{"transportRequestReqIDFromGit", "transportRequestUploadCTS", "transportRequestUploadRFC", "transportRequestUploadSOLMAN"}, ReadBy: []string{"transportRequestUploadCTS", "transportRequestUploadRFC", "transportRequestUploadSOLMAN"}},
+		"custom/whitesourceProjectNames":                              {Type: "[]string", WrittenBy: []string{"whitesourceExecuteScan"}},
+		"git/branch":                                                  {Type: "string", ReadBy: []string{"checkmarxOneExecuteScan"}},
+		"git/commitId":                                                {Type: "string", WrittenBy: []string{"artifactPrepareVersion"}, ReadBy: []string{"cnbBuild", "fortifyExecuteScan", "githubSetCommitStatus", "tmsExport", "tmsUpload"}},
+		"git/commitMessage":                                           {Type: "string", WrittenBy: []string{"artifactPrepareVersion"}, ReadBy: []string{"fortifyExecuteScan"}},
+		"git/headCommitId":                                            {Type: "string", WrittenBy: []string{"artifactPrepareVersion"}, ReadBy: []string{"cloudFoundryDeploy", "githubPublishRelease"}},
+		"git/httpsUrl":                                                {Type: "string", ReadBy: []string{"codeqlExecuteScan"}},
+		"git/ref":                                                     {Type: "string", ReadBy: []string{"codeqlExecuteScan"}},
+		"git/remoteCommitId":                                          {Type: "string", ReadBy: []string{"codeqlExecuteScan"}},
+		"github/owner":                                                {Type: "string", ReadBy: []string{"checkmarxExecuteScan", "checkmarxOneExecuteScan", "detectExecuteScan", "fortifyExecuteScan", "githubCheckBranchProtection", "githubCommentIssue", "githubCreateIssue", "githubCreatePullRequest", "githubPublishRelease", "githubSetCommitStatus", "sonarExecuteScan", "vaultRotateSecretId", "whitesourceExecuteScan"}},
+		"github/repository":                                           {Type: "string", ReadBy: []string{"checkmarxExecuteScan", "checkmarxOneExecuteScan", "detectExecuteScan", "fortifyExecuteScan", "githubCheckBranchProtection", "githubCommentIssue", "githubCreateIssue", "githubCreatePullRequest", "githubPublishRelease", "githubSetCommitStatus", "sonarExecuteScan", "vaultRotateSecretId", "whitesourceExecuteScan"}},
+		"groupId":                                                     {Type: "string", WrittenBy: []string{"artifactPrepareVersion"}, ReadBy: []string{"gradleExecuteBuild"}},
+		"mtaPath":                                                     {Type: "string", ReadBy: []string{"xsDeploy"}},
+		"mtarFilePath":                                                {Type: "string", WrittenBy: []string{"mtaBuild"}, ReadBy: []string{"awsS3Upload", "azureBlobUpload", "cloudFoundryDeploy", "tmsExport", "tmsUpload", "transportRequestUploadSOLMAN"}},
+		"operationId":                                                 {Type: "string", WrittenBy: []string{"xsDeploy"}, ReadBy: []string{"xsDeploy"}},
+		"originalArtifactVersion":                                     {Type: "string", WrittenBy: []string{"artifactPrepareVersion"}},
+		"packaging":                                                   {Type: "string", WrittenBy: []string{"artifactPrepareVersion"}},
+	}
+}
@@ -16,6 +16,7 @@ import (
 	bd "github.com/SAP/jenkins-library/pkg/blackduck"
 	"github.com/SAP/jenkins-library/pkg/command"
 	piperDocker "github.com/SAP/jenkins-library/pkg/docker"
+	"github.com/SAP/jenkins-library/pkg/format"
 	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	"github.com/SAP/jenkins-library/pkg/golang"
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
@@ -739,6 +740,19 @@ func createVulnerabilityReport(config detectExecuteScanOptions, vulns *bd.Vulner
 	}
 
 	scanReport.DetailTable = detailTable
+
+	scanReport.Findings = map[string]int{}
+	for _, vuln := range vulnItems {
+		if !isActiveVulnerability(vuln) {
+			continue
+		}
+		severity := format.NormalizeSeverity(vuln.VulnerabilityWithRemediation.Severity)
+		if len(severity) == 0 {
+			severity = format.SeverityInfo
+		}
+		scanReport.Findings[severity]++
+	}
+	scanReport.SarifTool = bd.SarifToolName
 	return scanReport
 }
 
@@ -800,8 +814,18 @@ func postScanChecksAndReporting(ctx context.Context, config detectExecuteScanOpt
 		return nil
 	}
 
+	assessments := []format.Assessment{}
+	if len(config.AssessmentFile) > 0 {
+		var err error
+		assessments, err = format.ReadAssessmentFile(config.AssessmentFile, utils)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return err
+		}
+	}
+
 	errorsOccured := []string{}
-	vulns, err := getVulnerabilitiesWithComponents(config, influx, sys)
+	vulns, err := getVulnerabilitiesWithComponents(config, influx, sys, assessments)
 	if err != nil {
 		if config.GenerateReportsForEmptyProjects &&
 			strings.Contains(err.Error(), "No Components found for project version") {
@@ -880,7 +904,7 @@ func postScanChecksAndReporting(ctx context.Context, config detectExecuteScanOpt
 	return nil
 }
 
-func getVulnerabilitiesWithComponents(config detectExecuteScanOptions, influx *detectExecuteScanInflux, sys *blackduckSystem) (*bd.Vulnerabilities, error) {
+func getVulnerabilitiesWithComponents(config detectExecuteScanOptions, influx *detectExecuteScanInflux, sys *blackduckSystem, assessments []format.Assessment) (*bd.Vulnerabilities, error) {
 	detectVersionName := getVersionName(config)
 	components, err := sys.Client.GetComponents(config.ProjectName, detectVersionName)
 	if err != nil {
@@ -901,18 +925,24 @@ func getVulnerabilitiesWithComponents(config detectExecuteScanOptions, influx *d
 	majorVulns := 0
 	activeVulns := 0
 	for index, vuln := range vulns.Items {
-		if isActiveVulnerability(vuln) {
-			activeVulns++
-			if isMajorVulnerability(vuln) {
-				majorVulns++
-			}
-		}
 		component := componentLookup[fmt.Sprintf(keyFormat, vuln.Name, vuln.Version)]
 		if component != nil && len(component.Name) > 0 {
 			vulns.Items[index].Component = component
 		} else {
 			vulns.Items[index].Component = &bd.Component{Name: vuln.Name, Version: vuln.Version}
 		}
+		// vulnerabilities assessed as not relevant are treated like vulnerabilities ignored in BlackDuck
+		purl := vulns.Items[index].Component.ToPackageUrl().ToString()
+		if assessment := format.FindAssessment(assessments, vuln.VulnerabilityName, purl); assessment != nil && assessment.Status == format.NotRelevant {
+			log.Entry().Debugf("vulnerability %v of %v ignored due to assessment with analysis %v", vuln.VulnerabilityName, purl, assessment.Analysis)
+			vulns.Items[index].Ignored = true
+		}
+		if isActiveVulnerability(vulns.Items[index]) {
+			activeVulns++
+			if isMajorVulnerability(vulns.Items[index]) {
+				majorVulns++
+			}
+		}
 	}
 	influx.detect_data.fields.vulnerabilities = activeVulns
 	influx.detect_data.fields.major_vulnerabilities = majorVulns
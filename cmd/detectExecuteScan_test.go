@@ -15,6 +15,7 @@ import (
 
 	bd "github.com/SAP/jenkins-library/pkg/blackduck"
 	piperDocker "github.com/SAP/jenkins-library/pkg/docker"
+	"github.com/SAP/jenkins-library/pkg/format"
 	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/mock"
@@ -913,7 +914,7 @@ func TestGetVulnerabilitiesWithComponents(t *testing.T) {
 		config := detectExecuteScanOptions{Token: "token", ServerURL: "https://my.blackduck.system", ProjectName: "SHC-PiperTest", Version: "", CustomScanVersion: "1.0"}
 		sys := newBlackduckMockSystem(config)
 
-		vulns, err := getVulnerabilitiesWithComponents(config, &detectExecuteScanInflux{}, &sys)
+		vulns, err := getVulnerabilitiesWithComponents(config, &detectExecuteScanInflux{}, &sys, nil)
 		assert.NoError(t, err)
 		vulnerabilitySpring := bd.Vulnerability{}
 		vulnerabilityLog4j1 := bd.Vulnerability{}
@@ -943,6 +944,21 @@ func TestGetVulnerabilitiesWithComponents(t *testing.T) {
 		assert.Equal(t, vulnerableComponentLog4j, vulnerabilityLog4j1.Component)
 		assert.Equal(t, vulnerableComponentLog4j, vulnerabilityLog4j2.Component)
 	})
+	t.Run("with assessments", func(t *testing.T) {
+		config := detectExecuteScanOptions{Token: "token", ServerURL: "https://my.blackduck.system", ProjectName: "SHC-PiperTest", Version: "", CustomScanVersion: "1.0"}
+		sys := newBlackduckMockSystem(config)
+		log4jPurl := (&bd.Component{Name: "Apache Log4j", Version: "4.5.16"}).ToPackageUrl().ToString()
+		assessments := []format.Assessment{
+			{Vulnerability: "BDSA-2020-4711", Status: format.NotRelevant, Analysis: format.NotUsed, Purls: []format.Purl{{Purl: log4jPurl}}},
+			{Vulnerability: "BDSA-2020-4712", Status: format.Relevant, Analysis: format.WaitingForFix, Purls: []format.Purl{{Purl: log4jPurl}}},
+		}
+
+		vulns, err := getVulnerabilitiesWithComponents(config, &detectExecuteScanInflux{}, &sys, assessments)
+		assert.NoError(t, err)
+		for _, v := range vulns.Items {
+			assert.Equal(t, v.VulnerabilityWithRemediation.VulnerabilityName == "BDSA-2020-4711", v.Ignored, v.VulnerabilityWithRemediation.VulnerabilityName)
+		}
+	})
 }
 
 func TestAddDetectArgsImages(t *testing.T) {
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/log"
+)
+
+const maskedValue = "****"
+
+// stepPlan describes what a step would do when executed without --dry-run
+type stepPlan struct {
+	Step       string                 `json:"step"`
+	Stage      string                 `json:"stage,omitempty"`
+	Config     map[string]interface{} `json:"config"`
+	Containers []config.Container     `json:"containers,omitempty"`
+	Sidecars   []config.Container     `json:"sidecars,omitempty"`
+}
+
+// PrintStepPlan prints the resolved step configuration with masked secrets as well as the containers and sidecars
+// the step would run in. It is called by the generated step wrappers instead of the step itself when --dry-run is set.
+// The step is not called since its side effects, e.g. requests of SDK clients or files written, cannot be intercepted reliably.
+func PrintStepPlan(stepName string, metadata *config.StepData, stepConfig interface{}) {
+	if err := writeStepPlan(os.Stdout, stepName, metadata, stepConfig); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		log.Entry().WithError(err).Fatal("failed to print the step plan")
+	}
+	log.Entry().Infof("Dry run: step %s has not been executed", stepName)
+}
+
+func writeStepPlan(w io.Writer, stepName string, metadata *config.StepData, stepConfig interface{}) error {
+	confJSON, err := json.Marshal(stepConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal step configuration")
+	}
+	plan := stepPlan{Step: stepName, Stage: GeneralConfig.StageName}
+	if err := json.Unmarshal(confJSON, &plan.Config); err != nil {
+		return errors.Wrap(err, "failed to unmarshal step configuration")
+	}
+
+	for _, param := range metadata.Spec.Inputs.Parameters {
+		if value, ok := plan.Config[param.Name]; ok && param.Secret && !isEmptyValue(value) {
+			plan.Config[param.Name] = maskedValue
+		}
+	}
+	plan.Containers = activeContainers(metadata.Spec.Containers, plan.Config)
+	plan.Sidecars = activeContainers(metadata.Spec.Sidecars, plan.Config)
+
+	planYAML, err := config.GetYAML(plan)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal step plan")
+	}
+	// secrets resolved from Vault may be contained in parameters which are not marked as secret
+	_, err = fmt.Fprintln(w, log.MaskSecrets(planYAML))
+	return err
+}
+
+// activeContainers returns the containers without conditions and the ones whose strings-equal condition matches the step configuration
+func activeContainers(containers []config.Container, stepConfig map[string]interface{}) []config.Container {
+	active := []config.Container{}
+	for _, container := range containers {
+		if len(container.Conditions) == 0 {
+			active = append(active, container)
+			continue
+		}
+		for _, param := range container.Conditions[0].Params {
+			if container.Conditions[0].ConditionRef == "strings-equal" && fmt.Sprint(stepConfig[param.Name]) == param.Value {
+				container.Conditions = nil
+				active = append(active, container)
+				break
+			}
+		}
+	}
+	return active
+}
+
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
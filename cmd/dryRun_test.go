@@ -0,0 +1,67 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/log"
+)
+
+func TestWriteStepPlan(t *testing.T) {
+	type planOptions struct {
+		BuildTool string   `json:"buildTool,omitempty"`
+		Password  string   `json:"password,omitempty"`
+		Token     string   `json:"token,omitempty"`
+		Targets   []string `json:"targets,omitempty"`
+	}
+	metadata := config.StepData{
+		Spec: config.StepSpec{
+			Inputs: config.StepInputs{Parameters: []config.StepParameters{
+				{Name: "buildTool"},
+				{Name: "password", Secret: true},
+				{Name: "token", Secret: true},
+				{Name: "targets"},
+			}},
+			Containers: []config.Container{
+				{Name: "mvn", Image: "maven:3-jdk-11", Conditions: []config.Condition{{ConditionRef: "strings-equal", Params: []config.Param{{Name: "buildTool", Value: "maven"}}}}},
+				{Name: "npm", Image: "node:lts", Conditions: []config.Condition{{ConditionRef: "strings-equal", Params: []config.Param{{Name: "buildTool", Value: "npm"}}}}},
+			},
+			Sidecars: []config.Container{{Name: "selenium", Image: "selenium/standalone-chrome"}},
+		},
+	}
+
+	t.Run("success case", func(t *testing.T) {
+		defer func() { GeneralConfig.StageName = "" }()
+		GeneralConfig.StageName = "Build"
+		var out bytes.Buffer
+		// secrets from Vault are registered before the dry run
+		log.RegisterSecret("vault-secret!")
+
+		err := writeStepPlan(&out, "testStep", &metadata, &planOptions{BuildTool: "maven", Password: "secret!", Targets: []string{"a", "vault-secret!"}})
+
+		assert.NoError(t, err)
+		plan := out.String()
+		assert.Contains(t, plan, "step: testStep")
+		assert.Contains(t, plan, "stage: Build")
+		assert.Contains(t, plan, "password: '****'")
+		assert.NotContains(t, plan, "secret!")
+		assert.NotContains(t, plan, "token")
+		assert.Contains(t, plan, "- a\n")
+		assert.Contains(t, plan, "image: maven:3-jdk-11")
+		assert.NotContains(t, plan, "node:lts")
+		assert.Contains(t, plan, "image: selenium/standalone-chrome")
+		assert.NotContains(t, plan, "vault-secret!")
+	})
+
+	t.Run("error case", func(t *testing.T) {
+		var out bytes.Buffer
+		err := writeStepPlan(&out, "testStep", &metadata, map[string]interface{}{"invalid": make(chan int)})
+		assert.EqualError(t, err, "failed to marshal step configuration: json: unsupported type: chan int")
+	})
+}
@@ -26,6 +26,7 @@ type ConfigCommandOptions struct {
 	StepMetadata                  string // metadata to be considered, can be filePath or ENV containing JSON in format 'ENV:MY_ENV_VAR'
 	StepName                      string
 	ContextConfig                 bool
+	Explain                       bool // if set: the origin of each configuration value is written instead of the configuration
 	OpenFile                      func(s string, t map[string]string) (io.ReadCloser, error)
 }
 
@@ -33,6 +34,7 @@ var configOptions ConfigCommandOptions
 
 func SetConfigOptions(c ConfigCommandOptions) {
 	configOptions.ContextConfig = c.ContextConfig
+	configOptions.Explain = c.Explain
 	configOptions.OpenFile = c.OpenFile
 	configOptions.Output = c.Output
 	configOptions.OutputFile = c.OutputFile
@@ -137,6 +139,7 @@ func GetStageConfig() (config.StepConfig, error) {
 	}
 
 	defaultConfig := []io.ReadCloser{}
+	defaultNames := []string{}
 	for _, f := range GeneralConfig.DefaultConfig {
 		if configOptions.OpenFile == nil {
 			return stepConfig, errors.New("config: open file function not set")
@@ -148,8 +151,12 @@ func GetStageConfig() (config.StepConfig, error) {
 		}
 		if err == nil {
 			defaultConfig = append(defaultConfig, fc)
+			defaultNames = append(defaultNames, f)
 		}
 	}
+	if configOptions.Explain {
+		myConfig.EnableProvenance(defaultNames...)
+	}
 
 	return myConfig.GetStageConfig(GeneralConfig.ParametersJSON, customConfig, defaultConfig, GeneralConfig.IgnoreCustomDefaults, configOptions.StageConfigAcceptedParameters, GeneralConfig.StageName)
 }
@@ -187,6 +194,9 @@ func getConfigWithFlagValues(cmd *cobra.Command) (config.StepConfig, error) {
 
 		prepareOutputEnvironment(metadata.Spec.Outputs.Resources, GeneralConfig.EnvRootPath)
 
+		if err := configureCPEEncryption(); err != nil {
+			return stepConfig, err
+		}
 		envParams := metadata.GetResourceParameters(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")
 		reportingEnvParams := config.ReportingParameters.GetResourceParameters(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")
 		resourceParams := mergeResourceParameters(envParams, reportingEnvParams)
@@ -205,6 +215,10 @@ func getConfigWithFlagValues(cmd *cobra.Command) (config.StepConfig, error) {
 		if err != nil {
 			return stepConfig, errors.Wrap(err, "defaults: retrieving step defaults failed")
 		}
+		var defaultNames []string
+		if configOptions.ContextConfig {
+			defaultNames = []string{"contextDefaults"}
+		}
 
 		for _, f := range GeneralConfig.DefaultConfig {
 			fc, err := configOptions.OpenFile(f, GeneralConfig.GitHubAccessTokens)
@@ -214,9 +228,14 @@ func getConfigWithFlagValues(cmd *cobra.Command) (config.StepConfig, error) {
 			}
 			if err == nil {
 				defaultConfig = append(defaultConfig, fc)
+				defaultNames = append(defaultNames, f)
 			}
 		}
 
+		if configOptions.Explain {
+			myConfig.EnableProvenance(defaultNames...)
+		}
+
 		if configOptions.ContextConfig {
 			metadata.Spec.Inputs.Parameters = []config.StepParameters{}
 		}
@@ -260,7 +279,12 @@ func GenerateConfig(cmd *cobra.Command, formatter func(interface{}) (string, err
 		return err
 	}
 
-	myConfig, err := formatter(stepConfig.Config)
+	var myConfig string
+	if configOptions.Explain {
+		myConfig, err = formatter(stepConfig.Provenance)
+	} else {
+		myConfig, err = formatter(stepConfig.Config)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
@@ -287,6 +311,7 @@ func addConfigFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&configOptions.StepMetadata, "stepMetadata", "", "Step metadata, passed as path to yaml")
 	cmd.Flags().StringVar(&configOptions.StepName, "stepName", "", "Step name, used to get step metadata if yaml path is not set")
 	cmd.Flags().BoolVar(&configOptions.ContextConfig, "contextConfig", false, "Defines if step context configuration should be loaded instead of step config")
+	cmd.Flags().BoolVar(&configOptions.Explain, "explain", false, "Defines if the origin of each configuration value and the overridden values should be written instead of the configuration, secrets are masked")
 }
 
 func defaultsAndFilters(metadata *config.StepData, stepName string) ([]io.ReadCloser, config.StepFilters, error) {
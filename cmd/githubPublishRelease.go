@@ -10,10 +10,15 @@ import (
 	"time"
 
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/releasenotes"
+	"github.com/SAP/jenkins-library/pkg/sbom"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/go-git/go-git/v5"
 	"github.com/google/go-github/v68/github"
 	"github.com/pkg/errors"
 
+	gitUtils "github.com/SAP/jenkins-library/pkg/git"
 	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 )
 
@@ -29,7 +34,40 @@ type githubIssueClient interface {
 	ListByRepo(ctx context.Context, owner string, repo string, opt *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error)
 }
 
+type githubPublishReleaseUtils interface {
+	FileExists(filename string) (bool, error)
+	FileRead(path string) ([]byte, error)
+	FileWrite(path string, content []byte, perm os.FileMode) error
+	MkdirAll(path string, perm os.FileMode) error
+}
+
+type githubPublishReleaseUtilsBundle struct {
+	*piperutils.Files
+}
+
+func newGithubPublishReleaseUtils() githubPublishReleaseUtils {
+	utils := githubPublishReleaseUtilsBundle{
+		Files: &piperutils.Files{},
+	}
+	return &utils
+}
+
+// openReleaseNotesRepository opens the git repository the release notes are created from, replaced in tests
+var openReleaseNotesRepository = func() (*git.Repository, error) {
+	return gitUtils.PlainOpen(".")
+}
+
 func githubPublishRelease(config githubPublishReleaseOptions, telemetryData *telemetry.CustomData) {
+	utils := newGithubPublishReleaseUtils()
+	if config.ReleaseNotesOnly {
+		// no GitHub release is created, e.g. for repositories hosted on Azure DevOps
+		_, err := createReleaseNotes(context.Background(), &config, "", github.Timestamp{}, nil, utils)
+		if err != nil {
+			log.Entry().WithError(err).Fatal("Failed to create release notes.")
+		}
+		return
+	}
+
 	// TODO provide parameter for trusted certs
 	ctx, client, err := piperGithub.NewClientBuilder(config.Token, config.APIURL).
 		WithTimeout(time.Duration(config.GithubAPITimeout) * time.Second).
@@ -39,13 +77,13 @@ func githubPublishRelease(config githubPublishReleaseOptions, telemetryData *tel
 		log.Entry().WithError(err).Fatal("Failed to get GitHub client.")
 	}
 
-	err = runGithubPublishRelease(ctx, &config, client.Repositories, client.Issues)
+	err = runGithubPublishRelease(ctx, &config, client.Repositories, client.Issues, utils)
 	if err != nil {
 		log.Entry().WithError(err).Fatal("Failed to publish GitHub release.")
 	}
 }
 
-func runGithubPublishRelease(ctx context.Context, config *githubPublishReleaseOptions, ghRepoClient GithubRepoClient, ghIssueClient githubIssueClient) error {
+func runGithubPublishRelease(ctx context.Context, config *githubPublishReleaseOptions, ghRepoClient GithubRepoClient, ghIssueClient githubIssueClient, utils githubPublishReleaseUtils) error {
 	var publishedAt github.Timestamp
 
 	lastRelease, resp, err := ghRepoClient.GetLatestRelease(ctx, config.Owner, config.Repository)
@@ -78,6 +116,16 @@ func runGithubPublishRelease(ctx context.Context, config *githubPublishReleaseOp
 		releaseBody += getClosedIssuesText(ctx, publishedAt, config, ghIssueClient)
 	}
 
+	if config.AddReleaseNotes || len(config.ReleaseNotesFile) > 0 {
+		releaseNotes, err := createReleaseNotes(ctx, config, lastRelease.GetTagName(), publishedAt, ghIssueClient, utils)
+		if err != nil {
+			return err
+		}
+		if config.AddReleaseNotes {
+			releaseBody += releaseNotes
+		}
+	}
+
 	if config.AddDeltaToLastRelease {
 		releaseBody += getReleaseDeltaText(config, lastRelease)
 	}
@@ -156,6 +204,126 @@ func getClosedIssuesText(ctx context.Context, publishedAt github.Timestamp, conf
 	return closedIssuesText
 }
 
+// createReleaseNotes creates the release notes from the git history since the previous release and writes them to the releaseNotesFile.
+// Without previous release tag, the tag with the highest version lower than the release version is used.
+func createReleaseNotes(ctx context.Context, config *githubPublishReleaseOptions, previousTag string, publishedAt github.Timestamp, ghIssueClient githubIssueClient, utils githubPublishReleaseUtils) (string, error) {
+	repository, err := openReleaseNotesRepository()
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return "", errors.Wrap(err, "failed to open git repository for release notes")
+	}
+	if len(previousTag) == 0 {
+		previousTag, err = releasenotes.PreviousTag(repository, config.TagPrefix, config.Version)
+		if err != nil {
+			return "", err
+		}
+	}
+	log.Entry().Infof("Creating release notes for changes since '%v'", previousTag)
+	commits, err := releasenotes.Commits(repository, previousTag, "HEAD")
+	if err != nil {
+		// e.g. the tag of the previous release is not contained in a shallow clone
+		if config.ReleaseNotesOnly {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return "", errors.Wrapf(err, "the history since '%v' is not available, please fetch the history including tags", previousTag)
+		}
+		log.Entry().WithError(err).Warningf("Release notes not created since the history since '%v' is not available, please fetch the history including tags", previousTag)
+		return "", nil
+	}
+
+	opts := releasenotes.Options{GroupBy: config.ReleaseNotesGroupBy, Labels: config.ReleaseNotesLabels, ExcludeLabels: config.ReleaseNotesExcludeLabels}
+	if config.ReleaseNotesGroupBy == releasenotes.GroupByLabel {
+		if ghIssueClient == nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return "", fmt.Errorf("grouping release notes by label requires the pull requests of GitHub")
+		}
+		opts.PullRequestLabels, err = getPullRequestLabels(ctx, publishedAt, config, ghIssueClient)
+		if err != nil {
+			return "", err
+		}
+	}
+	notes, err := releasenotes.Compose(config.Version, releasenotes.Changes(commits, opts), opts)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return "", err
+	}
+	notes.PreviousTag = previousTag
+	notes.Date = time.Now()
+
+	if len(config.SbomFile) > 0 && len(config.PreviousSbomFile) > 0 {
+		previous, err := sbom.ReadFile(config.PreviousSbomFile, utils)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return "", err
+		}
+		current, err := sbom.ReadFile(config.SbomFile, utils)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return "", err
+		}
+		diff := sbom.Compare(previous, current)
+		notes.Dependencies = &diff
+	}
+
+	releaseNotesTemplate := ""
+	if len(config.ReleaseNotesTemplate) > 0 {
+		content, err := utils.FileRead(config.ReleaseNotesTemplate)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return "", errors.Wrapf(err, "failed to read release notes template %v", config.ReleaseNotesTemplate)
+		}
+		releaseNotesTemplate = string(content)
+	}
+	releaseNotes, err := releasenotes.Render(notes, releaseNotesTemplate)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return "", err
+	}
+
+	if len(config.ReleaseNotesFile) > 0 {
+		if dir := filepath.Dir(config.ReleaseNotesFile); dir != "." {
+			if err := utils.MkdirAll(dir, 0777); err != nil {
+				return "", errors.Wrapf(err, "failed to create directory %v", dir)
+			}
+		}
+		if err := utils.FileWrite(config.ReleaseNotesFile, []byte(releaseNotes), 0666); err != nil {
+			return "", errors.Wrapf(err, "failed to write release notes %v", config.ReleaseNotesFile)
+		}
+		log.Entry().Infof("Release notes written to %v", config.ReleaseNotesFile)
+	}
+	return releaseNotes, nil
+}
+
+// getPullRequestLabels provides the labels of the pull requests closed since the last release
+func getPullRequestLabels(ctx context.Context, publishedAt github.Timestamp, config *githubPublishReleaseOptions, ghIssueClient githubIssueClient) (map[int][]string, error) {
+	options := github.IssueListByRepoOptions{
+		State:     "closed",
+		Direction: "asc",
+		Since:     publishedAt.Time,
+	}
+	pullRequestLabels := map[int][]string{}
+	for {
+		issues, resp, err := ghIssueClient.ListByRepo(ctx, config.Owner, config.Repository, &options)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get GitHub pull requests")
+		}
+		for _, issue := range issues {
+			if !issue.IsPullRequest() {
+				continue
+			}
+			labels := []string{}
+			for _, label := range issue.Labels {
+				labels = append(labels, label.GetName())
+			}
+			pullRequestLabels[issue.GetNumber()] = labels
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return pullRequestLabels, nil
+}
+
 func getReleaseDeltaText(config *githubPublishReleaseOptions, lastRelease *github.RepositoryRelease) string {
 	releaseDeltaText := ""
 	tagName := config.TagPrefix + config.Version
@@ -17,24 +17,33 @@ import (
 )
 
 type githubPublishReleaseOptions struct {
-	AddClosedIssues       bool     `json:"addClosedIssues,omitempty"`
-	AddDeltaToLastRelease bool     `json:"addDeltaToLastRelease,omitempty"`
-	APIURL                string   `json:"apiUrl,omitempty"`
-	GithubAPITimeout      int      `json:"githubApiTimeout,omitempty"`
-	AssetPath             string   `json:"assetPath,omitempty"`
-	AssetPathList         []string `json:"assetPathList,omitempty"`
-	Commitish             string   `json:"commitish,omitempty"`
-	ExcludeLabels         []string `json:"excludeLabels,omitempty"`
-	Labels                []string `json:"labels,omitempty"`
-	Owner                 string   `json:"owner,omitempty"`
-	PreRelease            bool     `json:"preRelease,omitempty"`
-	ReleaseBodyHeader     string   `json:"releaseBodyHeader,omitempty"`
-	Repository            string   `json:"repository,omitempty"`
-	ServerURL             string   `json:"serverUrl,omitempty"`
-	TagPrefix             string   `json:"tagPrefix,omitempty"`
-	Token                 string   `json:"token,omitempty"`
-	UploadURL             string   `json:"uploadUrl,omitempty"`
-	Version               string   `json:"version,omitempty"`
+	AddClosedIssues           bool     `json:"addClosedIssues,omitempty"`
+	AddReleaseNotes           bool     `json:"addReleaseNotes,omitempty"`
+	AddDeltaToLastRelease     bool     `json:"addDeltaToLastRelease,omitempty"`
+	APIURL                    string   `json:"apiUrl,omitempty"`
+	GithubAPITimeout          int      `json:"githubApiTimeout,omitempty"`
+	AssetPath                 string   `json:"assetPath,omitempty"`
+	AssetPathList             []string `json:"assetPathList,omitempty"`
+	Commitish                 string   `json:"commitish,omitempty"`
+	ExcludeLabels             []string `json:"excludeLabels,omitempty"`
+	Labels                    []string `json:"labels,omitempty"`
+	Owner                     string   `json:"owner,omitempty" validate:"required_if=ReleaseNotesOnly false"`
+	PreRelease                bool     `json:"preRelease,omitempty"`
+	ReleaseBodyHeader         string   `json:"releaseBodyHeader,omitempty"`
+	PreviousSbomFile          string   `json:"previousSbomFile,omitempty"`
+	ReleaseNotesExcludeLabels []string `json:"releaseNotesExcludeLabels,omitempty"`
+	ReleaseNotesFile          string   `json:"releaseNotesFile,omitempty" validate:"required_if=ReleaseNotesOnly true"`
+	ReleaseNotesGroupBy       string   `json:"releaseNotesGroupBy,omitempty" validate:"possible-values=type label"`
+	ReleaseNotesLabels        []string `json:"releaseNotesLabels,omitempty"`
+	ReleaseNotesOnly          bool     `json:"releaseNotesOnly,omitempty"`
+	ReleaseNotesTemplate      string   `json:"releaseNotesTemplate,omitempty"`
+	Repository                string   `json:"repository,omitempty" validate:"required_if=ReleaseNotesOnly false"`
+	SbomFile                  string   `json:"sbomFile,omitempty"`
+	ServerURL                 string   `json:"serverUrl,omitempty"`
+	TagPrefix                 string   `json:"tagPrefix,omitempty"`
+	Token                     string   `json:"token,omitempty" validate:"required_if=ReleaseNotesOnly false"`
+	UploadURL                 string   `json:"uploadUrl,omitempty"`
+	Version                   string   `json:"version,omitempty"`
 }
 
 // GithubPublishReleaseCommand Publish a release in GitHub
@@ -57,10 +66,25 @@ The release can be filled with text plus additional information like:
 * Closed pull request since last release
 * Closed issues since last release
 * Link to delta information showing all commits since last release
+* Release notes created from the git history since last release
 
 The result looks like
 
-![Example release](../images/githubRelease.png)`,
+![Example release](../images/githubRelease.png)
+
+### Release notes from the git history
+
+With ` + "`" + `addReleaseNotes: true` + "`" + ` the release notes are created from the commits since the tag of the last release, which also works offline without the GitHub API.
+The changes are grouped by the type of the [Conventional Commits](https://www.conventionalcommits.org/) (` + "`" + `releaseNotesGroupBy: type` + "`" + `) or by the labels of their pull requests (` + "`" + `releaseNotesGroupBy: label` + "`" + `).
+Pull requests are identified by the reference GitHub adds to the commit message of squash merges, e.g. ` + "`" + `fix: handle timeout (#123)` + "`" + `, and by the merge commits of pull requests, e.g. ` + "`" + `Merge pull request #123 from owner/branch` + "`" + `.
+The commits of a merged pull request inherit its labels, the sections and their order are defined via [` + "`" + `releaseNotesLabels` + "`" + `](#releasenoteslabels).
+In case [` + "`" + `sbomFile` + "`" + `](#sbomfile) and [` + "`" + `previousSbomFile` + "`" + `](#previoussbomfile) are provided, the dependency changes between both SBOMs are added.
+
+The release notes can be customized via a [Go template](https://pkg.go.dev/text/template) provided in [` + "`" + `releaseNotesTemplate` + "`" + `](#releasenotestemplate), which has access to
+` + "`" + `.Version` + "`" + `, ` + "`" + `.PreviousTag` + "`" + `, ` + "`" + `.Date` + "`" + `, ` + "`" + `.BreakingChanges` + "`" + `, ` + "`" + `.Sections` + "`" + ` (with ` + "`" + `.Title` + "`" + ` and ` + "`" + `.Changes` + "`" + `) and ` + "`" + `.Dependencies` + "`" + ` as well as to the [sprig](https://masterminds.github.io/sprig/) functions.
+
+With [` + "`" + `releaseNotesFile` + "`" + `](#releasenotesfile) the release notes are written into a file, e.g. for publishing them as part of the documentation.
+Setting ` + "`" + `releaseNotesOnly: true` + "`" + ` only writes this file without creating a GitHub release, thus it can be used for repositories which are not hosted on GitHub like Azure DevOps.`,
 		PreRunE: func(cmd *cobra.Command, _ []string) error {
 			startTime = time.Now()
 			log.SetStepName(STEP_NAME)
@@ -125,10 +149,18 @@ The result looks like
 				defer vaultClient.MustRevokeToken()
 			}
 
+			if GeneralConfig.DryRun {
+				PrintStepPlan(STEP_NAME, &metadata, stepConfig)
+				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
+				return
+			}
+
 			stepTelemetryData := telemetry.CustomData{}
 			stepTelemetryData.ErrorCode = "1"
 			handler := func() {
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
@@ -179,6 +211,7 @@ The result looks like
 
 func addGithubPublishReleaseFlags(cmd *cobra.Command, stepConfig *githubPublishReleaseOptions) {
 	cmd.Flags().BoolVar(&stepConfig.AddClosedIssues, "addClosedIssues", false, "If set to `true`, closed issues and merged pull-requests since the last release will added below the `releaseBodyHeader`")
+	cmd.Flags().BoolVar(&stepConfig.AddReleaseNotes, "addReleaseNotes", false, "If set to `true`, release notes created from the git history since the last release will be added below the `releaseBodyHeader`.")
 	cmd.Flags().BoolVar(&stepConfig.AddDeltaToLastRelease, "addDeltaToLastRelease", false, "If set to `true`, a link will be added to the release information that brings up all commits since the last release.")
 	cmd.Flags().StringVar(&stepConfig.APIURL, "apiUrl", `https://api.github.com`, "Set the GitHub API url.")
 	cmd.Flags().IntVar(&stepConfig.GithubAPITimeout, "githubApiTimeout", 30, "Set HTTP timeout for GitHub API calls (in seconds)")
@@ -190,7 +223,15 @@ func addGithubPublishReleaseFlags(cmd *cobra.Command, stepConfig *githubPublishR
 	cmd.Flags().StringVar(&stepConfig.Owner, "owner", os.Getenv("PIPER_owner"), "Name of the GitHub organization.")
 	cmd.Flags().BoolVar(&stepConfig.PreRelease, "preRelease", false, "If set to `true` the release will be marked as Pre-release.")
 	cmd.Flags().StringVar(&stepConfig.ReleaseBodyHeader, "releaseBodyHeader", os.Getenv("PIPER_releaseBodyHeader"), "Content which will appear for the release.")
+	cmd.Flags().StringVar(&stepConfig.PreviousSbomFile, "previousSbomFile", os.Getenv("PIPER_previousSbomFile"), "Path of the CycloneDX SBOM (XML or JSON) of the last release. Together with `sbomFile` the dependency changes are added to the release notes.")
+	cmd.Flags().StringSliceVar(&stepConfig.ReleaseNotesExcludeLabels, "releaseNotesExcludeLabels", []string{}, "Labels of pull requests whose changes are not contained in the release notes created from the git history.")
+	cmd.Flags().StringVar(&stepConfig.ReleaseNotesFile, "releaseNotesFile", os.Getenv("PIPER_releaseNotesFile"), "Path of a file the release notes created from the git history are written to. Mandatory with `releaseNotesOnly: true`.")
+	cmd.Flags().StringVar(&stepConfig.ReleaseNotesGroupBy, "releaseNotesGroupBy", `type`, "Defines how the changes in the release notes are grouped: by the type of the Conventional Commits or by the labels of the pull requests.")
+	cmd.Flags().StringSliceVar(&stepConfig.ReleaseNotesLabels, "releaseNotesLabels", []string{}, "Labels of pull requests defining the sections of the release notes and their order in case of `releaseNotesGroupBy: label`. By default a section is created per label.")
+	cmd.Flags().BoolVar(&stepConfig.ReleaseNotesOnly, "releaseNotesOnly", false, "If set to `true`, only the release notes are written to the `releaseNotesFile` and no GitHub release is created.")
+	cmd.Flags().StringVar(&stepConfig.ReleaseNotesTemplate, "releaseNotesTemplate", os.Getenv("PIPER_releaseNotesTemplate"), "Path of a file containing a Go template used for the release notes.")
 	cmd.Flags().StringVar(&stepConfig.Repository, "repository", os.Getenv("PIPER_repository"), "Name of the GitHub repository.")
+	cmd.Flags().StringVar(&stepConfig.SbomFile, "sbomFile", os.Getenv("PIPER_sbomFile"), "Path of the CycloneDX SBOM (XML or JSON) of the release. Together with `previousSbomFile` the dependency changes are added to the release notes.")
 	cmd.Flags().StringVar(&stepConfig.ServerURL, "serverUrl", `https://github.com`, "GitHub server url for end-user access.")
 	cmd.Flags().StringVar(&stepConfig.TagPrefix, "tagPrefix", ``, "Defines a prefix to be added to the tag.")
 	cmd.Flags().StringVar(&stepConfig.Token, "token", os.Getenv("PIPER_token"), "GitHub personal access token as per https://help.github.com/en/github/authenticating-to-github/creating-a-personal-access-token-for-the-command-line")
@@ -198,10 +239,7 @@ func addGithubPublishReleaseFlags(cmd *cobra.Command, stepConfig *githubPublishR
 	cmd.Flags().StringVar(&stepConfig.Version, "version", os.Getenv("PIPER_version"), "Define the version number which will be written as tag as well as release name.")
 
 	cmd.MarkFlagRequired("apiUrl")
-	cmd.MarkFlagRequired("owner")
-	cmd.MarkFlagRequired("repository")
 	cmd.MarkFlagRequired("serverUrl")
-	cmd.MarkFlagRequired("token")
 	cmd.MarkFlagRequired("uploadUrl")
 	cmd.MarkFlagRequired("version")
 }
@@ -229,6 +267,15 @@ func githubPublishReleaseMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     false,
 					},
+					{
+						Name:        "addReleaseNotes",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
 					{
 						Name:        "addDeltaToLastRelease",
 						ResourceRef: []config.ResourceReference{},
@@ -316,7 +363,7 @@ func githubPublishReleaseMetadata() config.StepData {
 						},
 						Scope:     []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
 						Type:      "string",
-						Mandatory: true,
+						Mandatory: false,
 						Aliases:   []config.Alias{{Name: "githubOrg"}},
 						Default:   os.Getenv("PIPER_owner"),
 					},
@@ -338,6 +385,69 @@ func githubPublishReleaseMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     os.Getenv("PIPER_releaseBodyHeader"),
 					},
+					{
+						Name:        "previousSbomFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_previousSbomFile"),
+					},
+					{
+						Name:        "releaseNotesExcludeLabels",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "releaseNotesFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_releaseNotesFile"),
+					},
+					{
+						Name:        "releaseNotesGroupBy",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `type`,
+					},
+					{
+						Name:        "releaseNotesLabels",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "releaseNotesOnly",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "releaseNotesTemplate",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_releaseNotesTemplate"),
+					},
 					{
 						Name: "repository",
 						ResourceRef: []config.ResourceReference{
@@ -348,10 +458,19 @@ func githubPublishReleaseMetadata() config.StepData {
 						},
 						Scope:     []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
 						Type:      "string",
-						Mandatory: true,
+						Mandatory: false,
 						Aliases:   []config.Alias{{Name: "githubRepo"}},
 						Default:   os.Getenv("PIPER_repository"),
 					},
+					{
+						Name:        "sbomFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_sbomFile"),
+					},
 					{
 						Name:        "serverUrl",
 						ResourceRef: []config.ResourceReference{},
@@ -386,7 +505,7 @@ func githubPublishReleaseMetadata() config.StepData {
 						},
 						Scope:     []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
 						Type:      "string",
-						Mandatory: true,
+						Mandatory: false,
 						Aliases:   []config.Alias{{Name: "githubToken"}, {Name: "access_token"}},
 						Default:   os.Getenv("PIPER_token"),
 					},
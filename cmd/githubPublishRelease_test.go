@@ -13,6 +13,11 @@ import (
 	"time"
 
 	"github.com/SAP/jenkins-library/cmd/mocks"
+	piperMocks "github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/google/go-github/v68/github"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -99,6 +104,37 @@ func (g *ghICMock) ListByRepo(ctx context.Context, owner string, repo string, op
 	return g.issues, &g.response, nil
 }
 
+type githubPublishReleaseMockUtils struct {
+	*piperMocks.FilesMock
+}
+
+func newGithubPublishReleaseTestsUtils() githubPublishReleaseMockUtils {
+	utils := githubPublishReleaseMockUtils{
+		FilesMock: &piperMocks.FilesMock{},
+	}
+	return utils
+}
+
+// newReleaseNotesTestRepository provides an in-memory repository containing the release 1.0.0 and the given commits on top
+func newReleaseNotesTestRepository(t *testing.T, messages ...string) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	assert.NoError(t, err)
+	worktree, err := repo.Worktree()
+	assert.NoError(t, err)
+	for i, message := range append([]string{"feat: initial implementation"}, messages...) {
+		hash, err := worktree.Commit(message, &git.CommitOptions{AllowEmptyCommits: true, Author: &object.Signature{Name: "Test", When: time.Now()}})
+		assert.NoError(t, err)
+		if i == 0 {
+			_, err = repo.CreateTag("1.0.0", hash, nil)
+			assert.NoError(t, err)
+		}
+	}
+
+	openRepository := openReleaseNotesRepository
+	openReleaseNotesRepository = func() (*git.Repository, error) { return repo, nil }
+	t.Cleanup(func() { openReleaseNotesRepository = openRepository })
+}
+
 func TestRunGithubPublishRelease(t *testing.T) {
 	ctx := context.Background()
 
@@ -119,7 +155,7 @@ func TestRunGithubPublishRelease(t *testing.T) {
 			ReleaseBodyHeader:     "Header",
 			Version:               "1.0",
 		}
-		err := runGithubPublishRelease(ctx, &myGithubPublishReleaseOptions, &ghRepoClient, &ghIssueClient)
+		err := runGithubPublishRelease(ctx, &myGithubPublishReleaseOptions, &ghRepoClient, &ghIssueClient, newGithubPublishReleaseTestsUtils())
 		assert.NoError(t, err, "Error occurred but none expected.")
 
 		assert.Equal(t, "Header\n", ghRepoClient.release.GetBody())
@@ -145,7 +181,7 @@ func TestRunGithubPublishRelease(t *testing.T) {
 			Version:               "1.0",
 			TagPrefix:             "v",
 		}
-		err := runGithubPublishRelease(ctx, &myGithubPublishReleaseOptions, &ghRepoClient, &ghIssueClient)
+		err := runGithubPublishRelease(ctx, &myGithubPublishReleaseOptions, &ghRepoClient, &ghIssueClient, newGithubPublishReleaseTestsUtils())
 		assert.NoError(t, err, "Error occurred but none expected.")
 
 		assert.Equal(t, "Header\n", ghRepoClient.release.GetBody())
@@ -187,7 +223,7 @@ func TestRunGithubPublishRelease(t *testing.T) {
 			ReleaseBodyHeader:     "Header",
 			Version:               "1.1",
 		}
-		err := runGithubPublishRelease(ctx, &myGithubPublishReleaseOptions, &ghRepoClient, &ghIssueClient)
+		err := runGithubPublishRelease(ctx, &myGithubPublishReleaseOptions, &ghRepoClient, &ghIssueClient, newGithubPublishReleaseTestsUtils())
 
 		assert.NoError(t, err, "Error occurred but none expected.")
 
@@ -213,7 +249,7 @@ func TestRunGithubPublishRelease(t *testing.T) {
 			Version:   "latest",
 		}
 
-		err := runGithubPublishRelease(ctx, &myGithubPublishReleaseOptions, &ghRepoClient, &ghIssueClient)
+		err := runGithubPublishRelease(ctx, &myGithubPublishReleaseOptions, &ghRepoClient, &ghIssueClient, newGithubPublishReleaseTestsUtils())
 
 		assert.NoError(t, err, "Error occurred but none expected.")
 
@@ -232,7 +268,7 @@ func TestRunGithubPublishRelease(t *testing.T) {
 			Owner:      "TEST",
 			Repository: "test",
 		}
-		err := runGithubPublishRelease(ctx, &myGithubPublishReleaseOptions, &ghRepoClient, &ghIssueClient)
+		err := runGithubPublishRelease(ctx, &myGithubPublishReleaseOptions, &ghRepoClient, &ghIssueClient, newGithubPublishReleaseTestsUtils())
 
 		assert.Equal(t, "Error occurred when retrieving latest GitHub release (TEST/test): Latest release error", fmt.Sprint(err))
 	})
@@ -246,7 +282,7 @@ func TestRunGithubPublishRelease(t *testing.T) {
 			Owner:      "",
 			Repository: "test",
 		}
-		err := runGithubPublishRelease(ctx, &myGithubPublishReleaseOptions, &ghRepoClient, &ghIssueClient)
+		err := runGithubPublishRelease(ctx, &myGithubPublishReleaseOptions, &ghRepoClient, &ghIssueClient, newGithubPublishReleaseTestsUtils())
 
 		assert.Equal(t, "Error occurred when retrieving latest GitHub release (/test): Latest release error, no response", fmt.Sprint(err))
 	})
@@ -259,10 +295,150 @@ func TestRunGithubPublishRelease(t *testing.T) {
 		myGithubPublishReleaseOptions := githubPublishReleaseOptions{
 			Version: "1.0",
 		}
-		err := runGithubPublishRelease(ctx, &myGithubPublishReleaseOptions, &ghRepoClient, &ghIssueClient)
+		err := runGithubPublishRelease(ctx, &myGithubPublishReleaseOptions, &ghRepoClient, &ghIssueClient, newGithubPublishReleaseTestsUtils())
 
 		assert.Equal(t, "Creation of release '1.0' failed: Create release error", fmt.Sprint(err))
 	})
+
+	t.Run("Success - with release notes", func(t *testing.T) {
+		newReleaseNotesTestRepository(t, "feat: new feature (#3)", "fix: handle timeout")
+		lastTag := "1.0.0"
+		ghIssueClient := ghICMock{}
+		ghRepoClient := ghRCMock{
+			latestRelease: &github.RepositoryRelease{TagName: &lastTag},
+		}
+		utils := newGithubPublishReleaseTestsUtils()
+		myGithubPublishReleaseOptions := githubPublishReleaseOptions{
+			AddReleaseNotes:   true,
+			Owner:             "TEST",
+			Repository:        "test",
+			ReleaseBodyHeader: "Header",
+			ReleaseNotesFile:  "docs/CHANGELOG.md",
+			Version:           "1.1.0",
+		}
+		err := runGithubPublishRelease(ctx, &myGithubPublishReleaseOptions, &ghRepoClient, &ghIssueClient, utils)
+
+		assert.NoError(t, err)
+		body := ghRepoClient.release.GetBody()
+		assert.Contains(t, body, "Header\n\n### Features\n\n* new feature (#3) (")
+		assert.Contains(t, body, "### Bug Fixes\n\n* handle timeout (")
+		assert.NotContains(t, body, "initial implementation")
+		releaseNotes, err := utils.FileRead("docs/CHANGELOG.md")
+		assert.NoError(t, err)
+		assert.Equal(t, "Header\n"+string(releaseNotes), body)
+	})
+}
+
+func TestCreateReleaseNotes(t *testing.T) {
+	ctx := context.Background()
+	customTemplate := `{{ .Version }} since {{ .PreviousTag }}
+{{ range .Sections }}{{ .Title }}:{{ range .Changes }} {{ .Description }}{{ end }}
+{{ end }}`
+
+	t.Run("success - previous tag from version", func(t *testing.T) {
+		newReleaseNotesTestRepository(t, "feat: new feature", "docs: update readme")
+		utils := newGithubPublishReleaseTestsUtils()
+		utils.AddFile("releaseNotes.tmpl", []byte(customTemplate))
+		config := githubPublishReleaseOptions{Version: "1.1.0", ReleaseNotesTemplate: "releaseNotes.tmpl", ReleaseNotesFile: "CHANGELOG.md"}
+
+		releaseNotes, err := createReleaseNotes(ctx, &config, "", github.Timestamp{}, nil, utils)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1.1.0 since 1.0.0\nFeatures: new feature\nOther Changes: update readme\n", releaseNotes)
+		assert.True(t, utils.HasWrittenFile("CHANGELOG.md"))
+	})
+
+	t.Run("success - grouped by label", func(t *testing.T) {
+		newReleaseNotesTestRepository(t, "feat: new feature (#3)", "fix: handle timeout (#4)", "chore: update tooling (#5)")
+		utils := newGithubPublishReleaseTestsUtils()
+		utils.AddFile("releaseNotes.tmpl", []byte(customTemplate))
+		featureNo, bugNo, internalNo := 3, 4, 5
+		prURL := "https://github.com/TEST/test/pull"
+		ghIssueClient := ghICMock{
+			issues: []*github.Issue{
+				{Number: &featureNo, PullRequestLinks: &github.PullRequestLinks{URL: &prURL}, Labels: []*github.Label{{Name: github.Ptr("enhancement")}}},
+				{Number: &bugNo, PullRequestLinks: &github.PullRequestLinks{URL: &prURL}, Labels: []*github.Label{{Name: github.Ptr("bug")}}},
+				{Number: &internalNo, PullRequestLinks: &github.PullRequestLinks{URL: &prURL}, Labels: []*github.Label{{Name: github.Ptr("internal")}}},
+			},
+		}
+		config := githubPublishReleaseOptions{
+			Owner:                     "TEST",
+			Repository:                "test",
+			Version:                   "1.1.0",
+			ReleaseNotesLabels:        []string{"enhancement", "bug"},
+			ReleaseNotesExcludeLabels: []string{"internal"},
+			ReleaseNotesGroupBy:       "label",
+			ReleaseNotesTemplate:      "releaseNotes.tmpl",
+		}
+
+		releaseNotes, err := createReleaseNotes(ctx, &config, "1.0.0", github.Timestamp{}, &ghIssueClient, utils)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1.1.0 since 1.0.0\nenhancement: new feature\nbug: handle timeout\n", releaseNotes)
+		assert.Equal(t, "closed", ghIssueClient.options.State)
+	})
+
+	t.Run("success - dependency changes", func(t *testing.T) {
+		newReleaseNotesTestRepository(t, "fix: handle timeout")
+		utils := newGithubPublishReleaseTestsUtils()
+		utils.AddFile("previous/bom.json", []byte(`{"bomFormat": "CycloneDX", "specVersion": "1.4", "version": 1, "components": [
+  {"type": "library", "name": "lodash", "version": "4.17.20", "purl": "pkg:npm/lodash@4.17.20"},
+  {"type": "library", "name": "left-pad", "version": "1.3.0", "purl": "pkg:npm/left-pad@1.3.0"}
+]}`))
+		utils.AddFile("bom.json", []byte(`{"bomFormat": "CycloneDX", "specVersion": "1.4", "version": 1, "components": [
+  {"type": "library", "name": "lodash", "version": "4.17.21", "purl": "pkg:npm/lodash@4.17.21"}
+]}`))
+		config := githubPublishReleaseOptions{Version: "1.1.0", SbomFile: "bom.json", PreviousSbomFile: "previous/bom.json"}
+
+		releaseNotes, err := createReleaseNotes(ctx, &config, "1.0.0", github.Timestamp{}, nil, utils)
+
+		assert.NoError(t, err)
+		assert.Contains(t, releaseNotes, "### Dependency Changes\n")
+		assert.Contains(t, releaseNotes, "* removed `left-pad` 1.3.0\n")
+		assert.Contains(t, releaseNotes, "* upgraded `lodash` from 4.17.20 to 4.17.21\n")
+	})
+
+	t.Run("success - previous release not available", func(t *testing.T) {
+		// e.g. a shallow clone without tags
+		newReleaseNotesTestRepository(t, "fix: handle timeout")
+		utils := newGithubPublishReleaseTestsUtils()
+		config := githubPublishReleaseOptions{Version: "1.2.0", ReleaseNotesFile: "CHANGELOG.md"}
+
+		releaseNotes, err := createReleaseNotes(ctx, &config, "1.1.0", github.Timestamp{}, nil, utils)
+
+		assert.NoError(t, err)
+		assert.Empty(t, releaseNotes)
+		assert.False(t, utils.HasWrittenFile("CHANGELOG.md"))
+	})
+
+	t.Run("error - previous release not available in release notes only mode", func(t *testing.T) {
+		newReleaseNotesTestRepository(t, "fix: handle timeout")
+		utils := newGithubPublishReleaseTestsUtils()
+		config := githubPublishReleaseOptions{Version: "1.2.0", ReleaseNotesFile: "CHANGELOG.md", ReleaseNotesOnly: true}
+
+		_, err := createReleaseNotes(ctx, &config, "1.1.0", github.Timestamp{}, nil, utils)
+
+		assert.ErrorContains(t, err, "the history since '1.1.0' is not available, please fetch the history including tags")
+		assert.False(t, utils.HasWrittenFile("CHANGELOG.md"))
+	})
+
+	t.Run("error - grouped by label without GitHub", func(t *testing.T) {
+		newReleaseNotesTestRepository(t, "fix: handle timeout")
+		config := githubPublishReleaseOptions{Version: "1.1.0", ReleaseNotesGroupBy: "label"}
+
+		_, err := createReleaseNotes(ctx, &config, "", github.Timestamp{}, nil, newGithubPublishReleaseTestsUtils())
+
+		assert.EqualError(t, err, "grouping release notes by label requires the pull requests of GitHub")
+	})
+
+	t.Run("error - missing template", func(t *testing.T) {
+		newReleaseNotesTestRepository(t, "fix: handle timeout")
+		config := githubPublishReleaseOptions{Version: "1.1.0", ReleaseNotesTemplate: "releaseNotes.tmpl"}
+
+		_, err := createReleaseNotes(ctx, &config, "1.0.0", github.Timestamp{}, nil, newGithubPublishReleaseTestsUtils())
+
+		assert.ErrorContains(t, err, "failed to read release notes template releaseNotes.tmpl")
+	})
 }
 
 func TestGetClosedIssuesText(t *testing.T) {
@@ -116,7 +116,9 @@ Available Commands:
 
 ` + "`" + `` + "`" + `` + "`" + `
 
-Note: piper supports only helm3 version, since helm2 is deprecated.`,
+Note: piper supports only helm3 version, since helm2 is deprecated.
+
+The container image signatures are not verified by this step, please execute ` + "`" + `containerVerifyImage` + "`" + ` before the deployment for this purpose.`,
 		PreRunE: func(cmd *cobra.Command, _ []string) error {
 			startTime = time.Now()
 			log.SetStepName(STEP_NAME)
@@ -186,11 +188,19 @@ Note: piper supports only helm3 version, since helm2 is deprecated.`,
 				defer vaultClient.MustRevokeToken()
 			}
 
+			if GeneralConfig.DryRun {
+				PrintStepPlan(STEP_NAME, &metadata, stepConfig)
+				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
+				return
+			}
+
 			stepTelemetryData := telemetry.CustomData{}
 			stepTelemetryData.ErrorCode = "1"
 			handler := func() {
 				commonPipelineEnvironment.persist(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
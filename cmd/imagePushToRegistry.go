@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"regexp"
-	"strings"
 
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/pkg/errors"
@@ -15,6 +14,7 @@ import (
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/versioning"
 )
 
 const (
@@ -99,8 +99,8 @@ func runImagePushToRegistry(config *imagePushToRegistryOptions, telemetryData *t
 	}
 
 	// Docker image tags don't allow plus signs in tags, thus replacing with dash
-	config.SourceImageTag = strings.ReplaceAll(config.SourceImageTag, "+", "-")
-	config.TargetImageTag = strings.ReplaceAll(config.TargetImageTag, "+", "-")
+	config.SourceImageTag = versioning.ContainerImageTag(config.SourceImageTag)
+	config.TargetImageTag = versioning.ContainerImageTag(config.TargetImageTag)
 	re := regexp.MustCompile(`^https?://`)
 	config.SourceRegistryURL = re.ReplaceAllString(config.SourceRegistryURL, "")
 	config.TargetRegistryURL = re.ReplaceAllString(config.TargetRegistryURL, "")
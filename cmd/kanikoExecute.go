@@ -17,6 +17,7 @@ import (
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/syft"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/versioning"
 )
 
 func kanikoExecute(config kanikoExecuteOptions, telemetryData *telemetry.CustomData, commonPipelineEnvironment *kanikoExecuteCommonPipelineEnvironment) {
@@ -158,7 +159,7 @@ func runKanikoExecute(config *kanikoExecuteOptions, telemetryData *telemetry.Cus
 		commonPipelineEnvironment.container.registryURL = config.ContainerRegistryURL
 
 		// Docker image tags don't allow plus signs in tags, thus replacing with dash
-		containerImageTag := strings.ReplaceAll(config.ContainerImageTag, "+", "-")
+		containerImageTag := versioning.ContainerImageTag(config.ContainerImageTag)
 
 		imageListWithFilePath, err := docker.ImageListWithFilePath(config.ContainerImageName, config.ContainerMultiImageBuildExcludes, config.ContainerMultiImageBuildTrimDir, fileUtils)
 		if err != nil {
@@ -217,7 +218,7 @@ func runKanikoExecute(config *kanikoExecuteOptions, telemetryData *telemetry.Cus
 					entry.ContainerImageTag = config.ContainerImageTag
 				}
 				// Docker image tags don't allow plus signs in tags, thus replacing with dash
-				containerImageTag := strings.ReplaceAll(entry.ContainerImageTag, "+", "-")
+				containerImageTag := versioning.ContainerImageTag(entry.ContainerImageTag)
 				containerImageNameAndTag := fmt.Sprintf("%v:%v", entry.ContainerImageName, containerImageTag)
 
 				log.Entry().Debugf("multipleImages: image build '%v'", entry.ContainerImageName)
@@ -275,7 +276,7 @@ func runKanikoExecute(config *kanikoExecuteOptions, telemetryData *telemetry.Cus
 		}
 
 		// Docker image tags don't allow plus signs in tags, thus replacing with dash
-		containerImageTag := strings.ReplaceAll(config.ContainerImageTag, "+", "-")
+		containerImageTag := versioning.ContainerImageTag(config.ContainerImageTag)
 
 		// for compatibility reasons also fill single imageNameTag field with "root" image in commonPipelineEnvironment
 		containerImageNameAndTag := fmt.Sprintf("%v:%v", config.ContainerImageName, containerImageTag)
@@ -326,7 +327,7 @@ func runKanikoExecute(config *kanikoExecuteOptions, telemetryData *telemetry.Cus
 		}
 
 		// Docker image tags don't allow plus signs in tags, thus replacing with dash
-		containerImageTag := strings.ReplaceAll(config.ContainerImageTag, "+", "-")
+		containerImageTag := versioning.ContainerImageTag(config.ContainerImageTag)
 		containerImageNameAndTag := fmt.Sprintf("%v:%v", config.ContainerImageName, containerImageTag)
 
 		commonPipelineEnvironment.container.registryURL = config.ContainerRegistryURL
@@ -53,6 +53,8 @@ func GetAllStepMetadata() map[string]config.StepData {
 		"codeqlExecuteScan":                         codeqlExecuteScanMetadata(),
 		"containerExecuteStructureTests":            containerExecuteStructureTestsMetadata(),
 		"containerSaveImage":                        containerSaveImageMetadata(),
+		"containerSignImage":                        containerSignImageMetadata(),
+		"containerVerifyImage":                      containerVerifyImageMetadata(),
 		"contrastExecuteScan":                       contrastExecuteScanMetadata(),
 		"credentialdiggerScan":                      credentialdiggerScanMetadata(),
 		"detectExecuteScan":                         detectExecuteScanMetadata(),
@@ -104,9 +106,16 @@ func GetAllStepMetadata() map[string]config.StepData {
 		"npmExecuteLint":                            npmExecuteLintMetadata(),
 		"npmExecuteScripts":                         npmExecuteScriptsMetadata(),
 		"npmExecuteTests":                           npmExecuteTestsMetadata(),
+		"osvExecuteScan":                            osvExecuteScanMetadata(),
 		"pipelineCreateScanSummary":                 pipelineCreateScanSummaryMetadata(),
 		"protecodeExecuteScan":                      protecodeExecuteScanMetadata(),
+		"provenanceCreate":                          provenanceCreateMetadata(),
 		"pythonBuild":                               pythonBuildMetadata(),
+		"sarifMerge":                                sarifMergeMetadata(),
+		"sbomConvert":                               sbomConvertMetadata(),
+		"sbomLicenseCheck":                          sbomLicenseCheckMetadata(),
+		"sbomMerge":                                 sbomMergeMetadata(),
+		"securityQualityGate":                       securityQualityGateMetadata(),
 		"shellExecute":                              shellExecuteMetadata(),
 		"sonarExecuteScan":                          sonarExecuteScanMetadata(),
 		"terraformExecute":                          terraformExecuteMetadata(),
@@ -119,6 +128,7 @@ func GetAllStepMetadata() map[string]config.StepData {
 		"transportRequestUploadSOLMAN":              transportRequestUploadSOLMANMetadata(),
 		"uiVeri5ExecuteTests":                       uiVeri5ExecuteTestsMetadata(),
 		"vaultRotateSecretId":                       vaultRotateSecretIdMetadata(),
+		"vexCreate":                                 vexCreateMetadata(),
 		"whitesourceExecuteScan":                    whitesourceExecuteScanMetadata(),
 		"xsDeploy":                                  xsDeployMetadata(),
 	}
@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/pkg/errors"
+
+	"github.com/SAP/jenkins-library/pkg/command"
+	"github.com/SAP/jenkins-library/pkg/format"
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/osv"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/SAP/jenkins-library/pkg/sbom"
+	"github.com/SAP/jenkins-library/pkg/syft"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/toolrecord"
+)
+
+const osvExecuteScanReportDir = "osvExecuteScan"
+
+type osvExecuteScanUtils interface {
+	FileExists(filename string) (bool, error)
+	DirExists(path string) (bool, error)
+	FileRead(path string) ([]byte, error)
+	FileWrite(path string, content []byte, perm os.FileMode) error
+	WriteFile(filename string, data []byte, perm os.FileMode) error
+	MkdirAll(path string, perm os.FileMode) error
+	Glob(pattern string) (matches []string, err error)
+
+	// CreateSBOMs creates the SBOMs bom-docker-<index>.xml of the images using Syft
+	CreateSBOMs(syftDownloadURL string, dockerConfigJSON []byte, registryURL string, images []string) error
+}
+
+type osvExecuteScanUtilsBundle struct {
+	*command.Command
+	*piperutils.Files
+	*piperhttp.Client
+}
+
+func (o *osvExecuteScanUtilsBundle) CreateSBOMs(syftDownloadURL string, dockerConfigJSON []byte, registryURL string, images []string) error {
+	dockerConfigDir, err := o.TempDir("", "docker")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary directory")
+	}
+	defer o.RemoveAll(dockerConfigDir)
+	if len(dockerConfigJSON) == 0 {
+		dockerConfigJSON = []byte("{}")
+	}
+	if err := o.FileWrite(filepath.Join(dockerConfigDir, "config.json"), dockerConfigJSON, 0600); err != nil {
+		return errors.Wrap(err, "failed to write Docker config.json")
+	}
+	return syft.GenerateSBOM(syftDownloadURL, dockerConfigDir, o, o, o, registryURL, images)
+}
+
+func newOsvExecuteScanUtils() osvExecuteScanUtils {
+	utils := osvExecuteScanUtilsBundle{
+		Command: &command.Command{},
+		Files:   &piperutils.Files{},
+		Client:  &piperhttp.Client{},
+	}
+	utils.Stdout(log.Writer())
+	utils.Stderr(log.Writer())
+	return &utils
+}
+
+func osvExecuteScan(config osvExecuteScanOptions, telemetryData *telemetry.CustomData) {
+	utils := newOsvExecuteScanUtils()
+
+	err := runOsvExecuteScan(&config, telemetryData, utils)
+	if err != nil {
+		log.Entry().WithError(err).Fatal("step execution failed")
+	}
+}
+
+func runOsvExecuteScan(config *osvExecuteScanOptions, telemetryData *telemetry.CustomData, utils osvExecuteScanUtils) error {
+	database, err := loadOsvDatabase(config.VulnerabilityDatabase, utils)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+	log.Entry().Infof("vulnerability database loaded: %v vulnerabilities, last modified %v", database.Size(), database.LastModified())
+
+	boms, err := readOsvScanSBOMs(config, utils)
+	if err != nil {
+		return err
+	}
+	files := make([]string, 0, len(boms))
+	for file := range boms {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	result := database.Scan(boms)
+	log.Entry().Infof("%v component(s) scanned, %v vulnerability(s) found", result.Components, len(result.Findings))
+	if result.Unsupported > 0 {
+		log.Entry().Warnf("%v component(s) of unsupported ecosystems could not be scanned", result.Unsupported)
+	}
+
+	if err := applyOsvAssessments(config.AssessmentFile, &result, utils); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
+	severe := []osv.Finding{}
+	for _, finding := range result.Active() {
+		if osv.IsAtLeast(finding.Severity, config.FailOnSeverity) {
+			log.Entry().Errorf("%v vulnerability %v in %v %v", finding.Severity, finding.Vulnerability.ID, finding.Package.Name, finding.Version)
+			severe = append(severe, finding)
+		}
+	}
+
+	scanReport := osv.CreateScanReport(result, database, "osvExecuteScan", files, config.FailOnSeverity)
+	reports, err := writeOsvExecuteScanReports(scanReport, osv.ToSarif(result, database), utils)
+	if err != nil {
+		log.Entry().WithError(err).Warn("failed to write reports")
+	}
+	toolRecordFileName, err := createToolRecordOsv(utils, "./", database, files)
+	if err != nil {
+		// do not fail until the framework is well established
+		log.Entry().Warning("TR_OSV: Failed to create toolrecord file ...", err)
+	} else {
+		reports = append(reports, piperutils.Path{Target: toolRecordFileName})
+	}
+	if err := piperutils.PersistReportsAndLinks("osvExecuteScan", "", utils, reports, nil); err != nil {
+		log.Entry().WithError(err).Warn("failed to persist reports")
+	}
+
+	if len(severe) > 0 {
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("%v vulnerability(s) with severity %v or higher found", len(severe), config.FailOnSeverity)
+	}
+	return nil
+}
+
+// loadOsvDatabase loads all vulnerability database files matching the patterns
+func loadOsvDatabase(patterns []string, utils osvExecuteScanUtils) (*osv.Database, error) {
+	files, err := osvGlobFiles(patterns, utils)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no vulnerability database found matching %v", patterns)
+	}
+	database := osv.NewDatabase()
+	for _, file := range files {
+		content, err := utils.FileRead(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read vulnerability database %v", file)
+		}
+		if err := database.Load(file, content); err != nil {
+			return nil, err
+		}
+	}
+	return database, nil
+}
+
+// readOsvScanSBOMs reads the SBOMs to scan, they are created if none is found and images are configured
+func readOsvScanSBOMs(config *osvExecuteScanOptions, utils osvExecuteScanUtils) (map[string]*cdx.BOM, error) {
+	files, err := osvGlobFiles(config.SbomFiles, utils)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return nil, err
+	}
+	if len(files) == 0 && len(config.ContainerImageNameTags) > 0 {
+		log.Entry().Infof("no SBOM found matching %v, creating SBOMs of the images %v", config.SbomFiles, config.ContainerImageNameTags)
+		dockerConfig, err := readContainerDockerConfigJSON(config.DockerConfigJSON, utils.FileRead)
+		if err != nil {
+			return nil, err
+		}
+		if err := utils.CreateSBOMs(config.SyftDownloadURL, dockerConfig, config.ContainerRegistryURL, config.ContainerImageNameTags); err != nil {
+			return nil, errors.Wrap(err, "failed to create SBOMs")
+		}
+		if files, err = osvGlobFiles(config.SbomFiles, utils); err != nil {
+			return nil, err
+		}
+	}
+	if len(files) == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return nil, fmt.Errorf("no SBOM found matching %v", config.SbomFiles)
+	}
+
+	boms := map[string]*cdx.BOM{}
+	for _, file := range files {
+		bom, err := sbom.ReadFile(file, utils)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return nil, err
+		}
+		boms[file] = bom
+	}
+	return boms, nil
+}
+
+// applyOsvAssessments marks the findings assessed as not relevant in the assessment file, like detectExecuteScan a configured file has to exist
+func applyOsvAssessments(assessmentFile string, result *osv.ScanResult, utils osvExecuteScanUtils) error {
+	if len(assessmentFile) == 0 {
+		return nil
+	}
+	assessments, err := format.ReadAssessmentFile(assessmentFile, utils)
+	if err != nil {
+		return err
+	}
+	assessed := result.ApplyAssessments(assessments)
+	log.Entry().Infof("%v vulnerability(s) assessed as not relevant in %v", assessed, assessmentFile)
+	return nil
+}
+
+func osvGlobFiles(patterns []string, utils osvExecuteScanUtils) ([]string, error) {
+	files := []string{}
+	for _, pattern := range patterns {
+		matches, err := utils.Glob(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern %v", pattern)
+		}
+		files = append(files, matches...)
+	}
+	files = piperutils.UniqueStrings(files)
+	sort.Strings(files)
+	return files, nil
+}
+
+func writeOsvExecuteScanReports(scanReport reporting.ScanReport, sarif format.SARIF, utils osvExecuteScanUtils) ([]piperutils.Path, error) {
+	reportPaths := []piperutils.Path{}
+	if err := utils.MkdirAll(osvExecuteScanReportDir, 0777); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create report directory")
+	}
+
+	// ignore templating errors since template is in our hands and issues will be detected with the automated tests
+	htmlReport, _ := scanReport.ToHTML()
+	htmlReportPath := filepath.Join(osvExecuteScanReportDir, "report.html")
+	if err := utils.FileWrite(htmlReportPath, htmlReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write html report")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "OSV Vulnerability Report", Target: htmlReportPath})
+
+	sarifReport, err := json.Marshal(sarif)
+	if err != nil {
+		return reportPaths, errors.Wrap(err, "failed to marshal SARIF report")
+	}
+	sarifReportPath := filepath.Join(osvExecuteScanReportDir, "vulnerabilities.sarif")
+	if err := utils.FileWrite(sarifReportPath, sarifReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write SARIF report")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "OSV Vulnerability SARIF file", Target: sarifReportPath})
+
+	// JSON report is used by step pipelineCreateScanSummary
+	jsonReport, _ := scanReport.ToJSON()
+	if exists, _ := utils.DirExists(reporting.StepReportDirectory); !exists {
+		if err := utils.MkdirAll(reporting.StepReportDirectory, 0777); err != nil {
+			return reportPaths, errors.Wrap(err, "failed to create reporting directory")
+		}
+	}
+	if err := utils.FileWrite(filepath.Join(reporting.StepReportDirectory, "osvExecuteScan.json"), jsonReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write json report")
+	}
+	return reportPaths, nil
+}
+
+// create toolrecord file for osvExecuteScan, the database snapshot is identified by its last modification
+func createToolRecordOsv(utils osvExecuteScanUtils, workspace string, database *osv.Database, sbomFiles []string) (string, error) {
+	record := toolrecord.New(utils, workspace, "osv", "offline")
+	snapshot := database.LastModified()
+	if len(snapshot) == 0 {
+		snapshot = "n/a"
+	}
+	if err := record.AddKeyData("database", snapshot, fmt.Sprintf("OSV database %v (%v vulnerabilities)", snapshot, database.Size()), ""); err != nil {
+		return "", err
+	}
+	for _, file := range sbomFiles {
+		if err := record.AddKeyData("sbom", file, file, ""); err != nil {
+			return "", err
+		}
+	}
+	if err := record.Persist(); err != nil {
+		return "", err
+	}
+	return record.GetFileName(), nil
+}
@@ -0,0 +1,216 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/SAP/jenkins-library/pkg/sbom"
+)
+
+type osvExecuteScanMockUtils struct {
+	*mock.FilesMock
+	createdSBOMs     []string
+	dockerConfigJSON []byte
+	createError      error
+}
+
+func (o *osvExecuteScanMockUtils) CreateSBOMs(syftDownloadURL string, dockerConfigJSON []byte, registryURL string, images []string) error {
+	if o.createError != nil {
+		return o.createError
+	}
+	o.dockerConfigJSON = dockerConfigJSON
+	for i, image := range images {
+		o.createdSBOMs = append(o.createdSBOMs, registryURL+"/"+image)
+		o.AddFile(fmt.Sprintf("bom-docker-%v.xml", i), osvTestSBOM)
+	}
+	return nil
+}
+
+func newOsvExecuteScanTestsUtils() *osvExecuteScanMockUtils {
+	utils := osvExecuteScanMockUtils{
+		FilesMock: &mock.FilesMock{},
+	}
+	utils.AddFile("osv/Maven.json", []byte(`{
+		"id": "GHSA-jfh8-c2jp-5v3q",
+		"modified": "2024-03-01T12:00:00Z",
+		"aliases": ["CVE-2021-44228"],
+		"severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H"}],
+		"affected": [{
+			"package": {"ecosystem": "Maven", "name": "org.apache.logging.log4j:log4j-core"},
+			"ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "2.13.0"}, {"fixed": "2.15.0"}]}]
+		}]
+	}`))
+	utils.AddFile("osv/Debian.json", []byte(`[{
+		"id": "DSA-5532-1",
+		"modified": "2024-02-01T12:00:00Z",
+		"affected": [{
+			"package": {"ecosystem": "Debian:12", "name": "openssl"},
+			"ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "3.0.11-1~deb12u2"}]}]
+		}]
+	}]`))
+	return &utils
+}
+
+var osvTestSBOM = func() []byte {
+	content, _ := sbom.Encode(&cdx.BOM{
+		Components: &[]cdx.Component{
+			{Type: cdx.ComponentTypeLibrary, Name: "libssl3", Version: "3.0.11-1~deb12u1", PackageURL: "pkg:deb/debian/libssl3@3.0.11-1~deb12u1?distro=debian-12&upstream=openssl"},
+			{Type: cdx.ComponentTypeLibrary, Name: "log4j-core", Version: "2.14.1", PackageURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"},
+		},
+	}, "bom.xml")
+	return content
+}()
+
+func TestRunOsvExecuteScan(t *testing.T) {
+	t.Parallel()
+
+	newConfig := func() osvExecuteScanOptions {
+		return osvExecuteScanOptions{
+			VulnerabilityDatabase: []string{"osv/*.json"},
+			SbomFiles:             []string{"**/bom-docker-*.xml"},
+			FailOnSeverity:        "high",
+		}
+	}
+
+	t.Run("severe vulnerability", func(t *testing.T) {
+		t.Parallel()
+		config := newConfig()
+		utils := newOsvExecuteScanTestsUtils()
+		utils.AddFile("bom-docker-0.xml", osvTestSBOM)
+
+		err := runOsvExecuteScan(&config, nil, utils)
+
+		assert.EqualError(t, err, "1 vulnerability(s) with severity high or higher found")
+		assert.True(t, utils.HasWrittenFile(filepath.Join("osvExecuteScan", "report.html")))
+		assert.True(t, utils.HasWrittenFile(filepath.Join("toolruns", "toolrun_osv_all.json")))
+
+		sarifContent, err := utils.FileRead(filepath.Join("osvExecuteScan", "vulnerabilities.sarif"))
+		assert.NoError(t, err)
+		sarif, err := format.ParseSarif(sarifContent)
+		assert.NoError(t, err)
+		assert.Len(t, sarif.Runs[0].Results, 2)
+
+		jsonReport, err := utils.FileRead(filepath.Join(reporting.StepReportDirectory, "osvExecuteScan.json"))
+		assert.NoError(t, err)
+		scanReport := reporting.ScanReport{}
+		assert.NoError(t, json.Unmarshal(jsonReport, &scanReport))
+		assert.False(t, scanReport.SuccessfulScan)
+		assert.Len(t, scanReport.DetailTable.Rows, 2)
+	})
+
+	t.Run("severe vulnerability assessed", func(t *testing.T) {
+		t.Parallel()
+		config := newConfig()
+		config.AssessmentFile = "hs-assessments.yaml"
+		utils := newOsvExecuteScanTestsUtils()
+		utils.AddFile("bom-docker-0.xml", osvTestSBOM)
+		utils.AddFile("hs-assessments.yaml", []byte(`ignore:
+  - vulnerability: CVE-2021-44228
+    status: notRelevant
+    analysis: notUsed
+`))
+
+		err := runOsvExecuteScan(&config, nil, utils)
+
+		assert.NoError(t, err)
+		sarifContent, _ := utils.FileRead(filepath.Join("osvExecuteScan", "vulnerabilities.sarif"))
+		sarif, _ := format.ParseSarif(sarifContent)
+		assert.True(t, format.IsSarifResultNotRelevant(sarif.Runs[0].Results[0]))
+	})
+
+	t.Run("failOnSeverity none", func(t *testing.T) {
+		t.Parallel()
+		config := newConfig()
+		config.FailOnSeverity = "none"
+		utils := newOsvExecuteScanTestsUtils()
+		utils.AddFile("bom-docker-0.xml", osvTestSBOM)
+
+		err := runOsvExecuteScan(&config, nil, utils)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("SBOMs created", func(t *testing.T) {
+		t.Parallel()
+		config := newConfig()
+		config.ContainerRegistryURL = "https://my.registry"
+		config.ContainerImageNameTags = []string{"app:1.0.0"}
+		config.DockerConfigJSON = ".docker/config.json"
+		config.FailOnSeverity = "critical"
+		utils := newOsvExecuteScanTestsUtils()
+		utils.AddFile(".docker/config.json", []byte(`{"auths":{}}`))
+
+		err := runOsvExecuteScan(&config, nil, utils)
+
+		assert.EqualError(t, err, "1 vulnerability(s) with severity critical or higher found")
+		assert.Equal(t, []string{"https://my.registry/app:1.0.0"}, utils.createdSBOMs)
+		assert.Equal(t, []byte(`{"auths":{}}`), utils.dockerConfigJSON)
+	})
+
+	t.Run("error - creating SBOMs", func(t *testing.T) {
+		t.Parallel()
+		config := newConfig()
+		config.ContainerImageNameTags = []string{"app:1.0.0"}
+		utils := newOsvExecuteScanTestsUtils()
+		utils.createError = fmt.Errorf("download failed")
+
+		err := runOsvExecuteScan(&config, nil, utils)
+
+		assert.EqualError(t, err, "failed to create SBOMs: download failed")
+	})
+
+	t.Run("error - no SBOM", func(t *testing.T) {
+		t.Parallel()
+		config := newConfig()
+		utils := newOsvExecuteScanTestsUtils()
+
+		err := runOsvExecuteScan(&config, nil, utils)
+
+		assert.EqualError(t, err, "no SBOM found matching [**/bom-docker-*.xml]")
+	})
+
+	t.Run("error - assessment file missing", func(t *testing.T) {
+		t.Parallel()
+		config := newConfig()
+		config.AssessmentFile = "hs-assessments.yaml"
+		utils := newOsvExecuteScanTestsUtils()
+		utils.AddFile("bom-docker-0.xml", osvTestSBOM)
+
+		err := runOsvExecuteScan(&config, nil, utils)
+
+		assert.ErrorContains(t, err, "failed to read assessment file hs-assessments.yaml")
+	})
+
+	t.Run("error - no database", func(t *testing.T) {
+		t.Parallel()
+		config := newConfig()
+		config.VulnerabilityDatabase = []string{"osv/*.zip"}
+		utils := newOsvExecuteScanTestsUtils()
+
+		err := runOsvExecuteScan(&config, nil, utils)
+
+		assert.EqualError(t, err, "no vulnerability database found matching [osv/*.zip]")
+	})
+
+	t.Run("error - invalid database", func(t *testing.T) {
+		t.Parallel()
+		config := newConfig()
+		utils := newOsvExecuteScanTestsUtils()
+		utils.AddFile("osv/broken.json", []byte("no json"))
+
+		err := runOsvExecuteScan(&config, nil, utils)
+
+		assert.ErrorContains(t, err, "failed to parse vulnerability osv/broken.json")
+	})
+}
@@ -15,6 +15,7 @@ import (
 type pipelineCreateScanSummaryUtils interface {
 	FileRead(path string) ([]byte, error)
 	FileWrite(path string, content []byte, perm os.FileMode) error
+	WriteFile(filename string, data []byte, perm os.FileMode) error
 	Glob(pattern string) (matches []string, err error)
 }
 
@@ -58,15 +59,23 @@ func runPipelineCreateScanSummary(config *pipelineCreateScanSummaryOptions, tele
 		scanReports = append(scanReports, scanReport)
 	}
 
+	if config.FailedOnly {
+		failedReports := []reporting.ScanReport{}
+		for _, scanReport := range scanReports {
+			if !scanReport.SuccessfulScan {
+				failedReports = append(failedReports, scanReport)
+			}
+		}
+		scanReports = failedReports
+	}
+
 	output := []byte{}
 	if len(config.PipelineLink) > 0 {
 		output = []byte(fmt.Sprintf("## Pipeline Source for Details\n\nAs listed results might be incomplete, it is crucial that you check the detailed [pipeline](%v) status.\n\n", config.PipelineLink))
 	}
 	for _, scanReport := range scanReports {
-		if (config.FailedOnly && !scanReport.SuccessfulScan) || !config.FailedOnly {
-			mdReport, _ := scanReport.ToMarkdown()
-			output = append(output, mdReport...)
-		}
+		mdReport, _ := scanReport.ToMarkdown()
+		output = append(output, mdReport...)
 	}
 
 	if err := utils.FileWrite(config.OutputFilePath, output, 0666); err != nil {
@@ -74,5 +83,33 @@ func runPipelineCreateScanSummary(config *pipelineCreateScanSummaryOptions, tele
 		return errors.Wrapf(err, "failed to write %v", config.OutputFilePath)
 	}
 
+	reportPaths := []piperutils.Path{{Name: "Scan Summary", Target: config.OutputFilePath}}
+	summary := reporting.ScanSummary{Title: "Scan Summary", PipelineLink: config.PipelineLink, Reports: scanReports}
+	if len(config.HtmlOutputFilePath) > 0 {
+		htmlReport, err := summary.ToHTML()
+		if err != nil {
+			return errors.Wrap(err, "failed to create HTML dashboard")
+		}
+		if err := utils.FileWrite(config.HtmlOutputFilePath, htmlReport, 0666); err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return errors.Wrapf(err, "failed to write %v", config.HtmlOutputFilePath)
+		}
+		reportPaths = append(reportPaths, piperutils.Path{Name: "Scan Summary Dashboard", Target: config.HtmlOutputFilePath})
+	}
+	if len(config.JunitOutputFilePath) > 0 {
+		junitReport, err := summary.ToJUnit()
+		if err != nil {
+			return errors.Wrap(err, "failed to create JUnit report")
+		}
+		if err := utils.FileWrite(config.JunitOutputFilePath, junitReport, 0666); err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return errors.Wrapf(err, "failed to write %v", config.JunitOutputFilePath)
+		}
+		reportPaths = append(reportPaths, piperutils.Path{Name: "Scan Summary JUnit Report", Target: config.JunitOutputFilePath})
+	}
+
+	if err := piperutils.PersistReportsAndLinks("pipelineCreateScanSummary", "", utils, reportPaths, nil); err != nil {
+		log.Entry().WithError(err).Warn("failed to persist reports")
+	}
 	return nil
 }
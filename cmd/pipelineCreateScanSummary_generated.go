@@ -17,9 +17,11 @@ import (
 )
 
 type pipelineCreateScanSummaryOptions struct {
-	FailedOnly     bool   `json:"failedOnly,omitempty"`
-	OutputFilePath string `json:"outputFilePath,omitempty"`
-	PipelineLink   string `json:"pipelineLink,omitempty"`
+	FailedOnly          bool   `json:"failedOnly,omitempty"`
+	OutputFilePath      string `json:"outputFilePath,omitempty"`
+	HtmlOutputFilePath  string `json:"htmlOutputFilePath,omitempty"`
+	JunitOutputFilePath string `json:"junitOutputFilePath,omitempty"`
+	PipelineLink        string `json:"pipelineLink,omitempty"`
 }
 
 // PipelineCreateScanSummaryCommand Collect scan result information anc create a summary report
@@ -38,7 +40,11 @@ func PipelineCreateScanSummaryCommand() *cobra.Command {
 		Short: "Collect scan result information anc create a summary report",
 		Long: `This step allows you to create a summary report of your scan results.
 
-It is for example used to create a markdown file which can be used to create a GitHub issue.`,
+It is for example used to create a markdown file which can be used to create a GitHub issue.
+
+In addition an HTML dashboard containing an overview of all scans as well as a JUnit XML report can be created.
+In the JUnit XML report each scan is represented by a test suite and each failed check of a scan by a failed test case,
+so that the scan results are shown in the test result views of e.g. Jenkins and Azure DevOps.`,
 		PreRunE: func(cmd *cobra.Command, _ []string) error {
 			startTime = time.Now()
 			log.SetStepName(STEP_NAME)
@@ -102,10 +108,18 @@ It is for example used to create a markdown file which can be used to create a G
 				defer vaultClient.MustRevokeToken()
 			}
 
+			if GeneralConfig.DryRun {
+				PrintStepPlan(STEP_NAME, &metadata, stepConfig)
+				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
+				return
+			}
+
 			stepTelemetryData := telemetry.CustomData{}
 			stepTelemetryData.ErrorCode = "1"
 			handler := func() {
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
@@ -157,6 +171,8 @@ It is for example used to create a markdown file which can be used to create a G
 func addPipelineCreateScanSummaryFlags(cmd *cobra.Command, stepConfig *pipelineCreateScanSummaryOptions) {
 	cmd.Flags().BoolVar(&stepConfig.FailedOnly, "failedOnly", false, "Defines if only failed scans should be included into the summary.")
 	cmd.Flags().StringVar(&stepConfig.OutputFilePath, "outputFilePath", `scanSummary.md`, "Defines the filepath to the target file which will be created by the step.")
+	cmd.Flags().StringVar(&stepConfig.HtmlOutputFilePath, "htmlOutputFilePath", os.Getenv("PIPER_htmlOutputFilePath"), "Defines the filepath to the HTML dashboard which will be created by the step. If empty, no HTML dashboard is created.")
+	cmd.Flags().StringVar(&stepConfig.JunitOutputFilePath, "junitOutputFilePath", os.Getenv("PIPER_junitOutputFilePath"), "Defines the filepath to the JUnit XML report which will be created by the step. If empty, no JUnit XML report is created.")
 	cmd.Flags().StringVar(&stepConfig.PipelineLink, "pipelineLink", os.Getenv("PIPER_pipelineLink"), "Link to the pipeline (e.g. Jenkins job url) for reference in the scan summary.")
 
 }
@@ -190,6 +206,24 @@ func pipelineCreateScanSummaryMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     `scanSummary.md`,
 					},
+					{
+						Name:        "htmlOutputFilePath",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_htmlOutputFilePath"),
+					},
+					{
+						Name:        "junitOutputFilePath",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_junitOutputFilePath"),
+					},
 					{
 						Name:        "pipelineLink",
 						ResourceRef: []config.ResourceReference{},
@@ -94,6 +94,40 @@ func TestRunPipelineCreateScanSummary(t *testing.T) {
 		assert.Contains(t, fileContentString, "https://test.com/link")
 	})
 
+	t.Run("success - HTML and JUnit", func(t *testing.T) {
+		t.Parallel()
+
+		config := pipelineCreateScanSummaryOptions{
+			OutputFilePath:      "scanSummary.md",
+			HtmlOutputFilePath:  "scanSummary.html",
+			JunitOutputFilePath: "TEST-scanSummary.xml",
+			FailedOnly:          true,
+		}
+
+		utils := newPipelineCreateScanSummaryTestsUtils()
+		utils.AddFile(".pipeline/stepReports/step1.json", []byte(`{"title":"Title Scan 1", "successfulScan": true}`))
+		utils.AddFile(".pipeline/stepReports/step2.json", []byte(`{"stepName":"step2", "title":"Title Scan 2", "overview": [{"description": "High issues: 1", "style": 3}]}`))
+
+		err := runPipelineCreateScanSummary(&config, nil, utils)
+
+		assert.NoError(t, err)
+		htmlContent, err := utils.FileRead("scanSummary.html")
+		assert.NoError(t, err)
+		assert.Contains(t, string(htmlContent), `<a href="#scan-1">Title Scan 2</a>`)
+		assert.NotContains(t, string(htmlContent), "Title Scan 1")
+		junitContent, err := utils.FileRead("TEST-scanSummary.xml")
+		assert.NoError(t, err)
+		assert.Contains(t, string(junitContent), `<testsuite name="Title Scan 2" tests="2" failures="2">`)
+		assert.Contains(t, string(junitContent), `<testcase name="High issues: 1" classname="step2">`)
+		assert.NotContains(t, string(junitContent), "Title Scan 1")
+		persistedReports, err := utils.FileRead("pipelineCreateScanSummary_reports.json")
+		if assert.NoError(t, err) {
+			assert.Contains(t, string(persistedReports), `"target":"scanSummary.md"`)
+			assert.Contains(t, string(persistedReports), `"target":"scanSummary.html"`)
+			assert.Contains(t, string(persistedReports), `"target":"TEST-scanSummary.xml"`)
+		}
+	})
+
 	t.Run("error - read file", func(t *testing.T) {
 		t.Skip()
 		//ToDo
@@ -13,6 +13,7 @@ import (
 	"github.com/SAP/jenkins-library/pkg/config"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/orchestrator"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -48,6 +49,10 @@ type GeneralConfigOptions struct {
 	GCSFolderPath        string
 	GCSBucketId          string
 	GCSSubFolder         string
+	DryRun               bool   // print the resolved configuration of a step instead of executing it
+	CPEEncryption        string // key provider used to keep the commonPipelineEnvironment encrypted at rest
+	CPEEncryptionKeyFile string
+	CPETransitKey        string
 }
 
 // HookConfiguration contains the configuration for supported hooks, so far Sentry and Splunk are supported.
@@ -114,6 +119,7 @@ func Execute() {
 	rootCmd.AddCommand(ArtifactPrepareVersionCommand())
 	rootCmd.AddCommand(ConfigCommand())
 	rootCmd.AddCommand(DefaultsCommand())
+	rootCmd.AddCommand(ValidateConfigCommand())
 	rootCmd.AddCommand(ContainerSaveImageCommand())
 	rootCmd.AddCommand(CommandLineCompletionCommand())
 	rootCmd.AddCommand(VersionCommand())
@@ -210,6 +216,7 @@ func Execute() {
 	rootCmd.AddCommand(InfluxWriteDataCommand())
 	rootCmd.AddCommand(AbapEnvironmentRunAUnitTestCommand())
 	rootCmd.AddCommand(CheckStepActiveCommand())
+	rootCmd.AddCommand(RunCommand())
 	rootCmd.AddCommand(GolangBuildCommand())
 	rootCmd.AddCommand(ShellExecuteCommand())
 	rootCmd.AddCommand(ApiProxyDownloadCommand())
@@ -230,6 +237,16 @@ func Execute() {
 	rootCmd.AddCommand(AscAppUploadCommand())
 	rootCmd.AddCommand(AbapLandscapePortalUpdateAddOnProductCommand())
 	rootCmd.AddCommand(ImagePushToRegistryCommand())
+	rootCmd.AddCommand(SarifMergeCommand())
+	rootCmd.AddCommand(SecurityQualityGateCommand())
+	rootCmd.AddCommand(VexCreateCommand())
+	rootCmd.AddCommand(SbomMergeCommand())
+	rootCmd.AddCommand(SbomLicenseCheckCommand())
+	rootCmd.AddCommand(SbomConvertCommand())
+	rootCmd.AddCommand(ProvenanceCreateCommand())
+	rootCmd.AddCommand(ContainerSignImageCommand())
+	rootCmd.AddCommand(ContainerVerifyImageCommand())
+	rootCmd.AddCommand(OsvExecuteScanCommand())
 
 	addRootFlags(rootCmd)
 
@@ -265,6 +282,10 @@ func addRootFlags(rootCmd *cobra.Command) {
 	rootCmd.PersistentFlags().StringVar(&GeneralConfig.GCSFolderPath, "gcsFolderPath", "", "GCS folder path. One of the components of GCS target folder")
 	rootCmd.PersistentFlags().StringVar(&GeneralConfig.GCSBucketId, "gcsBucketId", "", "Bucket name for Google Cloud Storage")
 	rootCmd.PersistentFlags().StringVar(&GeneralConfig.GCSSubFolder, "gcsSubFolder", "", "Used to logically separate results of the same step result type")
+	rootCmd.PersistentFlags().BoolVar(&GeneralConfig.DryRun, "dry-run", false, "Prints the resolved step configuration with masked secrets and the containers to be used instead of executing the step")
+	rootCmd.PersistentFlags().StringVar(&GeneralConfig.CPEEncryption, "cpeEncryption", os.Getenv("PIPER_cpeEncryption"), "Key provider used to keep the commonPipelineEnvironment encrypted at rest. Options: password (via environment variable PIPER_cpeEncryptionPassword), vaultTransit, x25519.")
+	rootCmd.PersistentFlags().StringVar(&GeneralConfig.CPEEncryptionKeyFile, "cpeEncryptionKeyFile", os.Getenv("PIPER_cpeEncryptionKeyFile"), "Path to the age key file containing the X25519 identity used for the encryption of the commonPipelineEnvironment")
+	rootCmd.PersistentFlags().StringVar(&GeneralConfig.CPETransitKey, "cpeEncryptionTransitKey", os.Getenv("PIPER_cpeEncryptionTransitKey"), "Key of the Vault transit secrets engine used for the encryption of the commonPipelineEnvironment, format: [<mountPath>/]<keyName> (default mount path: transit)")
 }
 
 // ResolveAccessTokens reads a list of tokens in format host:token passed via command line
@@ -359,6 +380,11 @@ func PrepareConfig(cmd *cobra.Command, metadata *config.StepData, stepName strin
 	filters.General = append(filters.General, "collectTelemetryData")
 	filters.Parameters = append(filters.Parameters, "collectTelemetryData")
 
+	if err := configureCPEEncryption(); err != nil {
+		return err
+	}
+	piperenv.SetRegistry(CPERegistry())
+
 	envParams := metadata.GetResourceParameters(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")
 	reportingEnvParams := config.ReportingParameters.GetResourceParameters(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")
 	resourceParams := mergeResourceParameters(envParams, reportingEnvParams)
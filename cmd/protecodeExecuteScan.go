@@ -17,6 +17,7 @@ import (
 	"github.com/SAP/jenkins-library/pkg/command"
 	"github.com/SAP/jenkins-library/pkg/docker"
 	piperDocker "github.com/SAP/jenkins-library/pkg/docker"
+	"github.com/SAP/jenkins-library/pkg/format"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/protecode"
@@ -78,8 +79,12 @@ func runProtecodeScan(config *protecodeExecuteScanOptions, influx *protecodeExec
 		return err
 	}
 
+	componentAssessments, err := excludeAssessedVulnerabilities(config, utils)
+	if err != nil {
+		return err
+	}
+
 	var fileName, filePath string
-	var err error
 
 	if len(config.FetchURL) == 0 && len(config.FilePath) == 0 {
 		log.Entry().Debugf("Get docker image: %v, %v, %v", config.ScanImage, config.DockerRegistryURL, config.FilePath)
@@ -108,7 +113,7 @@ func runProtecodeScan(config *protecodeExecuteScanOptions, influx *protecodeExec
 	}
 
 	log.Entry().Debug("Execute protecode scan")
-	if err := executeProtecodeScan(influx, client, config, fileName, utils); err != nil {
+	if err := executeProtecodeScan(influx, client, config, fileName, componentAssessments, utils); err != nil {
 		return err
 	}
 
@@ -149,7 +154,7 @@ func getDockerImage(utils protecodeUtils, config *protecodeExecuteScanOptions, c
 	return filepath.Base(tarFilePath), filepath.Dir(tarFilePath), nil
 }
 
-func executeProtecodeScan(influx *protecodeExecuteScanInflux, client protecode.Protecode, config *protecodeExecuteScanOptions, fileName string, utils protecodeUtils) error {
+func executeProtecodeScan(influx *protecodeExecuteScanInflux, client protecode.Protecode, config *protecodeExecuteScanOptions, fileName string, componentAssessments []format.Assessment, utils protecodeUtils) error {
 	reportPath := "./"
 
 	log.Entry().Debugf("[DEBUG] ===> Load existing product Group:%v, VerifyOnly:%v, Filename:%v, replaceProductId:%v", config.Group, config.VerifyOnly, fileName, config.ReplaceProductID)
@@ -204,6 +209,11 @@ func executeProtecodeScan(influx *protecodeExecuteScanInflux, client protecode.P
 		return fmt.Errorf("protecode scan failed: %v/products/%v", config.ServerURL, productID)
 	}
 
+	if len(componentAssessments) > 0 {
+		triaged := protecode.ApplyComponentAssessments(&result.Result, componentAssessments, config.AssessmentFile)
+		log.Entry().Infof("%v vulnerability(s) of assessed components triaged as not relevant", triaged)
+	}
+
 	//loadReport
 	log.Entry().Debugf("Load report %v for %v", config.ReportFileName, productID)
 	resp := client.LoadReport(config.ReportFileName, productID)
@@ -285,6 +295,39 @@ func executeProtecodeScan(influx *protecodeExecuteScanInflux, client protecode.P
 	return nil
 }
 
+// excludeAssessedVulnerabilities adds the vulnerabilities assessed as not relevant for all components to the excluded CVEs.
+// Assessments which apply to specific components only are returned in order to triage the vulnerabilities of these components.
+func excludeAssessedVulnerabilities(config *protecodeExecuteScanOptions, utils protecodeUtils) ([]format.Assessment, error) {
+	if len(config.AssessmentFile) == 0 {
+		return nil, nil
+	}
+	assessments, err := format.ReadAssessmentFile(config.AssessmentFile, utils)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return nil, err
+	}
+	notRelevant := []string{}
+	componentAssessments := []format.Assessment{}
+	for _, assessment := range assessments {
+		if assessment.Status != format.NotRelevant || len(assessment.Vulnerability) == 0 {
+			continue
+		}
+		if len(assessment.Purls) > 0 {
+			componentAssessments = append(componentAssessments, assessment)
+		} else {
+			notRelevant = append(notRelevant, assessment.Vulnerability)
+		}
+	}
+	log.Entry().Infof("%v vulnerability(s) assessed as not relevant in %v", len(notRelevant)+len(componentAssessments), config.AssessmentFile)
+	if len(notRelevant) > 0 {
+		if len(config.ExcludeCVEs) > 0 {
+			notRelevant = append([]string{config.ExcludeCVEs}, notRelevant...)
+		}
+		config.ExcludeCVEs = strings.Join(notRelevant, ",")
+	}
+	return componentAssessments, nil
+}
+
 func setInfluxData(influx *protecodeExecuteScanInflux, result map[string]int) {
 	influx.protecode_data.fields.historical_vulnerabilities = result["historical_vulnerabilities"]
 	influx.protecode_data.fields.triaged_vulnerabilities = result["triaged_vulnerabilities"]
@@ -24,6 +24,7 @@ import (
 
 type protecodeExecuteScanOptions struct {
 	ExcludeCVEs                 string `json:"excludeCVEs,omitempty"`
+	AssessmentFile              string `json:"assessmentFile,omitempty"`
 	FailOnSevereVulnerabilities bool   `json:"failOnSevereVulnerabilities,omitempty"`
 	ScanImage                   string `json:"scanImage,omitempty"`
 	DockerRegistryURL           string `json:"dockerRegistryUrl,omitempty"`
@@ -44,7 +45,7 @@ type protecodeExecuteScanOptions struct {
 	UserAPIKey                  string `json:"userAPIKey,omitempty"`
 	Version                     string `json:"version,omitempty"`
 	CustomScanVersion           string `json:"customScanVersion,omitempty"`
-	VersioningModel             string `json:"versioningModel,omitempty" validate:"possible-values=major major-minor semantic full"`
+	VersioningModel             string `json:"versioningModel,omitempty" validate:"possible-values=major major-minor semantic full semver2 semantic-prerelease pep440 maven"`
 	PullRequestName             string `json:"pullRequestName,omitempty"`
 	CustomDataJSONMap           string `json:"customDataJSONMap,omitempty"`
 }
@@ -227,12 +228,20 @@ BDBA (Protecode) uses a combination of static binary analysis techniques to X-ra
 				defer vaultClient.MustRevokeToken()
 			}
 
+			if GeneralConfig.DryRun {
+				PrintStepPlan(STEP_NAME, &metadata, stepConfig)
+				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
+				return
+			}
+
 			stepTelemetryData := telemetry.CustomData{}
 			stepTelemetryData.ErrorCode = "1"
 			handler := func() {
 				influx.persist(GeneralConfig.EnvRootPath, "influx")
 				reports.persist(stepConfig, GeneralConfig.GCPJsonKeyFilePath, GeneralConfig.GCSBucketId, GeneralConfig.GCSFolderPath, GeneralConfig.GCSSubFolder)
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
@@ -283,6 +292,7 @@ BDBA (Protecode) uses a combination of static binary analysis techniques to X-ra
 
 func addProtecodeExecuteScanFlags(cmd *cobra.Command, stepConfig *protecodeExecuteScanOptions) {
 	cmd.Flags().StringVar(&stepConfig.ExcludeCVEs, "excludeCVEs", ``, "DEPRECATED: Do use triaging within the Protecode UI instead")
+	cmd.Flags().StringVar(&stepConfig.AssessmentFile, "assessmentFile", os.Getenv("PIPER_assessmentFile"), "Path to an assessment file, either an assessment YAML file or a VEX document (OpenVEX or CycloneDX VEX). Vulnerabilities assessed as not relevant are excluded like the ones listed in `excludeCVEs`. Assessments with package URLs only apply to the components matching name and, if specified, version of a package URL.")
 	cmd.Flags().BoolVar(&stepConfig.FailOnSevereVulnerabilities, "failOnSevereVulnerabilities", true, "Whether to fail the step on severe vulnerabilties or not")
 	cmd.Flags().StringVar(&stepConfig.ScanImage, "scanImage", os.Getenv("PIPER_scanImage"), "The reference to the docker image to scan with Protecode. Note: If possible please also check [fetchUrl](https://www.project-piper.io/steps/protecodeExecuteScan/#fetchurl) parameter, which might help you to optimize upload time.")
 	cmd.Flags().StringVar(&stepConfig.DockerRegistryURL, "dockerRegistryUrl", os.Getenv("PIPER_dockerRegistryUrl"), "The reference to the docker registry to scan with Protecode")
@@ -338,6 +348,15 @@ func protecodeExecuteScanMetadata() config.StepData {
 						Aliases:     []config.Alias{{Name: "protecodeExcludeCVEs"}},
 						Default:     ``,
 					},
+					{
+						Name:        "assessmentFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_assessmentFile"),
+					},
 					{
 						Name:        "failOnSevereVulnerabilities",
 						ResourceRef: []config.ResourceReference{},
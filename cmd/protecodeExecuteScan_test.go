@@ -335,7 +335,7 @@ func TestExecuteProtecodeScan(t *testing.T) {
 			config := protecodeExecuteScanOptions{VerifyOnly: c.reuse, CleanupMode: c.clean, Group: c.group, FetchURL: c.fetchURL, TimeoutMinutes: "3", ExcludeCVEs: "CVE-2018-1, CVE-2017-1000382", ReportFileName: "./cache/report-file.txt"}
 			influxData := &protecodeExecuteScanInflux{}
 			// test
-			executeProtecodeScan(influxData, pc, &config, "dummy", utils)
+			executeProtecodeScan(influxData, pc, &config, "dummy", nil, utils)
 			// assert
 			assert.Equal(t, 1125, influxData.protecode_data.fields.historical_vulnerabilities)
 			assert.Equal(t, 0, influxData.protecode_data.fields.triaged_vulnerabilities)
@@ -385,3 +385,44 @@ func TestCorrectDockerConfigEnvVar(t *testing.T) {
 		assert.Equal(t, resetValue, os.Getenv("DOCKER_CONFIG"))
 	})
 }
+
+func TestExcludeAssessedVulnerabilities(t *testing.T) {
+	utils := protecodeTestUtilsBundle{
+		FilesMock:    &mock.FilesMock{},
+		DownloadMock: &mock.DownloadMock{},
+	}
+	utils.AddFile("vex.json", []byte(`{"@context": "https://openvex.dev/ns/v0.2.0", "statements": [
+		{"vulnerability": {"name": "CVE-2023-1"}, "status": "not_affected", "justification": "vulnerable_code_not_present"},
+		{"vulnerability": {"name": "CVE-2023-2"}, "status": "affected"},
+		{"vulnerability": {"name": "CVE-2023-3"}, "status": "fixed"},
+		{"vulnerability": {"name": "CVE-2023-4"}, "products": [{"@id": "pkg:deb/ubuntu/curl@7.35.0"}], "status": "not_affected", "justification": "vulnerable_code_not_in_execute_path"}
+	]}`))
+
+	t.Run("with assessment file", func(t *testing.T) {
+		config := protecodeExecuteScanOptions{AssessmentFile: "vex.json", ExcludeCVEs: "CVE-2022-1"}
+
+		componentAssessments, err := excludeAssessedVulnerabilities(&config, utils)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "CVE-2022-1,CVE-2023-1,CVE-2023-3", config.ExcludeCVEs)
+		if assert.Len(t, componentAssessments, 1) {
+			assert.Equal(t, "CVE-2023-4", componentAssessments[0].Vulnerability)
+		}
+	})
+	t.Run("without assessment file", func(t *testing.T) {
+		config := protecodeExecuteScanOptions{ExcludeCVEs: "CVE-2022-1"}
+
+		componentAssessments, err := excludeAssessedVulnerabilities(&config, utils)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "CVE-2022-1", config.ExcludeCVEs)
+		assert.Empty(t, componentAssessments)
+	})
+	t.Run("missing assessment file", func(t *testing.T) {
+		config := protecodeExecuteScanOptions{AssessmentFile: "hs-assessments.yaml"}
+
+		_, err := excludeAssessedVulnerabilities(&config, utils)
+
+		assert.ErrorContains(t, err, "failed to read assessment file hs-assessments.yaml")
+	})
+}
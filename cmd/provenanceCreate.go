@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/SAP/jenkins-library/pkg/attestation"
+	"github.com/SAP/jenkins-library/pkg/docker"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/orchestrator"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+)
+
+type provenanceCreateUtils interface {
+	FileExists(filename string) (bool, error)
+	FileRead(path string) ([]byte, error)
+	FileWrite(path string, content []byte, perm os.FileMode) error
+	WriteFile(filename string, data []byte, perm os.FileMode) error
+	MkdirAll(path string, perm os.FileMode) error
+	Glob(pattern string) (matches []string, err error)
+
+	GetConfigProvider() (orchestrator.ConfigProvider, error)
+	containerImageDigestResolver
+	AttachAttestation(repository, digest string, envelope attestation.Envelope, predicateType string, dockerConfigJSON []byte) (string, error)
+}
+
+type provenanceCreateUtilsBundle struct {
+	*piperutils.Files
+}
+
+func (p *provenanceCreateUtilsBundle) GetConfigProvider() (orchestrator.ConfigProvider, error) {
+	return orchestrator.GetOrchestratorConfigProvider(nil)
+}
+
+func (p *provenanceCreateUtilsBundle) GetImageDigest(image string, dockerConfigJSON []byte) (string, error) {
+	keychain, err := docker.KeychainFromDockerConfigJSON(dockerConfigJSON)
+	if err != nil {
+		return "", err
+	}
+	return docker.GetImageDigest(image, keychain)
+}
+
+func (p *provenanceCreateUtilsBundle) AttachAttestation(repository, digest string, envelope attestation.Envelope, predicateType string, dockerConfigJSON []byte) (string, error) {
+	keychain, err := docker.KeychainFromDockerConfigJSON(dockerConfigJSON)
+	if err != nil {
+		return "", err
+	}
+	return docker.AttachAttestation(repository, digest, envelope, predicateType, keychain)
+}
+
+func newProvenanceCreateUtils() provenanceCreateUtils {
+	utils := provenanceCreateUtilsBundle{
+		Files: &piperutils.Files{},
+	}
+	return &utils
+}
+
+func provenanceCreate(config provenanceCreateOptions, telemetryData *telemetry.CustomData) {
+	utils := newProvenanceCreateUtils()
+
+	err := runProvenanceCreate(&config, telemetryData, utils, time.Now())
+	if err != nil {
+		log.Entry().WithError(err).Fatal("step execution failed")
+	}
+}
+
+func runProvenanceCreate(config *provenanceCreateOptions, telemetryData *telemetry.CustomData, utils provenanceCreateUtils, timestamp time.Time) error {
+	keyContent, err := utils.FileRead(config.SigningKeyFile)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.Wrapf(err, "failed to read signing key %v", config.SigningKeyFile)
+	}
+	signer, err := attestation.LoadPrivateKey(keyContent)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.Wrapf(err, "invalid signing key %v", config.SigningKeyFile)
+	}
+
+	subjects, err := provenanceFileSubjects(config, utils)
+	if err != nil {
+		return err
+	}
+	dockerConfig, err := readContainerDockerConfigJSON(config.DockerConfigJSON, utils.FileRead)
+	if err != nil {
+		return err
+	}
+	images := []docker.ContainerImage{}
+	if len(config.ContainerImageNameTags) > 0 {
+		if images, err = containerImagesWithDigests(config.ContainerRegistryURL, config.ContainerImageNameTags, config.ContainerImageDigests, dockerConfig, utils); err != nil {
+			return err
+		}
+	}
+	for _, image := range images {
+		subject, err := attestation.ImageSubject(image.Repository, image.Digest)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return err
+		}
+		subjects = append(subjects, subject)
+	}
+	if len(subjects) == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return fmt.Errorf("no subjects found, neither artifacts matching %v nor container images", config.ArtifactPatterns)
+	}
+
+	statement := attestation.NewProvenanceStatement(subjects, provenanceBuildInfo(utils, timestamp))
+	envelope, err := attestation.SignStatement(statement, signer)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(envelope)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal attestation")
+	}
+	if err := utils.MkdirAll(filepath.Dir(config.OutputPath), 0777); err != nil {
+		return errors.Wrapf(err, "failed to create directory of %v", config.OutputPath)
+	}
+	// DSSE envelopes are stored in JSON Lines format, one envelope per line
+	if err := utils.FileWrite(config.OutputPath, append(content, '\n'), 0666); err != nil {
+		return errors.Wrapf(err, "failed to write attestation %v", config.OutputPath)
+	}
+	log.Entry().Infof("provenance attestation for %v subject(s) written to %v", len(subjects), config.OutputPath)
+
+	if config.AttachToImages {
+		if err := attachProvenance(images, envelope, dockerConfig, utils); err != nil {
+			return err
+		}
+	}
+
+	reports := []piperutils.Path{{Name: "Provenance Attestation", Target: config.OutputPath}}
+	if err := piperutils.PersistReportsAndLinks("provenanceCreate", "", utils, reports, nil); err != nil {
+		log.Entry().WithError(err).Warn("failed to persist reports")
+	}
+	return nil
+}
+
+// provenanceFileSubjects returns the build artifacts matching the configured patterns
+func provenanceFileSubjects(config *provenanceCreateOptions, utils provenanceCreateUtils) ([]attestation.Subject, error) {
+	files := []string{}
+	for _, pattern := range config.ArtifactPatterns {
+		matches, err := utils.Glob(pattern)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return nil, errors.Wrapf(err, "invalid pattern %v", pattern)
+		}
+		files = append(files, matches...)
+	}
+	files = piperutils.UniqueStrings(files)
+	sort.Strings(files)
+
+	subjects := []attestation.Subject{}
+	for _, file := range files {
+		content, err := utils.FileRead(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read artifact %v", file)
+		}
+		subjects = append(subjects, attestation.FileSubject(filepath.ToSlash(file), content))
+	}
+	return subjects, nil
+}
+
+// provenanceBuildInfo collects the information about the build provided by the orchestrator
+func provenanceBuildInfo(utils provenanceCreateUtils, timestamp time.Time) attestation.BuildInfo {
+	build := attestation.BuildInfo{FinishedOn: timestamp}
+	provider, err := utils.GetConfigProvider()
+	if err != nil {
+		log.Entry().WithError(err).Warning("cannot infer build information from CI environment")
+		return build
+	}
+	// values which are not known to the orchestrator are returned as n/a
+	value := func(v string) string {
+		if v == "n/a" {
+			return ""
+		}
+		return v
+	}
+	build.RepositoryURL = value(provider.RepoURL())
+	build.CommitSHA = value(provider.CommitSHA())
+	build.Reference = value(provider.GitReference())
+	build.Orchestrator = value(provider.OrchestratorType())
+	build.JobURL = value(provider.JobURL())
+	build.BuildURL = value(provider.BuildURL())
+	build.StartedOn = provider.PipelineStartTime()
+	return build
+}
+
+func attachProvenance(images []docker.ContainerImage, envelope attestation.Envelope, dockerConfig []byte, utils provenanceCreateUtils) error {
+	if len(images) == 0 {
+		log.Entry().Warn("no container images found, attestation is not attached")
+		return nil
+	}
+	for _, image := range images {
+		tag, err := utils.AttachAttestation(image.Repository, image.Digest, envelope, attestation.PredicateTypeSLSAProvenance, dockerConfig)
+		if err != nil {
+			return errors.Wrapf(err, "failed to attach attestation to image %v", image.Reference())
+		}
+		log.Entry().Infof("attestation attached to image %v as %v", image.Reference(), tag)
+	}
+	return nil
+}
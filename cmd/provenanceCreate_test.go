@@ -0,0 +1,207 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/attestation"
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/orchestrator"
+	"github.com/stretchr/testify/assert"
+)
+
+type provenanceCreateMockUtils struct {
+	*mock.FilesMock
+	attached         []string
+	dockerConfigJSON []byte
+	attachError      error
+	// imageDigests contains the digests of the images in the registry
+	imageDigests map[string]string
+}
+
+func (p *provenanceCreateMockUtils) GetImageDigest(image string, dockerConfigJSON []byte) (string, error) {
+	digest, ok := p.imageDigests[image]
+	if !ok {
+		return "", fmt.Errorf("image %v not found", image)
+	}
+	return digest, nil
+}
+
+func (p *provenanceCreateMockUtils) GetConfigProvider() (orchestrator.ConfigProvider, error) {
+	return &provenanceCreateConfigProviderMock{}, nil
+}
+
+func (p *provenanceCreateMockUtils) AttachAttestation(repository, digest string, envelope attestation.Envelope, predicateType string, dockerConfigJSON []byte) (string, error) {
+	if p.attachError != nil {
+		return "", p.attachError
+	}
+	p.attached = append(p.attached, fmt.Sprintf("%v@%v %v", repository, digest, predicateType))
+	p.dockerConfigJSON = dockerConfigJSON
+	return repository + ":" + digest + ".att", nil
+}
+
+type provenanceCreateConfigProviderMock struct {
+	orchestrator.UnknownOrchestratorConfigProvider
+}
+
+func (p *provenanceCreateConfigProviderMock) OrchestratorType() string { return "GitHubActions" }
+func (p *provenanceCreateConfigProviderMock) RepoURL() string {
+	return "https://github.com/SAP/jenkins-library"
+}
+func (p *provenanceCreateConfigProviderMock) CommitSHA() string    { return "0123456789abcdef" }
+func (p *provenanceCreateConfigProviderMock) GitReference() string { return "refs/heads/main" }
+func (p *provenanceCreateConfigProviderMock) BuildURL() string {
+	return "https://github.com/SAP/jenkins-library/actions/runs/42"
+}
+
+func newProvenanceCreateTestsUtils(t *testing.T) (*provenanceCreateMockUtils, *ecdsa.PrivateKey) {
+	utils := provenanceCreateMockUtils{
+		FilesMock: &mock.FilesMock{},
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+	utils.AddFile("signing-key.pem", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	return &utils, key
+}
+
+func TestRunProvenanceCreate(t *testing.T) {
+	t.Parallel()
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("artifacts and images", func(t *testing.T) {
+		t.Parallel()
+		config := provenanceCreateOptions{
+			SigningKeyFile:         "signing-key.pem",
+			ArtifactPatterns:       []string{"target/*.jar"},
+			ContainerRegistryURL:   "https://my.registry:5000",
+			ContainerImageNameTags: []string{"app:1.0.0"},
+			ContainerImageDigests:  []string{"sha256:abc"},
+			AttachToImages:         true,
+			DockerConfigJSON:       "config.json",
+			OutputPath:             "provenance/provenance.intoto.jsonl",
+		}
+		utils, key := newProvenanceCreateTestsUtils(t)
+		utils.AddFile("target/app.jar", []byte("hello world"))
+		utils.AddFile("config.json", []byte(`{"auths":{}}`))
+
+		err := runProvenanceCreate(&config, nil, utils, timestamp)
+
+		assert.NoError(t, err)
+		content, err := utils.FileRead("provenance/provenance.intoto.jsonl")
+		assert.NoError(t, err)
+		envelope := attestation.Envelope{}
+		assert.NoError(t, json.Unmarshal(content, &envelope))
+		payload, err := envelope.Verify(key.Public())
+		assert.NoError(t, err)
+		statement := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(payload, &statement))
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "target/app.jar", "digest": map[string]interface{}{"sha256": "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"}},
+			map[string]interface{}{"name": "my.registry:5000/app", "digest": map[string]interface{}{"sha256": "abc"}},
+		}, statement["subject"])
+		predicate := statement["predicate"].(map[string]interface{})
+		assert.Equal(t, []interface{}{map[string]interface{}{"uri": "git+https://github.com/SAP/jenkins-library@refs/heads/main", "digest": map[string]interface{}{"gitCommit": "0123456789abcdef"}}},
+			predicate["buildDefinition"].(map[string]interface{})["resolvedDependencies"])
+		runDetails := predicate["runDetails"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"id": "https://www.project-piper.io/attestation/builders/githubactions"}, runDetails["builder"])
+		assert.Equal(t, map[string]interface{}{"invocationId": "https://github.com/SAP/jenkins-library/actions/runs/42", "finishedOn": "2024-01-02T03:04:05Z"}, runDetails["metadata"])
+
+		assert.Equal(t, []string{"my.registry:5000/app@sha256:abc https://slsa.dev/provenance/v1"}, utils.attached)
+		assert.Equal(t, []byte(`{"auths":{}}`), utils.dockerConfigJSON)
+		assert.True(t, utils.HasWrittenFile("provenanceCreate_reports.json"))
+	})
+
+	t.Run("attestation not attached by default", func(t *testing.T) {
+		t.Parallel()
+		config := provenanceCreateOptions{
+			SigningKeyFile:         "signing-key.pem",
+			ContainerImageNameTags: []string{"my.registry/app:1.0.0"},
+			ContainerImageDigests:  []string{"sha256:abc"},
+			OutputPath:             "provenance.intoto.jsonl",
+		}
+		utils, _ := newProvenanceCreateTestsUtils(t)
+
+		err := runProvenanceCreate(&config, nil, utils, timestamp)
+
+		assert.NoError(t, err)
+		assert.True(t, utils.HasWrittenFile("provenance.intoto.jsonl"))
+		assert.Empty(t, utils.attached)
+	})
+
+	t.Run("attaching fails", func(t *testing.T) {
+		t.Parallel()
+		config := provenanceCreateOptions{
+			SigningKeyFile:         "signing-key.pem",
+			ContainerImageNameTags: []string{"my.registry/app:1.0.0"},
+			ContainerImageDigests:  []string{"sha256:abc"},
+			AttachToImages:         true,
+			OutputPath:             "provenance.intoto.jsonl",
+		}
+		utils, _ := newProvenanceCreateTestsUtils(t)
+		utils.attachError = fmt.Errorf("unauthorized")
+
+		err := runProvenanceCreate(&config, nil, utils, timestamp)
+
+		assert.EqualError(t, err, "failed to attach attestation to image my.registry/app@sha256:abc: unauthorized")
+	})
+
+	t.Run("no subjects", func(t *testing.T) {
+		t.Parallel()
+		config := provenanceCreateOptions{SigningKeyFile: "signing-key.pem", ArtifactPatterns: []string{"target/*.jar"}}
+		utils, _ := newProvenanceCreateTestsUtils(t)
+
+		err := runProvenanceCreate(&config, nil, utils, timestamp)
+
+		assert.EqualError(t, err, "no subjects found, neither artifacts matching [target/*.jar] nor container images")
+	})
+
+	t.Run("digests of additional tags", func(t *testing.T) {
+		t.Parallel()
+		// one digest per build, the digests of the tags are resolved from the registry
+		config := provenanceCreateOptions{
+			SigningKeyFile:         "signing-key.pem",
+			OutputPath:             "provenance.intoto.jsonl",
+			ContainerImageNameTags: []string{"my.registry/app:1.0.0", "my.registry/app:latest"},
+			ContainerImageDigests:  []string{"sha256:abc"},
+		}
+		utils, _ := newProvenanceCreateTestsUtils(t)
+		utils.imageDigests = map[string]string{"my.registry/app:1.0.0": "sha256:abc", "my.registry/app:latest": "sha256:abc"}
+
+		err := runProvenanceCreate(&config, nil, utils, timestamp)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("missing image digests", func(t *testing.T) {
+		t.Parallel()
+		config := provenanceCreateOptions{SigningKeyFile: "signing-key.pem", ContainerImageNameTags: []string{"my.registry/app:1.0.0", "my.registry/other:1.0.0"}, ContainerImageDigests: []string{"sha256:abc"}}
+		utils, _ := newProvenanceCreateTestsUtils(t)
+
+		err := runProvenanceCreate(&config, nil, utils, timestamp)
+
+		assert.EqualError(t, err, "image my.registry/app:1.0.0 not found")
+	})
+
+	t.Run("invalid signing key", func(t *testing.T) {
+		t.Parallel()
+		config := provenanceCreateOptions{SigningKeyFile: "config.json"}
+		utils, _ := newProvenanceCreateTestsUtils(t)
+		utils.AddFile("config.json", []byte(`{"auths":{}}`))
+
+		err := runProvenanceCreate(&config, nil, utils, timestamp)
+
+		assert.EqualError(t, err, "invalid signing key config.json: no PEM encoded private key found")
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/SAP/jenkins-library/pkg/config"
 	"github.com/SAP/jenkins-library/pkg/encryption"
@@ -17,6 +18,7 @@ import (
 func ReadPipelineEnv() *cobra.Command {
 	var stepConfig artifactPrepareVersionOptions
 	var encryptedCPE bool
+	var validate bool
 	metadata := artifactPrepareVersionMetadata()
 
 	readPipelineEnvCmd := &cobra.Command{
@@ -37,6 +39,13 @@ func ReadPipelineEnv() *cobra.Command {
 		},
 
 		Run: func(cmd *cobra.Command, args []string) {
+			if validate {
+				if err := runValidatePipelineEnv(CPERegistry()); err != nil {
+					log.SetErrorCategory(log.ErrorConfiguration)
+					log.Entry().Fatalf("validation of Pipeline environment failed: %v", err)
+				}
+				return
+			}
 			err := runReadPipelineEnv(stepConfig.Password, encryptedCPE)
 			if err != nil {
 				log.Entry().Fatalf("error when writing reading Pipeline environment: %v", err)
@@ -45,6 +54,7 @@ func ReadPipelineEnv() *cobra.Command {
 	}
 
 	readPipelineEnvCmd.Flags().BoolVar(&encryptedCPE, "encryptedCPE", false, "Bool to use encryption in CPE")
+	readPipelineEnvCmd.Flags().BoolVar(&validate, "validate", false, "Validates the CPE against the keys defined in the step metadata instead of printing it. Unknown keys are reported as warnings, keys with a wrong type as errors.")
 	return readPipelineEnvCmd
 }
 
@@ -82,3 +92,29 @@ func runReadPipelineEnv(stepConfigPassword string, encryptedCPE bool) error {
 
 	return nil
 }
+
+func runValidatePipelineEnv(registry piperenv.Registry) error {
+	// findings are reported below, no need for the warnings while loading
+	piperenv.SetRegistry(nil)
+	cpe := piperenv.CPEMap{}
+	if err := cpe.LoadFromDisk(path.Join(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")); err != nil {
+		return err
+	}
+
+	errorCount, unknownCount := 0, 0
+	for _, finding := range registry.ValidateCPE(cpe) {
+		if finding.Unknown {
+			unknownCount++
+			log.Entry().Warnf("%v: %v", finding.Key, finding.Message)
+			continue
+		}
+		errorCount++
+		entry := registry[finding.Key]
+		log.Entry().Errorf("%v: %v (written by: %v, read by: %v)", finding.Key, finding.Message, strings.Join(entry.WrittenBy, ", "), strings.Join(entry.ReadBy, ", "))
+	}
+	if errorCount > 0 {
+		return fmt.Errorf("%v key(s) with wrong type and %v unknown key(s)", errorCount, unknownCount)
+	}
+	log.Entry().Infof("Pipeline environment is valid (%v keys, %v unknown)", len(cpe), unknownCount)
+	return nil
+}
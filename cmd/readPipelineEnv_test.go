@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/SAP/jenkins-library/pkg/encryption"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,3 +22,28 @@ func TestCpeEncryption(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, decrypted, payload)
 }
+
+func TestRunValidatePipelineEnv(t *testing.T) {
+	registry := piperenv.Registry{
+		"artifactVersion":      {Type: "string", WrittenBy: []string{"artifactPrepareVersion"}},
+		"container/imageNames": {Type: "[]string", WrittenBy: []string{"kanikoExecute"}, ReadBy: []string{"kubernetesDeploy"}},
+	}
+	prepare := func(t *testing.T, cpe piperenv.CPEMap) {
+		envRootPath := GeneralConfig.EnvRootPath
+		t.Cleanup(func() { GeneralConfig.EnvRootPath = envRootPath })
+		GeneralConfig.EnvRootPath = t.TempDir()
+		assert.NoError(t, cpe.WriteToDisk(filepath.Join(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")))
+	}
+
+	t.Run("valid with unknown keys", func(t *testing.T) {
+		prepare(t, piperenv.CPEMap{"artifactVersion": "1.0.0", "container/imageNames": []string{"image"}, "custom/myKey": "value"})
+
+		assert.NoError(t, runValidatePipelineEnv(registry))
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		prepare(t, piperenv.CPEMap{"container/imageNames": "image", "custom/myKey": "value"})
+
+		assert.EqualError(t, runValidatePipelineEnv(registry), "1 key(s) with wrong type and 1 unknown key(s)")
+	})
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/SAP/jenkins-library/pkg/command"
+	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+)
+
+type runCommandOptions struct {
+	openFile        func(s string, t map[string]string) (io.ReadCloser, error)
+	fileExists      func(filename string) (bool, error)
+	executeStep     func(stepName string) error
+	stageConfigFile string
+	stageName       string
+}
+
+var runOptions runCommandOptions
+
+// RunCommand is the entry command for executing all active steps of a stage
+func RunCommand() *cobra.Command {
+	runOptions.openFile = config.OpenPiperFile
+	runOptions.fileExists = piperutils.FileExists
+	var runCmd = &cobra.Command{
+		Use:   "run",
+		Short: "Executes all active steps of a stage.",
+		Long: `Evaluates the step conditions of a stage defined in the stage configuration and executes all active steps in order.
+Each step is executed by the piper binary in a separate process, which parses the step flags given to this command and shares the commonPipelineEnvironment with the other steps.
+This allows to reproduce a stage locally, e.g. via ` + "`" + `piper run --stage Build --verbose` + "`" + `.`,
+		// flags of the steps are passed on to the steps which define them
+		FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: true},
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			path, _ := os.Getwd()
+			fatalHook := &log.FatalHook{CorrelationID: GeneralConfig.CorrelationID, Path: path}
+			log.RegisterHook(fatalHook)
+			initStageName(false)
+			log.SetVerbose(GeneralConfig.Verbose)
+			GeneralConfig.GitHubAccessTokens = ResolveAccessTokens(GeneralConfig.GitHubTokens)
+		},
+		Run: func(cmd *cobra.Command, _ []string) {
+			utils := &piperutils.Files{}
+			execRunner := &command.Command{}
+			execRunner.Stdout(log.Writer())
+			execRunner.Stderr(log.Writer())
+			runOptions.executeStep = stepExecutor(cmd.Root(), execRunner, os.Args[1:])
+			err := runStage(utils)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				log.Entry().WithError(err).Fatal("Running the stage failed")
+			}
+		},
+	}
+	addRunFlags(runCmd)
+	return runCmd
+}
+
+func runStage(utils piperutils.FileUtils) error {
+	// make the stage the leading parameter
+	if len(runOptions.stageName) == 0 && GeneralConfig.StageName != "" {
+		runOptions.stageName = GeneralConfig.StageName
+	}
+	if runOptions.stageName == "" {
+		return errors.New("stage name must not be empty")
+	}
+	var pConfig config.Config
+
+	// load project config and defaults
+	projectConfig, err := initializeConfig(&pConfig, runOptions.openFile, runOptions.fileExists)
+	if err != nil {
+		return errors.Wrap(err, "failed to load project config")
+	}
+
+	stageConfigFile, err := runOptions.openFile(runOptions.stageConfigFile, GeneralConfig.GitHubAccessTokens)
+	if err != nil {
+		return errors.Wrapf(err, "config: open stage configuration file '%v' failed", runOptions.stageConfigFile)
+	}
+	defer stageConfigFile.Close()
+
+	// load and evaluate step conditions
+	runConfigV1 := &config.RunConfigV1{RunConfig: config.RunConfig{StageConfigFile: stageConfigFile}}
+	err = runConfigV1.InitRunConfigV1(projectConfig, utils, GeneralConfig.EnvRootPath)
+	if err != nil {
+		return err
+	}
+
+	stage, ok := findStage(runConfigV1.PipelineConfig, runOptions.stageName)
+	if !ok {
+		return errors.Errorf("stage %s is not defined in stage configuration '%v'", runOptions.stageName, runOptions.stageConfigFile)
+	}
+	if !runConfigV1.RunStages[stage.DisplayName] {
+		log.Entry().Infof("Stage %s is not active, no step is executed", stage.DisplayName)
+		return nil
+	}
+
+	// steps of the stage read their stage-specific configuration
+	GeneralConfig.StageName = stage.DisplayName
+	for _, step := range stage.Steps {
+		if !runConfigV1.RunSteps[stage.DisplayName][step.Name] {
+			log.Entry().Infof("Step %s in stage %s is not active", step.Name, stage.DisplayName)
+			continue
+		}
+		log.Entry().Infof("Executing step %s in stage %s", step.Name, stage.DisplayName)
+		if err := runOptions.executeStep(step.Name); err != nil {
+			return errors.Wrapf(err, "step %s in stage %s failed", step.Name, stage.DisplayName)
+		}
+	}
+	log.Entry().Infof("Stage %s finished", stage.DisplayName)
+
+	return nil
+}
+
+// findStage returns the stage with the given display name or technical name
+func findStage(pipelineConfig config.PipelineDefinitionV1, stageName string) (config.Stage, bool) {
+	for _, stage := range pipelineConfig.Spec.Stages {
+		if stage.DisplayName == stageName || stage.Name == stageName {
+			return stage, true
+		}
+	}
+	return config.Stage{}, false
+}
+
+// stepExecutor returns a function executing a step command registered at the root command with the piper binary in a separate process.
+// The step performs its own setup and a failing step ends only its own process, which is reported as error.
+// Steps which are not available in the binary (e.g. orchestrator-specific steps) are skipped.
+func stepExecutor(rootCmd *cobra.Command, execRunner command.ExecRunner, args []string) func(stepName string) error {
+	return func(stepName string) error {
+		stepCmd, _, err := rootCmd.Find([]string{stepName})
+		if err != nil || stepCmd == rootCmd {
+			log.Entry().Warnf("Step %s is not available in the piper binary, skipping it", stepName)
+			return nil
+		}
+		executable, err := os.Executable()
+		if err != nil {
+			return errors.Wrap(err, "failed to determine the path of the piper binary")
+		}
+		stepArgs := append([]string{stepName, "--stageName", GeneralConfig.StageName}, stepFlags(stepCmd, args)...)
+		return execRunner.RunExecutable(executable, stepArgs...)
+	}
+}
+
+// stepFlags returns the flags of the given arguments which are defined by the step command or the root command.
+// The stage name is omitted since it is set for each step.
+func stepFlags(stepCmd *cobra.Command, args []string) []string {
+	flags := []string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" || arg == "--" {
+			continue
+		}
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		flag := lookupFlag(stepCmd, name, !strings.HasPrefix(arg, "--"))
+		if flag == nil {
+			// values of unknown flags are skipped like all other arguments which are no flags
+			continue
+		}
+		// the value of a non-boolean flag is the next argument unless it is given with '='
+		consumesNext := !hasValue && len(flag.NoOptDefVal) == 0 && i+1 < len(args)
+		if flag.Name != "stageName" {
+			flags = append(flags, arg)
+			if consumesNext {
+				flags = append(flags, args[i+1])
+			}
+		}
+		if consumesNext {
+			i++
+		}
+	}
+	return flags
+}
+
+func lookupFlag(stepCmd *cobra.Command, name string, shorthand bool) *pflag.Flag {
+	for _, flags := range []*pflag.FlagSet{stepCmd.Flags(), stepCmd.InheritedFlags()} {
+		if shorthand && len(name) == 1 {
+			if flag := flags.ShorthandLookup(name); flag != nil {
+				return flag
+			}
+		} else if flag := flags.Lookup(name); flag != nil {
+			return flag
+		}
+	}
+	return nil
+}
+
+func addRunFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&runOptions.stageConfigFile, "stageConfig", ".resources/piper-stage-config.yml",
+		"Default config of piper pipeline stages")
+	cmd.Flags().StringVar(&runOptions.stageName, "stage", "", "Name of the stage to be executed")
+}
@@ -0,0 +1,159 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func runOpenFileMock(name string, tokens map[string]string) (io.ReadCloser, error) {
+	var fileContent string
+	switch name {
+	case "stage-config.yml":
+		fileContent = `
+spec:
+  stages:
+    - name: build
+      displayName: Build
+      steps:
+        - name: firstStep
+        - name: inactiveStep
+          conditions:
+            - configKey: notExistingKey
+        - name: secondStep
+          conditions:
+            - configKey: testConfig
+    - name: release
+      displayName: Release
+      steps:
+        - name: releaseStep
+          conditions:
+            - configKey: notExistingKey`
+	case ".pipeline/config.yml":
+		fileContent = `
+steps:
+  secondStep:
+    testConfig: 'testValue'`
+	default:
+		fileContent = ""
+	}
+	return io.NopCloser(strings.NewReader(fileContent)), nil
+}
+
+func runFileExistsMock(filename string) (bool, error) {
+	return filename == ".pipeline/config.yml", nil
+}
+
+func TestRunStage(t *testing.T) {
+	setup := func(stageName string) *[]string {
+		executed := []string{}
+		runOptions = runCommandOptions{
+			openFile:        runOpenFileMock,
+			fileExists:      runFileExistsMock,
+			stageConfigFile: "stage-config.yml",
+			stageName:       stageName,
+			executeStep: func(stepName string) error {
+				executed = append(executed, stepName)
+				return nil
+			},
+		}
+		GeneralConfig.CustomConfig = ".pipeline/config.yml"
+		GeneralConfig.DefaultConfig = []string{".pipeline/defaults.yaml"}
+		GeneralConfig.StageName = ""
+		return &executed
+	}
+
+	t.Run("executes active steps in order", func(t *testing.T) {
+		executed := setup("Build")
+
+		err := runStage(&mock.FilesMock{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"firstStep", "secondStep"}, *executed)
+		assert.Equal(t, "Build", GeneralConfig.StageName)
+	})
+
+	t.Run("technical stage name", func(t *testing.T) {
+		executed := setup("build")
+
+		err := runStage(&mock.FilesMock{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"firstStep", "secondStep"}, *executed)
+	})
+
+	t.Run("inactive stage", func(t *testing.T) {
+		executed := setup("Release")
+
+		err := runStage(&mock.FilesMock{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, *executed)
+	})
+
+	t.Run("unknown stage", func(t *testing.T) {
+		setup("Deploy")
+
+		err := runStage(&mock.FilesMock{})
+
+		assert.EqualError(t, err, "stage Deploy is not defined in stage configuration 'stage-config.yml'")
+	})
+
+	t.Run("missing stage name", func(t *testing.T) {
+		setup("")
+
+		err := runStage(&mock.FilesMock{})
+
+		assert.EqualError(t, err, "stage name must not be empty")
+	})
+
+	t.Run("step failure", func(t *testing.T) {
+		setup("Build")
+		runOptions.executeStep = func(stepName string) error {
+			return assert.AnError
+		}
+
+		err := runStage(&mock.FilesMock{})
+
+		assert.EqualError(t, err, "step firstStep in stage Build failed: "+assert.AnError.Error())
+	})
+}
+
+func TestStepExecutor(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "piper"}
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().String("stageName", "", "stage name")
+	rootCmd.PersistentFlags().StringSlice("defaultConfig", []string{}, "defaults")
+	stepCmd := &cobra.Command{Use: "firstStep", Run: func(cmd *cobra.Command, _ []string) {}}
+	stepCmd.Flags().String("stepParam", "", "step parameter")
+	stepCmd.Flags().Bool("stepSwitch", false, "step switch")
+	rootCmd.AddCommand(stepCmd)
+	args := []string{"run", "--stage", "Build", "-v", "--stageName", "Other", "--defaultConfig", "a.yml", "--defaultConfig=b.yml",
+		"--stepParam", "value", "--otherStepParam", "otherValue", "--stepSwitch", "--stageConfig=stage.yml"}
+	GeneralConfig.StageName = "Build"
+
+	t.Run("success", func(t *testing.T) {
+		execRunner := &mock.ExecMockRunner{}
+		execute := stepExecutor(rootCmd, execRunner, args)
+
+		assert.NoError(t, execute("firstStep"))
+		assert.NoError(t, execute("notAvailableStep"))
+		if assert.Len(t, execRunner.Calls, 1) {
+			assert.Equal(t, []string{"firstStep", "--stageName", "Build", "-v", "--defaultConfig", "a.yml", "--defaultConfig=b.yml", "--stepParam", "value", "--stepSwitch"}, execRunner.Calls[0].Params)
+		}
+	})
+
+	t.Run("step failure", func(t *testing.T) {
+		execRunner := &mock.ExecMockRunner{ShouldFailOnCommand: map[string]error{"firstStep": assert.AnError}}
+		execute := stepExecutor(rootCmd, execRunner, args)
+
+		assert.EqualError(t, execute("firstStep"), assert.AnError.Error())
+	})
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/pkg/errors"
+)
+
+type sarifMergeUtils interface {
+	FileExists(filename string) (bool, error)
+	FileRead(path string) ([]byte, error)
+	FileWrite(path string, content []byte, perm os.FileMode) error
+	WriteFile(filename string, data []byte, perm os.FileMode) error
+	MkdirAll(path string, perm os.FileMode) error
+	Glob(pattern string) (matches []string, err error)
+}
+
+type sarifMergeUtilsBundle struct {
+	*piperutils.Files
+}
+
+func newSarifMergeUtils() sarifMergeUtils {
+	utils := sarifMergeUtilsBundle{
+		Files: &piperutils.Files{},
+	}
+	return &utils
+}
+
+func sarifMerge(config sarifMergeOptions, telemetryData *telemetry.CustomData) {
+	utils := newSarifMergeUtils()
+
+	err := runSarifMerge(&config, telemetryData, utils)
+	if err != nil {
+		log.Entry().WithError(err).Fatal("step execution failed")
+	}
+}
+
+func runSarifMerge(config *sarifMergeOptions, telemetryData *telemetry.CustomData, utils sarifMergeUtils) error {
+	files, err := findSarifFiles(config.SarifFiles, utils, config.OutputPath, config.BaselineFile)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		log.Entry().Warnf("no SARIF files found matching %v", config.SarifFiles)
+	}
+
+	documents := []format.SarifDocument{}
+	for _, file := range files {
+		document, err := readSarifFile(file, utils)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return err
+		}
+		log.Entry().Infof("merging %v run(s) of %v", document.RunCount(), file)
+		documents = append(documents, document)
+	}
+	merged := format.MergeSarif(documents...)
+	duplicates, crossToolDuplicates := format.DeduplicateSarif(merged)
+	if duplicates > 0 || crossToolDuplicates > 0 {
+		log.Entry().Infof("removed %v duplicate finding(s) and %v finding(s) already reported by another tool", duplicates, crossToolDuplicates)
+	}
+
+	// without baseline all findings are considered new
+	newFindings := merged.ResultCount()
+	if len(config.BaselineFile) > 0 {
+		exists, err := utils.FileExists(config.BaselineFile)
+		if err != nil {
+			return errors.Wrapf(err, "failed to check for baseline file %v", config.BaselineFile)
+		}
+		if exists {
+			baseline, err := readSarifFile(config.BaselineFile, utils)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+			comparison := format.ApplySarifBaseline(merged, baseline)
+			log.Entry().Infof("compared with baseline %v: %v new, %v unchanged and %v fixed finding(s)", config.BaselineFile, comparison.New, comparison.Unchanged, comparison.Absent)
+			newFindings = comparison.New
+		} else {
+			log.Entry().Infof("baseline file %v does not exist, all findings are considered new", config.BaselineFile)
+		}
+		if config.UpdateBaseline {
+			if err := writeSarifFile(config.BaselineFile, merged, utils); err != nil {
+				return err
+			}
+			log.Entry().Infof("baseline %v updated", config.BaselineFile)
+		}
+		if config.NewFindingsOnly {
+			format.RemoveUnchangedResults(merged)
+		}
+	}
+
+	if err := writeSarifFile(config.OutputPath, merged, utils); err != nil {
+		return err
+	}
+	reports := []piperutils.Path{{Name: "Merged SARIF file", Target: config.OutputPath}}
+	if err := piperutils.PersistReportsAndLinks("sarifMerge", "", utils, reports, nil); err != nil {
+		log.Entry().WithError(err).Warn("failed to persist reports")
+	}
+	log.Entry().Infof("%v finding(s) of %v run(s) written to %v", merged.ResultCount(), merged.RunCount(), config.OutputPath)
+
+	if config.FailOnNewFindings && newFindings > 0 {
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("%v new finding(s) which are not contained in the baseline", newFindings)
+	}
+	return nil
+}
+
+// findSarifFiles returns the files matching the patterns except the excluded files, e.g. the output and the baseline file
+func findSarifFiles(patterns []string, utils sarifMergeUtils, excludes ...string) ([]string, error) {
+	excluded := map[string]bool{}
+	for _, exclude := range excludes {
+		if len(exclude) > 0 {
+			excluded[filepath.Clean(exclude)] = true
+		}
+	}
+	files := []string{}
+	for _, pattern := range patterns {
+		matches, err := utils.Glob(pattern)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return nil, errors.Wrapf(err, "invalid pattern %v", pattern)
+		}
+		for _, match := range matches {
+			if !excluded[filepath.Clean(match)] {
+				excluded[filepath.Clean(match)] = true
+				files = append(files, match)
+			}
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func readSarifFile(path string, utils sarifMergeUtils) (format.SarifDocument, error) {
+	content, err := utils.FileRead(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %v", path)
+	}
+	sarif, err := format.ParseSarifDocument(content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %v", path)
+	}
+	return sarif, nil
+}
+
+func writeSarifFile(path string, sarif format.SarifDocument, utils sarifMergeUtils) error {
+	content, err := json.Marshal(sarif)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal SARIF")
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := utils.MkdirAll(dir, 0777); err != nil {
+			return errors.Wrapf(err, "failed to create directory %v", dir)
+		}
+	}
+	if err := utils.FileWrite(path, content, 0666); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.Wrapf(err, "failed to write %v", path)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type sarifMergeMockUtils struct {
+	*mock.FilesMock
+}
+
+func newSarifMergeTestsUtils() sarifMergeMockUtils {
+	utils := sarifMergeMockUtils{
+		FilesMock: &mock.FilesMock{},
+	}
+	return utils
+}
+
+const (
+	checkmarxSarif = `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "Checkmarx"}}, "results": [
+		{"ruleId": "SQL_Injection", "partialFingerprints": {"checkmarxSimilarityID": "1234"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "src/db.js"}, "region": {"startLine": 10}}}]},
+		{"ruleId": "SQL_Injection", "partialFingerprints": {"checkmarxSimilarityID": "1234"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "src/db.js"}, "region": {"startLine": 10}}}]},
+		{"ruleId": "XSS", "partialFingerprints": {"checkmarxSimilarityID": "5678"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "src/web.js"}, "region": {"startLine": 3}}}]}
+	]}]}`
+	fortifySarif = `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "MicroFocus Fortify SCA"}}, "results": [
+		{"ruleId": "Password Management", "partialFingerprints": {"fortifyInstanceID": "abcd"}, "properties": {"fortifyCategory": "Password Management"}}
+	]}]}`
+	sarifMergeBaseline = `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "Checkmarx"}}, "results": [
+		{"ruleId": "SQL_Injection", "partialFingerprints": {"checkmarxSimilarityID": "1234"}, "locations": [{"physicalLocation": {"artifactLocation": {"uri": "src/db.js"}, "region": {"startLine": 8}}}]}
+	]}]}`
+)
+
+func readMergedSarif(t *testing.T, utils sarifMergeMockUtils, path string) format.SARIF {
+	content, err := utils.FileRead(path)
+	assert.NoError(t, err)
+	sarif, err := format.ParseSarif(content)
+	assert.NoError(t, err)
+	return sarif
+}
+
+func TestRunSarifMerge(t *testing.T) {
+	t.Parallel()
+
+	t.Run("merge and deduplicate", func(t *testing.T) {
+		t.Parallel()
+		config := sarifMergeOptions{SarifFiles: []string{"**/*.sarif", "checkmarx/*.sarif"}, OutputPath: "sarifMerge/merged.sarif"}
+		utils := newSarifMergeTestsUtils()
+		utils.AddFile("checkmarx/result.sarif", []byte(checkmarxSarif))
+		utils.AddFile("fortify/result.sarif", []byte(fortifySarif))
+		// result of a previous run
+		utils.AddFile("sarifMerge/merged.sarif", []byte(`{"version": "2.1.0", "runs": []}`))
+
+		err := runSarifMerge(&config, nil, utils)
+		assert.NoError(t, err)
+
+		merged := readMergedSarif(t, utils, "sarifMerge/merged.sarif")
+		assert.Len(t, merged.Runs, 2)
+		assert.Equal(t, "Checkmarx", merged.Runs[0].Tool.Driver.Name)
+		assert.Len(t, merged.Runs[0].Results, 2)
+		assert.Equal(t, "MicroFocus Fortify SCA", merged.Runs[1].Tool.Driver.Name)
+		assert.Len(t, merged.Runs[1].Results, 1)
+		content, err := utils.FileRead("sarifMerge/merged.sarif")
+		assert.NoError(t, err)
+		// tool specific properties are kept
+		assert.Contains(t, string(content), `"properties":{"fortifyCategory":"Password Management"}`)
+		assert.True(t, utils.HasWrittenFile("sarifMerge_reports.json"))
+	})
+
+	t.Run("new findings only", func(t *testing.T) {
+		t.Parallel()
+		config := sarifMergeOptions{SarifFiles: []string{"**/*.sarif"}, OutputPath: "merged.sarif", BaselineFile: "baseline.sarif", NewFindingsOnly: true}
+		utils := newSarifMergeTestsUtils()
+		utils.AddFile("checkmarx/result.sarif", []byte(checkmarxSarif))
+		utils.AddFile("baseline.sarif", []byte(sarifMergeBaseline))
+
+		err := runSarifMerge(&config, nil, utils)
+		assert.NoError(t, err)
+
+		merged := readMergedSarif(t, utils, "merged.sarif")
+		assert.Len(t, merged.Runs, 1)
+		if assert.Len(t, merged.Runs[0].Results, 1) {
+			assert.Equal(t, "XSS", merged.Runs[0].Results[0].RuleID)
+			assert.Equal(t, format.BaselineStateNew, merged.Runs[0].Results[0].BaselineState)
+		}
+	})
+
+	t.Run("fail on new findings", func(t *testing.T) {
+		t.Parallel()
+		config := sarifMergeOptions{SarifFiles: []string{"**/*.sarif"}, OutputPath: "merged.sarif", BaselineFile: "baseline.sarif", FailOnNewFindings: true}
+		utils := newSarifMergeTestsUtils()
+		utils.AddFile("checkmarx/result.sarif", []byte(checkmarxSarif))
+		utils.AddFile("fortify/result.sarif", []byte(fortifySarif))
+		utils.AddFile("baseline.sarif", []byte(sarifMergeBaseline))
+
+		err := runSarifMerge(&config, nil, utils)
+		assert.EqualError(t, err, "2 new finding(s) which are not contained in the baseline")
+		// the merged file is written nevertheless
+		merged := readMergedSarif(t, utils, "merged.sarif")
+		assert.Len(t, merged.Runs[0].Results, 2)
+	})
+
+	t.Run("create baseline", func(t *testing.T) {
+		t.Parallel()
+		config := sarifMergeOptions{SarifFiles: []string{"**/*.sarif"}, OutputPath: "merged.sarif", BaselineFile: "security/baseline.sarif", UpdateBaseline: true, FailOnNewFindings: true}
+		utils := newSarifMergeTestsUtils()
+		utils.AddFile("fortify/result.sarif", []byte(fortifySarif))
+
+		err := runSarifMerge(&config, nil, utils)
+		assert.EqualError(t, err, "1 new finding(s) which are not contained in the baseline")
+
+		baseline := readMergedSarif(t, utils, "security/baseline.sarif")
+		assert.Len(t, baseline.Runs[0].Results, 1)
+	})
+
+	t.Run("no SARIF files", func(t *testing.T) {
+		t.Parallel()
+		config := sarifMergeOptions{SarifFiles: []string{"**/*.sarif"}, OutputPath: "merged.sarif", FailOnNewFindings: true}
+		utils := newSarifMergeTestsUtils()
+
+		err := runSarifMerge(&config, nil, utils)
+		assert.NoError(t, err)
+		merged := readMergedSarif(t, utils, "merged.sarif")
+		assert.Empty(t, merged.Runs)
+	})
+
+	t.Run("invalid SARIF file", func(t *testing.T) {
+		t.Parallel()
+		config := sarifMergeOptions{SarifFiles: []string{"**/*.sarif"}, OutputPath: "merged.sarif"}
+		utils := newSarifMergeTestsUtils()
+		utils.AddFile("result.sarif", []byte(`{"version": "2.0.0"}`))
+
+		err := runSarifMerge(&config, nil, utils)
+		assert.EqualError(t, err, "failed to read result.sarif: unsupported SARIF version '2.0.0'")
+	})
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/sbom"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+)
+
+const spdxFileExtension = ".spdx.json"
+
+var spdxNamespaceInvalidCharacters = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+type sbomConvertUtils interface {
+	FileExists(filename string) (bool, error)
+	FileRead(path string) ([]byte, error)
+	FileWrite(path string, content []byte, perm os.FileMode) error
+	WriteFile(filename string, data []byte, perm os.FileMode) error
+	MkdirAll(path string, perm os.FileMode) error
+	Glob(pattern string) (matches []string, err error)
+}
+
+type sbomConvertUtilsBundle struct {
+	*piperutils.Files
+}
+
+func newSbomConvertUtils() sbomConvertUtils {
+	utils := sbomConvertUtilsBundle{
+		Files: &piperutils.Files{},
+	}
+	return &utils
+}
+
+func sbomConvert(config sbomConvertOptions, telemetryData *telemetry.CustomData) {
+	utils := newSbomConvertUtils()
+
+	err := runSbomConvert(&config, telemetryData, utils, time.Now())
+	if err != nil {
+		log.Entry().WithError(err).Fatal("step execution failed")
+	}
+}
+
+func runSbomConvert(config *sbomConvertOptions, telemetryData *telemetry.CustomData, utils sbomConvertUtils, timestamp time.Time) error {
+	files := []string{}
+	for _, pattern := range config.SbomFiles {
+		matches, err := utils.Glob(pattern)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return errors.Wrapf(err, "invalid pattern %v", pattern)
+		}
+		for _, match := range matches {
+			// SPDX documents written by a previous run are no CycloneDX SBOMs
+			if !strings.HasSuffix(match, spdxFileExtension) {
+				files = append(files, match)
+			}
+		}
+	}
+	files = piperutils.UniqueStrings(files)
+	sort.Strings(files)
+	if len(files) == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return fmt.Errorf("no SBOM found matching %v", config.SbomFiles)
+	}
+
+	reports := []piperutils.Path{}
+	for _, file := range files {
+		content, err := utils.FileRead(file)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read SBOM %v", file)
+		}
+		bom, err := sbom.Decode(content)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return errors.Wrapf(err, "failed to parse SBOM %v", file)
+		}
+
+		document := sbom.ToSPDX(bom, "", timestamp)
+		document.DocumentNamespace = spdxDocumentNamespace(config.DocumentNamespacePrefix, document.Name, content)
+		spdxContent, err := json.MarshalIndent(document, "", "  ")
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal SPDX document of SBOM %v", file)
+		}
+		target := strings.TrimSuffix(file, filepath.Ext(file)) + spdxFileExtension
+		if err := utils.FileWrite(target, spdxContent, 0666); err != nil {
+			return errors.Wrapf(err, "failed to write SPDX document %v", target)
+		}
+		log.Entry().Infof("SPDX document with %v package(s) written to %v", len(document.Packages), target)
+		reports = append(reports, piperutils.Path{Name: fmt.Sprintf("SPDX SBOM %v", filepath.Base(target)), Target: target})
+	}
+
+	if err := piperutils.PersistReportsAndLinks("sbomConvert", "", utils, reports, nil); err != nil {
+		log.Entry().WithError(err).Warn("failed to persist reports")
+	}
+	return nil
+}
+
+// spdxDocumentNamespace returns a unique namespace for the document, the UUID is derived from the content of the SBOM
+func spdxDocumentNamespace(prefix, name string, content []byte) string {
+	id := uuid.NewSHA1(uuid.NameSpaceURL, content)
+	return fmt.Sprintf("%v/%v-%v", strings.TrimSuffix(prefix, "/"), spdxNamespaceInvalidCharacters.ReplaceAllString(name, "-"), id)
+}
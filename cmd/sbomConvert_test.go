@@ -0,0 +1,81 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/sbom"
+	"github.com/stretchr/testify/assert"
+)
+
+type sbomConvertMockUtils struct {
+	*mock.FilesMock
+}
+
+func newSbomConvertTestsUtils() sbomConvertMockUtils {
+	utils := sbomConvertMockUtils{
+		FilesMock: &mock.FilesMock{},
+	}
+	return utils
+}
+
+func TestRunSbomConvert(t *testing.T) {
+	t.Parallel()
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		config := sbomConvertOptions{SbomFiles: []string{"**/bom-*.xml", "**/product-bom.xml"}, DocumentNamespacePrefix: "https://sbom.example.com/"}
+		utils := newSbomConvertTestsUtils()
+		utils.AddFile("ui/bom-npm.xml", []byte(sbomLicenseCheckSBOM))
+		utils.AddFile("sbom/product-bom.xml", []byte(sbomLicenseCheckDeniedSBOM))
+
+		err := runSbomConvert(&config, nil, utils, timestamp)
+
+		assert.NoError(t, err)
+		content, err := utils.FileRead("ui/bom-npm.spdx.json")
+		assert.NoError(t, err)
+		document := sbom.SPDXDocument{}
+		assert.NoError(t, json.Unmarshal(content, &document))
+		assert.Equal(t, "ui@1.0.0", document.Name)
+		assert.Regexp(t, `^https://sbom\.example\.com/ui-1\.0\.0-[0-9a-f-]{36}$`, document.DocumentNamespace)
+		assert.Equal(t, "2024-01-02T03:04:05Z", document.CreationInfo.Created)
+		assert.Len(t, document.Packages, 3)
+		assert.True(t, utils.HasWrittenFile("sbom/product-bom.spdx.json"))
+		assert.True(t, utils.HasWrittenFile("sbomConvert_reports.json"))
+
+		// the namespace is stable for the same SBOM
+		first := document.DocumentNamespace
+		assert.NoError(t, runSbomConvert(&config, nil, utils, timestamp))
+		content, _ = utils.FileRead("ui/bom-npm.spdx.json")
+		assert.NoError(t, json.Unmarshal(content, &document))
+		assert.Equal(t, first, document.DocumentNamespace)
+	})
+
+	t.Run("SPDX documents are not converted", func(t *testing.T) {
+		t.Parallel()
+		config := sbomConvertOptions{SbomFiles: []string{"**/bom-*.json"}}
+		utils := newSbomConvertTestsUtils()
+		utils.AddFile("bom-npm.spdx.json", []byte(`{"spdxVersion":"SPDX-2.3"}`))
+
+		err := runSbomConvert(&config, nil, utils, timestamp)
+
+		assert.EqualError(t, err, "no SBOM found matching [**/bom-*.json]")
+	})
+
+	t.Run("invalid SBOM", func(t *testing.T) {
+		t.Parallel()
+		config := sbomConvertOptions{SbomFiles: []string{"**/bom-*.xml"}}
+		utils := newSbomConvertTestsUtils()
+		utils.AddFile("bom-npm.xml", []byte("<bom"))
+
+		err := runSbomConvert(&config, nil, utils, timestamp)
+
+		assert.ErrorContains(t, err, "failed to parse SBOM bom-npm.xml")
+	})
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/SAP/jenkins-library/pkg/sbom"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+)
+
+const sbomLicenseCheckReportDir = "sbomLicenseCheck"
+
+type sbomLicenseCheckUtils interface {
+	FileExists(filename string) (bool, error)
+	DirExists(path string) (bool, error)
+	FileRead(path string) ([]byte, error)
+	FileWrite(path string, content []byte, perm os.FileMode) error
+	WriteFile(filename string, data []byte, perm os.FileMode) error
+	MkdirAll(path string, perm os.FileMode) error
+	Glob(pattern string) (matches []string, err error)
+}
+
+type sbomLicenseCheckUtilsBundle struct {
+	*piperutils.Files
+}
+
+func newSbomLicenseCheckUtils() sbomLicenseCheckUtils {
+	utils := sbomLicenseCheckUtilsBundle{
+		Files: &piperutils.Files{},
+	}
+	return &utils
+}
+
+func sbomLicenseCheck(config sbomLicenseCheckOptions, telemetryData *telemetry.CustomData) {
+	utils := newSbomLicenseCheckUtils()
+
+	err := runSbomLicenseCheck(&config, telemetryData, utils)
+	if err != nil {
+		log.Entry().WithError(err).Fatal("step execution failed")
+	}
+}
+
+func runSbomLicenseCheck(config *sbomLicenseCheckOptions, telemetryData *telemetry.CustomData, utils sbomLicenseCheckUtils) error {
+	policy, err := loadLicensePolicy(config, utils)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
+	files := []string{}
+	for _, pattern := range config.SbomFiles {
+		matches, err := utils.Glob(pattern)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return errors.Wrapf(err, "invalid pattern %v", pattern)
+		}
+		files = append(files, matches...)
+	}
+	files = piperutils.UniqueStrings(files)
+	sort.Strings(files)
+	if len(files) == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return fmt.Errorf("no SBOM found matching %v", config.SbomFiles)
+	}
+
+	findings := []sbom.LicenseFinding{}
+	checked := map[string]bool{}
+	for _, file := range files {
+		bom, err := sbom.ReadFile(file, utils)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return err
+		}
+		for _, finding := range sbom.CheckLicenses(bom, policy) {
+			id := fmt.Sprintf("%v|%v|%v|%v", finding.Component.PackageURL, finding.Component.Group, finding.Component.Name, finding.Component.Version)
+			if checked[id] {
+				continue
+			}
+			checked[id] = true
+			findings = append(findings, finding)
+		}
+	}
+	counts := sbom.CountLicenseDecisions(findings)
+	log.Entry().Infof("%v component(s) checked: %v denied, %v to be reviewed, %v allowed", len(findings), counts[sbom.LicenseDeny], counts[sbom.LicenseReview], counts[sbom.LicenseAllow])
+
+	violations := []sbom.LicenseFinding{}
+	for _, finding := range findings {
+		if finding.Decision == sbom.LicenseDeny || (config.FailOnReview && finding.Decision == sbom.LicenseReview) {
+			log.Entry().Errorf("license '%v' of component %v %v: %v", finding.License, finding.Component.Name, finding.Component.Version, finding.Decision)
+			violations = append(violations, finding)
+		}
+	}
+
+	scanReport := sbom.CreateLicenseReport(findings, "sbomLicenseCheck", files, config.FailOnReview)
+	reports, err := writeSbomLicenseCheckReports(scanReport, violations, utils)
+	if err != nil {
+		log.Entry().WithError(err).Warn("failed to write reports")
+	}
+	if err := piperutils.PersistReportsAndLinks("sbomLicenseCheck", "", utils, reports, nil); err != nil {
+		log.Entry().WithError(err).Warn("failed to persist reports")
+	}
+
+	if len(violations) > 0 {
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("%v license policy violation(s) found", len(violations))
+	}
+	return nil
+}
+
+// loadLicensePolicy reads the policy from the policy file or the policy parameter
+func loadLicensePolicy(config *sbomLicenseCheckOptions, utils sbomLicenseCheckUtils) (sbom.LicensePolicy, error) {
+	policy := sbom.LicensePolicy{}
+	exists := false
+	if len(config.PolicyFile) > 0 {
+		var err error
+		if exists, err = utils.FileExists(config.PolicyFile); err != nil {
+			return policy, errors.Wrapf(err, "failed to check for policy file %v", config.PolicyFile)
+		}
+	}
+	if exists {
+		content, err := utils.FileRead(config.PolicyFile)
+		if err != nil {
+			return policy, errors.Wrapf(err, "failed to read policy file %v", config.PolicyFile)
+		}
+		if err := yaml.Unmarshal(content, &policy); err != nil {
+			return policy, errors.Wrapf(err, "failed to parse policy file %v", config.PolicyFile)
+		}
+	} else if len(config.Policy) > 0 {
+		content, err := json.Marshal(config.Policy)
+		if err != nil {
+			return policy, errors.Wrap(err, "failed to marshal policy")
+		}
+		if err := json.Unmarshal(content, &policy); err != nil {
+			return policy, errors.Wrap(err, "failed to parse policy")
+		}
+	} else {
+		return policy, fmt.Errorf("no license policy defined, policy file %v does not exist and parameter policy is not set", config.PolicyFile)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return policy, err
+	}
+	return policy, nil
+}
+
+func writeSbomLicenseCheckReports(scanReport reporting.ScanReport, violations []sbom.LicenseFinding, utils sbomLicenseCheckUtils) ([]piperutils.Path, error) {
+	reportPaths := []piperutils.Path{}
+	if err := utils.MkdirAll(sbomLicenseCheckReportDir, 0777); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create report directory")
+	}
+
+	// ignore templating errors since template is in our hands and issues will be detected with the automated tests
+	htmlReport, _ := scanReport.ToHTML()
+	htmlReportPath := filepath.Join(sbomLicenseCheckReportDir, "report.html")
+	if err := utils.FileWrite(htmlReportPath, htmlReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write html report")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "License Compliance Report", Target: htmlReportPath})
+
+	if len(violations) > 0 {
+		markdown := [][]byte{}
+		for _, violation := range violations {
+			violationReport, err := violation.ToMarkdown()
+			if err != nil {
+				return reportPaths, errors.Wrap(err, "failed to create policy violation report")
+			}
+			markdown = append(markdown, violationReport)
+		}
+		markdownReportPath := filepath.Join(sbomLicenseCheckReportDir, "policy-violations.md")
+		if err := utils.FileWrite(markdownReportPath, bytes.Join(markdown, []byte("\n")), 0666); err != nil {
+			return reportPaths, errors.Wrap(err, "failed to write policy violation report")
+		}
+		reportPaths = append(reportPaths, piperutils.Path{Name: "License Policy Violations", Target: markdownReportPath})
+	}
+
+	// JSON report is used by step pipelineCreateScanSummary
+	jsonReport, _ := scanReport.ToJSON()
+	if exists, _ := utils.DirExists(reporting.StepReportDirectory); !exists {
+		if err := utils.MkdirAll(reporting.StepReportDirectory, 0777); err != nil {
+			return reportPaths, errors.Wrap(err, "failed to create reporting directory")
+		}
+	}
+	if err := utils.FileWrite(filepath.Join(reporting.StepReportDirectory, "sbomLicenseCheck.json"), jsonReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write json report")
+	}
+	return reportPaths, nil
+}
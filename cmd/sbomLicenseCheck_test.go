@@ -0,0 +1,181 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type sbomLicenseCheckMockUtils struct {
+	*mock.FilesMock
+}
+
+func newSbomLicenseCheckTestsUtils() sbomLicenseCheckMockUtils {
+	utils := sbomLicenseCheckMockUtils{
+		FilesMock: &mock.FilesMock{},
+	}
+	return utils
+}
+
+const (
+	sbomLicenseCheckPolicy = `allow:
+  - MIT
+  - Apache-2.0
+review:
+  - LGPL-*
+deny:
+  - GPL-*
+`
+	sbomLicenseCheckSBOM = `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.4" version="1">
+  <metadata>
+    <component type="application" bom-ref="ui">
+      <name>ui</name>
+      <version>1.0.0</version>
+    </component>
+  </metadata>
+  <components>
+    <component type="library" bom-ref="lodash">
+      <name>lodash</name>
+      <version>4.17.21</version>
+      <licenses>
+        <license>
+          <id>MIT</id>
+        </license>
+      </licenses>
+      <purl>pkg:npm/lodash@4.17.21</purl>
+    </component>
+    <component type="library" bom-ref="readline">
+      <name>readline</name>
+      <version>8.1</version>
+      <licenses>
+        <expression>GPL-3.0-only OR LGPL-3.0-only</expression>
+      </licenses>
+      <purl>pkg:npm/readline@8.1</purl>
+    </component>
+  </components>
+  <dependencies>
+    <dependency ref="ui">
+      <dependency ref="lodash"/>
+      <dependency ref="readline"/>
+    </dependency>
+  </dependencies>
+</bom>`
+	sbomLicenseCheckDeniedSBOM = `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.4" version="1">
+  <components>
+    <component type="library">
+      <name>lodash</name>
+      <version>4.17.21</version>
+      <licenses>
+        <license>
+          <id>MIT</id>
+        </license>
+      </licenses>
+      <purl>pkg:npm/lodash@4.17.21</purl>
+    </component>
+    <component type="library">
+      <name>gpl-lib</name>
+      <version>1.0.0</version>
+      <licenses>
+        <license>
+          <id>GPL-2.0-only</id>
+        </license>
+      </licenses>
+      <purl>pkg:npm/gpl-lib@1.0.0</purl>
+    </component>
+  </components>
+</bom>`
+)
+
+func TestRunSbomLicenseCheck(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success with licenses to be reviewed", func(t *testing.T) {
+		t.Parallel()
+		config := sbomLicenseCheckOptions{SbomFiles: []string{"**/bom-*.xml"}, PolicyFile: "license-policy.yaml"}
+		utils := newSbomLicenseCheckTestsUtils()
+		utils.AddFile("license-policy.yaml", []byte(sbomLicenseCheckPolicy))
+		utils.AddFile("ui/bom-npm.xml", []byte(sbomLicenseCheckSBOM))
+
+		err := runSbomLicenseCheck(&config, nil, utils)
+
+		assert.NoError(t, err)
+		assert.True(t, utils.HasWrittenFile("sbomLicenseCheck/report.html"))
+		assert.False(t, utils.HasWrittenFile("sbomLicenseCheck/policy-violations.md"))
+		assert.True(t, utils.HasWrittenFile(".pipeline/stepReports/sbomLicenseCheck.json"))
+		assert.True(t, utils.HasWrittenFile("sbomLicenseCheck_reports.json"))
+	})
+
+	t.Run("fail on review", func(t *testing.T) {
+		t.Parallel()
+		config := sbomLicenseCheckOptions{SbomFiles: []string{"**/bom-*.xml"}, PolicyFile: "license-policy.yaml", FailOnReview: true}
+		utils := newSbomLicenseCheckTestsUtils()
+		utils.AddFile("license-policy.yaml", []byte(sbomLicenseCheckPolicy))
+		utils.AddFile("ui/bom-npm.xml", []byte(sbomLicenseCheckSBOM))
+
+		err := runSbomLicenseCheck(&config, nil, utils)
+
+		assert.EqualError(t, err, "1 license policy violation(s) found")
+		content, err := utils.FileRead("sbomLicenseCheck/policy-violations.md")
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "# Policy Violation - pkg:npm/readline@8.1")
+		assert.Contains(t, string(content), "**Dependency:** direct")
+	})
+
+	t.Run("denied license with policy parameter", func(t *testing.T) {
+		t.Parallel()
+		config := sbomLicenseCheckOptions{
+			SbomFiles:  []string{"**/bom-*.xml"},
+			PolicyFile: "license-policy.yaml",
+			Policy:     map[string]interface{}{"allow": []interface{}{"MIT"}, "default": "deny"},
+		}
+		utils := newSbomLicenseCheckTestsUtils()
+		utils.AddFile("a/bom-npm.xml", []byte(sbomLicenseCheckDeniedSBOM))
+		// lodash and gpl-lib are only checked once
+		utils.AddFile("b/bom-npm.xml", []byte(sbomLicenseCheckDeniedSBOM))
+
+		err := runSbomLicenseCheck(&config, nil, utils)
+
+		assert.EqualError(t, err, "1 license policy violation(s) found")
+		content, err := utils.FileRead("sbomLicenseCheck/policy-violations.md")
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "# Policy Violation - pkg:npm/gpl-lib@1.0.0")
+		assert.NotContains(t, string(content), "**Dependency:**")
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		t.Parallel()
+		config := sbomLicenseCheckOptions{SbomFiles: []string{"**/bom-*.xml"}, PolicyFile: "license-policy.yaml"}
+		utils := newSbomLicenseCheckTestsUtils()
+
+		err := runSbomLicenseCheck(&config, nil, utils)
+
+		assert.EqualError(t, err, "no license policy defined, policy file license-policy.yaml does not exist and parameter policy is not set")
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		t.Parallel()
+		config := sbomLicenseCheckOptions{SbomFiles: []string{"**/bom-*.xml"}, Policy: map[string]interface{}{"default": "ignore"}}
+		utils := newSbomLicenseCheckTestsUtils()
+
+		err := runSbomLicenseCheck(&config, nil, utils)
+
+		assert.EqualError(t, err, "invalid license decision 'ignore', supported decisions are allow, review and deny")
+	})
+
+	t.Run("no SBOM", func(t *testing.T) {
+		t.Parallel()
+		config := sbomLicenseCheckOptions{SbomFiles: []string{"**/bom-*.xml"}, PolicyFile: "license-policy.yaml"}
+		utils := newSbomLicenseCheckTestsUtils()
+		utils.AddFile("license-policy.yaml", []byte(sbomLicenseCheckPolicy))
+
+		err := runSbomLicenseCheck(&config, nil, utils)
+
+		assert.EqualError(t, err, "no SBOM found matching [**/bom-*.xml]")
+	})
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/pkg/errors"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/sbom"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+)
+
+type sbomMergeUtils interface {
+	FileExists(filename string) (bool, error)
+	FileRead(path string) ([]byte, error)
+	FileWrite(path string, content []byte, perm os.FileMode) error
+	WriteFile(filename string, data []byte, perm os.FileMode) error
+	MkdirAll(path string, perm os.FileMode) error
+	Glob(pattern string) (matches []string, err error)
+	Getwd() (string, error)
+}
+
+type sbomMergeUtilsBundle struct {
+	*piperutils.Files
+}
+
+func newSbomMergeUtils() sbomMergeUtils {
+	utils := sbomMergeUtilsBundle{
+		Files: &piperutils.Files{},
+	}
+	return &utils
+}
+
+func sbomMerge(config sbomMergeOptions, telemetryData *telemetry.CustomData) {
+	utils := newSbomMergeUtils()
+
+	err := runSbomMerge(&config, telemetryData, utils, time.Now())
+	if err != nil {
+		log.Entry().WithError(err).Fatal("step execution failed")
+	}
+}
+
+func runSbomMerge(config *sbomMergeOptions, telemetryData *telemetry.CustomData, utils sbomMergeUtils, timestamp time.Time) error {
+	files, err := findSbomFiles(config, utils)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return fmt.Errorf("no SBOM found matching %v", config.SbomFiles)
+	}
+
+	boms := []*cdx.BOM{}
+	for _, file := range files {
+		bom, err := sbom.ReadFile(file, utils)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return err
+		}
+		log.Entry().Infof("SBOM %v with %v component(s) read", file, len(sbom.Components(bom)))
+		boms = append(boms, bom)
+	}
+
+	product, err := sbomProduct(config, utils)
+	if err != nil {
+		return err
+	}
+	merged := sbom.Merge(product, boms, timestamp)
+	content, err := sbom.Encode(merged, config.OutputPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode merged SBOM")
+	}
+	if err := writeSbomFile(config.OutputPath, content, utils); err != nil {
+		return err
+	}
+	log.Entry().Infof("merged SBOM with %v component(s) written to %v", len(*merged.Components), config.OutputPath)
+	reports := []piperutils.Path{{Name: "Product SBOM", Target: config.OutputPath}}
+
+	if len(config.PreviousSbomFile) > 0 {
+		diffReports, err := compareSboms(config, merged, utils)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, diffReports...)
+	}
+
+	if err := piperutils.PersistReportsAndLinks("sbomMerge", "", utils, reports, nil); err != nil {
+		log.Entry().WithError(err).Warn("failed to persist reports")
+	}
+	return nil
+}
+
+// findSbomFiles returns the SBOMs matching the configured patterns except the merged SBOM and the previous SBOM
+func findSbomFiles(config *sbomMergeOptions, utils sbomMergeUtils) ([]string, error) {
+	excluded := map[string]bool{filepath.Clean(config.OutputPath): true}
+	if len(config.PreviousSbomFile) > 0 {
+		excluded[filepath.Clean(config.PreviousSbomFile)] = true
+	}
+	files := []string{}
+	for _, pattern := range config.SbomFiles {
+		matches, err := utils.Glob(pattern)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return nil, errors.Wrapf(err, "invalid pattern %v", pattern)
+		}
+		for _, match := range matches {
+			if !excluded[filepath.Clean(match)] {
+				excluded[filepath.Clean(match)] = true
+				files = append(files, match)
+			}
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func sbomProduct(config *sbomMergeOptions, utils sbomMergeUtils) (cdx.Component, error) {
+	product := cdx.Component{
+		Type:    cdx.ComponentTypeApplication,
+		Group:   config.ProductGroup,
+		Name:    config.ProductName,
+		Version: config.ProductVersion,
+	}
+	if len(product.Name) == 0 {
+		workspace, err := utils.Getwd()
+		if err != nil {
+			return product, errors.Wrap(err, "failed to get current working directory")
+		}
+		product.Name = filepath.Base(workspace)
+		if product.Name == string(filepath.Separator) || product.Name == "." {
+			product.Name = "product"
+		}
+		log.Entry().Infof("no product name configured, using %v", product.Name)
+	}
+	return product, nil
+}
+
+func compareSboms(config *sbomMergeOptions, merged *cdx.BOM, utils sbomMergeUtils) ([]piperutils.Path, error) {
+	exists, err := utils.FileExists(config.PreviousSbomFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to check for previous SBOM %v", config.PreviousSbomFile)
+	}
+	if !exists {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return nil, fmt.Errorf("previous SBOM %v does not exist", config.PreviousSbomFile)
+	}
+	previous, err := sbom.ReadFile(config.PreviousSbomFile, utils)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return nil, err
+	}
+
+	diff := sbom.Compare(previous, merged)
+	log.Entry().Infof("compared to %v: %v component(s) added, %v removed, %v upgraded, %v downgraded, %v with changed licenses",
+		config.PreviousSbomFile, len(diff.Added), len(diff.Removed), len(diff.Upgraded), len(diff.Downgraded), len(diff.LicenseChanges))
+
+	reportDir := filepath.Dir(config.OutputPath)
+	jsonReport, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal SBOM diff")
+	}
+	jsonReportPath := filepath.Join(reportDir, "sbom-diff.json")
+	if err := writeSbomFile(jsonReportPath, jsonReport, utils); err != nil {
+		return nil, err
+	}
+
+	scanReport := sbom.CreateDiffReport(diff, "sbomMerge", config.PreviousSbomFile)
+	// ignore templating errors since template is in our hands and issues will be detected with the automated tests
+	htmlReport, _ := scanReport.ToHTML()
+	htmlReportPath := filepath.Join(reportDir, "sbom-diff.html")
+	if err := writeSbomFile(htmlReportPath, htmlReport, utils); err != nil {
+		return nil, err
+	}
+	return []piperutils.Path{
+		{Name: "SBOM Comparison Report", Target: htmlReportPath},
+		{Name: "SBOM Comparison (JSON)", Target: jsonReportPath},
+	}, nil
+}
+
+func writeSbomFile(path string, content []byte, utils sbomMergeUtils) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := utils.MkdirAll(dir, 0777); err != nil {
+			return errors.Wrapf(err, "failed to create directory %v", dir)
+		}
+	}
+	if err := utils.FileWrite(path, content, 0666); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.Wrapf(err, "failed to write %v", path)
+	}
+	return nil
+}
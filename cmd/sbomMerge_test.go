@@ -0,0 +1,167 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/sbom"
+	"github.com/stretchr/testify/assert"
+)
+
+type sbomMergeMockUtils struct {
+	*mock.FilesMock
+}
+
+func newSbomMergeTestsUtils() sbomMergeMockUtils {
+	utils := sbomMergeMockUtils{
+		FilesMock: &mock.FilesMock{},
+	}
+	return utils
+}
+
+const (
+	sbomMergeMavenSBOM = `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.4" version="1">
+  <metadata>
+    <component type="library" bom-ref="pkg:maven/com.sap/backend@1.0.0">
+      <group>com.sap</group>
+      <name>backend</name>
+      <version>1.0.0</version>
+      <purl>pkg:maven/com.sap/backend@1.0.0</purl>
+    </component>
+  </metadata>
+  <components>
+    <component type="library" bom-ref="pkg:maven/org.apache.commons/commons-text@1.10.0">
+      <group>org.apache.commons</group>
+      <name>commons-text</name>
+      <version>1.10.0</version>
+      <purl>pkg:maven/org.apache.commons/commons-text@1.10.0</purl>
+    </component>
+  </components>
+</bom>`
+	sbomMergeNpmSBOM = `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.4" version="1">
+  <metadata>
+    <component type="application" bom-ref="ui">
+      <name>ui</name>
+      <version>1.0.0</version>
+      <purl>pkg:npm/ui@1.0.0</purl>
+    </component>
+  </metadata>
+  <components>
+    <component type="library" bom-ref="lodash">
+      <name>lodash</name>
+      <version>4.17.21</version>
+      <purl>pkg:npm/lodash@4.17.21</purl>
+    </component>
+  </components>
+</bom>`
+	sbomMergePreviousSBOM = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "version": 1,
+  "components": [
+    {"type": "library", "group": "org.apache.commons", "name": "commons-text", "version": "1.9", "purl": "pkg:maven/org.apache.commons/commons-text@1.9"},
+    {"type": "library", "name": "left-pad", "version": "1.3.0", "purl": "pkg:npm/left-pad@1.3.0"}
+  ]
+}`
+)
+
+func TestRunSbomMerge(t *testing.T) {
+	t.Parallel()
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("merge", func(t *testing.T) {
+		t.Parallel()
+		config := sbomMergeOptions{
+			SbomFiles:      []string{"**/bom-*.xml"},
+			OutputPath:     "sbom/product-bom.xml",
+			ProductName:    "product",
+			ProductVersion: "1.0.0",
+		}
+		utils := newSbomMergeTestsUtils()
+		utils.AddFile("backend/target/bom-maven.xml", []byte(sbomMergeMavenSBOM))
+		utils.AddFile("ui/bom-npm.xml", []byte(sbomMergeNpmSBOM))
+		// result of a previous run
+		utils.AddFile("sbom/product-bom.xml", []byte("<bom"))
+
+		err := runSbomMerge(&config, nil, utils, timestamp)
+		assert.NoError(t, err)
+
+		merged, err := sbom.ReadFile("sbom/product-bom.xml", utils)
+		assert.NoError(t, err)
+		assert.Equal(t, "product", merged.Metadata.Component.Name)
+		assert.Equal(t, "1.0.0", merged.Metadata.Component.Version)
+		assert.Len(t, *merged.Components, 4)
+		assert.False(t, utils.HasWrittenFile("sbom/sbom-diff.json"))
+		assert.True(t, utils.HasWrittenFile("sbomMerge_reports.json"))
+	})
+
+	t.Run("compare with previous SBOM", func(t *testing.T) {
+		t.Parallel()
+		config := sbomMergeOptions{
+			SbomFiles:        []string{"**/bom-*.xml", "**/*.json"},
+			OutputPath:       "product-bom.json",
+			PreviousSbomFile: "previous/bom-product.json",
+		}
+		utils := newSbomMergeTestsUtils()
+		utils.AddFile("backend/target/bom-maven.xml", []byte(sbomMergeMavenSBOM))
+		utils.AddFile("ui/bom-npm.xml", []byte(sbomMergeNpmSBOM))
+		utils.AddFile("previous/bom-product.json", []byte(sbomMergePreviousSBOM))
+
+		err := runSbomMerge(&config, nil, utils, timestamp)
+		assert.NoError(t, err)
+
+		merged, err := sbom.ReadFile("product-bom.json", utils)
+		assert.NoError(t, err)
+		assert.Equal(t, "product", merged.Metadata.Component.Name)
+
+		content, err := utils.FileRead("sbom-diff.json")
+		assert.NoError(t, err)
+		diff := sbom.Diff{}
+		assert.NoError(t, json.Unmarshal(content, &diff))
+		assert.Len(t, diff.Added, 3)
+		if assert.Len(t, diff.Removed, 1) {
+			assert.Equal(t, "left-pad", diff.Removed[0].Name)
+		}
+		if assert.Len(t, diff.Upgraded, 1) {
+			assert.Equal(t, "1.9", diff.Upgraded[0].PreviousVersion)
+			assert.Equal(t, "1.10.0", diff.Upgraded[0].Version)
+		}
+		assert.True(t, utils.HasWrittenFile("sbom-diff.html"))
+	})
+
+	t.Run("no SBOM found", func(t *testing.T) {
+		t.Parallel()
+		config := sbomMergeOptions{SbomFiles: []string{"**/bom-*.xml"}, OutputPath: "sbom/product-bom.xml"}
+		utils := newSbomMergeTestsUtils()
+
+		err := runSbomMerge(&config, nil, utils, timestamp)
+		assert.EqualError(t, err, "no SBOM found matching [**/bom-*.xml]")
+	})
+
+	t.Run("invalid SBOM", func(t *testing.T) {
+		t.Parallel()
+		config := sbomMergeOptions{SbomFiles: []string{"**/bom-*.xml"}, OutputPath: "sbom/product-bom.xml"}
+		utils := newSbomMergeTestsUtils()
+		utils.AddFile("bom-npm.xml", []byte("<bom"))
+
+		err := runSbomMerge(&config, nil, utils, timestamp)
+		assert.ErrorContains(t, err, "failed to parse SBOM bom-npm.xml")
+	})
+
+	t.Run("missing previous SBOM", func(t *testing.T) {
+		t.Parallel()
+		config := sbomMergeOptions{SbomFiles: []string{"**/bom-*.xml"}, OutputPath: "sbom/product-bom.xml", PreviousSbomFile: "previous.xml"}
+		utils := newSbomMergeTestsUtils()
+		utils.AddFile("bom-npm.xml", []byte(sbomMergeNpmSBOM))
+
+		err := runSbomMerge(&config, nil, utils, timestamp)
+		assert.EqualError(t, err, "previous SBOM previous.xml does not exist")
+	})
+}
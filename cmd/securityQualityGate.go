@@ -0,0 +1,448 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+type securityQualityGateUtils interface {
+	FileExists(filename string) (bool, error)
+	DirExists(path string) (bool, error)
+	FileRead(path string) ([]byte, error)
+	FileWrite(path string, content []byte, perm os.FileMode) error
+	WriteFile(filename string, data []byte, perm os.FileMode) error
+	MkdirAll(path string, perm os.FileMode) error
+	Glob(pattern string) (matches []string, err error)
+}
+
+type securityQualityGateUtilsBundle struct {
+	*piperutils.Files
+}
+
+func newSecurityQualityGateUtils() securityQualityGateUtils {
+	utils := securityQualityGateUtilsBundle{
+		Files: &piperutils.Files{},
+	}
+	return &utils
+}
+
+// securityPolicy defines the maximum number of findings which are accepted
+type securityPolicy struct {
+	// Severities limits the findings per severity
+	Severities map[string]int `json:"severities,omitempty"`
+	// NewFindings limits the findings per severity which are not contained in the baseline
+	NewFindings map[string]int `json:"newFindings,omitempty"`
+	// Tools limits the findings per severity of dedicated tools
+	Tools map[string]map[string]int `json:"tools,omitempty"`
+	// CWEs limits the findings per CWE id
+	CWEs                    map[string]int `json:"cwes,omitempty"`
+	FailOnUnsuccessfulScans bool           `json:"failOnUnsuccessfulScans,omitempty"`
+}
+
+type securityFinding struct {
+	tool     string
+	severity string
+	cwes     []string
+	isNew    bool
+	// crossToolDuplicate is set if the finding has already been reported by another tool, it only counts for the limits of its tool
+	crossToolDuplicate bool
+}
+
+type securityPolicyViolation struct {
+	description string
+	count       int
+	limit       int
+}
+
+func (v securityPolicyViolation) String() string {
+	return fmt.Sprintf("%v %v (limit %v)", v.count, v.description, v.limit)
+}
+
+func securityQualityGate(config securityQualityGateOptions, telemetryData *telemetry.CustomData) {
+	utils := newSecurityQualityGateUtils()
+
+	err := runSecurityQualityGate(&config, telemetryData, utils)
+	if err != nil {
+		log.Entry().WithError(err).Fatal("step execution failed")
+	}
+}
+
+func runSecurityQualityGate(config *securityQualityGateOptions, telemetryData *telemetry.CustomData, utils securityQualityGateUtils) error {
+	policy, err := loadSecurityPolicy(config, utils)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
+	findings, sarifTools, err := collectSecurityFindings(config, utils)
+	if err != nil {
+		return err
+	}
+	scanReports, err := readScanReports(utils)
+	if err != nil {
+		return err
+	}
+	findings = append(findings, scanReportFindings(scanReports, sarifTools)...)
+	log.Entry().Infof("evaluating %v finding(s) and %v scan report(s)", len(findings), len(scanReports))
+
+	violations := policy.evaluate(findings, scanReports)
+
+	scanReport := securityQualityGateReport(findings, scanReports, violations)
+	reports, err := writeSecurityQualityGateReport(scanReport, utils)
+	if err != nil {
+		log.Entry().WithError(err).Warn("failed to write report")
+	}
+	if err := piperutils.PersistReportsAndLinks("securityQualityGate", "", utils, reports, nil); err != nil {
+		log.Entry().WithError(err).Warn("failed to persist reports")
+	}
+
+	if len(violations) > 0 {
+		explanation := []string{}
+		for _, violation := range violations {
+			log.Entry().Error(violation.String())
+			explanation = append(explanation, violation.String())
+		}
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("security quality gate failed with %v violation(s): %v", len(violations), strings.Join(explanation, ", "))
+	}
+	log.Entry().Info("security quality gate passed")
+	return nil
+}
+
+// loadSecurityPolicy reads the policy from the policy file or the policy parameter
+func loadSecurityPolicy(config *securityQualityGateOptions, utils securityQualityGateUtils) (securityPolicy, error) {
+	policy := securityPolicy{}
+	if len(config.PolicyFile) > 0 {
+		content, err := utils.FileRead(config.PolicyFile)
+		if err != nil {
+			return policy, errors.Wrapf(err, "failed to read policy file %v", config.PolicyFile)
+		}
+		if err := yaml.Unmarshal(content, &policy); err != nil {
+			return policy, errors.Wrapf(err, "failed to parse policy file %v", config.PolicyFile)
+		}
+	} else if len(config.Policy) > 0 {
+		content, err := json.Marshal(config.Policy)
+		if err != nil {
+			return policy, errors.Wrap(err, "failed to marshal policy")
+		}
+		if err := json.Unmarshal(content, &policy); err != nil {
+			return policy, errors.Wrap(err, "failed to parse policy")
+		}
+	} else {
+		log.Entry().Warn("no policy defined, all findings are accepted")
+	}
+
+	limits := []map[string]int{policy.Severities, policy.NewFindings}
+	for _, toolLimits := range policy.Tools {
+		limits = append(limits, toolLimits)
+	}
+	for _, severityLimits := range limits {
+		for severity := range severityLimits {
+			if !slices.Contains(format.Severities, severity) {
+				return policy, fmt.Errorf("invalid severity '%v' in policy, supported severities are %v", severity, strings.Join(format.Severities, ", "))
+			}
+		}
+	}
+
+	// CWEs may be given as number or in the format CWE-<id>
+	cwes := map[string]int{}
+	for cwe, limit := range policy.CWEs {
+		id := strings.TrimLeft(strings.TrimPrefix(strings.ToUpper(cwe), "CWE-"), "0")
+		if _, err := strconv.Atoi(id); err != nil {
+			return policy, fmt.Errorf("invalid CWE '%v' in policy, expected a number or the format CWE-<id>", cwe)
+		}
+		cwes["CWE-"+id] = limit
+	}
+	policy.CWEs = cwes
+	return policy, nil
+}
+
+// collectSecurityFindings reads the relevant findings of all SARIF files and returns them together with the names of the tools of the files.
+// Findings contained in several files, e.g. in the SARIF file of the scan and in the merged SARIF file, are counted once.
+// Findings of different tools which match are marked as cross-tool duplicates, they are removed after the limits of the tools have been evaluated.
+func collectSecurityFindings(config *securityQualityGateOptions, utils securityQualityGateUtils) ([]securityFinding, map[string]bool, error) {
+	files, err := findSarifFiles(config.SarifFiles, utils, config.BaselineFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	documents := []format.SarifDocument{}
+	for _, file := range files {
+		document, err := readSarifFile(file, utils)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return nil, nil, err
+		}
+		log.Entry().Debugf("reading %v run(s) of %v", document.RunCount(), file)
+		documents = append(documents, document)
+	}
+	merged := format.MergeSarif(documents...)
+	// the baseline state written by sarifMerge is kept for the duplicates which are removed
+	format.RemoveSarifDuplicates(merged)
+
+	if len(config.BaselineFile) > 0 {
+		exists, err := utils.FileExists(config.BaselineFile)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to check for baseline file %v", config.BaselineFile)
+		}
+		if exists {
+			baseline, err := readSarifFile(config.BaselineFile, utils)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return nil, nil, err
+			}
+			format.ApplySarifBaseline(merged, baseline)
+		} else {
+			log.Entry().Infof("baseline file %v does not exist, all findings are considered new", config.BaselineFile)
+		}
+	}
+
+	crossToolDuplicates := format.SarifCrossToolDuplicates(merged)
+	sarif, err := merged.SARIF()
+	if err != nil {
+		return nil, nil, err
+	}
+	findings := []securityFinding{}
+	tools := map[string]bool{}
+	for i, run := range sarif.Runs {
+		tools[strings.ToLower(run.Tool.Driver.Name)] = true
+		for j, result := range run.Results {
+			if format.IsSarifResultNotRelevant(result) {
+				continue
+			}
+			baselineState := result.BaselineState
+			if baselineState == "absent" {
+				continue
+			}
+			findings = append(findings, securityFinding{
+				tool:               run.Tool.Driver.Name,
+				severity:           format.SarifResultSeverity(run, result),
+				cwes:               format.SarifResultCWEs(run, result),
+				isNew:              baselineState != format.BaselineStateUnchanged,
+				crossToolDuplicate: crossToolDuplicates[i][j],
+			})
+		}
+	}
+	return findings, tools, nil
+}
+
+// scanReportFindings returns the findings counted in the scan reports of steps whose findings are not contained in the SARIF files.
+// The tool of the findings is the step name. They cannot be compared with the baseline and are therefore considered new.
+func scanReportFindings(scanReports []reporting.ScanReport, sarifTools map[string]bool) []securityFinding {
+	findings := []securityFinding{}
+	for _, scanReport := range scanReports {
+		// the findings of the step are already contained in its SARIF file
+		if len(scanReport.SarifTool) > 0 && sarifTools[strings.ToLower(scanReport.SarifTool)] {
+			continue
+		}
+		for _, severity := range format.Severities {
+			for i := 0; i < scanReport.Findings[severity]; i++ {
+				findings = append(findings, securityFinding{tool: scanReport.StepName, severity: severity, isNew: true})
+			}
+		}
+	}
+	return findings
+}
+
+// readScanReports reads the scan reports of all scanning steps
+func readScanReports(utils securityQualityGateUtils) ([]reporting.ScanReport, error) {
+	files, _ := utils.Glob(reporting.StepReportDirectory + "/*.json")
+	scanReports := []reporting.ScanReport{}
+	for _, file := range files {
+		content, err := utils.FileRead(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read report %v", file)
+		}
+		scanReport := reporting.ScanReport{}
+		if err := json.Unmarshal(content, &scanReport); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse report %v", file)
+		}
+		// ignore the report of a previous evaluation
+		if scanReport.StepName != "securityQualityGate" {
+			scanReports = append(scanReports, scanReport)
+		}
+	}
+	return scanReports, nil
+}
+
+// evaluate returns all violations of the policy.
+// The limits of the tools apply to all findings of a tool, the other limits to the findings without cross-tool duplicates.
+func (p securityPolicy) evaluate(findings []securityFinding, scanReports []reporting.ScanReport) []securityPolicyViolation {
+	violations := []securityPolicyViolation{}
+
+	uniqueFindings := withoutCrossToolDuplicates(findings)
+	violations = append(violations, evaluateSeverityLimits(p.Severities, uniqueFindings, "")...)
+
+	newFindings := []securityFinding{}
+	for _, finding := range uniqueFindings {
+		if finding.isNew {
+			newFindings = append(newFindings, finding)
+		}
+	}
+	violations = append(violations, evaluateSeverityLimits(p.NewFindings, newFindings, "new ")...)
+
+	tools := []string{}
+	for tool := range p.Tools {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	for _, tool := range tools {
+		toolFindings := []securityFinding{}
+		for _, finding := range findings {
+			if strings.EqualFold(finding.tool, tool) {
+				toolFindings = append(toolFindings, finding)
+			}
+		}
+		violations = append(violations, evaluateSeverityLimits(p.Tools[tool], toolFindings, tool+" ")...)
+	}
+
+	cwes := []string{}
+	for cwe := range p.CWEs {
+		cwes = append(cwes, cwe)
+	}
+	sort.Strings(cwes)
+	for _, cwe := range cwes {
+		count := 0
+		for _, finding := range uniqueFindings {
+			if slices.Contains(finding.cwes, cwe) {
+				count++
+			}
+		}
+		if count > p.CWEs[cwe] {
+			violations = append(violations, securityPolicyViolation{description: cwe + " findings", count: count, limit: p.CWEs[cwe]})
+		}
+	}
+
+	if p.FailOnUnsuccessfulScans {
+		unsuccessful := []string{}
+		for _, scanReport := range scanReports {
+			if !scanReport.SuccessfulScan {
+				unsuccessful = append(unsuccessful, scanReport.StepName)
+			}
+		}
+		if len(unsuccessful) > 0 {
+			violations = append(violations, securityPolicyViolation{description: fmt.Sprintf("unsuccessful scans (%v)", strings.Join(unsuccessful, ", ")), count: len(unsuccessful), limit: 0})
+		}
+	}
+	return violations
+}
+
+func withoutCrossToolDuplicates(findings []securityFinding) []securityFinding {
+	unique := []securityFinding{}
+	for _, finding := range findings {
+		if !finding.crossToolDuplicate {
+			unique = append(unique, finding)
+		}
+	}
+	return unique
+}
+
+func evaluateSeverityLimits(limits map[string]int, findings []securityFinding, prefix string) []securityPolicyViolation {
+	violations := []securityPolicyViolation{}
+	counts := countFindingsBySeverity(findings)
+	for _, severity := range format.Severities {
+		if limit, ok := limits[severity]; ok && counts[severity] > limit {
+			violations = append(violations, securityPolicyViolation{description: prefix + severity + " findings", count: counts[severity], limit: limit})
+		}
+	}
+	return violations
+}
+
+func countFindingsBySeverity(findings []securityFinding) map[string]int {
+	counts := map[string]int{}
+	for _, finding := range findings {
+		counts[finding.severity]++
+	}
+	return counts
+}
+
+func securityQualityGateReport(findings []securityFinding, scanReports []reporting.ScanReport, violations []securityPolicyViolation) reporting.ScanReport {
+	scanReport := reporting.ScanReport{
+		StepName:       "securityQualityGate",
+		ReportTitle:    "Security Quality Gate",
+		ReportTime:     time.Now(),
+		SuccessfulScan: len(violations) == 0,
+	}
+	if scanReport.SuccessfulScan {
+		scanReport.Overview = append(scanReport.Overview, reporting.OverviewRow{Description: "Result", Details: "passed", Style: reporting.Green})
+	} else {
+		scanReport.Overview = append(scanReport.Overview, reporting.OverviewRow{Description: "Result", Details: "failed", Style: reporting.Red})
+	}
+
+	findings = withoutCrossToolDuplicates(findings)
+	counts := countFindingsBySeverity(findings)
+	newCounts := map[string]int{}
+	for _, finding := range findings {
+		if finding.isNew {
+			newCounts[finding.severity]++
+		}
+	}
+	for _, severity := range format.Severities {
+		scanReport.Overview = append(scanReport.Overview, reporting.OverviewRow{
+			Description: fmt.Sprintf("%v findings", severity),
+			Details:     fmt.Sprintf("%v (new: %v)", counts[severity], newCounts[severity]),
+		})
+	}
+	for _, report := range scanReports {
+		style := reporting.ColumnStyle(reporting.Green)
+		details := "successful"
+		if !report.SuccessfulScan {
+			style = reporting.Red
+			details = "unsuccessful"
+		}
+		scanReport.Overview = append(scanReport.Overview, reporting.OverviewRow{Description: report.Title(), Details: details, Style: style})
+	}
+
+	scanReport.DetailTable = reporting.ScanDetailTable{
+		Headers:       []string{"Policy", "Findings", "Limit"},
+		NoRowsMessage: "No policy violations",
+	}
+	for _, violation := range violations {
+		row := reporting.ScanRow{}
+		row.AddColumn(violation.description, 0)
+		row.AddColumn(violation.count, reporting.Red)
+		row.AddColumn(violation.limit, 0)
+		scanReport.DetailTable.Rows = append(scanReport.DetailTable.Rows, row)
+	}
+	return scanReport
+}
+
+func writeSecurityQualityGateReport(scanReport reporting.ScanReport, utils securityQualityGateUtils) ([]piperutils.Path, error) {
+	reportPaths := []piperutils.Path{}
+
+	htmlReport, _ := scanReport.ToHTML()
+	htmlReportPath := filepath.Join("securityQualityGate", "report.html")
+	if err := utils.MkdirAll(filepath.Dir(htmlReportPath), 0777); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create report directory")
+	}
+	if err := utils.FileWrite(htmlReportPath, htmlReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write html report")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "Security Quality Gate Report", Target: htmlReportPath})
+
+	// JSON report is used by step pipelineCreateScanSummary
+	jsonReport, _ := scanReport.ToJSON()
+	if exists, _ := utils.DirExists(reporting.StepReportDirectory); !exists {
+		if err := utils.MkdirAll(reporting.StepReportDirectory, 0777); err != nil {
+			return reportPaths, errors.Wrap(err, "failed to create reporting directory")
+		}
+	}
+	if err := utils.FileWrite(filepath.Join(reporting.StepReportDirectory, "securityQualityGate.json"), jsonReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write json report")
+	}
+	return reportPaths, nil
+}
@@ -0,0 +1,229 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/stretchr/testify/assert"
+)
+
+type securityQualityGateMockUtils struct {
+	*mock.FilesMock
+}
+
+func newSecurityQualityGateTestsUtils() securityQualityGateMockUtils {
+	utils := securityQualityGateMockUtils{
+		FilesMock: &mock.FilesMock{},
+	}
+	return utils
+}
+
+const (
+	qualityGateCheckmarxSarif = `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "Checkmarx", "rules": [
+			{"id": "SQL_Injection", "properties": {"tags": ["external/cwe/cwe-89"]}},
+			{"id": "XSS", "properties": {"tags": ["external/cwe/cwe-79"]}}
+		]}}, "results": [
+		{"ruleId": "SQL_Injection", "level": "none", "partialFingerprints": {"checkmarxSimilarityID": "1234"}, "properties": {"toolSeverity": "High"}},
+		{"ruleId": "XSS", "level": "none", "partialFingerprints": {"checkmarxSimilarityID": "5678"}, "properties": {"toolSeverity": "Medium"}},
+		{"ruleId": "XSS", "level": "none", "partialFingerprints": {"checkmarxSimilarityID": "9012"}, "properties": {"toolSeverity": "Medium", "toolState": "NotExploitable"}}
+	]}]}`
+	qualityGateCodeqlSarif = `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "CodeQL", "rules": [
+			{"id": "js/path-injection", "properties": {"security-severity": "7.5", "tags": ["external/cwe/cwe-022"]}}
+		]}}, "results": [
+		{"ruleId": "js/path-injection", "locations": [{"physicalLocation": {"artifactLocation": {"uri": "src/file.js"}, "region": {"startLine": 3}}}]}
+	]}]}`
+	// merged file of sarifMerge containing the CodeQL finding again with its baseline state
+	qualityGateMergedSarif = `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "CodeQL"}}, "results": [
+		{"ruleId": "js/path-injection", "baselineState": "unchanged", "locations": [{"physicalLocation": {"artifactLocation": {"uri": "src/file.js"}, "region": {"startLine": 3}}}]}
+	]}]}`
+	// finding of another tool for the same CWE in the same line as the CodeQL finding
+	qualityGateSemgrepSarif = `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "Semgrep", "rules": [
+			{"id": "path-traversal", "properties": {"tags": ["external/cwe/cwe-22"]}}
+		]}}, "results": [
+		{"ruleId": "path-traversal", "level": "error", "locations": [{"physicalLocation": {"artifactLocation": {"uri": "src/file.js"}, "region": {"startLine": 3}}}]}
+	]}]}`
+	qualityGateBaseline = `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "Checkmarx"}}, "results": [
+		{"ruleId": "SQL_Injection", "partialFingerprints": {"checkmarxSimilarityID": "1234"}}
+	]}]}`
+)
+
+func addQualityGateScanReport(t *testing.T, utils securityQualityGateMockUtils, name string, scanReport reporting.ScanReport) {
+	content, err := json.Marshal(scanReport)
+	assert.NoError(t, err)
+	utils.AddFile(reporting.StepReportDirectory+"/"+name, content)
+}
+
+func TestRunSecurityQualityGate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("passed", func(t *testing.T) {
+		t.Parallel()
+		config := securityQualityGateOptions{
+			SarifFiles: []string{"**/*.sarif"},
+			Policy: map[string]interface{}{
+				"severities": map[string]interface{}{"critical": 0, "high": 1},
+				"cwes":       map[string]interface{}{"CWE-79": 1},
+			},
+		}
+		utils := newSecurityQualityGateTestsUtils()
+		utils.AddFile("checkmarx/result.sarif", []byte(qualityGateCheckmarxSarif))
+
+		err := runSecurityQualityGate(&config, nil, utils)
+		assert.NoError(t, err)
+
+		content, err := utils.FileRead(reporting.StepReportDirectory + "/securityQualityGate.json")
+		assert.NoError(t, err)
+		scanReport := reporting.ScanReport{}
+		assert.NoError(t, json.Unmarshal(content, &scanReport))
+		assert.True(t, scanReport.SuccessfulScan)
+		assert.Empty(t, scanReport.DetailTable.Rows)
+		assert.True(t, utils.HasWrittenFile("securityQualityGate/report.html"))
+	})
+
+	t.Run("violations of all policies", func(t *testing.T) {
+		t.Parallel()
+		config := securityQualityGateOptions{
+			SarifFiles: []string{"**/*.sarif"},
+			Policy: map[string]interface{}{
+				"severities":              map[string]interface{}{"high": 1, "medium": 5},
+				"tools":                   map[string]interface{}{"checkmarx": map[string]interface{}{"medium": 0}},
+				"cwes":                    map[string]interface{}{"89": 0, "CWE-022": 0},
+				"failOnUnsuccessfulScans": true,
+			},
+		}
+		utils := newSecurityQualityGateTestsUtils()
+		utils.AddFile("checkmarx/result.sarif", []byte(qualityGateCheckmarxSarif))
+		utils.AddFile("codeql/result.sarif", []byte(qualityGateCodeqlSarif))
+		addQualityGateScanReport(t, utils, "checkmarxExecuteScan_sast_1234.json", reporting.ScanReport{StepName: "checkmarxExecuteScan", ReportTitle: "Checkmarx SAST Report", SuccessfulScan: true})
+		addQualityGateScanReport(t, utils, "whitesourceExecuteScan_oss_5678.json", reporting.ScanReport{StepName: "whitesourceExecuteScan", ReportTitle: "Whitesource Security Vulnerability Report", SuccessfulScan: false})
+		// report of a previous run
+		addQualityGateScanReport(t, utils, "securityQualityGate.json", reporting.ScanReport{StepName: "securityQualityGate", SuccessfulScan: false})
+
+		err := runSecurityQualityGate(&config, nil, utils)
+		assert.EqualError(t, err, "security quality gate failed with 5 violation(s): "+
+			"2 high findings (limit 1), 1 checkmarx medium findings (limit 0), 1 CWE-22 findings (limit 0), 1 CWE-89 findings (limit 0), "+
+			"1 unsuccessful scans (whitesourceExecuteScan) (limit 0)")
+
+		content, err := utils.FileRead(reporting.StepReportDirectory + "/securityQualityGate.json")
+		assert.NoError(t, err)
+		scanReport := reporting.ScanReport{}
+		assert.NoError(t, json.Unmarshal(content, &scanReport))
+		assert.False(t, scanReport.SuccessfulScan)
+		assert.Len(t, scanReport.DetailTable.Rows, 5)
+	})
+
+	t.Run("new findings according to baseline file", func(t *testing.T) {
+		t.Parallel()
+		config := securityQualityGateOptions{
+			SarifFiles:   []string{"**/*.sarif"},
+			BaselineFile: "baseline.sarif",
+			Policy:       map[string]interface{}{"newFindings": map[string]interface{}{"high": 0, "medium": 0}},
+		}
+		utils := newSecurityQualityGateTestsUtils()
+		utils.AddFile("checkmarx/result.sarif", []byte(qualityGateCheckmarxSarif))
+		utils.AddFile("baseline.sarif", []byte(qualityGateBaseline))
+
+		err := runSecurityQualityGate(&config, nil, utils)
+		// the high finding is contained in the baseline
+		assert.EqualError(t, err, "security quality gate failed with 1 violation(s): 1 new medium findings (limit 0)")
+	})
+
+	t.Run("new findings according to sarifMerge", func(t *testing.T) {
+		t.Parallel()
+		config := securityQualityGateOptions{
+			SarifFiles: []string{"**/*.sarif"},
+			Policy:     map[string]interface{}{"severities": map[string]interface{}{"high": 0}, "newFindings": map[string]interface{}{"high": 0}},
+		}
+		utils := newSecurityQualityGateTestsUtils()
+		utils.AddFile("codeql/result.sarif", []byte(qualityGateCodeqlSarif))
+		utils.AddFile("sarifMerge/merged.sarif", []byte(qualityGateMergedSarif))
+
+		err := runSecurityQualityGate(&config, nil, utils)
+		// the finding is contained in both files but counted once and it is not new
+		assert.EqualError(t, err, "security quality gate failed with 1 violation(s): 1 high findings (limit 0)")
+	})
+
+	t.Run("findings of several tools", func(t *testing.T) {
+		t.Parallel()
+		config := securityQualityGateOptions{
+			SarifFiles: []string{"**/*.sarif"},
+			Policy: map[string]interface{}{
+				"severities": map[string]interface{}{"high": 1},
+				"tools":      map[string]interface{}{"Semgrep": map[string]interface{}{"high": 0}},
+				"cwes":       map[string]interface{}{"22": 1},
+			},
+		}
+		utils := newSecurityQualityGateTestsUtils()
+		utils.AddFile("codeql/result.sarif", []byte(qualityGateCodeqlSarif))
+		utils.AddFile("semgrep/result.sarif", []byte(qualityGateSemgrepSarif))
+
+		err := runSecurityQualityGate(&config, nil, utils)
+		// the Semgrep finding is counted for the limits of Semgrep only since CodeQL reported it as well
+		assert.EqualError(t, err, "security quality gate failed with 1 violation(s): 1 Semgrep high findings (limit 0)")
+	})
+
+	t.Run("findings of scan reports", func(t *testing.T) {
+		t.Parallel()
+		config := securityQualityGateOptions{
+			SarifFiles: []string{"**/*.sarif"},
+			Policy: map[string]interface{}{
+				"severities":  map[string]interface{}{"high": 2, "critical": 0},
+				"newFindings": map[string]interface{}{"critical": 1},
+				"tools":       map[string]interface{}{"whitesourceExecuteScan": map[string]interface{}{"critical": 0}},
+			},
+		}
+		utils := newSecurityQualityGateTestsUtils()
+		utils.AddFile("checkmarx/result.sarif", []byte(qualityGateCheckmarxSarif))
+		// the findings are contained in the SARIF file
+		addQualityGateScanReport(t, utils, "checkmarxExecuteScan_sast_1234.json", reporting.ScanReport{StepName: "checkmarxExecuteScan", SuccessfulScan: true, Findings: map[string]int{"high": 5}, SarifTool: "Checkmarx"})
+		addQualityGateScanReport(t, utils, "whitesourceExecuteScan_oss_5678.json", reporting.ScanReport{StepName: "whitesourceExecuteScan", SuccessfulScan: true, Findings: map[string]int{"critical": 2, "high": 1}, SarifTool: "Mend"})
+
+		err := runSecurityQualityGate(&config, nil, utils)
+		assert.EqualError(t, err, "security quality gate failed with 3 violation(s): "+
+			"2 critical findings (limit 0), 2 new critical findings (limit 1), 2 whitesourceExecuteScan critical findings (limit 0)")
+	})
+
+	t.Run("policy file", func(t *testing.T) {
+		t.Parallel()
+		config := securityQualityGateOptions{SarifFiles: []string{"**/*.sarif"}, PolicyFile: ".pipeline/securityPolicy.yml"}
+		utils := newSecurityQualityGateTestsUtils()
+		utils.AddFile("codeql/result.sarif", []byte(qualityGateCodeqlSarif))
+		utils.AddFile(".pipeline/securityPolicy.yml", []byte("tools:\n  CodeQL:\n    high: 0\ncwes:\n  22: 0\n"))
+
+		err := runSecurityQualityGate(&config, nil, utils)
+		assert.EqualError(t, err, "security quality gate failed with 2 violation(s): 1 CodeQL high findings (limit 0), 1 CWE-22 findings (limit 0)")
+	})
+
+	t.Run("invalid severity", func(t *testing.T) {
+		t.Parallel()
+		config := securityQualityGateOptions{SarifFiles: []string{"**/*.sarif"}, Policy: map[string]interface{}{"severities": map[string]interface{}{"severe": 0}}}
+		utils := newSecurityQualityGateTestsUtils()
+
+		err := runSecurityQualityGate(&config, nil, utils)
+		assert.EqualError(t, err, "invalid severity 'severe' in policy, supported severities are critical, high, medium, low, info")
+	})
+
+	t.Run("invalid CWE", func(t *testing.T) {
+		t.Parallel()
+		config := securityQualityGateOptions{SarifFiles: []string{"**/*.sarif"}, Policy: map[string]interface{}{"cwes": map[string]interface{}{"XSS": 0}}}
+		utils := newSecurityQualityGateTestsUtils()
+
+		err := runSecurityQualityGate(&config, nil, utils)
+		assert.EqualError(t, err, "invalid CWE 'XSS' in policy, expected a number or the format CWE-<id>")
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		t.Parallel()
+		config := securityQualityGateOptions{SarifFiles: []string{"**/*.sarif"}}
+		utils := newSecurityQualityGateTestsUtils()
+		utils.AddFile("checkmarx/result.sarif", []byte(qualityGateCheckmarxSarif))
+
+		err := runSecurityQualityGate(&config, nil, utils)
+		assert.NoError(t, err)
+	})
+}
@@ -238,7 +238,10 @@ func runSonar(config sonarExecuteScanOptions, client piperhttp.Downloader, runne
 			Name:   "Sonar Web UI",
 		},
 	}
-	piperutils.PersistReportsAndLinks("sonarExecuteScan", sonar.workingDir, utils, reports, links)
+	// persisted once on return, including the reports written until then
+	defer func() {
+		piperutils.PersistReportsAndLinks("sonarExecuteScan", sonar.workingDir, utils, reports, links)
+	}()
 
 	if len(config.Token) == 0 {
 		log.Entry().Warn("no measurements are fetched due to missing credentials")
@@ -305,6 +308,32 @@ func runSonar(config sonarExecuteScanOptions, client piperhttp.Downloader, runne
 		reportData.LinesOfCode = loc
 	}
 
+	qualityGateService := SonarUtils.NewQualityGateService(serverUrl, config.Token, taskReport.ProjectKey, config.BranchName, config.ChangeID, apiClient)
+	qualityGate, err := qualityGateService.GetProjectStatus()
+	if err != nil {
+		log.Entry().Warnf("failed to retrieve sonar quality gate status: %v", err)
+	} else {
+		reportData.QualityGate = qualityGate
+	}
+
+	// export open vulnerabilities and security hotspots in SARIF format, other issue types are no security findings
+	issues, components, err := issueService.GetOpenIssues("VULNERABILITY")
+	if err != nil {
+		log.Entry().Warnf("failed to retrieve sonar issues: %v", err)
+	} else {
+		hotspotService := SonarUtils.NewHotspotService(serverUrl, config.Token, taskReport.ProjectKey, config.BranchName, config.ChangeID, apiClient)
+		hotspots, hotspotComponents, err := hotspotService.GetOpenHotspots()
+		if err != nil {
+			log.Entry().Warnf("failed to retrieve sonar security hotspots: %v", err)
+		}
+		components = append(components, hotspotComponents...)
+		sarifPaths, err := SonarUtils.WriteSarif(SonarUtils.IssuesToSarif(issues, hotspots, components, serverUrl), sonar.workingDir, utils)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, sarifPaths...)
+	}
+
 	log.Entry().Debugf("Influx values: %v", influx.sonarqube_data.fields)
 
 	err = SonarUtils.WriteReport(reportData, sonar.workingDir, os.WriteFile)
@@ -312,6 +341,13 @@ func runSonar(config sonarExecuteScanOptions, client piperhttp.Downloader, runne
 	if err != nil {
 		return err
 	}
+
+	scanReport := SonarUtils.CreateScanReport(reportData, taskReport.DashboardURL)
+	reportPaths, err := SonarUtils.WriteScanReports(reportData, scanReport, sonar.workingDir, utils)
+	if err != nil {
+		return err
+	}
+	reports = append(reports, reportPaths...)
 	return nil
 }
 
@@ -367,11 +403,17 @@ func handlePullRequest(config sonarExecuteScanOptions) error {
 		} else {
 			// see https://sonarcloud.io/documentation/analysis/pull-request/
 			provider := strings.ToLower(config.PullRequestProvider)
-			if provider == "github" {
+			if len(provider) == 0 {
+				provider = "github"
+			}
+			switch provider {
+			case "github":
 				if len(config.Owner) > 0 && len(config.Repository) > 0 {
 					sonar.addOption("sonar.pullrequest.github.repository=" + config.Owner + "/" + config.Repository)
 				}
-			} else {
+			case "gitlab":
+				// merge request decoration for GitLab is configured via the project binding on the server
+			default:
 				return errors.New("Pull-Request provider '" + provider + "' is not supported!")
 			}
 			sonar.addOption("sonar.pullrequest.key=" + config.ChangeID)
@@ -495,6 +537,11 @@ func detectParametersFromCI(options *sonarExecuteScanOptions) {
 		return
 	}
 
+	if len(options.PullRequestProvider) == 0 && provider.OrchestratorType() == "GitLab" {
+		log.Entry().Info("Inferring parameter pullRequestProvider from environment: GitLab")
+		options.PullRequestProvider = "GitLab"
+	}
+
 	if provider.IsPullRequest() {
 		config := provider.PullRequestConfig()
 		if len(options.ChangeBranch) == 0 {
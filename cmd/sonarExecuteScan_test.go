@@ -20,6 +20,7 @@ import (
 
 	piperHttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/orchestrator"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	SonarUtils "github.com/SAP/jenkins-library/pkg/sonar"
 )
@@ -154,7 +155,9 @@ func TestRunSonar(t *testing.T) {
 	// add response handler
 	httpmock.RegisterResponder(http.MethodGet, sonarServerURL+"/api/"+SonarUtils.EndpointCeTask+"", httpmock.NewStringResponder(http.StatusOK, `{ "task": { "componentId": "AXERR2JBbm9IiM5TEST", "status": "SUCCESS" }}`))
 	httpmock.RegisterResponder(http.MethodGet, sonarServerURL+"/api/"+SonarUtils.EndpointIssuesSearch+"", httpmock.NewStringResponder(http.StatusOK, `{ "total": 0 }`))
+	httpmock.RegisterResponder(http.MethodGet, sonarServerURL+"/api/"+SonarUtils.EndpointHotspotsSearch+"", httpmock.NewStringResponder(http.StatusOK, `{ "paging": { "total": 0 } }`))
 	httpmock.RegisterResponder(http.MethodGet, sonarServerURL+"/api/"+SonarUtils.EndpointMeasuresComponent+"", httpmock.NewStringResponder(http.StatusOK, measuresComponentResponse))
+	httpmock.RegisterResponder(http.MethodGet, sonarServerURL+"/api/"+SonarUtils.EndpointQualityGatesProjectStatus+"", httpmock.NewStringResponder(http.StatusOK, `{ "projectStatus": { "status": "OK", "conditions": [{ "status": "OK", "metricKey": "new_coverage", "comparator": "LT", "errorThreshold": "80", "actualValue": "84.2" }]}}`))
 
 	t.Run("default", func(t *testing.T) {
 		// init
@@ -179,8 +182,9 @@ func TestRunSonar(t *testing.T) {
 		fileUtilsExists = mockFileUtilsExists(true)
 		os.Setenv("SONAR_SCANNER_OPTS", "-Xmx42m")
 		defer os.Setenv("SONAR_SCANNER_OPTS", "")
+		utils := &mock.FilesMock{}
 		// test
-		err := runSonar(options, &mockDownloadClient, &mockRunner, apiClient, &mock.FilesMock{}, &sonarExecuteScanInflux{})
+		err := runSonar(options, &mockDownloadClient, &mockRunner, apiClient, utils, &sonarExecuteScanInflux{})
 		assert.NoError(t, err)
 		// load sonarscan report file
 		reportFile, err := os.ReadFile(filepath.Join(tmpFolder, "sonarscan.json"))
@@ -190,6 +194,18 @@ func TestRunSonar(t *testing.T) {
 		assert.NoError(t, err)
 		// assert
 		assert.NotNil(t, reportData.Errors)
+		if assert.NotNil(t, reportData.QualityGate) {
+			assert.Equal(t, "OK", reportData.QualityGate.Status)
+			assert.Len(t, reportData.QualityGate.Conditions, 1)
+		}
+		assert.True(t, utils.HasWrittenFile(filepath.Join(tmpFolder, "sonarscan.sarif")))
+		assert.True(t, utils.HasWrittenFile(filepath.Join(tmpFolder, "sonarscan.html")))
+		// reports are persisted once including the reports written after the scan
+		persistedReports, err := utils.FileRead(filepath.Join(tmpFolder, "sonarExecuteScan_reports.json"))
+		if assert.NoError(t, err) {
+			assert.Contains(t, string(persistedReports), "sonarscan.sarif")
+			assert.Contains(t, string(persistedReports), "sonarscan.html")
+		}
 		assert.Contains(t, sonar.options, "-Dsonar.projectVersion=1")
 		assert.Contains(t, sonar.options, "-Dsonar.organization=SAP")
 		assert.Contains(t, sonar.environment, "SONAR_HOST_URL="+sonarServerURL)
@@ -346,23 +362,45 @@ func TestSonarHandlePullRequest(t *testing.T) {
 			options:     []string{},
 		}
 		options := sonarExecuteScanOptions{
-			ChangeID:            "123",
-			PullRequestProvider: "GitHub",
-			ChangeBranch:        "feat/bogus",
-			ChangeTarget:        "master",
-			Owner:               "SAP",
-			Repository:          "jenkins-library",
+			ChangeID:     "123",
+			ChangeBranch: "feat/bogus",
+			ChangeTarget: "master",
+			Owner:        "SAP",
+			Repository:   "jenkins-library",
 		}
 		// test
 		err := handlePullRequest(options)
 		// assert
 		assert.NoError(t, err)
+		assert.Contains(t, sonar.options, "sonar.pullrequest.provider=github")
 		//assert.Contains(t, sonar.options, "sonar.pullrequest.key=123")
 		//assert.Contains(t, sonar.options, "sonar.pullrequest.provider=github")
 		//assert.Contains(t, sonar.options, "sonar.pullrequest.base=master")
 		//assert.Contains(t, sonar.options, "sonar.pullrequest.branch=feat/bogus")
 		//assert.Contains(t, sonar.options, "sonar.pullrequest.github.repository=SAP/jenkins-library")
 	})
+	t.Run("gitlab", func(t *testing.T) {
+		// init
+		sonar = sonarSettings{
+			binary:      "sonar-scanner",
+			environment: []string{},
+			options:     []string{},
+		}
+		options := sonarExecuteScanOptions{
+			ChangeID:            "42",
+			PullRequestProvider: "GitLab",
+			ChangeBranch:        "feat/bogus",
+			ChangeTarget:        "main",
+		}
+		// test
+		err := handlePullRequest(options)
+		// assert
+		assert.NoError(t, err)
+		assert.Contains(t, sonar.options, "sonar.pullrequest.key=42")
+		assert.Contains(t, sonar.options, "sonar.pullrequest.provider=gitlab")
+		assert.Contains(t, sonar.options, "sonar.pullrequest.base=main")
+		assert.Contains(t, sonar.options, "sonar.pullrequest.branch=feat/bogus")
+	})
 	t.Run("unsupported scm provider", func(t *testing.T) {
 		// init
 		sonar = sonarSettings{
@@ -407,6 +445,39 @@ func TestSonarHandlePullRequest(t *testing.T) {
 	})
 }
 
+func TestSonarDetectParametersFromCI(t *testing.T) {
+	t.Run("GitLab merge request", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		defer orchestrator.ResetConfigProvider()
+		os.Clearenv()
+		orchestrator.ResetConfigProvider()
+		os.Setenv("GITLAB_CI", "true")
+		os.Setenv("CI_MERGE_REQUEST_IID", "42")
+		os.Setenv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "feat/bogus")
+		os.Setenv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME", "main")
+		options := sonarExecuteScanOptions{}
+
+		detectParametersFromCI(&options)
+
+		assert.Equal(t, "GitLab", options.PullRequestProvider)
+		assert.Equal(t, "42", options.ChangeID)
+		assert.Equal(t, "feat/bogus", options.ChangeBranch)
+		assert.Equal(t, "main", options.ChangeTarget)
+	})
+	t.Run("configured provider", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		defer orchestrator.ResetConfigProvider()
+		os.Clearenv()
+		orchestrator.ResetConfigProvider()
+		os.Setenv("GITLAB_CI", "true")
+		options := sonarExecuteScanOptions{PullRequestProvider: "GitHub"}
+
+		detectParametersFromCI(&options)
+
+		assert.Equal(t, "GitHub", options.PullRequestProvider)
+	})
+}
+
 func TestSonarLoadScanner(t *testing.T) {
 	mockClient := mockDownloader{shouldFail: false}
 
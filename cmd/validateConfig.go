@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+)
+
+type validateConfigCommandOptions struct {
+	schemaOutputFile string // if set: path to file where the JSON Schema should be written to
+	failOnWarnings   bool
+	openFile         func(s string, t map[string]string) (io.ReadCloser, error)
+}
+
+var validateConfigOptions validateConfigCommandOptions
+
+type validateConfigUtils interface {
+	FileExists(filename string) (bool, error)
+	FileWrite(path string, content []byte, perm os.FileMode) error
+}
+
+// ValidateConfigCommand is the entry command for validating the project configuration against the step metadata
+func ValidateConfigCommand() *cobra.Command {
+	validateConfigOptions.openFile = config.OpenPiperFile
+	var validateConfigCmd = &cobra.Command{
+		Use:   "validateConfig",
+		Short: "Validates the project configuration against the JSON Schema generated from the step metadata.",
+		Long: `Validates the project configuration (general, stages and steps sections) against a JSON Schema generated from the metadata of all steps.
+Unknown keys and deprecated aliases are reported as warnings, wrong types and values outside of the possible values as errors.
+The schema can be written to a file in order to be used by editors for completion and validation.`,
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			path, _ := os.Getwd()
+			fatalHook := &log.FatalHook{CorrelationID: GeneralConfig.CorrelationID, Path: path}
+			log.RegisterHook(fatalHook)
+			log.SetVerbose(GeneralConfig.Verbose)
+			GeneralConfig.GitHubAccessTokens = ResolveAccessTokens(GeneralConfig.GitHubTokens)
+		},
+		Run: func(cmd *cobra.Command, _ []string) {
+			utils := &piperutils.Files{}
+			if err := validateConfig(utils); err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				log.Entry().WithError(err).Fatal("Validating the configuration failed")
+			}
+		},
+	}
+	addValidateConfigFlags(validateConfigCmd)
+	return validateConfigCmd
+}
+
+func validateConfig(utils validateConfigUtils) error {
+	if GeneralConfig.MetaDataResolver == nil {
+		GeneralConfig.MetaDataResolver = GetAllStepMetadata
+	}
+	schema := config.GenerateConfigSchema(GeneralConfig.MetaDataResolver())
+
+	if len(validateConfigOptions.schemaOutputFile) > 0 {
+		schemaJSON, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal configuration schema")
+		}
+		if err := utils.FileWrite(validateConfigOptions.schemaOutputFile, schemaJSON, 0o666); err != nil {
+			return fmt.Errorf("failed to write schema file %v: %w", validateConfigOptions.schemaOutputFile, err)
+		}
+		log.Entry().Infof("Configuration schema written to %v", validateConfigOptions.schemaOutputFile)
+	}
+
+	projectConfigFile := getProjectConfigFile(GeneralConfig.CustomConfig)
+	if exists, _ := utils.FileExists(projectConfigFile); !exists {
+		// only writing the schema does not require a project configuration
+		if len(validateConfigOptions.schemaOutputFile) > 0 {
+			log.Entry().Infof("Project config: NONE ('%s' does not exist)", projectConfigFile)
+			return nil
+		}
+		return errors.Errorf("project configuration '%v' does not exist", projectConfigFile)
+	}
+	customConfig, err := validateConfigOptions.openFile(projectConfigFile, GeneralConfig.GitHubAccessTokens)
+	if err != nil {
+		return errors.Wrapf(err, "config: open configuration file '%v' failed", projectConfigFile)
+	}
+	defer customConfig.Close()
+	content, err := io.ReadAll(customConfig)
+	if err != nil {
+		return errors.Wrapf(err, "config: reading configuration file '%v' failed", projectConfigFile)
+	}
+
+	findings, err := schema.ValidateConfig(projectConfigFile, content)
+	if err != nil {
+		return err
+	}
+
+	errorCount, warningCount := 0, 0
+	for _, finding := range findings {
+		if finding.Severity == config.SeverityError {
+			errorCount++
+			log.Entry().Error(finding.String())
+		} else {
+			warningCount++
+			log.Entry().Warn(finding.String())
+		}
+	}
+	if errorCount > 0 || validateConfigOptions.failOnWarnings && warningCount > 0 {
+		return errors.Errorf("configuration '%v' contains %v error(s) and %v warning(s)", projectConfigFile, errorCount, warningCount)
+	}
+	log.Entry().Infof("Configuration '%v' is valid (%v warning(s))", projectConfigFile, warningCount)
+	return nil
+}
+
+func addValidateConfigFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&validateConfigOptions.schemaOutputFile, "schemaOutputFile", "", "Defines a file path. If set, the JSON Schema of the configuration will be written to the defined file")
+	cmd.Flags().BoolVar(&validateConfigOptions.failOnWarnings, "failOnWarnings", false, "Defines if the validation fails in case of warnings like unknown keys or deprecated aliases")
+}
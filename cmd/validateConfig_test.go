@@ -0,0 +1,89 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/mock"
+)
+
+func TestValidateConfig(t *testing.T) {
+	metadata := map[string]config.StepData{
+		"testStep": {
+			Metadata: config.StepMetadata{Name: "testStep"},
+			Spec: config.StepSpec{Inputs: config.StepInputs{Parameters: []config.StepParameters{
+				{Name: "testParam", Type: "string", Scope: []string{"GENERAL", "STEPS"}, PossibleValues: []interface{}{"a", "b"}},
+				{Name: "testFlag", Type: "bool", Scope: []string{"STEPS"}},
+			}}},
+		},
+	}
+
+	prepare := func(t *testing.T, configContent string) *mock.FilesMock {
+		resolver, customConfig := GeneralConfig.MetaDataResolver, GeneralConfig.CustomConfig
+		t.Cleanup(func() {
+			GeneralConfig.MetaDataResolver = resolver
+			GeneralConfig.CustomConfig = customConfig
+			validateConfigOptions = validateConfigCommandOptions{}
+		})
+
+		files := &mock.FilesMock{}
+		if len(configContent) > 0 {
+			files.AddFile(".pipeline/config.yml", []byte(configContent))
+		}
+		GeneralConfig.MetaDataResolver = func() map[string]config.StepData { return metadata }
+		GeneralConfig.CustomConfig = ".pipeline/config.yml"
+		validateConfigOptions.openFile = func(name string, _ map[string]string) (io.ReadCloser, error) {
+			content, err := files.FileRead(name)
+			return io.NopCloser(bytes.NewReader(content)), err
+		}
+		return files
+	}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		files := prepare(t, "steps:\n  testStep:\n    testParam: a\n    testFlag: true\n")
+
+		assert.NoError(t, validateConfig(files))
+	})
+
+	t.Run("warnings", func(t *testing.T) {
+		files := prepare(t, "steps:\n  testStep:\n    unknown: a\n")
+
+		assert.NoError(t, validateConfig(files))
+
+		validateConfigOptions.failOnWarnings = true
+		assert.EqualError(t, validateConfig(files), "configuration '.pipeline/config.yml' contains 0 error(s) and 1 warning(s)")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		files := prepare(t, "general:\n  testParam: c\nsteps:\n  testStep:\n    testFlag: [true]\n")
+
+		assert.EqualError(t, validateConfig(files), "configuration '.pipeline/config.yml' contains 2 error(s) and 0 warning(s)")
+	})
+
+	t.Run("write schema", func(t *testing.T) {
+		files := prepare(t, "")
+		validateConfigOptions.schemaOutputFile = "piper-config.schema.json"
+
+		assert.NoError(t, validateConfig(files))
+
+		content, err := files.FileRead("piper-config.schema.json")
+		assert.NoError(t, err)
+		var schema config.JSONSchema
+		assert.NoError(t, json.Unmarshal(content, &schema))
+		assert.Equal(t, "boolean", schema.Properties["steps"].Properties["testStep"].Properties["testFlag"].Type)
+	})
+
+	t.Run("missing configuration", func(t *testing.T) {
+		files := prepare(t, "")
+
+		assert.EqualError(t, validateConfig(files), "project configuration '.pipeline/config.yml' does not exist")
+	})
+}
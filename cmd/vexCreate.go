@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+)
+
+type vexCreateUtils interface {
+	FileExists(filename string) (bool, error)
+	FileRead(path string) ([]byte, error)
+	FileWrite(path string, content []byte, perm os.FileMode) error
+	WriteFile(filename string, data []byte, perm os.FileMode) error
+	MkdirAll(path string, perm os.FileMode) error
+	Glob(pattern string) (matches []string, err error)
+}
+
+type vexCreateUtilsBundle struct {
+	*piperutils.Files
+}
+
+func newVexCreateUtils() vexCreateUtils {
+	utils := vexCreateUtilsBundle{
+		Files: &piperutils.Files{},
+	}
+	return &utils
+}
+
+func vexCreate(config vexCreateOptions, telemetryData *telemetry.CustomData) {
+	utils := newVexCreateUtils()
+
+	err := runVexCreate(&config, telemetryData, utils, time.Now())
+	if err != nil {
+		log.Entry().WithError(err).Fatal("step execution failed")
+	}
+}
+
+func runVexCreate(config *vexCreateOptions, telemetryData *telemetry.CustomData, utils vexCreateUtils, timestamp time.Time) error {
+	exists, err := utils.FileExists(config.AssessmentFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check for assessment file %v", config.AssessmentFile)
+	}
+	if !exists {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return fmt.Errorf("assessment file %v does not exist", config.AssessmentFile)
+	}
+	assessments, err := format.ReadAssessmentFile(config.AssessmentFile, utils)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+	log.Entry().Infof("%v assessment(s) read from %v", len(assessments), config.AssessmentFile)
+
+	boms, err := readVexSBOMs(config, utils)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
+	reports := []piperutils.Path{}
+	if slices.Contains(config.Formats, "openvex") {
+		document := format.AssessmentsToOpenVEX(assessments, boms, fmt.Sprintf("urn:uuid:%v", uuid.New()), config.Author, timestamp)
+		content, err := json.MarshalIndent(document, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal OpenVEX document")
+		}
+		if err := writeVexFile(config.OpenVexOutputPath, content, utils); err != nil {
+			return err
+		}
+		log.Entry().Infof("OpenVEX document with %v statement(s) written to %v", len(document.Statements), config.OpenVexOutputPath)
+		reports = append(reports, piperutils.Path{Name: "OpenVEX document", Target: config.OpenVexOutputPath})
+	}
+	if slices.Contains(config.Formats, "cyclonedx") {
+		bom := format.AssessmentsToCycloneDXVEX(assessments, boms, timestamp)
+		fileFormat := cdx.BOMFileFormatJSON
+		if strings.HasSuffix(strings.ToLower(config.CycloneDxVexOutputPath), ".xml") {
+			fileFormat = cdx.BOMFileFormatXML
+		}
+		buffer := new(bytes.Buffer)
+		encoder := cdx.NewBOMEncoder(buffer, fileFormat)
+		encoder.SetPretty(true)
+		if err := encoder.Encode(bom); err != nil {
+			return errors.Wrap(err, "failed to encode CycloneDX VEX document")
+		}
+		if err := writeVexFile(config.CycloneDxVexOutputPath, buffer.Bytes(), utils); err != nil {
+			return err
+		}
+		log.Entry().Infof("CycloneDX VEX document with %v vulnerability(s) written to %v", len(*bom.Vulnerabilities), config.CycloneDxVexOutputPath)
+		reports = append(reports, piperutils.Path{Name: "CycloneDX VEX document", Target: config.CycloneDxVexOutputPath})
+	}
+
+	if err := piperutils.PersistReportsAndLinks("vexCreate", "", utils, reports, nil); err != nil {
+		log.Entry().WithError(err).Warn("failed to persist reports")
+	}
+	return nil
+}
+
+// readVexSBOMs reads the CycloneDX SBOMs matching the configured patterns except the VEX documents written by the step
+func readVexSBOMs(config *vexCreateOptions, utils vexCreateUtils) ([]cdx.BOM, error) {
+	excluded := map[string]bool{
+		filepath.Clean(config.OpenVexOutputPath):      true,
+		filepath.Clean(config.CycloneDxVexOutputPath): true,
+	}
+	files := []string{}
+	for _, pattern := range config.SbomFiles {
+		matches, err := utils.Glob(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern %v", pattern)
+		}
+		for _, match := range matches {
+			if !excluded[filepath.Clean(match)] {
+				excluded[filepath.Clean(match)] = true
+				files = append(files, match)
+			}
+		}
+	}
+	sort.Strings(files)
+	if len(files) == 0 {
+		log.Entry().Infof("no SBOM found matching %v, all assessments are considered", config.SbomFiles)
+	}
+
+	boms := []cdx.BOM{}
+	for _, file := range files {
+		content, err := utils.FileRead(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read SBOM %v", file)
+		}
+		fileFormat := cdx.BOMFileFormatXML
+		if strings.HasSuffix(strings.ToLower(file), ".json") {
+			fileFormat = cdx.BOMFileFormatJSON
+		}
+		bom := cdx.BOM{}
+		if err := cdx.NewBOMDecoder(bytes.NewReader(content), fileFormat).Decode(&bom); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse SBOM %v", file)
+		}
+		log.Entry().Debugf("SBOM %v read", file)
+		boms = append(boms, bom)
+	}
+	return boms, nil
+}
+
+func writeVexFile(path string, content []byte, utils vexCreateUtils) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := utils.MkdirAll(dir, 0777); err != nil {
+			return errors.Wrapf(err, "failed to create directory %v", dir)
+		}
+	}
+	if err := utils.FileWrite(path, content, 0666); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.Wrapf(err, "failed to write %v", path)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVexCreateCommand(t *testing.T) {
+	t.Parallel()
+
+	testCmd := VexCreateCommand()
+
+	// only high level testing performed - details are tested in step generation procedure
+	assert.Equal(t, "vexCreate", testCmd.Use, "command name incorrect")
+
+}
@@ -0,0 +1,152 @@
+//go:build unit
+// +build unit
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type vexCreateMockUtils struct {
+	*mock.FilesMock
+}
+
+func newVexCreateTestsUtils() vexCreateMockUtils {
+	utils := vexCreateMockUtils{
+		FilesMock: &mock.FilesMock{},
+	}
+	return utils
+}
+
+const (
+	vexAssessmentFile = `ignore:
+  - vulnerability: CVE-2022-42889
+    status: notRelevant
+    analysis: notUsed
+    purls:
+      - purl: pkg:maven/org.apache.commons/commons-text
+  - vulnerability: CVE-2008-4318
+    status: notRelevant
+    analysis: mitigated
+    purls:
+      - purl: pkg:npm/observer@0.3.2
+`
+	vexSBOM = `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.4" version="1">
+  <metadata>
+    <component type="application">
+      <group>com.sap</group>
+      <name>app</name>
+      <version>1.0.0</version>
+      <purl>pkg:maven/com.sap/app@1.0.0</purl>
+    </component>
+  </metadata>
+  <components>
+    <component type="library" bom-ref="pkg:maven/org.apache.commons/commons-text@1.9">
+      <group>org.apache.commons</group>
+      <name>commons-text</name>
+      <version>1.9</version>
+      <purl>pkg:maven/org.apache.commons/commons-text@1.9</purl>
+    </component>
+  </components>
+</bom>`
+)
+
+func TestRunVexCreate(t *testing.T) {
+	t.Parallel()
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("with SBOM", func(t *testing.T) {
+		t.Parallel()
+		config := vexCreateOptions{
+			AssessmentFile:         "hs-assessments.yaml",
+			SbomFiles:              []string{"**/bom-*.xml"},
+			Formats:                []string{"openvex", "cyclonedx"},
+			Author:                 "Jane Doe",
+			OpenVexOutputPath:      "vex/vex.openvex.json",
+			CycloneDxVexOutputPath: "vex/vex.cdx.json",
+		}
+		utils := newVexCreateTestsUtils()
+		utils.AddFile("hs-assessments.yaml", []byte(vexAssessmentFile))
+		utils.AddFile("target/bom-maven.xml", []byte(vexSBOM))
+
+		err := runVexCreate(&config, nil, utils, timestamp)
+		assert.NoError(t, err)
+
+		content, err := utils.FileRead("vex/vex.openvex.json")
+		assert.NoError(t, err)
+		document := format.OpenVEX{}
+		assert.NoError(t, json.Unmarshal(content, &document))
+		assert.Contains(t, document.ID, "urn:uuid:")
+		assert.Equal(t, "Jane Doe", document.Author)
+		assert.Equal(t, "2024-01-02T03:04:05Z", document.Timestamp)
+		if assert.Len(t, document.Statements, 1) {
+			assert.Equal(t, "CVE-2022-42889", document.Statements[0].Vulnerability.Name)
+			assert.Equal(t, format.OpenVEXNotAffected, document.Statements[0].Status)
+			assert.Equal(t, format.OpenVEXVulnerableCodeNotInExecutePath, document.Statements[0].Justification)
+			assert.Equal(t, "pkg:maven/com.sap/app@1.0.0", document.Statements[0].Products[0].ID)
+			assert.Equal(t, "pkg:maven/org.apache.commons/commons-text@1.9", document.Statements[0].Products[0].Subcomponents[0].ID)
+		}
+
+		content, err = utils.FileRead("vex/vex.cdx.json")
+		assert.NoError(t, err)
+		bom := cdx.BOM{}
+		assert.NoError(t, cdx.NewBOMDecoder(bytes.NewReader(content), cdx.BOMFileFormatJSON).Decode(&bom))
+		if assert.Len(t, *bom.Vulnerabilities, 1) {
+			assert.Equal(t, cdx.IAJCodeNotReachable, (*bom.Vulnerabilities)[0].Analysis.Justification)
+			assert.Equal(t, "pkg:maven/org.apache.commons/commons-text@1.9", (*(*bom.Vulnerabilities)[0].Affects)[0].Ref)
+		}
+		assert.True(t, utils.HasWrittenFile("vexCreate_reports.json"))
+	})
+
+	t.Run("CycloneDX XML without SBOM", func(t *testing.T) {
+		t.Parallel()
+		config := vexCreateOptions{
+			AssessmentFile:         "hs-assessments.yaml",
+			SbomFiles:              []string{"**/bom-*.xml"},
+			Formats:                []string{"cyclonedx"},
+			OpenVexOutputPath:      "vex.openvex.json",
+			CycloneDxVexOutputPath: "vex.cdx.xml",
+		}
+		utils := newVexCreateTestsUtils()
+		utils.AddFile("hs-assessments.yaml", []byte(vexAssessmentFile))
+
+		err := runVexCreate(&config, nil, utils, timestamp)
+		assert.NoError(t, err)
+
+		assert.False(t, utils.HasWrittenFile("vex.openvex.json"))
+		content, err := utils.FileRead("vex.cdx.xml")
+		assert.NoError(t, err)
+		bom := cdx.BOM{}
+		assert.NoError(t, cdx.NewBOMDecoder(bytes.NewReader(content), cdx.BOMFileFormatXML).Decode(&bom))
+		assert.Len(t, *bom.Vulnerabilities, 2)
+	})
+
+	t.Run("missing assessment file", func(t *testing.T) {
+		t.Parallel()
+		config := vexCreateOptions{AssessmentFile: "hs-assessments.yaml"}
+		utils := newVexCreateTestsUtils()
+
+		err := runVexCreate(&config, nil, utils, timestamp)
+		assert.EqualError(t, err, "assessment file hs-assessments.yaml does not exist")
+	})
+
+	t.Run("invalid SBOM", func(t *testing.T) {
+		t.Parallel()
+		config := vexCreateOptions{AssessmentFile: "hs-assessments.yaml", SbomFiles: []string{"**/bom-*.xml"}, Formats: []string{"openvex"}}
+		utils := newVexCreateTestsUtils()
+		utils.AddFile("hs-assessments.yaml", []byte(vexAssessmentFile))
+		utils.AddFile("bom-npm.xml", []byte("<bom"))
+
+		err := runVexCreate(&config, nil, utils, timestamp)
+		assert.ErrorContains(t, err, "failed to parse SBOM bom-npm.xml")
+	})
+}
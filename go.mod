@@ -8,6 +8,7 @@ require (
 	cloud.google.com/go/pubsub v1.47.0
 	cloud.google.com/go/storage v1.54.0
 	dario.cat/mergo v1.0.1
+	filippo.io/age v1.2.1
 	github.com/Azure/azure-sdk-for-go/sdk/storage/azblob v0.4.1
 	github.com/BurntSushi/toml v1.4.0
 	github.com/Jeffail/gabs/v2 v2.7.0
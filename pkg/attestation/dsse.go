@@ -0,0 +1,111 @@
+package attestation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// PayloadTypeInToto is the DSSE payload type of in-toto statements
+const PayloadTypeInToto = "application/vnd.in-toto+json"
+
+// Envelope is a Dead Simple Signing Envelope, see https://github.com/secure-systems-lab/dsse
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+// Signature is the signature of an envelope
+type Signature struct {
+	KeyID     string `json:"keyid"`
+	Signature string `json:"sig"`
+}
+
+// Sign wraps the payload into an envelope signed with the given key
+func Sign(payloadType string, payload []byte, signer crypto.Signer) (Envelope, error) {
+	keyID, err := KeyID(signer.Public())
+	if err != nil {
+		return Envelope{}, err
+	}
+	signature, err := signMessage(preAuthenticationEncoding(payloadType, payload), signer)
+	if err != nil {
+		return Envelope{}, errors.Wrap(err, "failed to sign payload")
+	}
+
+	return Envelope{
+		PayloadType: payloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []Signature{{KeyID: keyID, Signature: base64.StdEncoding.EncodeToString(signature)}},
+	}, nil
+}
+
+// SignStatement signs the JSON encoding of the in-toto statement
+func SignStatement(statement Statement, signer crypto.Signer) (Envelope, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return Envelope{}, errors.Wrap(err, "failed to marshal statement")
+	}
+	return Sign(PayloadTypeInToto, payload, signer)
+}
+
+// Verify checks that the envelope carries a valid signature of the given key and returns the decoded payload
+func (e Envelope) Verify(key crypto.PublicKey) ([]byte, error) {
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode payload")
+	}
+	message := preAuthenticationEncoding(e.PayloadType, payload)
+
+	for _, signature := range e.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(signature.Signature)
+		if err != nil {
+			continue
+		}
+		valid, err := verifyMessage(message, sig, key)
+		if err != nil {
+			return nil, err
+		}
+		if valid {
+			return payload, nil
+		}
+	}
+	return nil, errors.New("no valid signature found")
+}
+
+// signMessage signs the message with the given key.
+// ECDSA and RSA (PKCS#1 v1.5) keys sign the SHA-256 hash of the message, Ed25519 keys the message itself.
+func signMessage(message []byte, signer crypto.Signer) ([]byte, error) {
+	if _, ok := signer.(ed25519.PrivateKey); ok {
+		return signer.Sign(rand.Reader, message, crypto.Hash(0))
+	}
+	hash := sha256.Sum256(message)
+	return signer.Sign(rand.Reader, hash[:], crypto.SHA256)
+}
+
+// verifyMessage checks the signature created by signMessage
+func verifyMessage(message, signature []byte, key crypto.PublicKey) (bool, error) {
+	hash := sha256.Sum256(message)
+	switch publicKey := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(publicKey, hash[:], signature), nil
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash[:], signature) == nil, nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(publicKey, message, signature), nil
+	}
+	return false, fmt.Errorf("unsupported public key type %T", key)
+}
+
+// preAuthenticationEncoding returns the message which is signed, see https://github.com/secure-systems-lab/dsse/blob/master/protocol.md
+func preAuthenticationEncoding(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
@@ -0,0 +1,127 @@
+//go:build unit
+// +build unit
+
+package attestation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreAuthenticationEncoding(t *testing.T) {
+	// example of the DSSE specification
+	assert.Equal(t, "DSSEv1 29 http://example.com/HelloWorld 11 hello world", string(preAuthenticationEncoding("http://example.com/HelloWorld", []byte("hello world"))))
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	ecdsaKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	_, ed25519Key, _ := ed25519.GenerateKey(rand.Reader)
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	for name, key := range map[string]crypto.Signer{"ecdsa": ecdsaKey, "rsa": rsaKey, "ed25519": ed25519Key} {
+		t.Run(name, func(t *testing.T) {
+			signer, err := LoadPrivateKey(pemPrivateKey(t, key))
+			assert.NoError(t, err)
+
+			envelope, err := Sign(PayloadTypeInToto, []byte(`{"_type":"https://in-toto.io/Statement/v1"}`), signer)
+
+			assert.NoError(t, err)
+			assert.Equal(t, PayloadTypeInToto, envelope.PayloadType)
+			assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(`{"_type":"https://in-toto.io/Statement/v1"}`)), envelope.Payload)
+			keyID, _ := KeyID(key.Public())
+			if assert.Len(t, envelope.Signatures, 1) {
+				assert.Equal(t, keyID, envelope.Signatures[0].KeyID)
+			}
+
+			payload, err := envelope.Verify(key.Public())
+			assert.NoError(t, err)
+			assert.Equal(t, `{"_type":"https://in-toto.io/Statement/v1"}`, string(payload))
+
+			_, err = envelope.Verify(otherKey.Public())
+			assert.EqualError(t, err, "no valid signature found")
+
+			envelope.PayloadType = "application/json"
+			_, err = envelope.Verify(key.Public())
+			assert.EqualError(t, err, "no valid signature found")
+		})
+	}
+
+	t.Run("statement", func(t *testing.T) {
+		statement := NewProvenanceStatement([]Subject{FileSubject("app.jar", []byte("content"))}, BuildInfo{})
+
+		envelope, err := SignStatement(statement, ecdsaKey)
+
+		assert.NoError(t, err)
+		payload, err := envelope.Verify(ecdsaKey.Public())
+		assert.NoError(t, err)
+		decoded := Statement{}
+		assert.NoError(t, json.Unmarshal(payload, &decoded))
+		assert.Equal(t, PredicateTypeSLSAProvenance, decoded.PredicateType)
+		assert.Equal(t, statement.Subject, decoded.Subject)
+	})
+}
+
+func TestLoadPrivateKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SEC 1 and PKCS#1 keys", func(t *testing.T) {
+		ecdsaKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		der, _ := x509.MarshalECPrivateKey(ecdsaKey)
+		signer, err := LoadPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+		assert.NoError(t, err)
+		assert.Equal(t, ecdsaKey, signer)
+
+		rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+		signer, err = LoadPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}))
+		assert.NoError(t, err)
+		assert.Equal(t, rsaKey, signer)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := LoadPrivateKey([]byte("no key"))
+		assert.EqualError(t, err, "no PEM encoded private key found")
+
+		_, err = LoadPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("cert")}))
+		assert.EqualError(t, err, "unsupported PEM block type 'CERTIFICATE'")
+
+		_, err = LoadPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Headers: map[string]string{"Proc-Type": "4,ENCRYPTED", "DEK-Info": "AES-256-CBC,00"}, Bytes: []byte("key")}))
+		assert.EqualError(t, err, "encrypted private keys are not supported")
+
+		_, err = LoadPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")}))
+		assert.ErrorContains(t, err, "failed to parse private key")
+	})
+}
+
+func TestLoadPublicKey(t *testing.T) {
+	t.Parallel()
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, _ := x509.MarshalPKIXPublicKey(key.Public())
+
+	publicKey, err := LoadPublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	assert.NoError(t, err)
+	assert.Equal(t, key.Public(), publicKey)
+
+	_, err = LoadPublicKey(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+	assert.EqualError(t, err, "unsupported PEM block type 'EC PRIVATE KEY'")
+}
+
+func pemPrivateKey(t *testing.T, key interface{}) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
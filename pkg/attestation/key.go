@@ -0,0 +1,82 @@
+package attestation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// LoadPrivateKey parses a PEM encoded ECDSA, RSA or Ed25519 private key (PKCS#8, SEC 1 or PKCS#1)
+func LoadPrivateKey(content []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key found")
+	}
+	if _, encrypted := block.Headers["DEK-Info"]; encrypted {
+		return nil, errors.New("encrypted private keys are not supported")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type '%v'", block.Type)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse private key")
+	}
+
+	switch signer := key.(type) {
+	case *ecdsa.PrivateKey:
+		return signer, nil
+	case *rsa.PrivateKey:
+		return signer, nil
+	case ed25519.PrivateKey:
+		return signer, nil
+	}
+	return nil, fmt.Errorf("unsupported private key type %T", key)
+}
+
+// LoadPublicKey parses a PEM encoded ECDSA, RSA or Ed25519 public key (PKIX)
+func LoadPublicKey(content []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.New("no PEM encoded public key found")
+	}
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("unsupported PEM block type '%v'", block.Type)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse public key")
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported public key type %T", key)
+}
+
+// KeyID identifies a public key by the SHA-256 hash of its DER encoding
+func KeyID(key crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal public key")
+	}
+	hash := sha256.Sum256(der)
+	return hex.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,172 @@
+package attestation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// StatementType is the type of in-toto statements, see https://github.com/in-toto/attestation/blob/main/spec/v1/statement.md
+	StatementType = "https://in-toto.io/Statement/v1"
+	// PredicateTypeSLSAProvenance is the predicate type of SLSA provenance, see https://slsa.dev/spec/v1.0/provenance
+	PredicateTypeSLSAProvenance = "https://slsa.dev/provenance/v1"
+	// BuildTypePiper describes builds executed with Project Piper
+	BuildTypePiper = "https://www.project-piper.io/attestation/build-types/piper/v1"
+)
+
+// Statement is an in-toto statement about a set of subjects
+type Statement struct {
+	Type          string      `json:"_type"`
+	Subject       []Subject   `json:"subject"`
+	PredicateType string      `json:"predicateType"`
+	Predicate     interface{} `json:"predicate"`
+}
+
+// Subject is an artifact the statement applies to
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Provenance is the SLSA provenance predicate
+type Provenance struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+// BuildDefinition describes the inputs of the build
+type BuildDefinition struct {
+	BuildType            string                 `json:"buildType"`
+	ExternalParameters   map[string]interface{} `json:"externalParameters"`
+	InternalParameters   map[string]interface{} `json:"internalParameters,omitempty"`
+	ResolvedDependencies []ResourceDescriptor   `json:"resolvedDependencies,omitempty"`
+}
+
+// ResourceDescriptor describes a resource used by the build
+type ResourceDescriptor struct {
+	URI    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest,omitempty"`
+	Name   string            `json:"name,omitempty"`
+}
+
+// RunDetails describes the execution of the build
+type RunDetails struct {
+	Builder  Builder       `json:"builder"`
+	Metadata BuildMetadata `json:"metadata"`
+}
+
+// Builder identifies the build platform
+type Builder struct {
+	ID string `json:"id"`
+}
+
+// BuildMetadata contains details about the build run
+type BuildMetadata struct {
+	InvocationID string     `json:"invocationId,omitempty"`
+	StartedOn    *time.Time `json:"startedOn,omitempty"`
+	FinishedOn   *time.Time `json:"finishedOn,omitempty"`
+}
+
+// BuildInfo contains the information about the build which is recorded in the provenance, typically taken from the orchestrator
+type BuildInfo struct {
+	RepositoryURL string
+	CommitSHA     string
+	Reference     string
+	Orchestrator  string
+	JobURL        string
+	BuildURL      string
+	StartedOn     time.Time
+	FinishedOn    time.Time
+	// Parameters are additional external parameters of the build
+	Parameters map[string]interface{}
+}
+
+// NewProvenanceStatement creates an in-toto statement with SLSA provenance for the given subjects
+func NewProvenanceStatement(subjects []Subject, build BuildInfo) Statement {
+	externalParameters := map[string]interface{}{}
+	for key, value := range build.Parameters {
+		externalParameters[key] = value
+	}
+	if len(build.RepositoryURL) > 0 {
+		externalParameters["repository"] = build.RepositoryURL
+	}
+	if len(build.Reference) > 0 {
+		externalParameters["ref"] = build.Reference
+	}
+
+	provenance := Provenance{
+		BuildDefinition: BuildDefinition{
+			BuildType:          BuildTypePiper,
+			ExternalParameters: externalParameters,
+		},
+		RunDetails: RunDetails{
+			Builder:  Builder{ID: builderID(build)},
+			Metadata: BuildMetadata{InvocationID: build.BuildURL},
+		},
+	}
+	if len(build.Orchestrator) > 0 {
+		provenance.BuildDefinition.InternalParameters = map[string]interface{}{"orchestrator": build.Orchestrator}
+	}
+	if len(build.RepositoryURL) > 0 {
+		source := ResourceDescriptor{URI: gitURI(build.RepositoryURL, build.Reference)}
+		if len(build.CommitSHA) > 0 {
+			source.Digest = map[string]string{"gitCommit": build.CommitSHA}
+		}
+		provenance.BuildDefinition.ResolvedDependencies = []ResourceDescriptor{source}
+	}
+	if !build.StartedOn.IsZero() {
+		startedOn := build.StartedOn.UTC()
+		provenance.RunDetails.Metadata.StartedOn = &startedOn
+	}
+	if !build.FinishedOn.IsZero() {
+		finishedOn := build.FinishedOn.UTC()
+		provenance.RunDetails.Metadata.FinishedOn = &finishedOn
+	}
+
+	return Statement{
+		Type:          StatementType,
+		Subject:       subjects,
+		PredicateType: PredicateTypeSLSAProvenance,
+		Predicate:     provenance,
+	}
+}
+
+// FileSubject creates a subject for a file using the SHA-256 hash of its content
+func FileSubject(name string, content []byte) Subject {
+	hash := sha256.Sum256(content)
+	return Subject{Name: name, Digest: map[string]string{"sha256": hex.EncodeToString(hash[:])}}
+}
+
+// ImageSubject creates a subject for a container image using its digest in the format `<algorithm>:<hex>`
+func ImageSubject(image, digest string) (Subject, error) {
+	algorithm, value, found := strings.Cut(digest, ":")
+	if !found || len(algorithm) == 0 || len(value) == 0 {
+		return Subject{}, fmt.Errorf("invalid digest '%v' of image %v", digest, image)
+	}
+	return Subject{Name: image, Digest: map[string]string{algorithm: value}}, nil
+}
+
+func builderID(build BuildInfo) string {
+	if len(build.JobURL) > 0 {
+		return build.JobURL
+	}
+	if len(build.Orchestrator) > 0 {
+		return fmt.Sprintf("https://www.project-piper.io/attestation/builders/%v", strings.ToLower(build.Orchestrator))
+	}
+	return "https://www.project-piper.io/attestation/builders/unknown"
+}
+
+// gitURI returns the repository URI in SPDX download location format as recommended by SLSA, e.g. git+https://github.com/org/repo@refs/heads/main
+func gitURI(repositoryURL, reference string) string {
+	uri := repositoryURL
+	if !strings.HasPrefix(uri, "git+") {
+		uri = "git+" + uri
+	}
+	if len(reference) > 0 {
+		uri = fmt.Sprintf("%v@%v", uri, reference)
+	}
+	return uri
+}
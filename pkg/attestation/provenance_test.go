@@ -0,0 +1,73 @@
+//go:build unit
+// +build unit
+
+package attestation
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProvenanceStatement(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with orchestrator information", func(t *testing.T) {
+		subjects := []Subject{FileSubject("target/app.jar", []byte("hello world"))}
+		build := BuildInfo{
+			RepositoryURL: "https://github.com/SAP/jenkins-library",
+			CommitSHA:     "0123456789abcdef",
+			Reference:     "refs/heads/main",
+			Orchestrator:  "GitHubActions",
+			JobURL:        "https://github.com/SAP/jenkins-library/actions/workflows/build.yml",
+			BuildURL:      "https://github.com/SAP/jenkins-library/actions/runs/42",
+			StartedOn:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Parameters:    map[string]interface{}{"buildTool": "maven"},
+		}
+
+		statement := NewProvenanceStatement(subjects, build)
+
+		content, err := json.Marshal(statement)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"_type": "https://in-toto.io/Statement/v1",
+			"subject": [{"name": "target/app.jar", "digest": {"sha256": "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"}}],
+			"predicateType": "https://slsa.dev/provenance/v1",
+			"predicate": {
+				"buildDefinition": {
+					"buildType": "https://www.project-piper.io/attestation/build-types/piper/v1",
+					"externalParameters": {"buildTool": "maven", "repository": "https://github.com/SAP/jenkins-library", "ref": "refs/heads/main"},
+					"internalParameters": {"orchestrator": "GitHubActions"},
+					"resolvedDependencies": [{"uri": "git+https://github.com/SAP/jenkins-library@refs/heads/main", "digest": {"gitCommit": "0123456789abcdef"}}]
+				},
+				"runDetails": {
+					"builder": {"id": "https://github.com/SAP/jenkins-library/actions/workflows/build.yml"},
+					"metadata": {"invocationId": "https://github.com/SAP/jenkins-library/actions/runs/42", "startedOn": "2024-01-02T03:04:05Z"}
+				}
+			}
+		}`, string(content))
+	})
+
+	t.Run("without orchestrator information", func(t *testing.T) {
+		statement := NewProvenanceStatement([]Subject{}, BuildInfo{})
+
+		provenance := statement.Predicate.(Provenance)
+		assert.Equal(t, "https://www.project-piper.io/attestation/builders/unknown", provenance.RunDetails.Builder.ID)
+		assert.Empty(t, provenance.BuildDefinition.ResolvedDependencies)
+		assert.Empty(t, provenance.BuildDefinition.ExternalParameters)
+		assert.Nil(t, provenance.RunDetails.Metadata.StartedOn)
+	})
+}
+
+func TestImageSubject(t *testing.T) {
+	t.Parallel()
+
+	subject, err := ImageSubject("my.registry/app", "sha256:abc")
+	assert.NoError(t, err)
+	assert.Equal(t, Subject{Name: "my.registry/app", Digest: map[string]string{"sha256": "abc"}}, subject)
+
+	_, err = ImageSubject("my.registry/app", "abc")
+	assert.EqualError(t, err, "invalid digest 'abc' of image my.registry/app")
+}
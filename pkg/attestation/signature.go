@@ -0,0 +1,102 @@
+package attestation
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+)
+
+// SignatureTypeCosign is the type of container image signatures created by cosign
+const SignatureTypeCosign = "cosign container image signature"
+
+// SimpleSigning is the payload of a container image signature, see https://github.com/containers/image/blob/main/docs/containers-signature.5.md
+type SimpleSigning struct {
+	Critical SimpleSigningCritical  `json:"critical"`
+	Optional map[string]interface{} `json:"optional"`
+}
+
+// SimpleSigningCritical contains the signed information about the image
+type SimpleSigningCritical struct {
+	Identity SimpleSigningIdentity `json:"identity"`
+	Image    SimpleSigningImage    `json:"image"`
+	Type     string                `json:"type"`
+}
+
+// SimpleSigningIdentity identifies the repository of the image
+type SimpleSigningIdentity struct {
+	DockerReference string `json:"docker-reference"`
+}
+
+// SimpleSigningImage identifies the image by its manifest digest
+type SimpleSigningImage struct {
+	DockerManifestDigest string `json:"docker-manifest-digest"`
+}
+
+// SignImage creates the payload of a cosign compatible signature for the image with the given repository and digest
+// and returns it together with the base64 encoded signature of the payload.
+func SignImage(repository, digest string, annotations map[string]interface{}, signer crypto.Signer) ([]byte, string, error) {
+	payload, err := json.Marshal(SimpleSigning{
+		Critical: SimpleSigningCritical{
+			Identity: SimpleSigningIdentity{DockerReference: repository},
+			Image:    SimpleSigningImage{DockerManifestDigest: digest},
+			Type:     SignatureTypeCosign,
+		},
+		Optional: annotations,
+	})
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to marshal signature payload")
+	}
+	signature, err := signMessage(payload, signer)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to sign image %v@%v", repository, digest)
+	}
+	return payload, base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// VerifyImageSignature checks that the signature of the payload has been created with the given key
+// and that the payload refers to the image with the given repository and digest.
+func VerifyImageSignature(payload []byte, signature string, repository, digest string, key crypto.PublicKey) (SimpleSigning, error) {
+	signed := SimpleSigning{}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return signed, errors.Wrap(err, "failed to decode signature")
+	}
+	valid, err := verifyMessage(payload, sig, key)
+	if err != nil {
+		return signed, err
+	}
+	if !valid {
+		return signed, errors.New("invalid signature")
+	}
+	if err := json.Unmarshal(payload, &signed); err != nil {
+		return signed, errors.Wrap(err, "failed to parse signature payload")
+	}
+	if signed.Critical.Type != SignatureTypeCosign {
+		return signed, fmt.Errorf("unsupported signature type '%v'", signed.Critical.Type)
+	}
+	if signed.Critical.Image.DockerManifestDigest != digest {
+		return signed, fmt.Errorf("signature refers to image digest %v instead of %v", signed.Critical.Image.DockerManifestDigest, digest)
+	}
+	// a signature copied from an image of another repository with the same digest is not valid for this repository
+	if !sameRepository(signed.Critical.Identity.DockerReference, repository) {
+		return signed, fmt.Errorf("signature refers to repository %v instead of %v", signed.Critical.Identity.DockerReference, repository)
+	}
+	return signed, nil
+}
+
+// sameRepository compares the repositories of both references in their normalized form, e.g. index.docker.io/library/alpine for alpine
+func sameRepository(reference, repository string) bool {
+	signedRef, err := name.ParseReference(reference)
+	if err != nil {
+		return false
+	}
+	expected, err := name.NewRepository(repository)
+	if err != nil {
+		return false
+	}
+	return signedRef.Context().Name() == expected.Name()
+}
@@ -0,0 +1,71 @@
+//go:build unit
+// +build unit
+
+package attestation
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignImage(t *testing.T) {
+	t.Parallel()
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	payload, signature, err := SignImage("my.registry/app", "sha256:abc", map[string]interface{}{"commit": "0123456789abcdef"}, key)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"critical": {
+			"identity": {"docker-reference": "my.registry/app"},
+			"image": {"docker-manifest-digest": "sha256:abc"},
+			"type": "cosign container image signature"
+		},
+		"optional": {"commit": "0123456789abcdef"}
+	}`, string(payload))
+
+	t.Run("valid signature", func(t *testing.T) {
+		signed, err := VerifyImageSignature(payload, signature, "my.registry/app", "sha256:abc", key.Public())
+		assert.NoError(t, err)
+		assert.Equal(t, "my.registry/app", signed.Critical.Identity.DockerReference)
+	})
+
+	t.Run("other key", func(t *testing.T) {
+		_, err := VerifyImageSignature(payload, signature, "my.registry/app", "sha256:abc", otherKey.Public())
+		assert.EqualError(t, err, "invalid signature")
+	})
+
+	t.Run("other image", func(t *testing.T) {
+		_, err := VerifyImageSignature(payload, signature, "my.registry/app", "sha256:def", key.Public())
+		assert.EqualError(t, err, "signature refers to image digest sha256:abc instead of sha256:def")
+	})
+
+	t.Run("other repository", func(t *testing.T) {
+		_, err := VerifyImageSignature(payload, signature, "my.registry/other", "sha256:abc", key.Public())
+		assert.EqualError(t, err, "signature refers to repository my.registry/app instead of my.registry/other")
+	})
+
+	t.Run("normalized repository", func(t *testing.T) {
+		payload, signature, err := SignImage("alpine", "sha256:abc", nil, key)
+		assert.NoError(t, err)
+		_, err = VerifyImageSignature(payload, signature, "index.docker.io/library/alpine", "sha256:abc", key.Public())
+		assert.NoError(t, err)
+	})
+
+	t.Run("manipulated payload", func(t *testing.T) {
+		_, err := VerifyImageSignature(append(payload, ' '), signature, "my.registry/app", "sha256:abc", key.Public())
+		assert.EqualError(t, err, "invalid signature")
+	})
+
+	t.Run("invalid signature encoding", func(t *testing.T) {
+		_, err := VerifyImageSignature(payload, "%", "my.registry/app", "sha256:abc", key.Public())
+		assert.ErrorContains(t, err, "failed to decode signature")
+	})
+}
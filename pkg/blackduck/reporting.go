@@ -16,6 +16,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+// SarifToolName is the name of the tool in the SARIF file
+const SarifToolName = "Black Duck"
+
 var severityIndex = map[string]int{"LOW": 1, "MEDIUM": 2, "HIGH": 3, "CRITICAL": 4}
 
 // CreateSarifResultFile creates a SARIF result from the Vulnerabilities that were brought up by the scan
@@ -158,7 +161,7 @@ func CreateSarifResultFile(vulns *Vulnerabilities, projectName, projectVersion,
 	//handle the tool object
 	tool := format.Tool{
 		Driver: format.Driver{
-			Name:           "Black Duck",
+			Name:           SarifToolName,
 			Version:        "unknown",
 			InformationUri: "https://documentation.blackduck.com/bundle/detect/page/introduction.html",
 			Rules:          rules,
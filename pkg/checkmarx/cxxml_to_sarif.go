@@ -15,6 +15,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+// sarifToolName is the name of the tool in the SARIF file
+const sarifToolName = "Checkmarx SCA"
+
 // CxXMLResults : This struct encapsulates everyting in the Cx XML document
 type CxXMLResults struct {
 	XMLName                  xml.Name     `xml:"CxXMLResults"`
@@ -369,7 +372,7 @@ func Parse(sys System, data []byte, scanID int) (format.SARIF, error) {
 	log.Entry().Debug("[SARIF] Now handling driver object.")
 	tool := *new(format.Tool)
 	tool.Driver = *new(format.Driver)
-	tool.Driver.Name = "Checkmarx SCA"
+	tool.Driver.Name = sarifToolName
 	versionData := strings.Split(cxxml.CheckmarxVersion, "V ")
 	if len(versionData) > 1 { // Safety check
 		tool.Driver.Version = strings.Split(cxxml.CheckmarxVersion, "V ")[1]
@@ -133,6 +133,13 @@ func CreateCustomReport(data map[string]interface{}, insecure, neutral []string)
 		detailTable.Rows = append(detailTable.Rows, row)
 	}
 	scanReport.DetailTable = detailTable
+	scanReport.Findings = map[string]int{
+		format.SeverityHigh:   data["High"].(map[string]int)["NotFalsePositive"],
+		format.SeverityMedium: data["Medium"].(map[string]int)["NotFalsePositive"],
+		format.SeverityLow:    data["Low"].(map[string]int)["NotFalsePositive"],
+		format.SeverityInfo:   data["Information"].(map[string]int)["NotFalsePositive"],
+	}
+	scanReport.SarifTool = sarifToolName
 
 	return scanReport
 }
@@ -294,6 +294,8 @@ func TestCreateCustomReport(t *testing.T) {
 	assert.Equal(t, "Checkmarx SAST Report", reportingData.ReportTitle)
 	assert.Equal(t, 15, len(reportingData.Subheaders))
 	assert.Equal(t, 2, len(reportingData.Overview))
+	assert.Equal(t, map[string]int{"high": 10, "medium": 0, "low": 2, "info": 5}, reportingData.Findings)
+	assert.Equal(t, "Checkmarx SCA", reportingData.SarifTool)
 
 	subheaders := make(map[string]string)
 	for _, subheader := range reportingData.Subheaders {
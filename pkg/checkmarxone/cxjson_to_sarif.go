@@ -10,6 +10,9 @@ import (
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 )
 
+// sarifToolName is the name of the tool in the SARIF file
+const sarifToolName = "Checkmarx One"
+
 // ConvertCxJSONToSarif is the entrypoint for the Parse function
 func ConvertCxJSONToSarif(sys System, serverURL string, scanResults *[]ScanResult, scanMeta *ScanMetadata, scan *Scan) (format.SARIF, error) {
 	// Process sarif
@@ -252,7 +255,7 @@ func ConvertCxJSONToSarif(sys System, serverURL string, scanResults *[]ScanResul
 	log.Entry().Debug("[SARIF] Now handling driver object.")
 	tool := *new(format.Tool)
 	tool.Driver = *new(format.Driver)
-	tool.Driver.Name = "Checkmarx One"
+	tool.Driver.Name = sarifToolName
 
 	// TODO: a way to fetch/store the version
 	tool.Driver.Version = "1" //strings.Split(cxxml.CheckmarxVersion, "V ")
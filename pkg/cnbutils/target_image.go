@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/SAP/jenkins-library/pkg/piperenv"
+	"github.com/SAP/jenkins-library/pkg/versioning"
 	"github.com/pkg/errors"
 )
 
@@ -23,7 +24,7 @@ func GetTargetImage(imageRegistry, imageName, imageTag, projectID, envRootPath s
 	}
 
 	targetImage := &TargetImage{
-		ContainerImageTag: strings.ReplaceAll(imageTag, "+", "-"),
+		ContainerImageTag: versioning.ContainerImageTag(imageTag),
 	}
 
 	if matched, _ := regexp.MatchString("^(http|https)://.*", imageRegistry); !matched {
@@ -34,12 +34,16 @@ type Config struct {
 	openFile                 func(s string, t map[string]string) (io.ReadCloser, error)
 	vaultCredentials         VaultCredentials
 	systemTrustConfiguration systemtrust.Configuration
+	trackProvenance          bool
+	defaultNames             []string
 }
 
 // StepConfig defines the structure for merged step configuration
 type StepConfig struct {
 	Config     map[string]interface{}
 	HookConfig map[string]interface{}
+	// Provenance contains the origin of each configuration value, only available if enabled via Config.EnableProvenance
+	Provenance map[string]*ParameterProvenance
 }
 
 // ReadConfig loads config and returns its content
@@ -165,6 +169,12 @@ func (c *Config) InitializeConfig(configuration io.ReadCloser, defaults []io.Rea
 			if err != nil {
 				return errors.Wrapf(err, "getting default '%v' failed", f)
 			}
+			if c.trackProvenance {
+				for len(c.defaultNames) < len(defaults) {
+					c.defaultNames = append(c.defaultNames, "")
+				}
+				c.defaultNames = append(c.defaultNames, f)
+			}
 			defaults = append(defaults, fc)
 		}
 	}
@@ -192,21 +202,31 @@ func (c *Config) GetStepConfig(flagValues map[string]interface{}, paramJSON stri
 		}
 	}
 
+	if c.trackProvenance {
+		stepConfig.Provenance = map[string]*ParameterProvenance{}
+	}
+
+	configKeys := c.sectionKeys(stageName, stepName)
 	c.ApplyAliasConfig(parameters, secrets, filters, stageName, stepName, stepAliases)
 
 	// initialize with defaults from step.yaml
+	snapshot := stepConfig.snapshot()
 	stepConfig.mixInStepDefaults(parameters)
+	stepConfig.recordChanges(sourceStepDefault, snapshot)
 
 	// merge parameters provided by Piper environment
-	stepConfig.mixIn(envParameters, filters.All, metadata)
-	stepConfig.mixIn(envParameters, ReportingParameters.getReportingFilter(), metadata)
+	stepConfig.mixInFrom(sourcePipelineEnvironment, envParameters, filters.All, metadata, nil)
+	stepConfig.mixInFrom(sourcePipelineEnvironment, envParameters, ReportingParameters.getReportingFilter(), metadata, nil)
 
 	// read defaults & merge general -> steps (-> general -> steps ...)
-	for _, def := range c.defaults.Defaults {
+	for i, def := range c.defaults.Defaults {
+		source := fmt.Sprintf("defaults[%v]", c.defaultName(i))
+		defKeys := def.sectionKeys(stageName, stepName)
 		def.ApplyAliasConfig(parameters, secrets, filters, stageName, stepName, stepAliases)
-		stepConfig.mixIn(def.General, filters.General, metadata)
-		stepConfig.mixIn(def.Steps[stepName], filters.Steps, metadata)
-		stepConfig.mixIn(def.Stages[stageName], filters.Steps, metadata)
+		stepConfig.mixInFrom(source+".general", def.General, filters.General, metadata, usedAliases(def.General, defKeys.general, parameters, secrets))
+		stepConfig.mixInFrom(source+".steps."+stepName, def.Steps[stepName], filters.Steps, metadata, usedAliases(def.Steps[stepName], defKeys.steps, parameters, secrets))
+		stepConfig.mixInFrom(source+".stages."+stageName, def.Stages[stageName], filters.Steps, metadata, usedAliases(def.Stages[stageName], defKeys.stages, parameters, secrets))
+		snapshot = stepConfig.snapshot()
 		stepConfig.mixinVaultConfig(parameters, def.General, def.Steps[stepName], def.Stages[stageName])
 		reportingConfig, err := cloneConfig(&def)
 		if err != nil {
@@ -214,17 +234,20 @@ func (c *Config) GetStepConfig(flagValues map[string]interface{}, paramJSON stri
 		}
 		reportingConfig.ApplyAliasConfig(ReportingParameters.Parameters, []StepSecrets{}, ReportingParameters.getStepFilters(), stageName, stepName, []Alias{})
 		stepConfig.mixinReportingConfig(reportingConfig.General, reportingConfig.Steps[stepName], reportingConfig.Stages[stageName])
+		stepConfig.recordChanges(source, snapshot)
 
 		stepConfig.mixInHookConfig(def.Hooks, metadata)
 	}
 
 	// read config & merge - general -> steps -> stages
-	stepConfig.mixIn(c.General, filters.General, metadata)
-	stepConfig.mixIn(c.Steps[stepName], filters.Steps, metadata)
-	stepConfig.mixIn(c.Stages[stageName], filters.Stages, metadata)
+	stepConfig.mixInFrom("config.general", c.General, filters.General, metadata, usedAliases(c.General, configKeys.general, parameters, secrets))
+	stepConfig.mixInFrom("config.steps."+stepName, c.Steps[stepName], filters.Steps, metadata, usedAliases(c.Steps[stepName], configKeys.steps, parameters, secrets))
+	stepConfig.mixInFrom("config.stages."+stageName, c.Stages[stageName], filters.Stages, metadata, usedAliases(c.Stages[stageName], configKeys.stages, parameters, secrets))
 
 	// merge parameters provided via env vars
-	stepConfig.mixIn(envValues(filters.All), filters.All, metadata)
+	for key, value := range envValues(filters.All) {
+		stepConfig.mixInFrom("env.PIPER_"+key, map[string]interface{}{key: value}, filters.All, metadata, nil)
+	}
 
 	vaultParams := map[string]interface{}{}
 
@@ -250,13 +273,13 @@ func (c *Config) GetStepConfig(flagValues map[string]interface{}, paramJSON stri
 				}
 			}
 
-			stepConfig.mixIn(params, filters.Parameters, metadata)
+			stepConfig.mixInFrom(sourceParametersJSON, params, filters.Parameters, metadata, nil)
 		}
 	}
 
 	// merge command line flags
 	if flagValues != nil {
-		stepConfig.mixIn(flagValues, filters.Parameters, metadata)
+		stepConfig.mixInFrom(sourceFlag, flagValues, filters.Parameters, metadata, nil)
 		// retrieve Vault config from flags if provided
 		for _, v := range vaultFilter {
 			if flagValues[v] != nil {
@@ -271,6 +294,7 @@ func (c *Config) GetStepConfig(flagValues map[string]interface{}, paramJSON stri
 		log.Entry().Warnf("invalid value for parameter verbose: '%v'", stepConfig.Config["verbose"])
 	}
 
+	snapshot = stepConfig.snapshot()
 	stepConfig.mixinVaultConfig(parameters, c.General, c.Steps[stepName], c.Stages[stageName], vaultParams)
 
 	reportingConfig, err := cloneConfig(c)
@@ -279,6 +303,17 @@ func (c *Config) GetStepConfig(flagValues map[string]interface{}, paramJSON stri
 	}
 	reportingConfig.ApplyAliasConfig(ReportingParameters.Parameters, []StepSecrets{}, ReportingParameters.getStepFilters(), stageName, stepName, []Alias{})
 	stepConfig.mixinReportingConfig(reportingConfig.General, reportingConfig.Steps[stepName], reportingConfig.Stages[stageName])
+	stepConfig.recordChanges("config", snapshot)
+
+	secretProvider, err := GetSecretProviderFromConfig(stepConfig.Config)
+	if err != nil {
+		return StepConfig{}, err
+	}
+	if secretProvider != nil {
+		snapshot = stepConfig.snapshot()
+		resolveAllSecretReferences(&stepConfig, secretProvider, append(parameters, ReportingParameters.Parameters...))
+		stepConfig.recordChanges(sourceSecretProvider, snapshot)
+	}
 
 	// check whether vault should be skipped
 	if skip, ok := stepConfig.Config["skipVault"].(bool); !ok || !skip {
@@ -288,9 +323,12 @@ func (c *Config) GetStepConfig(flagValues map[string]interface{}, paramJSON stri
 			return StepConfig{}, err
 		}
 		if vaultClient != nil {
+			snapshot = stepConfig.snapshot()
 			resolveAllVaultReferences(&stepConfig, vaultClient, append(parameters, ReportingParameters.Parameters...))
+			resolveVaultDynamicSecrets(&stepConfig, vaultClient, parameters)
 			resolveVaultTestCredentialsWrapper(&stepConfig, vaultClient)
 			resolveVaultCredentialsWrapper(&stepConfig, vaultClient)
+			stepConfig.recordChanges(sourceVault, snapshot)
 		}
 	}
 
@@ -300,10 +338,13 @@ func (c *Config) GetStepConfig(flagValues map[string]interface{}, paramJSON stri
 		log.Entry().WithError(err).Debug("System Trust lookup skipped due to missing or incorrect configuration")
 	} else {
 		systemTrustClient := systemtrust.PrepareClient(&piperhttp.Client{}, c.systemTrustConfiguration)
+		snapshot = stepConfig.snapshot()
 		resolveAllSystemTrustReferences(&stepConfig, append(parameters, ReportingParameters.Parameters...), c.systemTrustConfiguration, systemTrustClient)
+		stepConfig.recordChanges(sourceSystemTrust, snapshot)
 	}
 
 	// finally do the condition evaluation post processing
+	snapshot = stepConfig.snapshot()
 	for _, p := range parameters {
 		if len(p.Conditions) > 0 {
 			for _, cond := range p.Conditions {
@@ -323,6 +364,8 @@ func (c *Config) GetStepConfig(flagValues map[string]interface{}, paramJSON stri
 			}
 		}
 	}
+	stepConfig.recordChanges(sourceConditionalDefault, snapshot)
+	stepConfig.maskProvenance(append(parameters, ReportingParameters.Parameters...))
 	return stepConfig, nil
 }
 
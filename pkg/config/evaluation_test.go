@@ -234,6 +234,27 @@ func TestRunConfigV1EvaluateConditionsV1(t *testing.T) {
 	}
 }
 
+func TestRunConfigV1EvaluateConditionsV1Orchestrators(t *testing.T) {
+	t.Setenv("GITLAB_CI", "true")
+	for _, key := range []string{"AZURE_HTTP_USER_AGENT", "GITHUB_ACTION", "GITHUB_ACTIONS", "JENKINS_HOME", "JENKINS_URL"} {
+		t.Setenv(key, "")
+	}
+
+	config := Config{Stages: map[string]map[string]interface{}{"Test Stage 1": {}}}
+	r := &RunConfigV1{PipelineConfig: PipelineDefinitionV1{Spec: Spec{Stages: []Stage{{DisplayName: "Test Stage 1",
+		Steps: []Step{{
+			Name:          "step1",
+			Orchestrators: []string{"GitLab"},
+		}, {
+			Name:          "step2",
+			Orchestrators: []string{"Jenkins"},
+		}},
+	}}}}}
+
+	assert.NoError(t, r.evaluateConditionsV1(&config, &mock.FilesMock{}, ".pipeline"))
+	assert.Equal(t, map[string]map[string]bool{"Test Stage 1": {"step1": true}}, r.RunSteps)
+}
+
 func TestEvaluateV1(t *testing.T) {
 	tt := []struct {
 		name          string
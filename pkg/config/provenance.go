@@ -0,0 +1,164 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+const (
+	provenanceMask = "****"
+
+	sourceStepDefault         = "stepDefault"
+	sourceConditionalDefault  = "conditionalDefault"
+	sourcePipelineEnvironment = "commonPipelineEnvironment"
+	sourceParametersJSON      = "parametersJSON"
+	sourceFlag                = "flag"
+	sourceVault               = "vault"
+	sourceSecretProvider      = "secretProvider"
+	sourceSystemTrust         = "systemTrust"
+)
+
+// ValueSource describes a configuration source providing a value for a parameter
+type ValueSource struct {
+	Source string      `json:"source"`
+	Alias  string      `json:"alias,omitempty"`
+	Value  interface{} `json:"value"`
+}
+
+// ParameterProvenance contains the source of the effective value of a parameter as well as the candidates it has overridden
+type ParameterProvenance struct {
+	ValueSource
+	Overridden []ValueSource `json:"overridden,omitempty"`
+}
+
+// EnableProvenance activates tracking of the origin of each configuration value in GetStepConfig.
+// The optional names describe the passed defaults in the same order, custom defaults are named automatically.
+func (c *Config) EnableProvenance(defaultNames ...string) {
+	c.trackProvenance = true
+	c.defaultNames = defaultNames
+}
+
+func (c *Config) defaultName(index int) string {
+	if index < len(c.defaultNames) && len(c.defaultNames[index]) > 0 {
+		return c.defaultNames[index]
+	}
+	return fmt.Sprintf("#%d", index)
+}
+
+// recordSource stores the given values as effective values provided by source
+func (s *StepConfig) recordSource(source string, values map[string]interface{}, aliases map[string]string) {
+	if s.Provenance == nil {
+		return
+	}
+	for key, value := range values {
+		current := ValueSource{Source: source, Alias: aliases[key], Value: value}
+		if previous, ok := s.Provenance[key]; ok {
+			s.Provenance[key] = &ParameterProvenance{ValueSource: current, Overridden: append(previous.Overridden, previous.ValueSource)}
+			continue
+		}
+		s.Provenance[key] = &ParameterProvenance{ValueSource: current}
+	}
+}
+
+// mixInFrom merges the data like mixIn and records source as origin of the merged values
+func (s *StepConfig) mixInFrom(source string, mergeData map[string]interface{}, filter []string, metadata StepData, aliases map[string]string) {
+	s.recordSource(source, filterMap(mergeData, filter), aliases)
+	s.mixIn(mergeData, filter, metadata)
+}
+
+// snapshot returns a shallow copy of the configuration in case provenance is tracked
+func (s *StepConfig) snapshot() map[string]interface{} {
+	if s.Provenance == nil {
+		return nil
+	}
+	snapshot := make(map[string]interface{}, len(s.Config))
+	for key, value := range s.Config {
+		snapshot[key] = value
+	}
+	return snapshot
+}
+
+// recordChanges records source as origin of all values which differ from the snapshot
+func (s *StepConfig) recordChanges(source string, snapshot map[string]interface{}) {
+	if s.Provenance == nil {
+		return
+	}
+	changes := map[string]interface{}{}
+	for key, value := range s.Config {
+		if previous, ok := snapshot[key]; !ok || !reflect.DeepEqual(previous, value) {
+			changes[key] = value
+		}
+	}
+	s.recordSource(source, changes, nil)
+}
+
+// maskProvenance hides the values of secret parameters and of values retrieved from secret stores
+func (s *StepConfig) maskProvenance(parameters []StepParameters) {
+	secrets := map[string]bool{}
+	for _, param := range parameters {
+		if param.Secret {
+			secrets[param.Name] = true
+		}
+	}
+	for key, provenance := range s.Provenance {
+		if !secrets[key] && provenance.Source != sourceVault && provenance.Source != sourceSecretProvider && provenance.Source != sourceSystemTrust {
+			continue
+		}
+		provenance.Value = provenanceMask
+		for i := range provenance.Overridden {
+			provenance.Overridden[i].Value = provenanceMask
+		}
+	}
+}
+
+// usedAliases returns the alias each parameter of a configuration section has been taken from.
+// knownKeys contains the keys of the section before the aliases have been applied.
+func usedAliases(section map[string]interface{}, knownKeys map[string]bool, parameters []StepParameters, secrets []StepSecrets) map[string]string {
+	aliases := map[string]string{}
+	for key := range section {
+		if knownKeys[key] {
+			continue
+		}
+		var candidates []Alias
+		for _, param := range parameters {
+			if param.Name == key {
+				candidates = append(candidates, param.Aliases...)
+			}
+		}
+		for _, secret := range secrets {
+			if secret.Name == key {
+				candidates = append(candidates, secret.Aliases...)
+			}
+		}
+		for _, alias := range candidates {
+			if getDeepAliasValue(section, alias.Name) != nil {
+				aliases[key] = alias.Name
+				break
+			}
+		}
+	}
+	return aliases
+}
+
+// sectionKeys contains the keys of the configuration sections relevant for a step
+type sectionKeys struct {
+	general map[string]bool
+	steps   map[string]bool
+	stages  map[string]bool
+}
+
+func (c *Config) sectionKeys(stageName, stepName string) sectionKeys {
+	return sectionKeys{
+		general: keySet(c.General),
+		steps:   keySet(c.Steps[stepName]),
+		stages:  keySet(c.Stages[stageName]),
+	}
+}
+
+func keySet(section map[string]interface{}) map[string]bool {
+	keys := map[string]bool{}
+	for key := range section {
+		keys[key] = true
+	}
+	return keys
+}
@@ -0,0 +1,97 @@
+//go:build unit
+// +build unit
+
+package config
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStepConfigProvenance(t *testing.T) {
+	testConfig := `general:
+  p1: p1_general
+  oldName: p2_alias
+steps:
+  step1:
+    p1: p1_step
+    password: config_password
+stages:
+  stage1:
+    p3: p3_stage
+`
+	defaults1 := `general:
+  p1: p1_general_default
+  p3: p3_general_default
+`
+	defaults2 := `steps:
+  step1:
+    p3: p3_step_default
+`
+	filters := StepFilters{
+		All:        []string{"p0", "p1", "p2", "p3", "p4", "password"},
+		General:    []string{"p0", "p1", "p2", "p3", "password"},
+		Steps:      []string{"p0", "p1", "p2", "p3", "password"},
+		Stages:     []string{"p0", "p1", "p2", "p3", "password"},
+		Parameters: []string{"p0", "p1", "p2", "p3", "p4", "password"},
+	}
+	stepMeta := StepData{Spec: StepSpec{Inputs: StepInputs{Parameters: []StepParameters{
+		{Name: "p0", Default: "p0_metadata_default"},
+		{Name: "p1"},
+		{Name: "p2", Aliases: []Alias{{Name: "oldName"}}},
+		{Name: "p3"},
+		{Name: "p4"},
+		{Name: "password", Secret: true},
+	}}}}
+	defaults := []io.ReadCloser{io.NopCloser(strings.NewReader(defaults1)), io.NopCloser(strings.NewReader(defaults2))}
+
+	t.Run("provenance tracked", func(t *testing.T) {
+		os.Setenv("PIPER_p4", "p4_env")
+		defer os.Unsetenv("PIPER_p4")
+
+		var c Config
+		c.EnableProvenance("defaults1.yml")
+		stepConfig, err := c.GetStepConfig(map[string]interface{}{"p3": "p3_flag"}, `{"password":"json_password"}`, io.NopCloser(strings.NewReader(testConfig)), defaults, false, filters, stepMeta, nil, "stage1", "step1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "p3_flag", stepConfig.Config["p3"])
+		assert.Equal(t, "json_password", stepConfig.Config["password"])
+
+		assert.Equal(t, &ParameterProvenance{ValueSource: ValueSource{Source: "stepDefault", Value: "p0_metadata_default"}}, stepConfig.Provenance["p0"])
+		assert.Equal(t, &ParameterProvenance{
+			ValueSource: ValueSource{Source: "config.steps.step1", Value: "p1_step"},
+			Overridden: []ValueSource{
+				{Source: "defaults[defaults1.yml].general", Value: "p1_general_default"},
+				{Source: "config.general", Value: "p1_general"},
+			},
+		}, stepConfig.Provenance["p1"])
+		assert.Equal(t, &ParameterProvenance{ValueSource: ValueSource{Source: "config.general", Alias: "oldName", Value: "p2_alias"}}, stepConfig.Provenance["p2"])
+		assert.Equal(t, &ParameterProvenance{
+			ValueSource: ValueSource{Source: "flag", Value: "p3_flag"},
+			Overridden: []ValueSource{
+				{Source: "defaults[defaults1.yml].general", Value: "p3_general_default"},
+				{Source: "defaults[#1].steps.step1", Value: "p3_step_default"},
+				{Source: "config.stages.stage1", Value: "p3_stage"},
+			},
+		}, stepConfig.Provenance["p3"])
+		assert.Equal(t, &ParameterProvenance{ValueSource: ValueSource{Source: "env.PIPER_p4", Value: "p4_env"}}, stepConfig.Provenance["p4"])
+		assert.Equal(t, &ParameterProvenance{
+			ValueSource: ValueSource{Source: "parametersJSON", Value: "****"},
+			Overridden:  []ValueSource{{Source: "config.steps.step1", Value: "****"}},
+		}, stepConfig.Provenance["password"])
+	})
+
+	t.Run("provenance not tracked", func(t *testing.T) {
+		defaults := []io.ReadCloser{io.NopCloser(strings.NewReader(defaults1))}
+		var c Config
+		stepConfig, err := c.GetStepConfig(nil, "", io.NopCloser(strings.NewReader(testConfig)), defaults, false, filters, stepMeta, nil, "stage1", "step1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "p1_step", stepConfig.Config["p1"])
+		assert.Nil(t, stepConfig.Provenance)
+	})
+}
@@ -0,0 +1,408 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2019-09/schema"
+
+// JSONSchema contains the subset of JSON Schema used to describe the project configuration
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	PatternProperties    map[string]*JSONSchema `json:"patternProperties,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	Deprecated           bool                   `json:"deprecated,omitempty"`
+}
+
+// ConfigFinding describes a violation of the configuration schema
+type ConfigFinding struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Path     string `json:"path"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// Severities of configuration findings
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+func (f ConfigFinding) String() string {
+	return fmt.Sprintf("%v:%v:%v: %v: %v: %v", f.File, f.Line, f.Column, f.Severity, f.Path, f.Message)
+}
+
+// GenerateConfigSchema creates the JSON Schema of the project configuration (general, stages, steps) from the step metadata
+func GenerateConfigSchema(metadata map[string]StepData) *JSONSchema {
+	general := objectSchema("Configuration valid for all steps")
+	stage := objectSchema("Configuration valid for all steps of the stage")
+	steps := objectSchema("Step specific configuration")
+
+	common := commonParameterSchemas()
+	for name, schema := range common {
+		general.addProperty(name, schema)
+		stage.addProperty(name, schema)
+	}
+
+	stepNames := make([]string, 0, len(metadata))
+	for stepName := range metadata {
+		stepNames = append(stepNames, stepName)
+	}
+	sort.Strings(stepNames)
+
+	for _, stepName := range stepNames {
+		stepData := metadata[stepName]
+		step := objectSchema(stepData.Metadata.Description)
+		for name, schema := range common {
+			step.addProperty(name, schema)
+		}
+
+		for _, param := range stepData.Spec.Inputs.Parameters {
+			schema := parameterSchema(param)
+			for _, scope := range param.Scope {
+				switch scope {
+				case "GENERAL":
+					general.addParameter(param.Name, schema, param.Aliases)
+				case "STEPS":
+					step.addParameter(param.Name, schema, param.Aliases)
+					// conditional configuration is provided within a sub map named like the value of the condition
+					for _, condition := range param.Conditions {
+						for _, conditionParam := range condition.Params {
+							conditional := objectSchema(fmt.Sprintf("Configuration applied if '%v' is '%v'", conditionParam.Name, conditionParam.Value))
+							conditional.addProperty(param.Name, schema)
+							step.addProperty(conditionParam.Value, conditional)
+						}
+					}
+				case "STAGES":
+					stage.addParameter(param.Name, schema, param.Aliases)
+				}
+			}
+		}
+
+		for _, secret := range stepData.Spec.Inputs.Secrets {
+			schema := &JSONSchema{Type: "string", Description: secret.Description}
+			general.addParameter(secret.Name, schema, secret.Aliases)
+			step.addParameter(secret.Name, schema, secret.Aliases)
+			stage.addParameter(secret.Name, schema, secret.Aliases)
+		}
+
+		// context configuration like container details is not part of the parameters
+		contextFilters := stepData.GetContextParameterFilters()
+		for _, name := range contextFilters.Steps {
+			if _, ok := step.Properties[name]; !ok {
+				step.addProperty(name, &JSONSchema{})
+			}
+		}
+
+		steps.addProperty(stepName, step)
+		for _, alias := range stepData.Metadata.Aliases {
+			steps.addProperty(alias.Name, aliasSchema(step, stepName, alias))
+		}
+		stage.addProperty(stepName, &JSONSchema{Type: "boolean", Description: fmt.Sprintf("Activates or deactivates step '%v' in the stage", stepName)})
+	}
+
+	stages := objectSchema("Stage specific configuration")
+	stages.AdditionalProperties = stage
+
+	schema := objectSchema("")
+	schema.Schema = jsonSchemaDraft
+	schema.Title = "Project 'Piper' configuration"
+	schema.PatternProperties = nil
+	schema.Properties = map[string]*JSONSchema{
+		"customDefaults": {Type: "array", Items: &JSONSchema{Type: "string"}, Description: "Additional default configurations, passed as path or URL to yaml file"},
+		"general":        general,
+		"stages":         stages,
+		"steps":          steps,
+		"hooks":          {Type: "object", Description: "Configuration of hooks like Sentry or Splunk"},
+	}
+	return schema
+}
+
+// ValidateConfig validates a project configuration file against the schema and returns all findings with their position
+func (s *JSONSchema) ValidateConfig(fileName string, content []byte) ([]ConfigFinding, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse configuration file '%v'", fileName)
+	}
+	findings := []ConfigFinding{}
+	if len(document.Content) > 0 {
+		s.validate(document.Content[0], "", fileName, &findings)
+	}
+	return findings, nil
+}
+
+func (s *JSONSchema) validate(node *yaml.Node, path, fileName string, findings *[]ConfigFinding) {
+	report := func(severity, format string, args ...interface{}) {
+		*findings = append(*findings, ConfigFinding{File: fileName, Line: node.Line, Column: node.Column, Path: path, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if s.Deprecated {
+		report(SeverityWarning, "deprecated: %v", s.Description)
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+	if !matchesType(node, s.Type) {
+		report(SeverityError, "wrong type %v, expected %v", nodeType(node), s.Type)
+		return
+	}
+	if len(s.Enum) > 0 && node.Kind == yaml.ScalarNode && !enumContains(s.Enum, node.Value) {
+		report(SeverityError, "value '%v' is not one of the possible values %v", node.Value, s.Enum)
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "<<" {
+				continue
+			}
+			childPath := key.Value
+			if len(path) > 0 {
+				childPath = path + "." + key.Value
+			}
+			child := s.propertySchema(key.Value)
+			if child == nil {
+				if s.Properties != nil {
+					*findings = append(*findings, ConfigFinding{File: fileName, Line: key.Line, Column: key.Column, Path: childPath, Severity: SeverityWarning, Message: "unknown configuration key"})
+				}
+				continue
+			}
+			child.validate(value, childPath, fileName, findings)
+		}
+	case yaml.SequenceNode:
+		if s.Items != nil {
+			for i, item := range node.Content {
+				s.Items.validate(item, fmt.Sprintf("%v[%v]", path, i), fileName, findings)
+			}
+		}
+	}
+}
+
+func (s *JSONSchema) propertySchema(key string) *JSONSchema {
+	if property, ok := s.Properties[key]; ok {
+		return property
+	}
+	patterns := make([]string, 0, len(s.PatternProperties))
+	for pattern := range s.PatternProperties {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		if sliceContains([]string{pattern}, key) {
+			return s.PatternProperties[pattern]
+		}
+	}
+	return s.AdditionalProperties
+}
+
+// addParameter adds the schema of a parameter and of its aliases
+func (s *JSONSchema) addParameter(name string, schema *JSONSchema, aliases []Alias) {
+	s.addProperty(name, schema)
+	for _, alias := range aliases {
+		// deep aliases pointing into sub maps are not described
+		if strings.Contains(alias.Name, "/") {
+			continue
+		}
+		s.addProperty(alias.Name, aliasSchema(schema, name, alias))
+	}
+}
+
+// addProperty adds a property, in case the property is already defined with a different type the type is removed
+func (s *JSONSchema) addProperty(name string, schema *JSONSchema) {
+	existing, ok := s.Properties[name]
+	if !ok {
+		s.Properties[name] = schema
+		return
+	}
+	if existing.Type == "object" && schema.Type == "object" && existing.Properties != nil && schema.Properties != nil {
+		merged := *existing
+		merged.Properties = map[string]*JSONSchema{}
+		for key, value := range existing.Properties {
+			merged.Properties[key] = value
+		}
+		for key, value := range schema.Properties {
+			merged.addProperty(key, value)
+		}
+		s.Properties[name] = &merged
+		return
+	}
+	if existing.Type != schema.Type {
+		s.Properties[name] = &JSONSchema{Description: existing.Description, Deprecated: existing.Deprecated && schema.Deprecated}
+		return
+	}
+	merged := *existing
+	merged.Deprecated = existing.Deprecated && schema.Deprecated
+	merged.Enum = mergeEnum(existing.Enum, schema.Enum)
+	if existing.Items != nil && schema.Items != nil {
+		merged.Items = &JSONSchema{Type: existing.Items.Type, Enum: mergeEnum(existing.Items.Enum, schema.Items.Enum)}
+		if existing.Items.Type != schema.Items.Type {
+			merged.Items = nil
+		}
+	}
+	s.Properties[name] = &merged
+}
+
+// mergeEnum returns the union of both enumerations, an empty enumeration allows any value
+func mergeEnum(enum, other []interface{}) []interface{} {
+	if len(enum) == 0 || len(other) == 0 {
+		return nil
+	}
+	merged := append([]interface{}{}, enum...)
+	for _, value := range other {
+		if !enumContains(merged, fmt.Sprint(value)) {
+			merged = append(merged, value)
+		}
+	}
+	return merged
+}
+
+func objectSchema(description string) *JSONSchema {
+	return &JSONSchema{
+		Type:        "object",
+		Description: description,
+		Properties:  map[string]*JSONSchema{},
+		// parameters overwriting the names of referenced secrets, see getFilterForResourceReferences and secretProviderFilter
+		PatternProperties: map[string]*JSONSchema{secretReferenceName: {Type: "string"}},
+	}
+}
+
+func aliasSchema(schema *JSONSchema, name string, alias Alias) *JSONSchema {
+	aliased := *schema
+	aliased.Description = fmt.Sprintf("alias of '%v'", name)
+	if alias.Deprecated {
+		aliased.Deprecated = true
+		aliased.Description = fmt.Sprintf("use '%v' instead of '%v'", name, alias.Name)
+	}
+	return &aliased
+}
+
+func parameterSchema(param StepParameters) *JSONSchema {
+	schema := &JSONSchema{Description: param.Description}
+	switch param.Type {
+	case "string":
+		schema.Type = "string"
+	case "bool":
+		schema.Type = "boolean"
+	case "int", "int64":
+		schema.Type = "integer"
+	case "float64":
+		schema.Type = "number"
+	case "[]string":
+		schema.Type = "array"
+		schema.Items = &JSONSchema{Type: "string"}
+	case "[]map[string]interface{}":
+		schema.Type = "array"
+		schema.Items = &JSONSchema{Type: "object"}
+	case "[]interface{}":
+		schema.Type = "array"
+	case "map[string]interface{}":
+		schema.Type = "object"
+	}
+	if len(param.PossibleValues) > 0 && schema.Type != "boolean" {
+		if schema.Items != nil {
+			schema.Items.Enum = param.PossibleValues
+		} else {
+			schema.Enum = param.PossibleValues
+		}
+	}
+	if len(param.DeprecationMessage) > 0 {
+		schema.Deprecated = true
+		schema.Description = param.DeprecationMessage
+	}
+	return schema
+}
+
+// commonParameterSchemas returns the parameters available for all steps, i.e. verbose, Vault and reporting parameters
+func commonParameterSchemas() map[string]*JSONSchema {
+	schemas := map[string]*JSONSchema{
+		"verbose":              {Type: "boolean", Description: "verbose output"},
+		"collectTelemetryData": {Type: "boolean"},
+	}
+	for _, name := range vaultFilter {
+		schemas[name] = &JSONSchema{Description: "Vault configuration"}
+	}
+	for _, name := range []string{secretProvider, secretProviderPath} {
+		schemas[name] = &JSONSchema{Type: "string", Description: "Secret provider configuration"}
+	}
+	for _, param := range ReportingParameters.Parameters {
+		schemas[param.Name] = &JSONSchema{Type: "string", Description: "Reporting configuration"}
+	}
+	return schemas
+}
+
+// matchesType checks the type of a yaml node considering the type conversions done when reading the step configuration
+func matchesType(node *yaml.Node, schemaType string) bool {
+	switch schemaType {
+	case "":
+		return true
+	case "object":
+		return node.Kind == yaml.MappingNode
+	case "array":
+		return node.Kind == yaml.SequenceNode
+	}
+	if node.Kind != yaml.ScalarNode {
+		return false
+	}
+	switch schemaType {
+	case "string":
+		return node.Tag == "!!str" || node.Tag == "!!int" || node.Tag == "!!float"
+	case "boolean":
+		value := strings.ToLower(node.Value)
+		return node.Tag == "!!bool" || node.Tag == "!!str" && (value == "true" || value == "false")
+	case "integer":
+		if node.Tag == "!!float" {
+			value, err := strconv.ParseFloat(node.Value, 64)
+			return err == nil && value == math.Trunc(value)
+		}
+		return node.Tag == "!!int"
+	case "number":
+		return node.Tag == "!!int" || node.Tag == "!!float"
+	}
+	return true
+}
+
+func nodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch node.Tag {
+	case "!!bool":
+		return "boolean"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	}
+	return "string"
+}
+
+func enumContains(enum []interface{}, value string) bool {
+	for _, candidate := range enum {
+		if fmt.Sprint(candidate) == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+//go:build unit
+// +build unit
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func schemaTestMetadata() map[string]StepData {
+	return map[string]StepData{
+		"mavenBuild": {
+			Metadata: StepMetadata{Name: "mavenBuild", Description: "Builds with Maven", Aliases: []Alias{{Name: "mavenExecute", Deprecated: true}}},
+			Spec: StepSpec{Inputs: StepInputs{
+				Parameters: []StepParameters{
+					{Name: "pomPath", Type: "string", Scope: []string{"PARAMETERS", "STEPS"}},
+					{Name: "goals", Type: "[]string", Scope: []string{"PARAMETERS", "STEPS"}, PossibleValues: []interface{}{"install", "deploy"}},
+					{Name: "flatten", Type: "bool", Scope: []string{"PARAMETERS", "STEPS"}},
+					{Name: "retries", Type: "int", Scope: []string{"PARAMETERS", "STEPS"}},
+					{Name: "buildTool", Type: "string", Scope: []string{"GENERAL", "STEPS", "STAGES"}, PossibleValues: []interface{}{"maven", "npm"}, Aliases: []Alias{{Name: "tool", Deprecated: true}}},
+					{Name: "dockerImage", Type: "string", Scope: []string{"STEPS"}, Conditions: []Condition{{ConditionRef: "strings-equal", Params: []Param{{Name: "buildTool", Value: "maven"}}}}},
+				},
+				Secrets: []StepSecrets{{Name: "altDeploymentRepositoryPasswordId", Type: "jenkins"}},
+			}},
+		},
+		"npmExecuteScripts": {
+			Metadata: StepMetadata{Name: "npmExecuteScripts"},
+			Spec: StepSpec{Inputs: StepInputs{
+				Parameters: []StepParameters{
+					{Name: "buildTool", Type: "string", Scope: []string{"GENERAL", "STEPS"}},
+					{Name: "install", Type: "bool", Scope: []string{"PARAMETERS", "STAGES", "STEPS"}},
+				},
+			}},
+		},
+	}
+}
+
+func TestGenerateConfigSchema(t *testing.T) {
+	schema := GenerateConfigSchema(schemaTestMetadata())
+
+	assert.Equal(t, jsonSchemaDraft, schema.Schema)
+	general := schema.Properties["general"]
+	// possible values differ between the steps
+	assert.Equal(t, "string", general.Properties["buildTool"].Type)
+	assert.Nil(t, general.Properties["buildTool"].Enum)
+	assert.True(t, general.Properties["tool"].Deprecated)
+	assert.Equal(t, "string", general.Properties["altDeploymentRepositoryPasswordId"].Type)
+	assert.Equal(t, "boolean", general.Properties["verbose"].Type)
+
+	step := schema.Properties["steps"].Properties["mavenBuild"]
+	assert.Equal(t, "Builds with Maven", step.Description)
+	assert.Equal(t, &JSONSchema{Type: "string", Enum: []interface{}{"install", "deploy"}}, step.Properties["goals"].Items)
+	assert.Equal(t, "integer", step.Properties["retries"].Type)
+	assert.Equal(t, []interface{}{"maven", "npm"}, step.Properties["buildTool"].Enum)
+	assert.Equal(t, "string", step.Properties["maven"].Properties["dockerImage"].Type)
+	assert.True(t, schema.Properties["steps"].Properties["mavenExecute"].Deprecated)
+
+	stage := schema.Properties["stages"].AdditionalProperties
+	assert.Equal(t, "boolean", stage.Properties["install"].Type)
+	assert.Equal(t, "boolean", stage.Properties["mavenBuild"].Type)
+	assert.NotContains(t, stage.Properties, "pomPath")
+}
+
+func TestValidateConfig(t *testing.T) {
+	schema := GenerateConfigSchema(schemaTestMetadata())
+
+	t.Run("valid configuration", func(t *testing.T) {
+		config := `general:
+  buildTool: maven
+  verbose: "true"
+  myVaultSecretName: secret
+steps:
+  mavenBuild:
+    pomPath: pom.xml
+    goals:
+      - install
+    retries: 3.0
+    maven:
+      dockerImage: maven:3
+stages:
+  Build:
+    install: true
+    mavenBuild: false
+`
+		findings, err := schema.ValidateConfig(".pipeline/config.yml", []byte(config))
+		assert.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		config := `general:
+  tool: maven
+  unknownParam: true
+steps:
+  mavenBuild:
+    pomPath: [pom.xml]
+    goals:
+      - package
+    flatten: yes please
+    retries: 1.5
+  mavenExecute:
+    pomPath: pom.xml
+stages:
+  Build:
+    pomPath: pom.xml
+`
+		findings, err := schema.ValidateConfig(".pipeline/config.yml", []byte(config))
+		assert.NoError(t, err)
+		assert.Equal(t, []ConfigFinding{
+			{File: ".pipeline/config.yml", Line: 2, Column: 9, Path: "general.tool", Severity: SeverityWarning, Message: "deprecated: use 'buildTool' instead of 'tool'"},
+			{File: ".pipeline/config.yml", Line: 3, Column: 3, Path: "general.unknownParam", Severity: SeverityWarning, Message: "unknown configuration key"},
+			{File: ".pipeline/config.yml", Line: 6, Column: 14, Path: "steps.mavenBuild.pomPath", Severity: SeverityError, Message: "wrong type array, expected string"},
+			{File: ".pipeline/config.yml", Line: 8, Column: 9, Path: "steps.mavenBuild.goals[0]", Severity: SeverityError, Message: "value 'package' is not one of the possible values [install deploy]"},
+			{File: ".pipeline/config.yml", Line: 9, Column: 14, Path: "steps.mavenBuild.flatten", Severity: SeverityError, Message: "wrong type string, expected boolean"},
+			{File: ".pipeline/config.yml", Line: 10, Column: 14, Path: "steps.mavenBuild.retries", Severity: SeverityError, Message: "wrong type number, expected integer"},
+			{File: ".pipeline/config.yml", Line: 12, Column: 5, Path: "steps.mavenExecute", Severity: SeverityWarning, Message: "deprecated: use 'mavenBuild' instead of 'mavenExecute'"},
+			{File: ".pipeline/config.yml", Line: 15, Column: 5, Path: "stages.Build.pomPath", Severity: SeverityWarning, Message: "unknown configuration key"},
+		}, findings)
+		assert.Equal(t, ".pipeline/config.yml:2:9: warning: general.tool: deprecated: use 'buildTool' instead of 'tool'", findings[0].String())
+	})
+
+	t.Run("invalid yaml", func(t *testing.T) {
+		_, err := schema.ValidateConfig(".pipeline/config.yml", []byte("general: [:"))
+		assert.Error(t, err)
+	})
+}
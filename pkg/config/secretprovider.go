@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+)
+
+// Reference types resolved only by the configured secret provider
+const (
+	RefTypeExternalSecret     = "externalSecret"
+	RefTypeExternalSecretFile = "externalSecretFile"
+)
+
+const (
+	secretProvider      = "secretProvider"
+	secretProviderPath  = "secretProviderPath"
+	secretReferenceName = ".+SecretName$"
+)
+
+var secretProviderFilter = []string{
+	secretProvider,
+	secretProviderPath,
+	secretReferenceName,
+}
+
+// SecretProvider is implemented by secret stores which are used beside Vault to resolve the secret references of step parameters
+type SecretProvider interface {
+	// Name identifies the provider in the log
+	Name() string
+	// GetSecret returns the fields of the secret with the given name, nil if the secret does not exist
+	GetSecret(name string) (map[string]string, error)
+}
+
+// secretProviders contains the providers which can be selected with the secretProvider parameter.
+// The factory receives the value of the secretProviderPath parameter which may be empty.
+var secretProviders = map[string]func(path string) SecretProvider{
+	"sops": func(path string) SecretProvider {
+		return NewSopsFileProvider(path)
+	},
+	"kubernetes": func(path string) SecretProvider {
+		return NewKubernetesSecretProvider(path)
+	},
+}
+
+// GetSecretProviderFromConfig returns the secret provider selected with the secretProvider parameter, nil if none is configured
+func GetSecretProviderFromConfig(config map[string]interface{}) (SecretProvider, error) {
+	name, _ := config[secretProvider].(string)
+	if len(name) == 0 {
+		return nil, nil
+	}
+	factory, ok := secretProviders[name]
+	if !ok {
+		names := make([]string, 0, len(secretProviders))
+		for providerName := range secretProviders {
+			names = append(names, providerName)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown secret provider '%v', supported providers are %v", name, strings.Join(names, ", "))
+	}
+	path, _ := config[secretProviderPath].(string)
+	return factory(path), nil
+}
+
+// resolveAllSecretReferences resolves the references of type externalSecret and externalSecretFile with the given provider.
+// References to Vault secrets are resolved as well, so that the secrets of existing steps can be provided without Vault.
+// Secrets found in Vault take precedence since Vault references are resolved afterwards.
+func resolveAllSecretReferences(config *StepConfig, provider SecretProvider, params []StepParameters) {
+	secrets := map[string]map[string]string{}
+	for _, param := range params {
+		for _, refType := range []string{RefTypeExternalSecret, RefTypeExternalSecretFile, "vaultSecret", "vaultSecretFile"} {
+			if ref := param.GetReference(refType); ref != nil {
+				resolveSecretReference(ref, config, provider, param, secrets)
+				break
+			}
+		}
+	}
+}
+
+func resolveSecretReference(ref *ResourceReference, config *StepConfig, provider SecretProvider, param StepParameters, secrets map[string]map[string]string) {
+	vaultDisableOverwrite, _ := config.Config["vaultDisableOverwrite"].(bool)
+	if paramValue, _ := config.Config[param.Name].(string); vaultDisableOverwrite && paramValue != "" {
+		log.Entry().Debugf("Not fetching '%s' from secret provider since it has already been set", param.Name)
+		return
+	}
+
+	name := getSecretReferenceName(ref, config.Config)
+	secret, ok := secrets[name]
+	if !ok {
+		var err error
+		secret, err = provider.GetSecret(name)
+		if err != nil {
+			log.Entry().WithError(err).WithField("secret", name).Warnf("Failed to fetch secret from secret provider '%s'", provider.Name())
+		}
+		// remember missing secrets as well, they are requested only once
+		secrets[name] = secret
+	}
+	if secret == nil {
+		return
+	}
+
+	secretValue := lookupSecretField(secret, &param)
+	if secretValue == nil {
+		return
+	}
+	log.Entry().WithField("secret", name).Debugf("Secret resolved successfully from secret provider '%s'", provider.Name())
+	switch ref.Type {
+	case RefTypeExternalSecretFile, "vaultSecretFile":
+		filePath, err := createTemporarySecretFile(param.Name, *secretValue)
+		if err != nil {
+			log.Entry().WithError(err).Warnf("Couldn't create temporary secret file for '%s'", param.Name)
+			return
+		}
+		config.Config[param.Name] = filePath
+	default:
+		config.Config[param.Name] = *secretValue
+	}
+}
+
+// getSecretReferenceName returns the name of the referenced secret which can be overwritten with the parameter named in the reference
+func getSecretReferenceName(reference *ResourceReference, config map[string]interface{}) string {
+	if providedName, ok := config[reference.Name].(string); ok && providedName != "" {
+		return providedName
+	}
+	return reference.Default
+}
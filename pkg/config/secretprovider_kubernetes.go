@@ -0,0 +1,65 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const defaultKubernetesSecretsPath = "/etc/secrets"
+
+// KubernetesSecretProvider reads secrets mounted as volumes into the pod running the pipeline.
+// Each secret is expected in a directory named like the secret below the base path, with one file per field:
+//
+//	<basePath>/<secret name>/<field>
+type KubernetesSecretProvider struct {
+	basePath string
+}
+
+// NewKubernetesSecretProvider creates a provider for secrets mounted below the given path, by default /etc/secrets is used
+func NewKubernetesSecretProvider(basePath string) *KubernetesSecretProvider {
+	if len(basePath) == 0 {
+		basePath = defaultKubernetesSecretsPath
+	}
+	return &KubernetesSecretProvider{basePath: basePath}
+}
+
+// Name returns the name of the provider
+func (p *KubernetesSecretProvider) Name() string {
+	return "kubernetes"
+}
+
+// GetSecret returns the content of all files in the directory of the secret
+func (p *KubernetesSecretProvider) GetSecret(name string) (map[string]string, error) {
+	secretPath := filepath.Join(p.basePath, filepath.Clean("/"+name))
+	entries, err := os.ReadDir(secretPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret '%v': %w", name, err)
+	}
+
+	fields := map[string]string{}
+	for _, entry := range entries {
+		// the kubelet updates secret volumes atomically using hidden directories like '..data'
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		fieldPath := filepath.Join(secretPath, entry.Name())
+		// fields are symbolic links into the hidden directory, hence the link target is checked
+		info, err := os.Stat(fieldPath)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		content, err := os.ReadFile(fieldPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read field '%v' of secret '%v': %w", entry.Name(), name, err)
+		}
+		fields[entry.Name()] = string(content)
+	}
+	return fields, nil
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/command"
+	"github.com/SAP/jenkins-library/pkg/log"
+)
+
+const defaultSopsFile = ".pipeline/secrets.sops.yaml"
+
+type sopsRunner interface {
+	Stdout(out io.Writer)
+	RunExecutable(executable string, params ...string) error
+}
+
+// SopsFileProvider reads secrets from a file encrypted with SOPS (https://github.com/getsops/sops).
+// The file is decrypted with the sops command line tool, hence all key types supported by SOPS (age, PGP, cloud KMS, ...) can be used.
+// Secrets are the top-level entries of the file, nested secrets are addressed with '/', e.g. 'team/github'.
+type SopsFileProvider struct {
+	file    string
+	runner  sopsRunner
+	content map[string]interface{}
+}
+
+// NewSopsFileProvider creates a provider for the given SOPS file, by default .pipeline/secrets.sops.yaml is used
+func NewSopsFileProvider(file string) *SopsFileProvider {
+	if len(file) == 0 {
+		file = defaultSopsFile
+	}
+	return &SopsFileProvider{file: file, runner: &command.Command{}}
+}
+
+// Name returns the name of the provider
+func (p *SopsFileProvider) Name() string {
+	return "sops"
+}
+
+// GetSecret returns the fields of the secret with the given name, the file is decrypted only once
+func (p *SopsFileProvider) GetSecret(name string) (map[string]string, error) {
+	if p.content == nil {
+		if err := p.decrypt(); err != nil {
+			return nil, err
+		}
+	}
+
+	var secret interface{} = p.content
+	for _, segment := range strings.Split(strings.Trim(name, "/"), "/") {
+		entries, ok := secret.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		if secret, ok = entries[segment]; !ok {
+			return nil, nil
+		}
+	}
+	entries, ok := secret.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("secret '%v' in '%v' is not a map of fields", name, p.file)
+	}
+	fields := make(map[string]string, len(entries))
+	for key, value := range entries {
+		switch v := value.(type) {
+		case string:
+			fields[key] = v
+		case map[string]interface{}, []interface{}:
+			content, _ := json.Marshal(v)
+			fields[key] = string(content)
+		default:
+			fields[key] = fmt.Sprint(v)
+		}
+	}
+	return fields, nil
+}
+
+func (p *SopsFileProvider) decrypt() error {
+	log.Entry().WithField("file", p.file).Debug("Decrypting secrets with sops")
+	var plainText bytes.Buffer
+	p.runner.Stdout(&plainText)
+	if err := p.runner.RunExecutable("sops", "--decrypt", "--output-type", "json", p.file); err != nil {
+		return fmt.Errorf("failed to decrypt '%v' with sops: %w", p.file, err)
+	}
+	content := map[string]interface{}{}
+	if err := json.Unmarshal(plainText.Bytes(), &content); err != nil {
+		return fmt.Errorf("failed to parse decrypted content of '%v': %w", p.file, err)
+	}
+	p.content = content
+	return nil
+}
@@ -0,0 +1,187 @@
+//go:build unit
+// +build unit
+
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type secretProviderMock struct {
+	secrets  map[string]map[string]string
+	requests []string
+}
+
+func (p *secretProviderMock) Name() string {
+	return "mock"
+}
+
+func (p *secretProviderMock) GetSecret(name string) (map[string]string, error) {
+	p.requests = append(p.requests, name)
+	if name == "broken" {
+		return nil, fmt.Errorf("access denied")
+	}
+	return p.secrets[name], nil
+}
+
+type sopsRunnerMock struct {
+	stdout io.Writer
+	output string
+	err    error
+	calls  [][]string
+}
+
+func (r *sopsRunnerMock) Stdout(out io.Writer) {
+	r.stdout = out
+}
+
+func (r *sopsRunnerMock) RunExecutable(executable string, params ...string) error {
+	r.calls = append(r.calls, append([]string{executable}, params...))
+	if r.err != nil {
+		return r.err
+	}
+	_, err := r.stdout.Write([]byte(r.output))
+	return err
+}
+
+func TestGetSecretProviderFromConfig(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		provider, err := GetSecretProviderFromConfig(map[string]interface{}{})
+		assert.NoError(t, err)
+		assert.Nil(t, provider)
+	})
+
+	t.Run("sops", func(t *testing.T) {
+		provider, err := GetSecretProviderFromConfig(map[string]interface{}{"secretProvider": "sops", "secretProviderPath": "secrets.yaml"})
+		assert.NoError(t, err)
+		assert.Equal(t, "sops", provider.Name())
+		assert.Equal(t, "secrets.yaml", provider.(*SopsFileProvider).file)
+	})
+
+	t.Run("kubernetes with default path", func(t *testing.T) {
+		provider, err := GetSecretProviderFromConfig(map[string]interface{}{"secretProvider": "kubernetes"})
+		assert.NoError(t, err)
+		assert.Equal(t, "kubernetes", provider.Name())
+		assert.Equal(t, "/etc/secrets", provider.(*KubernetesSecretProvider).basePath)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		_, err := GetSecretProviderFromConfig(map[string]interface{}{"secretProvider": "keepass"})
+		assert.EqualError(t, err, "unknown secret provider 'keepass', supported providers are kubernetes, sops")
+	})
+}
+
+func TestResolveAllSecretReferences(t *testing.T) {
+	provider := &secretProviderMock{secrets: map[string]map[string]string{
+		"github": {"token": "ghp_1234"},
+		"deploy": {"username": "deployer", "password": "secret", "clientCertificate": "-----BEGIN CERTIFICATE-----"},
+		"custom": {"password": "custom-secret"},
+	}}
+	params := []StepParameters{
+		{Name: "token", ResourceRef: []ResourceReference{{Type: "secret", Name: "githubTokenCredentialsId"}, {Type: "vaultSecret", Name: "githubVaultSecretName", Default: "github"}}},
+		{Name: "username", ResourceRef: []ResourceReference{{Type: RefTypeExternalSecret, Name: "deploySecretName", Default: "deploy"}}},
+		{Name: "password", ResourceRef: []ResourceReference{{Type: RefTypeExternalSecret, Name: "passwordSecretName", Default: "deploy"}}},
+		{Name: "clientCertificate", ResourceRef: []ResourceReference{{Type: RefTypeExternalSecretFile, Name: "deploySecretName", Default: "deploy"}}},
+		{Name: "apiKey", ResourceRef: []ResourceReference{{Type: "vaultSecret", Name: "apiKeyVaultSecretName", Default: "broken"}}},
+		{Name: "url"},
+	}
+	VaultSecretFileDirectory = ""
+	defer RemoveVaultSecretFiles()
+	stepConfig := StepConfig{Config: map[string]interface{}{
+		"url":                "https://example.org",
+		"passwordSecretName": "custom",
+	}}
+
+	resolveAllSecretReferences(&stepConfig, provider, params)
+
+	assert.Equal(t, "ghp_1234", stepConfig.Config["token"])
+	assert.Equal(t, "deployer", stepConfig.Config["username"])
+	assert.Equal(t, "custom-secret", stepConfig.Config["password"])
+	assert.NotContains(t, stepConfig.Config, "apiKey")
+	assert.Equal(t, "https://example.org", stepConfig.Config["url"])
+	certificate, err := os.ReadFile(stepConfig.Config["clientCertificate"].(string))
+	assert.NoError(t, err)
+	assert.Equal(t, "-----BEGIN CERTIFICATE-----", string(certificate))
+	// each secret is requested only once
+	assert.Equal(t, []string{"github", "deploy", "custom", "broken"}, provider.requests)
+
+	t.Run("no overwrite", func(t *testing.T) {
+		stepConfig := StepConfig{Config: map[string]interface{}{"token": "configured", "vaultDisableOverwrite": true}}
+		resolveAllSecretReferences(&stepConfig, provider, params[:1])
+		assert.Equal(t, "configured", stepConfig.Config["token"])
+	})
+}
+
+func TestKubernetesSecretProvider(t *testing.T) {
+	basePath := t.TempDir()
+	// layout created by the kubelet for secret volumes
+	secretPath := filepath.Join(basePath, "deploy")
+	dataPath := filepath.Join(secretPath, "..2024_01_01_00_00_00.000000000")
+	assert.NoError(t, os.MkdirAll(dataPath, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dataPath, "username"), []byte("deployer"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dataPath, "password"), []byte("secret"), 0o644))
+	assert.NoError(t, os.Symlink(filepath.Base(dataPath), filepath.Join(secretPath, "..data")))
+	assert.NoError(t, os.Symlink(filepath.Join("..data", "username"), filepath.Join(secretPath, "username")))
+	assert.NoError(t, os.Symlink(filepath.Join("..data", "password"), filepath.Join(secretPath, "password")))
+
+	provider := NewKubernetesSecretProvider(basePath)
+
+	t.Run("mounted secret", func(t *testing.T) {
+		secret, err := provider.GetSecret("deploy")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"username": "deployer", "password": "secret"}, secret)
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		secret, err := provider.GetSecret("github")
+		assert.NoError(t, err)
+		assert.Nil(t, secret)
+	})
+
+	t.Run("path outside of base path", func(t *testing.T) {
+		secret, err := provider.GetSecret("../../etc")
+		assert.NoError(t, err)
+		assert.Nil(t, secret)
+	})
+}
+
+func TestSopsFileProvider(t *testing.T) {
+	t.Run("decrypt once", func(t *testing.T) {
+		runner := &sopsRunnerMock{output: `{"github": {"token": "ghp_1234"}, "team": {"deploy": {"password": "secret", "port": 5432, "hosts": ["a", "b"]}}, "plain": "value"}`}
+		provider := &SopsFileProvider{file: "secrets.sops.yaml", runner: runner}
+
+		secret, err := provider.GetSecret("github")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"token": "ghp_1234"}, secret)
+
+		secret, err = provider.GetSecret("team/deploy")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"password": "secret", "port": "5432", "hosts": `["a","b"]`}, secret)
+
+		secret, err = provider.GetSecret("unknown")
+		assert.NoError(t, err)
+		assert.Nil(t, secret)
+
+		_, err = provider.GetSecret("plain")
+		assert.EqualError(t, err, "secret 'plain' in 'secrets.sops.yaml' is not a map of fields")
+
+		assert.Equal(t, [][]string{{"sops", "--decrypt", "--output-type", "json", "secrets.sops.yaml"}}, runner.calls)
+	})
+
+	t.Run("decryption fails", func(t *testing.T) {
+		provider := &SopsFileProvider{file: "secrets.sops.yaml", runner: &sopsRunnerMock{err: fmt.Errorf("exit status 128")}}
+
+		_, err := provider.GetSecret("github")
+		assert.EqualError(t, err, "failed to decrypt 'secrets.sops.yaml' with sops: exit status 128")
+	})
+
+	t.Run("default file", func(t *testing.T) {
+		assert.Equal(t, ".pipeline/secrets.sops.yaml", NewSopsFileProvider("").file)
+	})
+}
@@ -474,6 +474,12 @@ func getFilterForResourceReferences(params []StepParameters) []string {
 		if reference == nil {
 			reference = param.GetReference("vaultSecretFile")
 		}
+		if reference == nil {
+			reference = param.GetReference(RefTypeExternalSecret)
+		}
+		if reference == nil {
+			reference = param.GetReference(RefTypeExternalSecretFile)
+		}
 		if reference == nil {
 			return filter
 		}
@@ -33,6 +33,7 @@ const (
 	vaultTestCredentialEnvPrefixDefault = "PIPER_TESTCREDENTIAL_"
 	VaultCredentialEnvPrefixDefault     = "PIPER_VAULTCREDENTIAL_"
 	vaultSecretName                     = ".+VaultSecretName$"
+	vaultDynamicSecrets                 = "vaultDynamicSecrets"
 )
 
 var (
@@ -54,6 +55,7 @@ var (
 		vaultCredentialKeys,
 		vaultCredentialEnvPrefix,
 		vaultSecretName,
+		vaultDynamicSecrets,
 	}
 
 	// VaultRootPaths are the lookup paths piper tries to use during the vault lookup.
@@ -66,6 +68,9 @@ var (
 
 	// VaultSecretFileDirectory holds the directory for the current step run to temporarily store secret files fetched from vault
 	VaultSecretFileDirectory = ""
+
+	// vaultLeases holds the leases of the dynamic secrets requested for the current step run
+	vaultLeases = []string{}
 )
 
 // VaultCredentials hold all the auth information needed to fetch configuration from vault
@@ -80,6 +85,8 @@ type VaultClient interface {
 	GetKvSecret(string) (map[string]string, error)
 	MustRevokeToken()
 	GetOIDCTokenByValidation(string) (string, error)
+	GetDynamicSecret(string, map[string]interface{}) (*vault.DynamicSecret, error)
+	RevokeLease(string) error
 }
 
 // globalVaultClient is supposed to be used in the steps code.
@@ -98,6 +105,7 @@ func GlobalVaultClient() VaultClient {
 func (s *StepConfig) mixinVaultConfig(parameters []StepParameters, configs ...map[string]interface{}) {
 	for _, config := range configs {
 		s.mixIn(config, vaultFilter, StepData{})
+		s.mixIn(config, secretProviderFilter, StepData{})
 		// when an empty filter is returned we skip the mixin call since an empty filter will allow everything
 		if referencesFilter := getFilterForResourceReferences(parameters); len(referencesFilter) > 0 {
 			s.mixIn(config, referencesFilter, StepData{})
@@ -196,6 +204,77 @@ func resolveVaultReference(ref *ResourceReference, config *StepConfig, client Va
 	}
 }
 
+// resolveVaultDynamicSecrets requests credentials from dynamic secrets engines for the step parameters configured in vaultDynamicSecrets, e.g.
+//
+//	vaultDynamicSecrets:
+//	  username: {path: database/creds/deploy}
+//	  password: {path: database/creds/deploy}
+//	  clientCertificate: {path: pki/issue/deploy, field: certificate, file: true, data: {common_name: deploy.example.com, ttl: 15m}}
+//
+// The field of the response defaults to the parameter name. Each path is requested only once, i.e. parameters referring to
+// the same path share the credentials and their lease. The leases are revoked at the end of the step (see RevokeVaultLeases).
+func resolveVaultDynamicSecrets(config *StepConfig, client VaultClient, params []StepParameters) {
+	definitions, ok := config.Config[vaultDynamicSecrets].(map[string]interface{})
+	if !ok || len(definitions) == 0 {
+		return
+	}
+	vaultDisableOverwrite, _ := config.Config["vaultDisableOverwrite"].(bool)
+
+	secrets := map[string]*vault.DynamicSecret{}
+	for _, param := range params {
+		definition, ok := definitions[param.Name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if paramValue, _ := config.Config[param.Name].(string); vaultDisableOverwrite && paramValue != "" {
+			log.Entry().Debugf("Not requesting '%s' from Vault since it has already been set", param.Name)
+			continue
+		}
+		secretPath, _ := definition["path"].(string)
+		secretPath, ok = interpolation.ResolveString(secretPath, config.Config)
+		if !ok || secretPath == "" {
+			log.Entry().WithField("parameter", param.Name).Warn("Vault dynamic secret is not configured properly: path is missing")
+			continue
+		}
+		field, _ := definition["field"].(string)
+		if field == "" {
+			field = param.Name
+		}
+
+		secret, requested := secrets[secretPath]
+		if !requested {
+			data, _ := definition["data"].(map[string]interface{})
+			var err error
+			secret, err = client.GetDynamicSecret(secretPath, data)
+			if err != nil {
+				log.Entry().WithError(err).WithField("vaultPath", secretPath).Warn("Failed to request dynamic secret from Vault")
+				continue
+			}
+			if secret.LeaseID != "" {
+				vaultLeases = append(vaultLeases, secret.LeaseID)
+			}
+			log.Entry().WithField("vaultPath", secretPath).WithField("leaseDuration", secret.LeaseDuration).Info("Dynamic secret requested from Vault")
+			secrets[secretPath] = secret
+		}
+
+		value := secret.Data[field]
+		if value == "" {
+			log.Entry().WithField("vaultPath", secretPath).Warnf("Dynamic secret did not contain a field named '%s'", field)
+			continue
+		}
+		log.RegisterSecret(value)
+		if fileOutput, _ := definition["file"].(bool); fileOutput {
+			filePath, err := createTemporarySecretFile(param.Name, value)
+			if err != nil {
+				log.Entry().WithError(err).Warnf("Couldn't create temporary secret file for '%s'", param.Name)
+				continue
+			}
+			value = filePath
+		}
+		config.Config[param.Name] = value
+	}
+}
+
 func resolveVaultTestCredentialsWrapper(config *StepConfig, client VaultClient) {
 	log.Entry().Debug("Resolving test credentials from Vault")
 	resolveVaultCredentialsWrapperBase(config, client, vaultTestCredentialPath, vaultTestCredentialKeys, vaultTestCredentialEnvPrefix, resolveVaultTestCredentials)
@@ -445,7 +524,25 @@ func ConvertEnvVar(s string) string {
 func RemoveVaultSecretFiles() {
 	if VaultSecretFileDirectory != "" {
 		os.RemoveAll(VaultSecretFileDirectory)
+		VaultSecretFileDirectory = ""
+	}
+}
+
+// RevokeVaultLeases revokes the leases of all dynamic secrets that have been requested during execution
+func RevokeVaultLeases() {
+	if globalVaultClient == nil {
+		return
 	}
+	revokeVaultLeases(globalVaultClient)
+}
+
+func revokeVaultLeases(client VaultClient) {
+	for _, leaseID := range vaultLeases {
+		if err := client.RevokeLease(leaseID); err != nil {
+			log.Entry().WithError(err).Warn("Failed to revoke Vault lease, the credentials stay valid until the lease expires")
+		}
+	}
+	vaultLeases = []string{}
 }
 
 func createTemporarySecretFile(namePattern string, content string) (string, error) {
@@ -480,7 +577,11 @@ func lookupPath(client VaultClient, path string, param *StepParameters) *string
 	if secret == nil {
 		return nil
 	}
+	return lookupSecretField(secret, param)
+}
 
+// lookupSecretField returns the field of the secret named like the parameter or one of its aliases
+func lookupSecretField(secret map[string]string, param *StepParameters) *string {
 	field := secret[param.Name]
 	if field != "" {
 		log.RegisterSecret(field)
@@ -504,10 +605,7 @@ func lookupPath(client VaultClient, path string, param *StepParameters) *string
 
 func getSecretReferencePaths(reference *ResourceReference, config map[string]interface{}) []string {
 	retPaths := make([]string, 0, len(VaultRootPaths))
-	secretName := reference.Default
-	if providedName, ok := config[reference.Name].(string); ok && providedName != "" {
-		secretName = providedName
-	}
+	secretName := getSecretReferenceName(reference, config)
 	for _, rootPath := range VaultRootPaths {
 		fullPath := path.Join(rootPath, secretName)
 		retPaths = append(retPaths, fullPath)
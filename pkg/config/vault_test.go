@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/SAP/jenkins-library/pkg/config/mocks"
+	"github.com/SAP/jenkins-library/pkg/vault"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -183,6 +184,109 @@ func TestVaultSecretFiles(t *testing.T) {
 	})
 }
 
+func TestVaultDynamicSecrets(t *testing.T) {
+	stepParams := []StepParameters{{Name: "username"}, {Name: "password"}, {Name: "clientCertificate"}}
+
+	t.Run("shared lease for the same path", func(t *testing.T) {
+		defer func() { vaultLeases = []string{} }()
+		vaultMock := &mocks.VaultClient{}
+		stepConfig := StepConfig{Config: map[string]interface{}{
+			"dbRole": "deploy",
+			"vaultDynamicSecrets": map[string]interface{}{
+				"username": map[string]interface{}{"path": "database/creds/$(dbRole)"},
+				"password": map[string]interface{}{"path": "database/creds/$(dbRole)"},
+			},
+		}}
+		vaultMock.On("GetDynamicSecret", "database/creds/deploy", map[string]interface{}(nil)).Return(&vault.DynamicSecret{
+			LeaseID: "database/creds/deploy/abcd",
+			Data:    map[string]string{"username": "v-deploy-1234", "password": "secret"},
+		}, nil).Once()
+
+		resolveVaultDynamicSecrets(&stepConfig, vaultMock, stepParams)
+		assert.Equal(t, "v-deploy-1234", stepConfig.Config["username"])
+		assert.Equal(t, "secret", stepConfig.Config["password"])
+		assert.Equal(t, []string{"database/creds/deploy/abcd"}, vaultLeases)
+		vaultMock.AssertExpectations(t)
+	})
+
+	t.Run("certificate as file", func(t *testing.T) {
+		defer func() { vaultLeases = []string{} }()
+		VaultSecretFileDirectory = ""
+		defer RemoveVaultSecretFiles()
+		vaultMock := &mocks.VaultClient{}
+		data := map[string]interface{}{"common_name": "deploy.example.com"}
+		stepConfig := StepConfig{Config: map[string]interface{}{
+			"vaultDynamicSecrets": map[string]interface{}{
+				"clientCertificate": map[string]interface{}{"path": "pki/issue/deploy", "field": "certificate", "file": true, "data": data},
+			},
+		}}
+		vaultMock.On("GetDynamicSecret", "pki/issue/deploy", data).Return(&vault.DynamicSecret{
+			Data: map[string]string{"certificate": "-----BEGIN CERTIFICATE-----"},
+		}, nil)
+
+		resolveVaultDynamicSecrets(&stepConfig, vaultMock, stepParams)
+		filePath, ok := stepConfig.Config["clientCertificate"].(string)
+		assert.True(t, ok)
+		content, err := os.ReadFile(filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, "-----BEGIN CERTIFICATE-----", string(content))
+		// certificates issued without lease are not tracked
+		assert.Empty(t, vaultLeases)
+	})
+
+	t.Run("no overwrite", func(t *testing.T) {
+		vaultMock := &mocks.VaultClient{}
+		stepConfig := StepConfig{Config: map[string]interface{}{
+			"username":              "admin",
+			"vaultDisableOverwrite": true,
+			"vaultDynamicSecrets": map[string]interface{}{
+				"username": map[string]interface{}{"path": "database/creds/deploy"},
+			},
+		}}
+
+		resolveVaultDynamicSecrets(&stepConfig, vaultMock, stepParams)
+		assert.Equal(t, "admin", stepConfig.Config["username"])
+		vaultMock.AssertNotCalled(t, "GetDynamicSecret", mock.Anything, mock.Anything)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		vaultMock := &mocks.VaultClient{}
+		stepConfig := StepConfig{Config: map[string]interface{}{
+			"vaultDynamicSecrets": map[string]interface{}{
+				"username":          map[string]interface{}{"path": "database/creds/unknown"},
+				"password":          map[string]interface{}{"path": "$(missing)/creds/deploy"},
+				"clientCertificate": map[string]interface{}{"path": "pki/issue/deploy", "field": "certificate", "data": map[string]interface{}{"ttl": "5m"}},
+				"otherParameter":    map[string]interface{}{"path": "aws/creds/deploy"},
+			},
+		}}
+		vaultMock.On("GetDynamicSecret", "database/creds/unknown", map[string]interface{}(nil)).Return(nil, fmt.Errorf("permission denied"))
+		vaultMock.On("GetDynamicSecret", "pki/issue/deploy", map[string]interface{}{"ttl": "5m"}).Return(&vault.DynamicSecret{
+			LeaseID: "pki/issue/deploy/efgh",
+			Data:    map[string]string{"private_key": "key"},
+		}, nil)
+
+		resolveVaultDynamicSecrets(&stepConfig, vaultMock, stepParams)
+		assert.NotContains(t, stepConfig.Config, "username")
+		assert.NotContains(t, stepConfig.Config, "password")
+		assert.NotContains(t, stepConfig.Config, "clientCertificate")
+		assert.NotContains(t, stepConfig.Config, "otherParameter")
+		// the lease is revoked even if the expected field is missing
+		assert.Equal(t, []string{"pki/issue/deploy/efgh"}, vaultLeases)
+		vaultLeases = []string{}
+	})
+}
+
+func TestRevokeVaultLeases(t *testing.T) {
+	vaultMock := &mocks.VaultClient{}
+	vaultLeases = []string{"database/creds/deploy/abcd", "aws/creds/deploy/efgh"}
+	vaultMock.On("RevokeLease", "database/creds/deploy/abcd").Return(fmt.Errorf("permission denied"))
+	vaultMock.On("RevokeLease", "aws/creds/deploy/efgh").Return(nil)
+
+	revokeVaultLeases(vaultMock)
+	vaultMock.AssertExpectations(t)
+	assert.Empty(t, vaultLeases)
+}
+
 func TestMixinVault(t *testing.T) {
 	vaultServerUrl := "https://testServer"
 	vaultPath := "testPath"
@@ -0,0 +1,190 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/docker/cli/cli/config"
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/SAP/jenkins-library/pkg/attestation"
+)
+
+// MediaTypeDSSE is the media type of image layers containing a DSSE envelope
+const MediaTypeDSSE = "application/vnd.dsse.envelope.v1+json"
+
+// AttestationTag returns the tag under which the attestations of the image with the given digest are stored.
+// The tag follows the cosign convention `<repository>:<algorithm>-<hex>.att`.
+func AttestationTag(repository, digest string) (name.Tag, error) {
+	return cosignTag(repository, digest, "att")
+}
+
+// AttachAttestation uploads the envelope as attestation of the image with the given digest in a cosign compatible way.
+// Attestations which have already been attached to the image are kept.
+func AttachAttestation(repository, digest string, envelope attestation.Envelope, predicateType string, keychain authn.Keychain) (string, error) {
+	tag, err := AttestationTag(repository, digest)
+	if err != nil {
+		return "", err
+	}
+	content, err := json.Marshal(envelope)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal envelope")
+	}
+	if err := appendCosignLayer(tag, static.NewLayer(content, MediaTypeDSSE), map[string]string{"predicateType": predicateType}, keychain); err != nil {
+		return "", err
+	}
+	return tag.String(), nil
+}
+
+// GetAttestations returns the envelopes attached to the image with the given digest
+func GetAttestations(repository, digest string, keychain authn.Keychain) ([]attestation.Envelope, error) {
+	tag, err := AttestationTag(repository, digest)
+	if err != nil {
+		return nil, err
+	}
+	layers, err := cosignLayers(tag, MediaTypeDSSE, keychain)
+	if err != nil {
+		return nil, err
+	}
+	result := []attestation.Envelope{}
+	for _, layer := range layers {
+		envelope := attestation.Envelope{}
+		if err := json.Unmarshal(layer.content, &envelope); err != nil {
+			return nil, errors.Wrap(err, "failed to parse attestation")
+		}
+		result = append(result, envelope)
+	}
+	return result, nil
+}
+
+// cosignTag returns the tag of the signatures (suffix sig) or attestations (suffix att) of an image
+func cosignTag(repository, digest, suffix string) (name.Tag, error) {
+	algorithm, value, found := strings.Cut(digest, ":")
+	if !found {
+		return name.Tag{}, fmt.Errorf("invalid image digest '%v'", digest)
+	}
+	tag, err := name.NewTag(fmt.Sprintf("%v:%v-%v.%v", repository, algorithm, value, suffix))
+	if err != nil {
+		return name.Tag{}, errors.Wrapf(err, "invalid image repository '%v'", repository)
+	}
+	return tag, nil
+}
+
+// appendCosignLayer adds the layer to the image with the given tag, the image is created if it does not exist yet
+func appendCosignLayer(tag name.Tag, layer v1.Layer, annotations map[string]string, keychain authn.Keychain) error {
+	auth := remote.WithAuthFromKeychain(keychain)
+	image, err := remote.Image(tag, auth)
+	if err != nil {
+		if !isNotFound(err) {
+			return errors.Wrapf(err, "failed to read %v", tag)
+		}
+		image = mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), types.OCIConfigJSON)
+	}
+	image, err = mutate.Append(image, mutate.Addendum{Layer: layer, Annotations: annotations})
+	if err != nil {
+		return errors.Wrapf(err, "failed to add layer to %v", tag)
+	}
+	if err := remote.Write(tag, image, auth); err != nil {
+		return errors.Wrapf(err, "failed to push %v", tag)
+	}
+	return nil
+}
+
+type cosignLayer struct {
+	content     []byte
+	annotations map[string]string
+}
+
+// cosignLayers returns the layers with the given media type of the image with the given tag, no layers if the image does not exist
+func cosignLayers(tag name.Tag, mediaType types.MediaType, keychain authn.Keychain) ([]cosignLayer, error) {
+	image, err := remote.Image(tag, remote.WithAuthFromKeychain(keychain))
+	if err != nil {
+		if isNotFound(err) {
+			return []cosignLayer{}, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read %v", tag)
+	}
+	manifest, err := image.Manifest()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read manifest of %v", tag)
+	}
+	result := []cosignLayer{}
+	for _, descriptor := range manifest.Layers {
+		if descriptor.MediaType != mediaType {
+			continue
+		}
+		layer, err := image.LayerByDigest(descriptor.Digest)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read layer %v of %v", descriptor.Digest, tag)
+		}
+		reader, err := layer.Uncompressed()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read layer %v of %v", descriptor.Digest, tag)
+		}
+		content, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read layer %v of %v", descriptor.Digest, tag)
+		}
+		result = append(result, cosignLayer{content: content, annotations: descriptor.Annotations})
+	}
+	return result, nil
+}
+
+func isNotFound(err error) bool {
+	var transportError *transport.Error
+	return errors.As(err, &transportError) && transportError.StatusCode == http.StatusNotFound
+}
+
+// KeychainFromDockerConfigJSON returns the credentials of the Docker config.json with the given content.
+// Registries not contained in the file are resolved using the default Docker configuration of the environment.
+func KeychainFromDockerConfigJSON(dockerConfigJSON []byte) (authn.Keychain, error) {
+	if len(dockerConfigJSON) == 0 {
+		return authn.DefaultKeychain, nil
+	}
+	configFile, err := config.LoadFromReader(bytes.NewReader(dockerConfigJSON))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse Docker config.json")
+	}
+	return authn.NewMultiKeychain(dockerConfigKeychain{configFile: configFile}, authn.DefaultKeychain), nil
+}
+
+type dockerConfigKeychain struct {
+	configFile *configfile.ConfigFile
+}
+
+// Resolve returns the credentials of the registry, anonymous access if the registry is not contained in the configuration
+func (k dockerConfigKeychain) Resolve(resource authn.Resource) (authn.Authenticator, error) {
+	registry := resource.RegistryStr()
+	if registry == name.DefaultRegistry {
+		registry = authn.DefaultAuthKey
+	}
+	auth, err := k.configFile.GetAuthConfig(registry)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get credentials of registry %v", registry)
+	}
+	if len(auth.Username) == 0 && len(auth.Password) == 0 && len(auth.Auth) == 0 && len(auth.IdentityToken) == 0 && len(auth.RegistryToken) == 0 {
+		return authn.Anonymous, nil
+	}
+	return authn.FromConfig(authn.AuthConfig{
+		Username:      auth.Username,
+		Password:      auth.Password,
+		Auth:          auth.Auth,
+		IdentityToken: auth.IdentityToken,
+		RegistryToken: auth.RegistryToken,
+	}), nil
+}
@@ -0,0 +1,81 @@
+//go:build unit
+// +build unit
+
+package docker
+
+import (
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/jenkins-library/pkg/attestation"
+)
+
+func TestAttestationTag(t *testing.T) {
+	t.Parallel()
+
+	tag, err := AttestationTag("my.registry/app", "sha256:abc")
+	assert.NoError(t, err)
+	assert.Equal(t, "my.registry/app:sha256-abc.att", tag.String())
+
+	_, err = AttestationTag("my.registry/app", "abc")
+	assert.EqualError(t, err, "invalid image digest 'abc'")
+}
+
+func TestAttachAttestation(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	repository := strings.TrimPrefix(server.URL, "http://") + "/app"
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	envelopes, err := GetAttestations(repository, digest, authn.DefaultKeychain)
+	assert.NoError(t, err)
+	assert.Empty(t, envelopes)
+
+	first := attestation.Envelope{PayloadType: attestation.PayloadTypeInToto, Payload: "Zmlyc3Q=", Signatures: []attestation.Signature{{KeyID: "key", Signature: "c2ln"}}}
+	tag, err := AttachAttestation(repository, digest, first, attestation.PredicateTypeSLSAProvenance, authn.DefaultKeychain)
+	assert.NoError(t, err)
+	assert.Equal(t, repository+":sha256-0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef.att", tag)
+
+	second := attestation.Envelope{PayloadType: attestation.PayloadTypeInToto, Payload: "c2Vjb25k", Signatures: []attestation.Signature{}}
+	_, err = AttachAttestation(repository, digest, second, "https://cyclonedx.org/bom", authn.DefaultKeychain)
+	assert.NoError(t, err)
+
+	envelopes, err = GetAttestations(repository, digest, authn.DefaultKeychain)
+	assert.NoError(t, err)
+	assert.Equal(t, []attestation.Envelope{first, second}, envelopes)
+
+	ref, _ := name.NewTag(tag)
+	manifest, err := remote.Get(ref)
+	assert.NoError(t, err)
+	assert.Contains(t, string(manifest.Manifest), `"mediaType":"application/vnd.dsse.envelope.v1+json"`)
+	assert.Contains(t, string(manifest.Manifest), `"predicateType":"https://slsa.dev/provenance/v1"`)
+}
+
+func TestKeychainFromDockerConfigJSON(t *testing.T) {
+	t.Parallel()
+
+	keychain, err := KeychainFromDockerConfigJSON([]byte(`{"auths":{"my.registry":{"username":"user","password":"secret"}}}`))
+	assert.NoError(t, err)
+
+	repository, _ := name.NewRepository("my.registry/app")
+	authenticator, err := keychain.Resolve(repository)
+	assert.NoError(t, err)
+	config, err := authenticator.Authorization()
+	assert.NoError(t, err)
+	assert.Equal(t, "user", config.Username)
+	assert.Equal(t, "secret", config.Password)
+
+	_, err = KeychainFromDockerConfigJSON([]byte(`{"auths":`))
+	assert.ErrorContains(t, err, "failed to parse Docker config.json")
+}
@@ -8,6 +8,8 @@ import (
 
 	containerName "github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
+
+	"github.com/SAP/jenkins-library/pkg/log"
 )
 
 // ContainerRegistryFromURL provides the registry part of a complete registry url including the port
@@ -54,3 +56,55 @@ func ContainerImageNameFromImage(fullImage string) (string, error) {
 
 	return m[0], nil
 }
+
+// ContainerImage is an image in a container registry
+type ContainerImage struct {
+	// Repository is the full name of the image without tag, e.g. my.registry/my-image
+	Repository string
+	Tag        string
+	// Digest is the digest of the image manifest in the format sha256:<hash>, empty if not known
+	Digest string
+}
+
+// Reference returns the reference of the image, by digest if known
+func (c ContainerImage) Reference() string {
+	if len(c.Digest) > 0 {
+		return fmt.Sprintf("%v@%v", c.Repository, c.Digest)
+	}
+	return fmt.Sprintf("%v:%v", c.Repository, c.Tag)
+}
+
+// ContainerImagesFromNameTags returns the images with the given names and tags (e.g. written by kanikoExecute or cnbBuild) in the given registry.
+// The digests are optional, if provided they have to be in the same order as the images. Builds pushing additional tags write
+// one digest per build only, in this case the digests cannot be assigned and are left empty in order to be resolved from the registry.
+func ContainerImagesFromNameTags(registryURL string, imageNameTags, imageDigests []string) ([]ContainerImage, error) {
+	if len(imageDigests) > 0 && len(imageDigests) != len(imageNameTags) {
+		log.Entry().Warnf("number of container images (%v) and image digests (%v) differ, digests are resolved from the registry", len(imageNameTags), len(imageDigests))
+		imageDigests = nil
+	}
+	registry := ""
+	if len(registryURL) > 0 {
+		var err error
+		if registry, err = ContainerRegistryFromURL(registryURL); err != nil {
+			return nil, errors.Wrapf(err, "invalid container registry url '%v'", registryURL)
+		}
+	}
+
+	images := []ContainerImage{}
+	for i, imageNameTag := range imageNameTags {
+		image := imageNameTag
+		if len(registry) > 0 {
+			image = fmt.Sprintf("%v/%v", registry, imageNameTag)
+		}
+		tag, err := containerName.NewTag(image)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid container image '%v'", image)
+		}
+		containerImage := ContainerImage{Repository: tag.Context().Name(), Tag: tag.TagStr()}
+		if len(imageDigests) > 0 {
+			containerImage.Digest = imageDigests[i]
+		}
+		images = append(images, containerImage)
+	}
+	return images, nil
+}
@@ -141,3 +141,39 @@ func TestContainerImageNameFromImage(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerImagesFromNameTags(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with registry and digests", func(t *testing.T) {
+		images, err := ContainerImagesFromNameTags("https://my.registry:5000", []string{"app:1.0.0", "group/other:2.0.0"}, []string{"sha256:abc", "sha256:def"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []ContainerImage{
+			{Repository: "my.registry:5000/app", Tag: "1.0.0", Digest: "sha256:abc"},
+			{Repository: "my.registry:5000/group/other", Tag: "2.0.0", Digest: "sha256:def"},
+		}, images)
+		assert.Equal(t, "my.registry:5000/app@sha256:abc", images[0].Reference())
+	})
+
+	t.Run("without registry and digests", func(t *testing.T) {
+		images, err := ContainerImagesFromNameTags("", []string{"my.registry/app:1.0.0"}, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []ContainerImage{{Repository: "my.registry/app", Tag: "1.0.0"}}, images)
+		assert.Equal(t, "my.registry/app:1.0.0", images[0].Reference())
+	})
+
+	t.Run("digests of additional tags", func(t *testing.T) {
+		// one digest per build, digests are left to be resolved from the registry
+		images, err := ContainerImagesFromNameTags("", []string{"my.registry/app:1.0.0", "my.registry/app:latest"}, []string{"sha256:abc"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []ContainerImage{{Repository: "my.registry/app", Tag: "1.0.0"}, {Repository: "my.registry/app", Tag: "latest"}}, images)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := ContainerImagesFromNameTags("my.registry", []string{"app:1.0.0"}, nil)
+		assert.ErrorContains(t, err, "invalid container registry url 'my.registry'")
+	})
+}
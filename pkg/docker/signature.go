@@ -0,0 +1,73 @@
+package docker
+
+import (
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MediaTypeSimpleSigning is the media type of image layers containing the payload of a cosign signature
+	MediaTypeSimpleSigning = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// cosignSignatureAnnotation is the layer annotation containing the base64 encoded signature
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+)
+
+// ImageSignature is a signature of a container image
+type ImageSignature struct {
+	Payload []byte
+	// Signature is the base64 encoded signature of the payload
+	Signature string
+}
+
+// SignatureTag returns the tag under which the signatures of the image with the given digest are stored.
+// The tag follows the cosign convention `<repository>:<algorithm>-<hex>.sig`.
+func SignatureTag(repository, digest string) (name.Tag, error) {
+	return cosignTag(repository, digest, "sig")
+}
+
+// AttachSignature uploads the signature of the image with the given digest in a cosign compatible way.
+// Signatures which have already been attached to the image are kept.
+func AttachSignature(repository, digest string, signature ImageSignature, keychain authn.Keychain) (string, error) {
+	tag, err := SignatureTag(repository, digest)
+	if err != nil {
+		return "", err
+	}
+	layer := static.NewLayer(signature.Payload, MediaTypeSimpleSigning)
+	if err := appendCosignLayer(tag, layer, map[string]string{cosignSignatureAnnotation: signature.Signature}, keychain); err != nil {
+		return "", err
+	}
+	return tag.String(), nil
+}
+
+// GetSignatures returns the signatures attached to the image with the given digest
+func GetSignatures(repository, digest string, keychain authn.Keychain) ([]ImageSignature, error) {
+	tag, err := SignatureTag(repository, digest)
+	if err != nil {
+		return nil, err
+	}
+	layers, err := cosignLayers(tag, MediaTypeSimpleSigning, keychain)
+	if err != nil {
+		return nil, err
+	}
+	result := []ImageSignature{}
+	for _, layer := range layers {
+		result = append(result, ImageSignature{Payload: layer.content, Signature: layer.annotations[cosignSignatureAnnotation]})
+	}
+	return result, nil
+}
+
+// GetImageDigest returns the digest of the manifest (or manifest list) of the image in the registry
+func GetImageDigest(image string, keychain authn.Keychain) (string, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid image reference '%v'", image)
+	}
+	descriptor, err := remote.Head(ref, remote.WithAuthFromKeychain(keychain))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get digest of image %v", image)
+	}
+	return descriptor.Digest.String(), nil
+}
@@ -0,0 +1,58 @@
+//go:build unit
+// +build unit
+
+package docker
+
+import (
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachSignature(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	repository := strings.TrimPrefix(server.URL, "http://") + "/app"
+
+	image, err := random.Image(64, 1)
+	assert.NoError(t, err)
+	ref, _ := name.NewTag(repository + ":1.0.0")
+	assert.NoError(t, remote.Write(ref, image))
+	imageDigest, _ := image.Digest()
+
+	digest, err := GetImageDigest(repository+":1.0.0", authn.DefaultKeychain)
+	assert.NoError(t, err)
+	assert.Equal(t, imageDigest.String(), digest)
+
+	signatures, err := GetSignatures(repository, digest, authn.DefaultKeychain)
+	assert.NoError(t, err)
+	assert.Empty(t, signatures)
+
+	signature := ImageSignature{Payload: []byte(`{"critical":{}}`), Signature: "c2ln"}
+	tag, err := AttachSignature(repository, digest, signature, authn.DefaultKeychain)
+	assert.NoError(t, err)
+	assert.Equal(t, repository+":"+strings.Replace(digest, ":", "-", 1)+".sig", tag)
+
+	signatures, err = GetSignatures(repository, digest, authn.DefaultKeychain)
+	assert.NoError(t, err)
+	assert.Equal(t, []ImageSignature{signature}, signatures)
+
+	// attestations are stored separately
+	envelopes, err := GetAttestations(repository, digest, authn.DefaultKeychain)
+	assert.NoError(t, err)
+	assert.Empty(t, envelopes)
+
+	_, err = GetImageDigest(repository+":2.0.0", authn.DefaultKeychain)
+	assert.ErrorContains(t, err, "failed to get digest of image")
+}
@@ -350,6 +350,9 @@ func resourceReferenceDetails(resourceRef []config.ResourceReference) string {
 		if resource.Type == config.RefTypeSystemTrustSecret {
 			resourceDetails = addSystemTrustResourceDetails(resource, resourceDetails)
 		}
+		if resource.Type == config.RefTypeExternalSecret || resource.Type == config.RefTypeExternalSecretFile {
+			resourceDetails = addExternalSecretResourceDetails(resource, resourceDetails)
+		}
 	}
 
 	return resourceDetails
@@ -377,6 +380,14 @@ func addSystemTrustResourceDetails(resource config.ResourceReference, resourceDe
 	return resourceDetails
 }
 
+func addExternalSecretResourceDetails(resource config.ResourceReference, resourceDetails string) string {
+	resourceDetails += "<br/>Secret provider resource:<br />"
+	resourceDetails += fmt.Sprintf("&nbsp;&nbsp;name: `%v`<br />", resource.Name)
+	resourceDetails += fmt.Sprintf("&nbsp;&nbsp;default value: `%v`<br />", resource.Default)
+
+	return resourceDetails
+}
+
 func sortStepParameters(stepData *config.StepData, considerMandatory bool) {
 	if stepData.Spec.Inputs.Parameters != nil {
 		parameters := stepData.Spec.Inputs.Parameters
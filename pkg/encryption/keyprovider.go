@@ -0,0 +1,59 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+)
+
+// PasswordKeyProvider encrypts data with AES-GCM using a key derived from a password
+type PasswordKeyProvider struct {
+	aead cipher.AEAD
+}
+
+// NewPasswordKeyProvider creates a key provider for the given password
+func NewPasswordKeyProvider(password string) (*PasswordKeyProvider, error) {
+	if len(password) == 0 {
+		return nil, fmt.Errorf("failed to create cipher: empty password")
+	}
+	key := sha256.Sum256([]byte(password))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return &PasswordKeyProvider{aead: aead}, nil
+}
+
+// Name returns the name of the key provider
+func (p *PasswordKeyProvider) Name() string {
+	return "password"
+}
+
+// Encrypt encrypts the plain text, the random nonce is prepended to the cipher text
+func (p *PasswordKeyProvider) Encrypt(plainText []byte) ([]byte, error) {
+	nonce := make([]byte, p.aead.NonceSize(), p.aead.NonceSize()+len(plainText)+p.aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to init nonce: %w", err)
+	}
+	return p.aead.Seal(nonce, nonce, plainText, nil), nil
+}
+
+// Decrypt decrypts cipher text created by Encrypt
+func (p *PasswordKeyProvider) Decrypt(cipherText []byte) ([]byte, error) {
+	if len(cipherText) < p.aead.NonceSize() {
+		return nil, fmt.Errorf("invalid ciphertext: too short")
+	}
+	nonce, cipherText := cipherText[:p.aead.NonceSize()], cipherText[p.aead.NonceSize():]
+	plainText, err := p.aead.Open(nil, nonce, cipherText, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: wrong password or corrupted data")
+	}
+	return plainText, nil
+}
@@ -0,0 +1,46 @@
+//go:build unit
+// +build unit
+
+package encryption
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordKeyProvider(t *testing.T) {
+	t.Run("encrypt and decrypt", func(t *testing.T) {
+		provider, err := NewPasswordKeyProvider("test-password")
+		assert.NoError(t, err)
+		assert.Equal(t, "password", provider.Name())
+
+		cipherText, err := provider.Encrypt([]byte("hello world"))
+		assert.NoError(t, err)
+		assert.NotContains(t, string(cipherText), "hello world")
+
+		plainText, err := provider.Decrypt(cipherText)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world", string(plainText))
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		provider, _ := NewPasswordKeyProvider("test-password")
+		cipherText, _ := provider.Encrypt([]byte("hello world"))
+
+		otherProvider, _ := NewPasswordKeyProvider("other-password")
+		_, err := otherProvider.Decrypt(cipherText)
+		assert.EqualError(t, err, "failed to decrypt: wrong password or corrupted data")
+	})
+
+	t.Run("invalid cipher text", func(t *testing.T) {
+		provider, _ := NewPasswordKeyProvider("test-password")
+		_, err := provider.Decrypt([]byte("short"))
+		assert.EqualError(t, err, "invalid ciphertext: too short")
+	})
+
+	t.Run("empty password", func(t *testing.T) {
+		_, err := NewPasswordKeyProvider("")
+		assert.EqualError(t, err, "failed to create cipher: empty password")
+	})
+}
@@ -0,0 +1,93 @@
+package encryption
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+)
+
+// The X25519KeyProvider writes files in the age v1 format (https://age-encryption.org/v1) with a single X25519 recipient,
+// i.e. encrypted data can also be decrypted with the age command line tool and the same key file.
+
+const ageSecretKeyPrefix = "AGE-SECRET-KEY-1"
+
+// X25519KeyProvider encrypts data for the X25519 key pair of an age key file
+type X25519KeyProvider struct {
+	identity *age.X25519Identity
+}
+
+// GenerateX25519Identity creates a new key pair and returns it in the format of age-keygen
+func GenerateX25519Identity() (identity, recipient string, err error) {
+	key, err := age.GenerateX25519Identity()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	return key.String(), key.Recipient().String(), nil
+}
+
+// NewX25519KeyProvider creates a key provider using the first identity (AGE-SECRET-KEY-1...) of the given key file content
+func NewX25519KeyProvider(keyFile []byte) (*X25519KeyProvider, error) {
+	for _, line := range strings.Split(string(keyFile), "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, ageSecretKeyPrefix) {
+			// do not print the line since it may contain key material
+			return nil, fmt.Errorf("invalid key file: expected identity starting with '%v'", ageSecretKeyPrefix)
+		}
+		identity, err := age.ParseX25519Identity(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key file: %w", err)
+		}
+		return &X25519KeyProvider{identity: identity}, nil
+	}
+	return nil, fmt.Errorf("invalid key file: no identity found")
+}
+
+// Name returns the name of the key provider
+func (p *X25519KeyProvider) Name() string {
+	return "x25519"
+}
+
+// Recipient returns the public key of the key pair (age1...)
+func (p *X25519KeyProvider) Recipient() string {
+	return p.identity.Recipient().String()
+}
+
+// Encrypt encrypts the plain text in the age format
+func (p *X25519KeyProvider) Encrypt(plainText []byte) ([]byte, error) {
+	var out bytes.Buffer
+	writer, err := age.Encrypt(&out, p.identity.Recipient())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	if _, err := writer.Write(plainText); err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// Decrypt decrypts data in the age format which has been encrypted for the X25519 key pair of the provider
+func (p *X25519KeyProvider) Decrypt(cipherText []byte) ([]byte, error) {
+	reader, err := age.Decrypt(bytes.NewReader(cipherText), p.identity)
+	if err != nil {
+		var noMatch *age.NoIdentityMatchError
+		if errors.As(err, &noMatch) {
+			return nil, fmt.Errorf("no recipient matches the identity of the key file")
+		}
+		return nil, fmt.Errorf("invalid age header: %w", err)
+	}
+	plainText, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("invalid age payload: %w", err)
+	}
+	return plainText, nil
+}
@@ -0,0 +1,82 @@
+//go:build unit
+// +build unit
+
+package encryption
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestX25519KeyProvider(t *testing.T) {
+	identity, recipient, err := GenerateX25519Identity()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(identity, "AGE-SECRET-KEY-1"))
+	assert.True(t, strings.HasPrefix(recipient, "age1"))
+
+	keyFile := "# created: 2024-01-01T00:00:00Z\n# public key: " + recipient + "\n" + identity + "\n"
+
+	t.Run("encrypt and decrypt", func(t *testing.T) {
+		provider, err := NewX25519KeyProvider([]byte(keyFile))
+		assert.NoError(t, err)
+		assert.Equal(t, "x25519", provider.Name())
+		assert.Equal(t, recipient, provider.Recipient())
+
+		// payloads spanning several chunks as well as an empty one
+		for _, plainText := range [][]byte{{}, []byte("hello world"), bytes.Repeat([]byte("a"), 64*1024), bytes.Repeat([]byte("b"), 2*64*1024+1)} {
+			cipherText, err := provider.Encrypt(plainText)
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(string(cipherText), "age-encryption.org/v1\n-> X25519 "))
+
+			decrypted, err := provider.Decrypt(cipherText)
+			if assert.NoError(t, err) {
+				assert.Equal(t, len(plainText), len(decrypted))
+				assert.True(t, bytes.Equal(plainText, decrypted))
+			}
+		}
+	})
+
+	t.Run("known age key pair", func(t *testing.T) {
+		// example key pair from the age documentation
+		provider, err := NewX25519KeyProvider([]byte("AGE-SECRET-KEY-1GFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPQ4EGAEX"))
+		assert.NoError(t, err)
+		assert.Equal(t, "age1zvkyg2lqzraa2lnjvqej32nkuu0ues2s82hzrye869xeexvn73equnujwj", provider.Recipient())
+	})
+
+	t.Run("different identity", func(t *testing.T) {
+		provider, _ := NewX25519KeyProvider([]byte(keyFile))
+		cipherText, _ := provider.Encrypt([]byte("hello world"))
+
+		otherIdentity, _, _ := GenerateX25519Identity()
+		otherProvider, _ := NewX25519KeyProvider([]byte(otherIdentity))
+		_, err := otherProvider.Decrypt(cipherText)
+		assert.EqualError(t, err, "no recipient matches the identity of the key file")
+	})
+
+	t.Run("manipulated payload", func(t *testing.T) {
+		provider, _ := NewX25519KeyProvider([]byte(keyFile))
+		cipherText, _ := provider.Encrypt([]byte("hello world"))
+		cipherText[len(cipherText)-1] ^= 1
+
+		_, err := provider.Decrypt(cipherText)
+		assert.ErrorContains(t, err, "invalid age payload")
+	})
+
+	t.Run("invalid key file", func(t *testing.T) {
+		_, err := NewX25519KeyProvider([]byte("# no key\n"))
+		assert.EqualError(t, err, "invalid key file: no identity found")
+
+		_, err = NewX25519KeyProvider([]byte("secret"))
+		assert.EqualError(t, err, "invalid key file: expected identity starting with 'AGE-SECRET-KEY-1'")
+
+		manipulated := identity[:len(identity)-1] + "Q"
+		if strings.HasSuffix(identity, "Q") {
+			manipulated = identity[:len(identity)-1] + "P"
+		}
+		_, err = NewX25519KeyProvider([]byte(manipulated))
+		assert.EqualError(t, err, "invalid key file: malformed secret key: invalid checksum")
+	})
+}
@@ -1,8 +1,10 @@
 package format
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"strings"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/ghodss/yaml"
@@ -39,6 +41,34 @@ const (
 	WronglyReported       AssessmentAnalysis = "wronglyReported"       //"Wrongly reported CVE"
 )
 
+var assessmentAnalysisDescriptions = map[AssessmentAnalysis]string{
+	WaitingForFix:         "Waiting for OSS community fix",
+	RiskAccepted:          "Risk Accepted",
+	NotPresent:            "Affected parts of the OSS library are not present",
+	NotUsed:               "Affected parts of the OSS library are not used",
+	AssessmentPropagation: "Assessment Propagation",
+	FixedByDevTeam:        "OSS Component fixed by development team",
+	Mitigated:             "Mitigated by the Application",
+	WronglyReported:       "Wrongly reported CVE",
+}
+
+// Description returns the human readable description of the analysis
+func (a AssessmentAnalysis) Description() string {
+	if description, ok := assessmentAnalysisDescriptions[a]; ok {
+		return description
+	}
+	return string(a)
+}
+
+func analysisFromDescription(description string) (AssessmentAnalysis, bool) {
+	for analysis, text := range assessmentAnalysisDescriptions {
+		if strings.EqualFold(text, strings.TrimSpace(description)) {
+			return analysis, true
+		}
+	}
+	return "", false
+}
+
 type Purl struct {
 	Purl string `json:"purl"`
 }
@@ -103,7 +133,8 @@ func (a Assessment) ToImpactAnalysisResponse() *[]cdx.ImpactAnalysisResponse {
 	return &[]cdx.ImpactAnalysisResponse{cdx.IARWillNotFix}
 }
 
-// ReadAssessment loads the assessments and returns their contents
+// ReadAssessments loads the assessments and returns their contents.
+// Besides the assessment YAML format also VEX documents (OpenVEX or CycloneDX VEX) are supported.
 func ReadAssessments(assessmentFile io.ReadCloser) (*[]Assessment, error) {
 	defer assessmentFile.Close()
 	ignore := struct {
@@ -117,9 +148,30 @@ func ReadAssessments(assessmentFile io.ReadCloser) (*[]Assessment, error) {
 		return nil, errors.Wrapf(err, "error reading %v", assessmentFile)
 	}
 
+	if assessments, isVEX, err := readVEX(content); isVEX {
+		return assessments, err
+	}
+
 	err = yaml.Unmarshal(content, &ignore)
 	if err != nil {
 		return nil, NewParseError(fmt.Sprintf("format of assessment file is invalid %q: %v", content, err))
 	}
 	return &ignore.Assessments, nil
 }
+
+type assessmentFileReader interface {
+	FileRead(path string) ([]byte, error)
+}
+
+// ReadAssessmentFile loads the assessments from an assessment YAML file or a VEX document
+func ReadAssessmentFile(path string, utils assessmentFileReader) ([]Assessment, error) {
+	content, err := utils.FileRead(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read assessment file %v", path)
+	}
+	assessments, err := ReadAssessments(io.NopCloser(bytes.NewReader(content)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse assessment file %v", path)
+	}
+	return *assessments, nil
+}
@@ -0,0 +1,344 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Values of the baselineState of a result
+const (
+	BaselineStateNew       = "new"
+	BaselineStateUnchanged = "unchanged"
+)
+
+// BaselineComparison summarizes the comparison of SARIF results against a baseline
+type BaselineComparison struct {
+	New       int
+	Unchanged int
+	// Absent counts the results of the baseline which are not reported anymore
+	Absent int
+}
+
+// SarifDocument is a SARIF document kept in its generic JSON representation.
+// In contrast to SARIF it preserves all properties of the original file, e.g. properties, fingerprints and partial fingerprints
+// which are not known to this package, and does not add any properties when it is written again.
+type SarifDocument map[string]interface{}
+
+// ParseSarif reads a SARIF document
+func ParseSarif(content []byte) (SARIF, error) {
+	sarif := SARIF{}
+	if err := json.Unmarshal(content, &sarif); err != nil {
+		return SARIF{}, fmt.Errorf("failed to parse SARIF: %w", err)
+	}
+	if len(sarif.Version) > 0 && sarif.Version != "2.1.0" {
+		return SARIF{}, fmt.Errorf("unsupported SARIF version '%v'", sarif.Version)
+	}
+	return sarif, nil
+}
+
+// ParseSarifDocument reads a SARIF document keeping all of its properties
+func ParseSarifDocument(content []byte) (SarifDocument, error) {
+	document := SarifDocument{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	// keep numbers as they are, e.g. large ids
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("failed to parse SARIF: %w", err)
+	}
+	if version, _ := document["version"].(string); len(version) > 0 && version != "2.1.0" {
+		return nil, fmt.Errorf("unsupported SARIF version '%v'", version)
+	}
+	return document, nil
+}
+
+// SARIF returns the typed representation of the document, e.g. to evaluate severities. Properties unknown to SARIF are dropped.
+func (d SarifDocument) SARIF() (SARIF, error) {
+	content, err := json.Marshal(d)
+	if err != nil {
+		return SARIF{}, fmt.Errorf("failed to marshal SARIF: %w", err)
+	}
+	return ParseSarif(content)
+}
+
+// RunCount returns the number of runs of the document
+func (d SarifDocument) RunCount() int {
+	return len(sarifRuns(d))
+}
+
+// ResultCount returns the number of results of all runs of the document
+func (d SarifDocument) ResultCount() int {
+	count := 0
+	for _, run := range sarifRuns(d) {
+		count += len(sarifRunResults(run))
+	}
+	return count
+}
+
+// MergeSarif combines the runs of several SARIF documents into one multi-run document.
+// The runs are kept separate since rule indices and artifact indices of the results refer to their run.
+// The documents passed are not modified by changes of the merged document.
+func MergeSarif(documents ...SarifDocument) SarifDocument {
+	runs := []interface{}{}
+	for _, document := range documents {
+		for _, run := range sarifRuns(document) {
+			merged := copyMap(run)
+			results := []map[string]interface{}{}
+			for _, result := range sarifRunResults(run) {
+				results = append(results, copyMap(result))
+			}
+			setSarifRunResults(merged, results)
+			runs = append(runs, merged)
+		}
+	}
+	return SarifDocument{
+		"$schema": "https://docs.oasis-open.org/sarif/sarif/v2.1.0/cos02/schemas/sarif-schema-2.1.0.json",
+		"version": "2.1.0",
+		"runs":    runs,
+	}
+}
+
+// DeduplicateSarif removes results which are reported more than once. It returns the number of removed results.
+//
+// Results of the same tool are removed as described for RemoveSarifDuplicates.
+// Results which have already been reported by a different tool are removed as described for SarifCrossToolDuplicates.
+func DeduplicateSarif(sarif SarifDocument) (duplicates, crossToolDuplicates int) {
+	duplicates = RemoveSarifDuplicates(sarif)
+	isCrossToolDuplicate := SarifCrossToolDuplicates(sarif)
+	for i, run := range sarifRuns(sarif) {
+		results := []map[string]interface{}{}
+		for j, result := range sarifRunResults(run) {
+			if isCrossToolDuplicate[i][j] {
+				crossToolDuplicates++
+				continue
+			}
+			results = append(results, result)
+		}
+		setSarifRunResults(run, results)
+	}
+	return duplicates, crossToolDuplicates
+}
+
+// RemoveSarifDuplicates removes results of the same tool, in the same or in different runs, if rule, fingerprints and primary location match.
+// The baseline state of a removed result is kept if the remaining result does not have one. It returns the number of removed results.
+func RemoveSarifDuplicates(sarif SarifDocument) int {
+	duplicates := 0
+	known := map[string]map[string]interface{}{}
+	for _, run := range sarifRuns(sarif) {
+		toolName := sarifToolName(run)
+		results := []map[string]interface{}{}
+		for _, result := range sarifRunResults(run) {
+			key := SarifResultKey(toolName, result) + "|" + sarifLocationKey(result, true)
+			if kept, ok := known[key]; ok {
+				if _, hasState := kept["baselineState"]; !hasState && result["baselineState"] != nil {
+					kept["baselineState"] = result["baselineState"]
+				}
+				duplicates++
+				continue
+			}
+			known[key] = result
+			results = append(results, result)
+		}
+		setSarifRunResults(run, results)
+	}
+	return duplicates
+}
+
+// SarifCrossToolDuplicates returns per run and result whether the result has already been reported by a different tool,
+// i.e. a previous result of another tool shares a CWE and its primary location refers to the same line of the same file.
+// The document is not modified, e.g. to evaluate the results of each tool before the duplicates are removed.
+func SarifCrossToolDuplicates(sarif SarifDocument) [][]bool {
+	duplicates := [][]bool{}
+	// tools which reported a CWE at a location
+	crossToolKnown := map[string]string{}
+	for _, run := range sarifRuns(sarif) {
+		toolName := sarifToolName(run)
+		cweKeys := crossToolKeys(run)
+		runDuplicates := make([]bool, len(cweKeys))
+		for i := range cweKeys {
+			if isCrossToolDuplicate(cweKeys[i], toolName, crossToolKnown) {
+				runDuplicates[i] = true
+				continue
+			}
+			for _, cweKey := range cweKeys[i] {
+				if _, ok := crossToolKnown[cweKey]; !ok {
+					crossToolKnown[cweKey] = toolName
+				}
+			}
+		}
+		duplicates = append(duplicates, runDuplicates)
+	}
+	return duplicates
+}
+
+func isCrossToolDuplicate(cweKeys []string, toolName string, crossToolKnown map[string]string) bool {
+	for _, cweKey := range cweKeys {
+		if tool, ok := crossToolKnown[cweKey]; ok && tool != toolName {
+			return true
+		}
+	}
+	return false
+}
+
+// crossToolKeys returns per result of the run the keys consisting of CWE and primary location, no keys if either is not known
+func crossToolKeys(run map[string]interface{}) [][]string {
+	results := sarifRunResults(run)
+	keys := make([][]string, len(results))
+	typedRun := Runs{}
+	if content, err := json.Marshal(run); err != nil || json.Unmarshal(content, &typedRun) != nil || len(typedRun.Results) != len(results) {
+		return keys
+	}
+	for i, result := range results {
+		location := sarifLocationKey(result, false)
+		if len(location) == 0 {
+			continue
+		}
+		for _, cwe := range SarifResultCWEs(typedRun, typedRun.Results[i]) {
+			keys[i] = append(keys[i], cwe+"|"+location)
+		}
+	}
+	return keys
+}
+
+// ApplySarifBaseline sets the baselineState of all results depending on whether the result is already contained in the baseline
+func ApplySarifBaseline(sarif SarifDocument, baseline SarifDocument) BaselineComparison {
+	comparison := BaselineComparison{}
+	baselineKeys := map[string]bool{}
+	for _, run := range sarifRuns(baseline) {
+		for _, result := range sarifRunResults(run) {
+			baselineKeys[SarifResultKey(sarifToolName(run), result)] = true
+		}
+	}
+	reported := map[string]bool{}
+	for _, run := range sarifRuns(sarif) {
+		for _, result := range sarifRunResults(run) {
+			key := SarifResultKey(sarifToolName(run), result)
+			reported[key] = true
+			if baselineKeys[key] {
+				result["baselineState"] = BaselineStateUnchanged
+				comparison.Unchanged++
+			} else {
+				result["baselineState"] = BaselineStateNew
+				comparison.New++
+			}
+		}
+	}
+	for key := range baselineKeys {
+		if !reported[key] {
+			comparison.Absent++
+		}
+	}
+	return comparison
+}
+
+// RemoveUnchangedResults removes all results which are already contained in the baseline, see ApplySarifBaseline
+func RemoveUnchangedResults(sarif SarifDocument) {
+	for _, run := range sarifRuns(sarif) {
+		results := []map[string]interface{}{}
+		for _, result := range sarifRunResults(run) {
+			if result["baselineState"] != BaselineStateUnchanged {
+				results = append(results, result)
+			}
+		}
+		setSarifRunResults(run, results)
+	}
+}
+
+// SarifResultKey identifies a result of a tool independent of the scan, e.g. to compare it with the results of a previous scan.
+// The partial fingerprints are used if the tool provides them since they are stable when lines are added above the finding.
+// Otherwise the result is identified by rule and primary location.
+func SarifResultKey(toolName string, result map[string]interface{}) string {
+	ruleID := stringValue(result, "ruleId")
+	if fingerprints, _ := result["partialFingerprints"].(map[string]interface{}); len(fingerprints) > 0 {
+		names := make([]string, 0, len(fingerprints))
+		for name := range fingerprints {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		parts := []string{toolName, ruleID}
+		for _, name := range names {
+			parts = append(parts, fmt.Sprintf("%v=%v", name, fingerprints[name]))
+		}
+		return strings.Join(parts, "|")
+	}
+	key := strings.Join([]string{toolName, ruleID, sarifLocationKey(result, true)}, "|")
+	if _, hasLocations := result["locations"]; !hasLocations {
+		key += "|" + stringValue(result, "message", "text")
+	}
+	return key
+}
+
+// sarifLocationKey returns file and line of the primary location, optionally including the column
+func sarifLocationKey(result map[string]interface{}, withColumn bool) string {
+	locations, _ := result["locations"].([]interface{})
+	if len(locations) == 0 {
+		return ""
+	}
+	location, _ := locations[0].(map[string]interface{})
+	uri := stringValue(location, "physicalLocation", "artifactLocation", "uri")
+	line := stringValue(location, "physicalLocation", "region", "startLine")
+	if !withColumn {
+		if len(uri) == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%v:%v", uri, line)
+	}
+	return fmt.Sprintf("%v:%v:%v", uri, line, stringValue(location, "physicalLocation", "region", "startColumn"))
+}
+
+func sarifRuns(sarif SarifDocument) []map[string]interface{} {
+	return objects(sarif["runs"])
+}
+
+func sarifRunResults(run map[string]interface{}) []map[string]interface{} {
+	return objects(run["results"])
+}
+
+func setSarifRunResults(run map[string]interface{}, results []map[string]interface{}) {
+	values := make([]interface{}, len(results))
+	for i, result := range results {
+		values[i] = result
+	}
+	run["results"] = values
+}
+
+func sarifToolName(run map[string]interface{}) string {
+	return stringValue(run, "tool", "driver", "name")
+}
+
+func objects(value interface{}) []map[string]interface{} {
+	list, _ := value.([]interface{})
+	result := make([]map[string]interface{}, 0, len(list))
+	for _, entry := range list {
+		if object, ok := entry.(map[string]interface{}); ok {
+			result = append(result, object)
+		}
+	}
+	return result
+}
+
+// stringValue returns the value at the given path as string, numbers are formatted as in the document
+func stringValue(object map[string]interface{}, path ...string) string {
+	var value interface{} = object
+	for _, name := range path {
+		current, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = current[name]
+	}
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+func copyMap(source map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(source))
+	for key, value := range source {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,208 @@
+//go:build unit
+// +build unit
+
+package format
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sarifResult(ruleID, uri string, line int, fingerprints PartialFingerprints) Results {
+	return Results{
+		RuleID:              ruleID,
+		Message:             &Message{Text: ruleID + " in " + uri},
+		Locations:           []Location{{PhysicalLocation: PhysicalLocation{ArtifactLocation: ArtifactLocation{URI: uri}, Region: Region{StartLine: line}}}},
+		PartialFingerprints: fingerprints,
+	}
+}
+
+func sarifRun(toolName string, results ...Results) Runs {
+	return Runs{Tool: Tool{Driver: Driver{Name: toolName}}, Results: results}
+}
+
+func TestParseSarif(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		sarif, err := ParseSarif([]byte(`{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "CodeQL"}}, "results": [{"ruleId": "js/xss", "baselineState": "new"}]}]}`))
+		assert.NoError(t, err)
+		assert.Equal(t, "CodeQL", sarif.Runs[0].Tool.Driver.Name)
+		assert.Equal(t, "new", sarif.Runs[0].Results[0].BaselineState)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := ParseSarif([]byte(`{"version": "1.0.0", "runs": []}`))
+		assert.EqualError(t, err, "unsupported SARIF version '1.0.0'")
+	})
+
+	t.Run("invalid content", func(t *testing.T) {
+		_, err := ParseSarif([]byte(`<xml/>`))
+		assert.ErrorContains(t, err, "failed to parse SARIF")
+	})
+}
+
+// sarifDocument converts a typed SARIF document into its generic representation
+func sarifDocument(t *testing.T, sarif SARIF) SarifDocument {
+	content, err := json.Marshal(sarif)
+	require.NoError(t, err)
+	document, err := ParseSarifDocument(content)
+	require.NoError(t, err)
+	return document
+}
+
+func typedSarif(t *testing.T, document SarifDocument) SARIF {
+	sarif, err := document.SARIF()
+	require.NoError(t, err)
+	return sarif
+}
+
+func TestParseSarifDocument(t *testing.T) {
+	t.Run("unknown properties are preserved", func(t *testing.T) {
+		content := `{"version":"2.1.0","runs":[{"properties":{"scanId":12345678901234567890},"results":[{"fingerprints":{"sha":"1"},"partialFingerprints":{"custom/v1":"a"},"properties":{"tags":["x"]},"ruleId":"r"}],"tool":{"driver":{"name":"Semgrep"}}}]}`
+		document, err := ParseSarifDocument([]byte(content))
+		require.NoError(t, err)
+		assert.Equal(t, 1, document.RunCount())
+		assert.Equal(t, 1, document.ResultCount())
+
+		merged := MergeSarif(document)
+		duplicates, crossToolDuplicates := DeduplicateSarif(merged)
+		assert.Equal(t, 0, duplicates+crossToolDuplicates)
+		written, err := json.Marshal(merged)
+		require.NoError(t, err)
+		assert.Contains(t, string(written), `"runs":[{"properties":{"scanId":12345678901234567890},"results":[{"fingerprints":{"sha":"1"},"partialFingerprints":{"custom/v1":"a"},"properties":{"tags":["x"]},"ruleId":"r"}],"tool":{"driver":{"name":"Semgrep"}}}]`)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := ParseSarifDocument([]byte(`{"version": "1.0.0", "runs": []}`))
+		assert.EqualError(t, err, "unsupported SARIF version '1.0.0'")
+	})
+
+	t.Run("invalid content", func(t *testing.T) {
+		_, err := ParseSarifDocument([]byte(`<xml/>`))
+		assert.ErrorContains(t, err, "failed to parse SARIF")
+	})
+}
+
+func TestMergeAndDeduplicateSarif(t *testing.T) {
+	t.Run("same tool", func(t *testing.T) {
+		checkmarx := sarifDocument(t, SARIF{Runs: []Runs{sarifRun("Checkmarx",
+			sarifResult("SQL_Injection", "src/db.js", 10, PartialFingerprints{CheckmarxSimilarityID: "1234"}),
+			sarifResult("SQL_Injection", "src/db.js", 10, PartialFingerprints{CheckmarxSimilarityID: "1234"}),
+			sarifResult("SQL_Injection", "src/api.js", 20, PartialFingerprints{CheckmarxSimilarityID: "1234"}),
+		)}})
+		codeql := sarifDocument(t, SARIF{Runs: []Runs{sarifRun("CodeQL", sarifResult("js/sql-injection", "src/db.js", 10, PartialFingerprints{}))}})
+		// the same CodeQL result uploaded twice, already compared with a baseline
+		codeqlAgain := sarifDocument(t, SARIF{Runs: []Runs{sarifRun("CodeQL", sarifResult("js/sql-injection", "src/db.js", 10, PartialFingerprints{}))}})
+		objects(objects(codeqlAgain["runs"])[0]["results"])[0]["baselineState"] = BaselineStateUnchanged
+
+		merged := MergeSarif(checkmarx, codeql, codeqlAgain)
+		assert.Equal(t, "2.1.0", merged["version"])
+		assert.Equal(t, 3, merged.RunCount())
+
+		duplicates, crossToolDuplicates := DeduplicateSarif(merged)
+		assert.Equal(t, 2, duplicates)
+		assert.Equal(t, 0, crossToolDuplicates)
+		sarif := typedSarif(t, merged)
+		assert.Len(t, sarif.Runs[0].Results, 2)
+		assert.Len(t, sarif.Runs[1].Results, 1)
+		assert.Equal(t, BaselineStateUnchanged, sarif.Runs[1].Results[0].BaselineState)
+		assert.Len(t, sarif.Runs[2].Results, 0)
+		// the documents passed to MergeSarif are not modified
+		assert.Equal(t, 3, checkmarx.ResultCount())
+		assert.Nil(t, objects(objects(codeql["runs"])[0]["results"])[0]["baselineState"])
+	})
+
+	t.Run("different tools", func(t *testing.T) {
+		cweRule := func(id, cwe string) SarifRule {
+			return SarifRule{ID: id, Properties: &SarifRuleProperties{Tags: []string{"security", "external/cwe/cwe-" + cwe}}}
+		}
+		codeqlRun := sarifRun("CodeQL",
+			sarifResult("js/sql-injection", "src/db.js", 10, PartialFingerprints{}),
+			sarifResult("js/xss", "src/web.js", 3, PartialFingerprints{}),
+		)
+		codeqlRun.Tool.Driver.Rules = []SarifRule{cweRule("js/sql-injection", "089"), cweRule("js/xss", "079")}
+		otherRun := sarifRun("Semgrep",
+			// same CWE and line
+			sarifResult("sqli", "src/db.js", 10, PartialFingerprints{}),
+			// same CWE in another line
+			sarifResult("sqli", "src/db.js", 12, PartialFingerprints{}),
+			// same line with another CWE
+			sarifResult("path-traversal", "src/web.js", 3, PartialFingerprints{}),
+		)
+		otherRun.Tool.Driver.Rules = []SarifRule{cweRule("sqli", "89"), cweRule("path-traversal", "22")}
+
+		merged := MergeSarif(sarifDocument(t, SARIF{Runs: []Runs{codeqlRun}}), sarifDocument(t, SARIF{Runs: []Runs{otherRun}}))
+
+		assert.Equal(t, [][]bool{{false, false}, {true, false, false}}, SarifCrossToolDuplicates(merged))
+		// the duplicates are only determined
+		assert.Equal(t, 5, merged.ResultCount())
+
+		duplicates, crossToolDuplicates := DeduplicateSarif(merged)
+		assert.Equal(t, 0, duplicates)
+		assert.Equal(t, 1, crossToolDuplicates)
+		sarif := typedSarif(t, merged)
+		assert.Len(t, sarif.Runs[0].Results, 2)
+		if assert.Len(t, sarif.Runs[1].Results, 2) {
+			assert.Equal(t, 12, sarif.Runs[1].Results[0].Locations[0].PhysicalLocation.Region.StartLine)
+			assert.Equal(t, "path-traversal", sarif.Runs[1].Results[1].RuleID)
+		}
+	})
+}
+
+func TestApplySarifBaseline(t *testing.T) {
+	baseline := sarifDocument(t, SARIF{Runs: []Runs{
+		sarifRun("Fortify",
+			sarifResult("Password Management", "src/config.java", 12, PartialFingerprints{FortifyInstanceID: "abcd"}),
+			sarifResult("Log Forging", "src/log.java", 7, PartialFingerprints{FortifyInstanceID: "efgh"}),
+		),
+		sarifRun("CodeQL", sarifResult("java/xss", "src/web.java", 30, PartialFingerprints{})),
+	}})
+	sarif := sarifDocument(t, SARIF{Runs: []Runs{
+		sarifRun("Fortify",
+			// lines moved but the fingerprint is stable
+			sarifResult("Password Management", "src/config.java", 15, PartialFingerprints{FortifyInstanceID: "abcd"}),
+			sarifResult("Path Manipulation", "src/file.java", 3, PartialFingerprints{FortifyInstanceID: "ijkl"}),
+		),
+		sarifRun("CodeQL",
+			sarifResult("java/xss", "src/web.java", 30, PartialFingerprints{}),
+			sarifResult("java/xss", "src/web.java", 42, PartialFingerprints{}),
+		),
+	}})
+
+	comparison := ApplySarifBaseline(sarif, baseline)
+	assert.Equal(t, BaselineComparison{New: 2, Unchanged: 2, Absent: 1}, comparison)
+	typed := typedSarif(t, sarif)
+	assert.Equal(t, BaselineStateUnchanged, typed.Runs[0].Results[0].BaselineState)
+	assert.Equal(t, BaselineStateNew, typed.Runs[0].Results[1].BaselineState)
+	assert.Equal(t, BaselineStateUnchanged, typed.Runs[1].Results[0].BaselineState)
+	assert.Equal(t, BaselineStateNew, typed.Runs[1].Results[1].BaselineState)
+
+	RemoveUnchangedResults(sarif)
+	typed = typedSarif(t, sarif)
+	assert.Len(t, typed.Runs[0].Results, 1)
+	assert.Equal(t, "Path Manipulation", typed.Runs[0].Results[0].RuleID)
+	assert.Len(t, typed.Runs[1].Results, 1)
+	assert.Equal(t, 42, typed.Runs[1].Results[0].Locations[0].PhysicalLocation.Region.StartLine)
+}
+
+func TestSarifResultKey(t *testing.T) {
+	t.Run("same rule in different tools", func(t *testing.T) {
+		result := map[string]interface{}{"ruleId": "rule", "locations": []interface{}{map[string]interface{}{"physicalLocation": map[string]interface{}{"artifactLocation": map[string]interface{}{"uri": "file.go"}}}}}
+		assert.NotEqual(t, SarifResultKey("toolA", result), SarifResultKey("toolB", result))
+	})
+
+	t.Run("results without location", func(t *testing.T) {
+		first := map[string]interface{}{"ruleId": "CVE-2024-1234", "message": map[string]interface{}{"text": "pkg:npm/lodash@4.17.20"}}
+		second := map[string]interface{}{"ruleId": "CVE-2024-1234", "message": map[string]interface{}{"text": "pkg:npm/lodash@4.17.15"}}
+		assert.NotEqual(t, SarifResultKey("WhiteSource", first), SarifResultKey("WhiteSource", second))
+	})
+
+	t.Run("partial fingerprints unknown to SARIF", func(t *testing.T) {
+		first := map[string]interface{}{"ruleId": "rule", "partialFingerprints": map[string]interface{}{"custom/v1": "a", "other/v1": "b"}}
+		second := map[string]interface{}{"ruleId": "rule", "partialFingerprints": map[string]interface{}{"custom/v1": "a", "other/v1": "c"}}
+		assert.Equal(t, "Semgrep|rule|custom/v1=a|other/v1=b", SarifResultKey("Semgrep", first))
+		assert.NotEqual(t, SarifResultKey("Semgrep", first), SarifResultKey("Semgrep", second))
+	})
+}
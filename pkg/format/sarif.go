@@ -40,6 +40,7 @@ type Results struct {
 	CodeFlows           []CodeFlow          `json:"codeFlows,omitempty"`
 	RelatedLocations    []RelatedLocation   `json:"relatedLocations,omitempty"`
 	PartialFingerprints PartialFingerprints `json:"partialFingerprints,omitempty"`
+	BaselineState       string              `json:"baselineState,omitempty"`
 	Properties          *SarifProperties    `json:"properties,omitempty"`
 }
 
@@ -0,0 +1,123 @@
+package format
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Unified severities of SARIF results independent of the reporting tool
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+	SeverityLow      = "low"
+	SeverityInfo     = "info"
+)
+
+// Severities lists the unified severities from the most to the least severe
+var Severities = []string{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityInfo}
+
+// SarifResultSeverity returns the unified severity of a result.
+// The severity reported by the tool takes precedence over the security-severity score of the rule and the level of the result.
+func SarifResultSeverity(run Runs, result Results) string {
+	if result.Properties != nil {
+		if severity := NormalizeSeverity(result.Properties.UnifiedSeverity); len(severity) > 0 {
+			return severity
+		}
+		if severity := NormalizeSeverity(result.Properties.ToolSeverity); len(severity) > 0 {
+			return severity
+		}
+	}
+	if rule := sarifRule(run, result); rule != nil && rule.Properties != nil && len(rule.Properties.SecuritySeverity) > 0 {
+		if score, err := strconv.ParseFloat(rule.Properties.SecuritySeverity, 64); err == nil {
+			return SeverityFromScore(score)
+		}
+	}
+	switch result.Level {
+	case "error":
+		return SeverityHigh
+	case "warning", "":
+		// warning is the default level of a result
+		return SeverityMedium
+	case "note":
+		return SeverityLow
+	default:
+		return SeverityInfo
+	}
+}
+
+// SarifResultCWEs returns the CWE ids of a result in the format CWE-<id>, taken from the external/cwe tags of its rule
+func SarifResultCWEs(run Runs, result Results) []string {
+	cwes := []string{}
+	rule := sarifRule(run, result)
+	if rule == nil || rule.Properties == nil {
+		return cwes
+	}
+	for _, tag := range rule.Properties.Tags {
+		if id := strings.TrimPrefix(strings.ToLower(tag), "external/cwe/cwe-"); id != strings.ToLower(tag) && len(id) > 0 {
+			// CodeQL pads the ids with zeros, e.g. cwe-079
+			cwes = append(cwes, "CWE-"+strings.TrimLeft(id, "0"))
+		}
+	}
+	return cwes
+}
+
+// IsSarifResultNotRelevant returns true if the result was audited as not relevant, e.g. as false positive
+func IsSarifResultNotRelevant(result Results) bool {
+	if result.Properties == nil {
+		return false
+	}
+	if result.Properties.UnifiedAuditState == "notRelevant" {
+		return true
+	}
+	switch result.Properties.ToolState {
+	case "NotExploitable", "NOT_EXPLOITABLE", "Not an Issue":
+		return true
+	}
+	return false
+}
+
+func sarifRule(run Runs, result Results) *SarifRule {
+	rules := run.Tool.Driver.Rules
+	if result.RuleIndex >= 0 && result.RuleIndex < len(rules) && rules[result.RuleIndex].ID == result.RuleID {
+		return &rules[result.RuleIndex]
+	}
+	for i := range rules {
+		if rules[i].ID == result.RuleID {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// NormalizeSeverity maps the severity of a tool to a unified severity, an empty string is returned for unknown severities
+func NormalizeSeverity(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical", "very high":
+		return SeverityCritical
+	case "high":
+		return SeverityHigh
+	case "medium":
+		return SeverityMedium
+	case "low":
+		return SeverityLow
+	case "info", "information", "informational":
+		return SeverityInfo
+	}
+	return ""
+}
+
+// SeverityFromScore maps a CVSS like score to a severity following the CVSS v3 rating
+func SeverityFromScore(score float64) string {
+	switch {
+	case score >= 9.0:
+		return SeverityCritical
+	case score >= 7.0:
+		return SeverityHigh
+	case score >= 4.0:
+		return SeverityMedium
+	case score > 0:
+		return SeverityLow
+	}
+	return SeverityInfo
+}
@@ -0,0 +1,53 @@
+//go:build unit
+// +build unit
+
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSarifResultSeverity(t *testing.T) {
+	run := Runs{Tool: Tool{Driver: Driver{Name: "CodeQL", Rules: []SarifRule{
+		{ID: "js/xss", Properties: &SarifRuleProperties{SecuritySeverity: "6.1", Tags: []string{"security", "external/cwe/cwe-079"}}},
+		{ID: "js/sql-injection", Properties: &SarifRuleProperties{SecuritySeverity: "9.8", Tags: []string{"external/cwe/cwe-89", "external/cwe/CWE-943"}}},
+		{ID: "js/unused-variable"},
+	}}}}
+
+	tt := []struct {
+		name     string
+		result   Results
+		expected string
+	}{
+		{name: "unified severity", result: Results{RuleID: "js/xss", Properties: &SarifProperties{UnifiedSeverity: "CRITICAL", ToolSeverity: "Medium"}}, expected: SeverityCritical},
+		{name: "tool severity", result: Results{RuleID: "js/xss", Properties: &SarifProperties{ToolSeverity: "Information"}}, expected: SeverityInfo},
+		{name: "unknown tool severity", result: Results{RuleID: "js/xss", Properties: &SarifProperties{ToolSeverity: "Unknown"}}, expected: SeverityMedium},
+		{name: "security severity of rule", result: Results{RuleID: "js/sql-injection", RuleIndex: 1}, expected: SeverityCritical},
+		{name: "rule index does not match", result: Results{RuleID: "js/xss", RuleIndex: 1}, expected: SeverityMedium},
+		{name: "level error", result: Results{RuleID: "js/unused-variable", Level: "error"}, expected: SeverityHigh},
+		{name: "default level", result: Results{RuleID: "js/unused-variable"}, expected: SeverityMedium},
+		{name: "level note", result: Results{RuleID: "js/unused-variable", Level: "note"}, expected: SeverityLow},
+		{name: "level none", result: Results{RuleID: "unknown", Level: "none"}, expected: SeverityInfo},
+	}
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, SarifResultSeverity(run, test.result))
+		})
+	}
+
+	t.Run("CWEs", func(t *testing.T) {
+		assert.Equal(t, []string{"CWE-79"}, SarifResultCWEs(run, Results{RuleID: "js/xss"}))
+		assert.Equal(t, []string{"CWE-89", "CWE-943"}, SarifResultCWEs(run, Results{RuleID: "js/sql-injection"}))
+		assert.Empty(t, SarifResultCWEs(run, Results{RuleID: "js/unused-variable"}))
+	})
+}
+
+func TestIsSarifResultNotRelevant(t *testing.T) {
+	assert.False(t, IsSarifResultNotRelevant(Results{}))
+	assert.False(t, IsSarifResultNotRelevant(Results{Properties: &SarifProperties{UnifiedAuditState: "relevant", ToolState: "Confirmed"}}))
+	assert.True(t, IsSarifResultNotRelevant(Results{Properties: &SarifProperties{UnifiedAuditState: "notRelevant"}}))
+	assert.True(t, IsSarifResultNotRelevant(Results{Properties: &SarifProperties{ToolState: "NotExploitable"}}))
+	assert.True(t, IsSarifResultNotRelevant(Results{Properties: &SarifProperties{ToolState: "Not an Issue"}}))
+}
@@ -0,0 +1,477 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/package-url/packageurl-go"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+)
+
+// OpenVEXContext is the context of OpenVEX documents, see https://github.com/openvex/spec
+const OpenVEXContext = "https://openvex.dev/ns/v0.2.0"
+
+// OpenVEX status values
+const (
+	OpenVEXNotAffected        = "not_affected"
+	OpenVEXAffected           = "affected"
+	OpenVEXFixed              = "fixed"
+	OpenVEXUnderInvestigation = "under_investigation"
+)
+
+// OpenVEX justification values
+const (
+	OpenVEXComponentNotPresent                         = "component_not_present"
+	OpenVEXVulnerableCodeNotPresent                    = "vulnerable_code_not_present"
+	OpenVEXVulnerableCodeNotInExecutePath              = "vulnerable_code_not_in_execute_path"
+	OpenVEXVulnerableCodeCannotBeControlledByAdversary = "vulnerable_code_cannot_be_controlled_by_adversary"
+	OpenVEXInlineMitigationsAlreadyExist               = "inline_mitigations_already_exist"
+)
+
+const vexTooling = "Project Piper"
+
+// OpenVEX is an OpenVEX document
+type OpenVEX struct {
+	Context    string             `json:"@context"`
+	ID         string             `json:"@id"`
+	Author     string             `json:"author"`
+	Timestamp  string             `json:"timestamp"`
+	Version    int                `json:"version"`
+	Tooling    string             `json:"tooling,omitempty"`
+	Statements []OpenVEXStatement `json:"statements"`
+}
+
+// OpenVEXStatement describes the impact of one vulnerability on a set of products
+type OpenVEXStatement struct {
+	Vulnerability   OpenVEXVulnerability `json:"vulnerability"`
+	Products        []OpenVEXProduct     `json:"products,omitempty"`
+	Status          string               `json:"status"`
+	StatusNotes     string               `json:"status_notes,omitempty"`
+	Justification   string               `json:"justification,omitempty"`
+	ImpactStatement string               `json:"impact_statement,omitempty"`
+	ActionStatement string               `json:"action_statement,omitempty"`
+}
+
+// OpenVEXVulnerability identifies the vulnerability of a statement
+type OpenVEXVulnerability struct {
+	Name string `json:"name"`
+}
+
+// OpenVEXProduct is a product or a subcomponent of a product
+type OpenVEXProduct struct {
+	ID            string            `json:"@id"`
+	Identifiers   map[string]string `json:"identifiers,omitempty"`
+	Subcomponents []OpenVEXProduct  `json:"subcomponents,omitempty"`
+}
+
+// vexStatement is an assessment joined with the components of the SBOMs it applies to
+type vexStatement struct {
+	assessment Assessment
+	products   []vexProduct
+}
+
+// vexProduct contains the affected components of one product
+type vexProduct struct {
+	// purl of the product, empty if the affected components are not related to a product
+	purl       string
+	components []cdx.Component
+}
+
+// MatchesPackage checks if one of the package URLs of the assessment refers to the given package.
+// Assessments without version apply to all versions of the package.
+func (a Assessment) MatchesPackage(purl string) bool {
+	if len(purl) == 0 {
+		return false
+	}
+	packageURL, err := packageurl.FromString(purl)
+	if err != nil {
+		return false
+	}
+	for _, assessmentPurl := range a.Purls {
+		assessed, err := assessmentPurl.ToPackageUrl()
+		if err != nil {
+			log.Entry().WithError(err).Warnf("assessment of %v ignored due to invalid packageUrl '%v'", a.Vulnerability, assessmentPurl.Purl)
+			continue
+		}
+		if assessed.Type == packageURL.Type &&
+			assessed.Namespace == packageURL.Namespace &&
+			assessed.Name == packageURL.Name &&
+			(len(assessed.Version) == 0 || assessed.Version == packageURL.Version) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindAssessment returns the assessment of the vulnerability for the given package, nil if there is none
+func FindAssessment(assessments []Assessment, vulnerability, purl string) *Assessment {
+	for i, assessment := range assessments {
+		if strings.EqualFold(assessment.Vulnerability, vulnerability) && assessment.MatchesPackage(purl) {
+			return &assessments[i]
+		}
+	}
+	return nil
+}
+
+// ToOpenVEXStatus maps the assessment to the status of an OpenVEX statement
+func (a Assessment) ToOpenVEXStatus() string {
+	switch a.Status {
+	case NotRelevant:
+		if a.Analysis == FixedByDevTeam {
+			return OpenVEXFixed
+		}
+		return OpenVEXNotAffected
+	case InProcess:
+		return OpenVEXUnderInvestigation
+	}
+	return OpenVEXAffected
+}
+
+// ToOpenVEXJustification maps the analysis to the justification of a not_affected OpenVEX statement
+func (a Assessment) ToOpenVEXJustification() string {
+	switch a.Analysis {
+	case NotPresent, WronglyReported:
+		return OpenVEXVulnerableCodeNotPresent
+	case NotUsed:
+		return OpenVEXVulnerableCodeNotInExecutePath
+	case Mitigated:
+		return OpenVEXInlineMitigationsAlreadyExist
+	}
+	return ""
+}
+
+// AssessmentsToOpenVEX creates an OpenVEX document from the assessments.
+// If SBOMs are provided, only assessments of components contained in the SBOMs are considered and the components are listed as subcomponents of the products described by the SBOMs.
+func AssessmentsToOpenVEX(assessments []Assessment, boms []cdx.BOM, id, author string, timestamp time.Time) OpenVEX {
+	document := OpenVEX{
+		Context:    OpenVEXContext,
+		ID:         id,
+		Author:     author,
+		Timestamp:  timestamp.UTC().Format(time.RFC3339),
+		Version:    1,
+		Tooling:    vexTooling,
+		Statements: []OpenVEXStatement{},
+	}
+	for _, statement := range joinAssessmentsWithSBOMs(assessments, boms) {
+		assessment := statement.assessment
+		vexStatement := OpenVEXStatement{
+			Vulnerability: OpenVEXVulnerability{Name: assessment.Vulnerability},
+			Status:        assessment.ToOpenVEXStatus(),
+		}
+		if len(assessment.Analysis) > 0 {
+			vexStatement.StatusNotes = assessment.Analysis.Description()
+		}
+		switch vexStatement.Status {
+		case OpenVEXNotAffected:
+			vexStatement.Justification = assessment.ToOpenVEXJustification()
+			if len(vexStatement.Justification) == 0 {
+				// a not_affected statement requires either a justification or an impact statement
+				vexStatement.ImpactStatement = assessment.Analysis.Description()
+			}
+		case OpenVEXAffected:
+			vexStatement.ActionStatement = assessment.Analysis.Description()
+		}
+
+		for _, product := range statement.products {
+			subcomponents := []OpenVEXProduct{}
+			for _, component := range product.components {
+				subcomponents = append(subcomponents, OpenVEXProduct{ID: component.PackageURL, Identifiers: map[string]string{"purl": component.PackageURL}})
+			}
+			if len(product.purl) == 0 {
+				vexStatement.Products = append(vexStatement.Products, subcomponents...)
+				continue
+			}
+			vexStatement.Products = append(vexStatement.Products, OpenVEXProduct{ID: product.purl, Identifiers: map[string]string{"purl": product.purl}, Subcomponents: subcomponents})
+		}
+		document.Statements = append(document.Statements, vexStatement)
+	}
+	return document
+}
+
+// AssessmentsToCycloneDXVEX creates a CycloneDX VEX document from the assessments.
+// If SBOMs are provided, only assessments of components contained in the SBOMs are considered.
+func AssessmentsToCycloneDXVEX(assessments []Assessment, boms []cdx.BOM, timestamp time.Time) *cdx.BOM {
+	components := map[string]cdx.Component{}
+	products := map[string]*cdx.Component{}
+	vulnerabilities := []cdx.Vulnerability{}
+	for _, statement := range joinAssessmentsWithSBOMs(assessments, boms) {
+		assessment := statement.assessment
+		affects := []cdx.Affects{}
+		for _, product := range statement.products {
+			for _, component := range product.components {
+				// the package URL is used as reference in order to make the VEX independent of the SBOM
+				component.BOMRef = component.PackageURL
+				component.Components = nil
+				components[component.PackageURL] = component
+				affects = append(affects, cdx.Affects{Ref: component.PackageURL})
+			}
+		}
+		analysis := &cdx.VulnerabilityAnalysis{
+			State:         assessment.ToImpactAnalysisState(),
+			Justification: assessment.ToImpactJustification(),
+			Response:      assessment.ToImpactAnalysisResponse(),
+		}
+		if len(assessment.Analysis) > 0 {
+			analysis.Detail = assessment.Analysis.Description()
+		}
+		vulnerabilities = append(vulnerabilities, cdx.Vulnerability{
+			ID:       assessment.Vulnerability,
+			Analysis: analysis,
+			Affects:  &affects,
+		})
+	}
+	for i, bom := range boms {
+		if bom.Metadata != nil && bom.Metadata.Component != nil && len(bom.Metadata.Component.PackageURL) > 0 {
+			products[bom.Metadata.Component.PackageURL] = boms[i].Metadata.Component
+		}
+	}
+
+	sortedComponents := []cdx.Component{}
+	for _, component := range components {
+		sortedComponents = append(sortedComponents, component)
+	}
+	sort.Slice(sortedComponents, func(i, j int) bool {
+		return sortedComponents[i].PackageURL < sortedComponents[j].PackageURL
+	})
+
+	metadata := cdx.Metadata{
+		Timestamp: timestamp.UTC().Format(time.RFC3339),
+		Tools:     &[]cdx.Tool{{Name: vexTooling}},
+	}
+	// the VEX describes a product only if all SBOMs belong to the same product
+	if len(products) == 1 {
+		for _, product := range products {
+			metadata.Component = &cdx.Component{
+				BOMRef:     product.PackageURL,
+				Type:       product.Type,
+				Group:      product.Group,
+				Name:       product.Name,
+				Version:    product.Version,
+				PackageURL: product.PackageURL,
+			}
+		}
+	}
+
+	bom := cdx.NewBOM()
+	bom.Metadata = &metadata
+	bom.Components = &sortedComponents
+	bom.Vulnerabilities = &vulnerabilities
+	return bom
+}
+
+// joinAssessmentsWithSBOMs determines the components affected by each assessment.
+// Without SBOMs the package URLs of the assessments are used as they are.
+func joinAssessmentsWithSBOMs(assessments []Assessment, boms []cdx.BOM) []vexStatement {
+	statements := []vexStatement{}
+	for _, assessment := range assessments {
+		statement := vexStatement{assessment: assessment}
+		if len(boms) == 0 {
+			product := vexProduct{}
+			for _, purl := range assessment.Purls {
+				product.components = append(product.components, componentFromPurl(purl.Purl))
+			}
+			statement.products = []vexProduct{product}
+		}
+		for _, bom := range boms {
+			product := vexProduct{}
+			if bom.Metadata != nil && bom.Metadata.Component != nil {
+				product.purl = bom.Metadata.Component.PackageURL
+			}
+			for _, component := range flattenComponents(bom.Components) {
+				if assessment.MatchesPackage(component.PackageURL) {
+					product.components = append(product.components, component)
+				}
+			}
+			if len(product.components) > 0 {
+				statement.products = append(statement.products, product)
+			}
+		}
+		if len(statement.products) == 0 {
+			log.Entry().Debugf("assessment of %v skipped since none of the packages %v is contained in the SBOMs", assessment.Vulnerability, assessment.Purls)
+			continue
+		}
+		statements = append(statements, statement)
+	}
+	return statements
+}
+
+func flattenComponents(components *[]cdx.Component) []cdx.Component {
+	flat := []cdx.Component{}
+	if components == nil {
+		return flat
+	}
+	for _, component := range *components {
+		flat = append(flat, component)
+		flat = append(flat, flattenComponents(component.Components)...)
+	}
+	return flat
+}
+
+func componentFromPurl(purl string) cdx.Component {
+	component := cdx.Component{Type: cdx.ComponentTypeLibrary, PackageURL: purl, Name: purl}
+	if packageURL, err := packageurl.FromString(purl); err == nil {
+		component.Group = packageURL.Namespace
+		component.Name = packageURL.Name
+		component.Version = packageURL.Version
+	}
+	return component
+}
+
+// readVEX reads the assessments contained in an OpenVEX or CycloneDX VEX document.
+// The second return value is false if the content is no VEX document.
+func readVEX(content []byte) (*[]Assessment, bool, error) {
+	trimmed := bytes.TrimSpace(content)
+	if bytes.HasPrefix(trimmed, []byte("<")) {
+		bom := cdx.BOM{}
+		if err := cdx.NewBOMDecoder(bytes.NewReader(trimmed), cdx.BOMFileFormatXML).Decode(&bom); err != nil {
+			return nil, true, NewParseError(fmt.Sprintf("format of CycloneDX VEX document is invalid: %v", err))
+		}
+		return cycloneDXVEXToAssessments(bom), true, nil
+	}
+	if !bytes.HasPrefix(trimmed, []byte("{")) {
+		return nil, false, nil
+	}
+	probe := struct {
+		Context   string `json:"@context"`
+		BOMFormat string `json:"bomFormat"`
+	}{}
+	if err := json.Unmarshal(trimmed, &probe); err != nil {
+		return nil, false, nil
+	}
+	switch {
+	case strings.Contains(probe.Context, "openvex"):
+		document := OpenVEX{}
+		if err := json.Unmarshal(trimmed, &document); err != nil {
+			return nil, true, NewParseError(fmt.Sprintf("format of OpenVEX document is invalid: %v", err))
+		}
+		return openVEXToAssessments(document), true, nil
+	case probe.BOMFormat == "CycloneDX":
+		bom := cdx.BOM{}
+		if err := cdx.NewBOMDecoder(bytes.NewReader(trimmed), cdx.BOMFileFormatJSON).Decode(&bom); err != nil {
+			return nil, true, NewParseError(fmt.Sprintf("format of CycloneDX VEX document is invalid: %v", err))
+		}
+		return cycloneDXVEXToAssessments(bom), true, nil
+	}
+	return nil, false, nil
+}
+
+func openVEXToAssessments(document OpenVEX) *[]Assessment {
+	assessments := []Assessment{}
+	for _, statement := range document.Statements {
+		assessment := Assessment{Vulnerability: statement.Vulnerability.Name, Purls: []Purl{}}
+		switch statement.Status {
+		case OpenVEXNotAffected:
+			assessment.Status = NotRelevant
+		case OpenVEXFixed:
+			assessment.Status = NotRelevant
+			assessment.Analysis = FixedByDevTeam
+		case OpenVEXUnderInvestigation:
+			assessment.Status = InProcess
+		default:
+			assessment.Status = Relevant
+		}
+		if analysis, ok := analysisFromDescription(statement.StatusNotes); ok {
+			assessment.Analysis = analysis
+		} else if len(assessment.Analysis) == 0 {
+			switch statement.Justification {
+			case OpenVEXComponentNotPresent:
+				assessment.Analysis = WronglyReported
+			case OpenVEXVulnerableCodeNotPresent:
+				assessment.Analysis = NotPresent
+			case OpenVEXVulnerableCodeNotInExecutePath:
+				assessment.Analysis = NotUsed
+			case OpenVEXVulnerableCodeCannotBeControlledByAdversary, OpenVEXInlineMitigationsAlreadyExist:
+				assessment.Analysis = Mitigated
+			}
+		}
+		for _, product := range statement.Products {
+			if len(product.Subcomponents) == 0 {
+				assessment.Purls = appendOpenVEXPurl(assessment.Purls, product)
+			}
+			for _, subcomponent := range product.Subcomponents {
+				assessment.Purls = appendOpenVEXPurl(assessment.Purls, subcomponent)
+			}
+		}
+		assessments = append(assessments, assessment)
+	}
+	return &assessments
+}
+
+func appendOpenVEXPurl(purls []Purl, product OpenVEXProduct) []Purl {
+	if purl, ok := product.Identifiers["purl"]; ok {
+		return append(purls, Purl{Purl: purl})
+	}
+	if strings.HasPrefix(product.ID, "pkg:") {
+		return append(purls, Purl{Purl: product.ID})
+	}
+	return purls
+}
+
+func cycloneDXVEXToAssessments(bom cdx.BOM) *[]Assessment {
+	references := map[string]string{}
+	for _, component := range flattenComponents(bom.Components) {
+		if len(component.BOMRef) > 0 && len(component.PackageURL) > 0 {
+			references[component.BOMRef] = component.PackageURL
+		}
+	}
+
+	assessments := []Assessment{}
+	if bom.Vulnerabilities == nil {
+		return &assessments
+	}
+	for _, vulnerability := range *bom.Vulnerabilities {
+		if vulnerability.Analysis == nil {
+			continue
+		}
+		assessment := Assessment{Vulnerability: vulnerability.ID, Purls: []Purl{}}
+		switch vulnerability.Analysis.State {
+		case cdx.IASExploitable:
+			assessment.Status = Relevant
+		case cdx.IASInTriage, "":
+			assessment.Status = InProcess
+		case cdx.IASResolved, cdx.IASResolvedWithPedigree:
+			assessment.Status = NotRelevant
+			assessment.Analysis = FixedByDevTeam
+		default:
+			assessment.Status = NotRelevant
+		}
+		if analysis, ok := analysisFromDescription(vulnerability.Analysis.Detail); ok {
+			assessment.Analysis = analysis
+		} else if len(assessment.Analysis) == 0 {
+			switch vulnerability.Analysis.Justification {
+			case cdx.IAJCodeNotPresent:
+				assessment.Analysis = NotPresent
+			case cdx.IAJCodeNotReachable, cdx.IAJRequiresConfiguration:
+				assessment.Analysis = NotUsed
+			case cdx.IAJRequiresDependency:
+				assessment.Analysis = AssessmentPropagation
+			case cdx.IAJRequiresEnvironment:
+				assessment.Analysis = RiskAccepted
+			case cdx.IAJProtectedByCompiler, cdx.IAJProtectedAtRuntime, cdx.IAJProtectedAtPerimeter, cdx.IAJProtectedByMitigatingControl:
+				assessment.Analysis = Mitigated
+			}
+		}
+		if vulnerability.Affects != nil {
+			for _, affects := range *vulnerability.Affects {
+				ref := affects.Ref
+				// references into other BOMs look like urn:cdx:<serial>/<version>#<bom-ref>
+				if index := strings.LastIndex(ref, "#"); strings.HasPrefix(ref, "urn:cdx:") && index >= 0 {
+					ref = ref[index+1:]
+				}
+				if purl, ok := references[ref]; ok {
+					assessment.Purls = append(assessment.Purls, Purl{Purl: purl})
+				} else if strings.HasPrefix(ref, "pkg:") {
+					assessment.Purls = append(assessment.Purls, Purl{Purl: ref})
+				}
+			}
+		}
+		assessments = append(assessments, assessment)
+	}
+	return &assessments
+}
@@ -0,0 +1,219 @@
+//go:build unit
+// +build unit
+
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+var vexAssessments = []Assessment{
+	{Vulnerability: "CVE-2008-4318", Status: NotRelevant, Analysis: Mitigated, Purls: []Purl{{Purl: "pkg:npm/observer@0.3.2"}}},
+	{Vulnerability: "CVE-2022-42889", Status: NotRelevant, Analysis: RiskAccepted, Purls: []Purl{{Purl: "pkg:maven/org.apache.commons/commons-text"}}},
+	{Vulnerability: "CVE-2021-44228", Status: InProcess, Purls: []Purl{{Purl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}}},
+	{Vulnerability: "CVE-2023-1370", Status: Relevant, Analysis: WaitingForFix, Purls: []Purl{{Purl: "pkg:maven/net.minidev/json-smart@2.4.8"}}},
+}
+
+var vexTimestamp = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func vexSBOM() cdx.BOM {
+	return cdx.BOM{
+		Metadata: &cdx.Metadata{Component: &cdx.Component{Type: cdx.ComponentTypeApplication, Group: "com.sap", Name: "app", Version: "1.0.0", PackageURL: "pkg:maven/com.sap/app@1.0.0"}},
+		Components: &[]cdx.Component{
+			{BOMRef: "commons-text", Type: cdx.ComponentTypeLibrary, Group: "org.apache.commons", Name: "commons-text", Version: "1.9", PackageURL: "pkg:maven/org.apache.commons/commons-text@1.9"},
+			{BOMRef: "json-smart", Type: cdx.ComponentTypeLibrary, Group: "net.minidev", Name: "json-smart", Version: "2.4.7", PackageURL: "pkg:maven/net.minidev/json-smart@2.4.7",
+				Components: &[]cdx.Component{{Type: cdx.ComponentTypeLibrary, Group: "org.apache.logging.log4j", Name: "log4j-core", Version: "2.14.1", PackageURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}}},
+		},
+	}
+}
+
+func TestMatchesPackage(t *testing.T) {
+	assert.True(t, vexAssessments[0].MatchesPackage("pkg:npm/observer@0.3.2"))
+	assert.False(t, vexAssessments[0].MatchesPackage("pkg:npm/observer@0.3.3"))
+	assert.True(t, vexAssessments[1].MatchesPackage("pkg:maven/org.apache.commons/commons-text@1.9"))
+	assert.False(t, vexAssessments[1].MatchesPackage("pkg:maven/org.apache.commons/commons-lang3@3.12.0"))
+	assert.False(t, vexAssessments[1].MatchesPackage(""))
+	assert.False(t, vexAssessments[1].MatchesPackage("invalid"))
+
+	assert.Equal(t, "CVE-2022-42889", FindAssessment(vexAssessments, "cve-2022-42889", "pkg:maven/org.apache.commons/commons-text@1.10").Vulnerability)
+	assert.Nil(t, FindAssessment(vexAssessments, "CVE-2008-4318", "pkg:maven/org.apache.commons/commons-text@1.10"))
+}
+
+func TestAssessmentsToOpenVEX(t *testing.T) {
+	t.Run("without SBOM", func(t *testing.T) {
+		document := AssessmentsToOpenVEX(vexAssessments, nil, "urn:uuid:1", "Jane Doe", vexTimestamp)
+
+		assert.Equal(t, OpenVEXContext, document.Context)
+		assert.Equal(t, "2024-01-02T03:04:05Z", document.Timestamp)
+		assert.Equal(t, "Jane Doe", document.Author)
+		if assert.Len(t, document.Statements, 4) {
+			assert.Equal(t, OpenVEXStatement{
+				Vulnerability: OpenVEXVulnerability{Name: "CVE-2008-4318"},
+				Products:      []OpenVEXProduct{{ID: "pkg:npm/observer@0.3.2", Identifiers: map[string]string{"purl": "pkg:npm/observer@0.3.2"}}},
+				Status:        OpenVEXNotAffected,
+				StatusNotes:   "Mitigated by the Application",
+				Justification: OpenVEXInlineMitigationsAlreadyExist,
+			}, document.Statements[0])
+			assert.Equal(t, OpenVEXNotAffected, document.Statements[1].Status)
+			assert.Empty(t, document.Statements[1].Justification)
+			assert.Equal(t, "Risk Accepted", document.Statements[1].ImpactStatement)
+			assert.Equal(t, OpenVEXUnderInvestigation, document.Statements[2].Status)
+			assert.Empty(t, document.Statements[2].StatusNotes)
+			assert.Equal(t, OpenVEXAffected, document.Statements[3].Status)
+			assert.Equal(t, "Waiting for OSS community fix", document.Statements[3].ActionStatement)
+		}
+	})
+
+	t.Run("with SBOM", func(t *testing.T) {
+		document := AssessmentsToOpenVEX(vexAssessments, []cdx.BOM{vexSBOM()}, "urn:uuid:1", "Jane Doe", vexTimestamp)
+
+		// observer is not contained in the SBOM and json-smart is contained in another version
+		if assert.Len(t, document.Statements, 2) {
+			assert.Equal(t, "CVE-2022-42889", document.Statements[0].Vulnerability.Name)
+			assert.Equal(t, []OpenVEXProduct{{
+				ID:            "pkg:maven/com.sap/app@1.0.0",
+				Identifiers:   map[string]string{"purl": "pkg:maven/com.sap/app@1.0.0"},
+				Subcomponents: []OpenVEXProduct{{ID: "pkg:maven/org.apache.commons/commons-text@1.9", Identifiers: map[string]string{"purl": "pkg:maven/org.apache.commons/commons-text@1.9"}}},
+			}}, document.Statements[0].Products)
+			assert.Equal(t, "CVE-2021-44228", document.Statements[1].Vulnerability.Name)
+			assert.Equal(t, "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", document.Statements[1].Products[0].Subcomponents[0].ID)
+		}
+	})
+}
+
+func TestAssessmentsToCycloneDXVEX(t *testing.T) {
+	bom := AssessmentsToCycloneDXVEX(vexAssessments, []cdx.BOM{vexSBOM()}, vexTimestamp)
+
+	assert.Equal(t, "2024-01-02T03:04:05Z", bom.Metadata.Timestamp)
+	assert.Equal(t, "pkg:maven/com.sap/app@1.0.0", bom.Metadata.Component.PackageURL)
+	if assert.Len(t, *bom.Components, 2) {
+		assert.Equal(t, "pkg:maven/org.apache.commons/commons-text@1.9", (*bom.Components)[0].BOMRef)
+		assert.Equal(t, "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", (*bom.Components)[1].BOMRef)
+	}
+	if assert.Len(t, *bom.Vulnerabilities, 2) {
+		vulnerability := (*bom.Vulnerabilities)[0]
+		assert.Equal(t, "CVE-2022-42889", vulnerability.ID)
+		assert.Equal(t, cdx.IASFalsePositive, vulnerability.Analysis.State)
+		assert.Equal(t, cdx.IAJRequiresEnvironment, vulnerability.Analysis.Justification)
+		assert.Equal(t, "Risk Accepted", vulnerability.Analysis.Detail)
+		assert.Equal(t, []cdx.Affects{{Ref: "pkg:maven/org.apache.commons/commons-text@1.9"}}, *vulnerability.Affects)
+		assert.Equal(t, cdx.IASInTriage, (*bom.Vulnerabilities)[1].Analysis.State)
+	}
+
+	t.Run("several products", func(t *testing.T) {
+		other := vexSBOM()
+		other.Metadata.Component = &cdx.Component{Name: "other", PackageURL: "pkg:npm/other@1.0.0"}
+
+		bom := AssessmentsToCycloneDXVEX(vexAssessments, []cdx.BOM{vexSBOM(), other}, vexTimestamp)
+
+		assert.Nil(t, bom.Metadata.Component)
+		assert.Len(t, *bom.Components, 2)
+	})
+}
+
+func TestReadAssessmentsFromVEX(t *testing.T) {
+	t.Run("OpenVEX", func(t *testing.T) {
+		content, err := json.Marshal(AssessmentsToOpenVEX(vexAssessments, nil, "urn:uuid:1", "Jane Doe", vexTimestamp))
+		assert.NoError(t, err)
+
+		assessments, err := ReadAssessments(io.NopCloser(bytes.NewReader(content)))
+
+		assert.NoError(t, err)
+		assert.Equal(t, vexAssessments[0], (*assessments)[0])
+		assert.Equal(t, vexAssessments[1], (*assessments)[1])
+		assert.Equal(t, Assessment{Vulnerability: "CVE-2021-44228", Status: InProcess, Purls: []Purl{{Purl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}}}, (*assessments)[2])
+		assert.Equal(t, vexAssessments[3], (*assessments)[3])
+	})
+
+	t.Run("OpenVEX without status notes", func(t *testing.T) {
+		content := `{
+			"@context": "https://openvex.dev/ns/v0.2.0",
+			"@id": "https://example.org/vex-1",
+			"statements": [
+				{"vulnerability": {"name": "CVE-2023-1"}, "products": [{"@id": "pkg:golang/example.org/mod@v1.0.0"}], "status": "not_affected", "justification": "vulnerable_code_not_in_execute_path"},
+				{"vulnerability": {"name": "CVE-2023-2"}, "products": [{"@id": "https://example.org/product"}], "status": "fixed"}
+			]
+		}`
+
+		assessments, err := ReadAssessments(io.NopCloser(strings.NewReader(content)))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Assessment{
+			{Vulnerability: "CVE-2023-1", Status: NotRelevant, Analysis: NotUsed, Purls: []Purl{{Purl: "pkg:golang/example.org/mod@v1.0.0"}}},
+			{Vulnerability: "CVE-2023-2", Status: NotRelevant, Analysis: FixedByDevTeam, Purls: []Purl{}},
+		}, *assessments)
+	})
+
+	t.Run("CycloneDX VEX", func(t *testing.T) {
+		for _, fileFormat := range []cdx.BOMFileFormat{cdx.BOMFileFormatJSON, cdx.BOMFileFormatXML} {
+			buffer := new(bytes.Buffer)
+			assert.NoError(t, cdx.NewBOMEncoder(buffer, fileFormat).Encode(AssessmentsToCycloneDXVEX(vexAssessments, nil, vexTimestamp)))
+
+			assessments, err := ReadAssessments(io.NopCloser(buffer))
+
+			assert.NoError(t, err)
+			if assert.Len(t, *assessments, 4) {
+				assert.Equal(t, vexAssessments[0], (*assessments)[0])
+				assert.Equal(t, vexAssessments[1], (*assessments)[1])
+				assert.Equal(t, InProcess, (*assessments)[2].Status)
+				assert.Equal(t, vexAssessments[3], (*assessments)[3])
+			}
+		}
+	})
+
+	t.Run("CycloneDX VEX with BOM link", func(t *testing.T) {
+		content := `{
+			"bomFormat": "CycloneDX",
+			"specVersion": "1.4",
+			"version": 1,
+			"vulnerabilities": [
+				{"id": "CVE-2023-1", "analysis": {"state": "not_affected", "justification": "code_not_reachable"}, "affects": [{"ref": "urn:cdx:3e671687-395b-41f5-a30f-a58921a69b79/1#pkg:npm/lodash@4.17.20"}]}
+			]
+		}`
+
+		assessments, err := ReadAssessments(io.NopCloser(strings.NewReader(content)))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Assessment{{Vulnerability: "CVE-2023-1", Status: NotRelevant, Analysis: NotUsed, Purls: []Purl{{Purl: "pkg:npm/lodash@4.17.20"}}}}, *assessments)
+	})
+
+	t.Run("assessment YAML", func(t *testing.T) {
+		content := "ignore:\n  - vulnerability: CVE-2008-4318\n    status: notRelevant\n    analysis: mitigated\n    purls:\n      - purl: pkg:npm/observer@0.3.2\n"
+
+		assessments, err := ReadAssessments(io.NopCloser(strings.NewReader(content)))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Assessment{vexAssessments[0]}, *assessments)
+	})
+
+	t.Run("invalid OpenVEX", func(t *testing.T) {
+		_, err := ReadAssessments(io.NopCloser(strings.NewReader(`{"@context": "https://openvex.dev/ns/v0.2.0", "statements": "none"}`)))
+
+		assert.ErrorContains(t, err, "format of OpenVEX document is invalid")
+	})
+}
+
+func TestReadAssessmentFile(t *testing.T) {
+	utils := &mock.FilesMock{}
+	utils.AddFile("vex.json", []byte(`{"@context": "https://openvex.dev/ns/v0.2.0", "statements": [{"vulnerability": {"name": "CVE-2023-1"}, "status": "under_investigation"}]}`))
+	utils.AddFile("invalid.yaml", []byte("ignore: {"))
+
+	assessments, err := ReadAssessmentFile("vex.json", utils)
+	assert.NoError(t, err)
+	assert.Equal(t, []Assessment{{Vulnerability: "CVE-2023-1", Status: InProcess, Purls: []Purl{}}}, assessments)
+
+	_, err = ReadAssessmentFile("invalid.yaml", utils)
+	assert.ErrorContains(t, err, "failed to parse assessment file invalid.yaml")
+
+	_, err = ReadAssessmentFile("missing.yaml", utils)
+	assert.ErrorContains(t, err, "failed to read assessment file missing.yaml")
+}
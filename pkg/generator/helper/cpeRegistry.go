@@ -0,0 +1,82 @@
+package helper
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
+)
+
+const cpeRegistryGeneratedFileName = "cpeRegistry_generated.go"
+const cpeRegistryGeneratedTemplate = `// Code generated by piper's step-generator. DO NOT EDIT.
+
+package cmd
+
+import "github.com/SAP/jenkins-library/pkg/piperenv"
+
+// CPERegistry returns all keys of the commonPipelineEnvironment defined in the step metadata together with their type
+func CPERegistry() piperenv.Registry {
+	return piperenv.Registry{
+		{{- range $key, $entry := . }}
+		{{ $key | quote }}: {Type: {{ $entry.Type | quote }}
+			{{- if $entry.WrittenBy }}, WrittenBy: []string{ {{- range $i, $step := $entry.WrittenBy }}{{ if $i }}, {{ end }}{{ $step | quote }}{{ end -}} }{{ end }}
+			{{- if $entry.ReadBy }}, ReadBy: []string{ {{- range $i, $step := $entry.ReadBy }}{{ if $i }}, {{ end }}{{ $step | quote }}{{ end -}} }{{ end -}} },
+		{{- end }}
+	}
+}
+`
+
+// addToCPERegistry adds the keys of the commonPipelineEnvironment written or read by the step to the registry.
+// Since all steps share the environment, a key must have the same type in all steps.
+func addToCPERegistry(registry piperenv.Registry, stepData *config.StepData) error {
+	stepName := stepData.Metadata.Name
+	for _, res := range stepData.Spec.Outputs.Resources {
+		if res.Type != "piperEnvironment" || res.Name != piperenv.CPEResourceName {
+			continue
+		}
+		for _, param := range res.Parameters {
+			key := fmt.Sprint(param["name"])
+			entry, err := cpeRegistryEntry(registry, key, resourceFieldType(fmt.Sprint(param["type"])), stepName)
+			if err != nil {
+				return err
+			}
+			entry.WrittenBy = appendUnique(entry.WrittenBy, stepName)
+			registry[key] = entry
+		}
+	}
+	for _, param := range stepData.Spec.Inputs.Parameters {
+		for _, ref := range param.ResourceRef {
+			if ref.Name != piperenv.CPEResourceName || len(ref.Param) == 0 {
+				continue
+			}
+			entry, err := cpeRegistryEntry(registry, ref.Param, param.Type, stepName)
+			if err != nil {
+				return err
+			}
+			entry.ReadBy = appendUnique(entry.ReadBy, stepName)
+			registry[ref.Param] = entry
+		}
+	}
+	return nil
+}
+
+func cpeRegistryEntry(registry piperenv.Registry, key, keyType, stepName string) (piperenv.RegistryEntry, error) {
+	entry, ok := registry[key]
+	if !ok {
+		return piperenv.RegistryEntry{Type: keyType}, nil
+	}
+	if entry.Type != keyType {
+		steps := append(append([]string{}, entry.WrittenBy...), entry.ReadBy...)
+		sort.Strings(steps)
+		return entry, fmt.Errorf("commonPipelineEnvironment key '%v' is used with type '%v' in step %v but with type '%v' in step(s) %v", key, keyType, stepName, entry.Type, steps)
+	}
+	return entry, nil
+}
+
+func appendUnique(list []string, value string) []string {
+	if contains(list, value) {
+		return list
+	}
+	return append(list, value)
+}
@@ -0,0 +1,59 @@
+//go:build unit
+// +build unit
+
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
+)
+
+func TestAddToCPERegistry(t *testing.T) {
+	writer := config.StepData{
+		Metadata: config.StepMetadata{Name: "writerStep"},
+		Spec: config.StepSpec{Outputs: config.StepOutputs{Resources: []config.StepResources{
+			{Name: "commonPipelineEnvironment", Type: "piperEnvironment", Parameters: []map[string]interface{}{
+				{"name": "artifactVersion"},
+				{"name": "custom/list", "type": "[]string"},
+			}},
+			{Name: "influx", Type: "influx", Parameters: []map[string]interface{}{{"name": "ignored"}}},
+		}}},
+	}
+	reader := config.StepData{
+		Metadata: config.StepMetadata{Name: "readerStep"},
+		Spec: config.StepSpec{Inputs: config.StepInputs{Parameters: []config.StepParameters{
+			{Name: "version", Type: "string", ResourceRef: []config.ResourceReference{{Name: "commonPipelineEnvironment", Param: "artifactVersion"}}},
+			{Name: "list", Type: "[]string", ResourceRef: []config.ResourceReference{{Name: "commonPipelineEnvironment", Param: "custom/list"}}},
+			{Name: "password", Type: "string", ResourceRef: []config.ResourceReference{{Name: "credentialsId", Type: "secret"}}},
+		}}},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		registry := piperenv.Registry{}
+
+		assert.NoError(t, addToCPERegistry(registry, &writer))
+		assert.NoError(t, addToCPERegistry(registry, &reader))
+
+		assert.Equal(t, piperenv.Registry{
+			"artifactVersion": {Type: "string", WrittenBy: []string{"writerStep"}, ReadBy: []string{"readerStep"}},
+			"custom/list":     {Type: "[]string", WrittenBy: []string{"writerStep"}, ReadBy: []string{"readerStep"}},
+		}, registry)
+	})
+
+	t.Run("conflicting types", func(t *testing.T) {
+		registry := piperenv.Registry{}
+		conflictingReader := config.StepData{
+			Metadata: config.StepMetadata{Name: "conflictingStep"},
+			Spec: config.StepSpec{Inputs: config.StepInputs{Parameters: []config.StepParameters{
+				{Name: "list", Type: "string", ResourceRef: []config.ResourceReference{{Name: "commonPipelineEnvironment", Param: "custom/list"}}},
+			}}},
+		}
+
+		assert.NoError(t, addToCPERegistry(registry, &writer))
+		assert.EqualError(t, addToCPERegistry(registry, &conflictingReader), "commonPipelineEnvironment key 'custom/list' is used with type 'string' in step conflictingStep but with type '[]string' in step(s) [writerStep]")
+	})
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/Masterminds/sprig"
 	"github.com/SAP/jenkins-library/pkg/config"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 )
 
@@ -187,6 +188,13 @@ func {{.CobraCmdFuncName}}() *cobra.Command {
 				defer vaultClient.MustRevokeToken()
 			}
 
+			if {{if .ExportPrefix}}{{ .ExportPrefix }}.{{end}}GeneralConfig.DryRun {
+				{{if .ExportPrefix}}{{ .ExportPrefix }}.{{end}}PrintStepPlan(STEP_NAME, &metadata, stepConfig)
+				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
+				return
+			}
+
 			stepTelemetryData := telemetry.CustomData{}
 			stepTelemetryData.ErrorCode = "1"
 			handler := func() {
@@ -201,6 +209,7 @@ func {{.CobraCmdFuncName}}() *cobra.Command {
 					{{if $.ExportPrefix}}{{ $.ExportPrefix }}.{{end}}GeneralConfig.EnvRootPath, {{ index $oRes "name" | quote }}{{- end -}}
 				){{- end }}
 				config.RemoveVaultSecretFiles()
+				config.RevokeVaultLeases()
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = {{if .ExportPrefix}}{{ .ExportPrefix }}.{{end}}GitCommit
@@ -571,6 +580,7 @@ func GetAllStepMetadata() map[string]config.StepData {
 func ProcessMetaFiles(metadataFiles []string, targetDir string, stepHelperData StepHelperData) error {
 
 	allSteps := struct{ Steps []string }{}
+	cpeRegistry := piperenv.Registry{}
 	for key := range metadataFiles {
 
 		var stepData config.StepData
@@ -602,6 +612,9 @@ func ProcessMetaFiles(metadataFiles []string, targetDir string, stepHelperData S
 			}
 		}
 
+		err = addToCPERegistry(cpeRegistry, &stepData)
+		checkError(err)
+
 		osImport := false
 		osImport, err = setDefaultParameters(&stepData)
 		checkError(err)
@@ -637,6 +650,11 @@ func ProcessMetaFiles(metadataFiles []string, targetDir string, stepHelperData S
 	err := stepHelperData.WriteFile(filepath.Join(targetDir, metadataGeneratedFileName), code, 0644)
 	checkError(err)
 
+	// expose the keys of the commonPipelineEnvironment
+	code = generateCode(cpeRegistry, "cpeRegistry", cpeRegistryGeneratedTemplate, sprig.HermeticTxtFuncMap())
+	err = stepHelperData.WriteFile(filepath.Join(targetDir, cpeRegistryGeneratedFileName), code, 0644)
+	checkError(err)
+
 	return nil
 }
 
@@ -148,6 +148,16 @@ func TestProcessMetaFiles(t *testing.T) {
 		assert.Equal(t, string(expected), string(files[resultFilePath]))
 	})
 
+	t.Run("cpe registry", func(t *testing.T) {
+		goldenFilePath := filepath.Join("testdata", t.Name()+"_generated.golden")
+		expected, err := os.ReadFile(goldenFilePath)
+		if err != nil {
+			t.Fatalf("failed reading %v", goldenFilePath)
+		}
+		resultFilePath := filepath.Join("cmd", "cpeRegistry_generated.go")
+		assert.Equal(t, string(expected), string(files[resultFilePath]))
+	})
+
 	t.Run("custom step code", func(t *testing.T) {
 		stepHelperData = StepHelperData{configOpenFileMock, writeFileMock, "piperOsCmd"}
 		ProcessMetaFiles([]string{"testStep.yaml"}, "./cmd", stepHelperData)
@@ -88,11 +88,15 @@ func (formatter *PiperLogFormatter) Format(entry *logrus.Entry) (bytes []byte, e
 		message = string(formattedMessage)
 	}
 
+	return []byte(MaskSecrets(message)), nil
+}
+
+// MaskSecrets replaces the registered secrets in a text which is not written via the logger
+func MaskSecrets(text string) string {
 	for _, secret := range secrets {
-		message = strings.Replace(message, secret, "****", -1)
+		text = strings.Replace(text, secret, "****", -1)
 	}
-
-	return []byte(message), nil
+	return text
 }
 
 // LibraryRepository that is passed into with -ldflags
@@ -46,6 +46,12 @@ func TestSecrets(t *testing.T) {
 		Entry().Infof("My secret is %s.", encodedSecret)
 		assert.NotContains(t, buffer.String(), encodedSecret)
 	})
+
+	t.Run("should mask text", func(t *testing.T) {
+		RegisterSecret("mask-me")
+
+		assert.Equal(t, "cf login -p ****", MaskSecrets("cf login -p mask-me"))
+	})
 }
 
 func TestWriteLargeBuffer(t *testing.T) {
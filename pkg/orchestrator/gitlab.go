@@ -0,0 +1,290 @@
+package orchestrator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	piperHttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/pkg/errors"
+)
+
+// gitlabEmptySHA is the value of CI_COMMIT_BEFORE_SHA for the first pipeline of a branch or for merge request pipelines
+const gitlabEmptySHA = "0000000000000000000000000000000000000000"
+
+type gitlabConfigProvider struct {
+	client piperHttp.Client
+	header http.Header
+}
+
+type gitlabJob struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Stage  string `json:"stage"`
+	Status string `json:"status"`
+}
+
+type gitlabCommit struct {
+	ID            string `json:"id"`
+	CommittedDate string `json:"committed_date"`
+}
+
+type gitlabCompare struct {
+	Commits []gitlabCommit `json:"commits"`
+}
+
+// newGitlabConfigProvider creates the GitLab CI config provider. It authenticates with the CI_JOB_TOKEN of the current job
+// until it is configured with a GitLab token, since the provider is usually retrieved without options.
+func newGitlabConfigProvider() *gitlabConfigProvider {
+	g := &gitlabConfigProvider{}
+	_ = g.Configure(&Options{})
+	return g
+}
+
+// Configure initializes http client for GitLab CI config provider.
+// If no GitLab token is provided, the CI_JOB_TOKEN of the current job is used for API calls.
+func (g *gitlabConfigProvider) Configure(opts *Options) error {
+	clientOptions := piperHttp.ClientOptions{
+		MaxRetries:       3,
+		TransportTimeout: time.Second * 10,
+	}
+
+	g.header = http.Header{}
+	if len(opts.GitLabToken) > 0 {
+		clientOptions.Token = "Bearer " + opts.GitLabToken
+	} else if jobToken := os.Getenv("CI_JOB_TOKEN"); len(jobToken) > 0 {
+		// not read via getEnv which logs the value
+		log.RegisterSecret(jobToken)
+		g.header.Set("JOB-TOKEN", jobToken)
+	}
+	g.client.SetOptions(clientOptions)
+
+	log.Entry().Debug("Successfully initialized GitLab config provider")
+	return nil
+}
+
+// OrchestratorVersion returns the version of the GitLab instance, e.g. 16.4.1
+func (g *gitlabConfigProvider) OrchestratorVersion() string {
+	return getEnv("CI_SERVER_VERSION", "n/a")
+}
+
+// OrchestratorType returns the orchestrator type GitLab
+func (g *gitlabConfigProvider) OrchestratorType() string {
+	return "GitLab"
+}
+
+// BuildStatus returns status of the current job. Return values are aligned with Jenkins build statuses.
+// CI_JOB_STATUS is only available in after_script, otherwise the job is considered to be in progress.
+func (g *gitlabConfigProvider) BuildStatus() string {
+	switch getEnv("CI_JOB_STATUS", "running") {
+	case "success":
+		return BuildStatusSuccess
+	case "canceled":
+		return BuildStatusAborted
+	case "running":
+		return BuildStatusInProgress
+	default:
+		return BuildStatusFailure
+	}
+}
+
+// FullLogs returns the traces of all jobs of the current pipeline.
+// See https://docs.gitlab.com/ee/api/jobs.html#get-a-log-file
+func (g *gitlabConfigProvider) FullLogs() ([]byte, error) {
+	jobsURL := g.projectAPIURL() + "/pipelines/" + g.BuildID() + "/jobs?per_page=100"
+	var jobs []gitlabJob
+	if err := g.getJSON(jobsURL, &jobs); err != nil {
+		return []byte{}, errors.Wrap(err, "failed to fetch pipeline jobs")
+	}
+
+	// jobs are returned in descending order, logs should be in order of execution
+	var logs [][]byte
+	for i := len(jobs) - 1; i >= 0; i-- {
+		traceURL := g.projectAPIURL() + "/jobs/" + strconv.FormatInt(jobs[i].ID, 10) + "/trace"
+		log.Entry().Debugf("Getting log of job %s from %v", jobs[i].Name, traceURL)
+		response, err := g.client.GetRequest(traceURL, g.header, nil)
+		if err != nil {
+			return []byte{}, errors.Wrapf(err, "could not GET log of job %s", jobs[i].Name)
+		}
+		if response.StatusCode != http.StatusOK {
+			log.Entry().Errorf("response code is %v, could not get log of job %s from GitLab.", response.StatusCode, jobs[i].Name)
+			response.Body.Close()
+			continue
+		}
+		content, err := io.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			return []byte{}, errors.Wrapf(err, "could not read log of job %s", jobs[i].Name)
+		}
+		logs = append(logs, content)
+	}
+
+	return bytes.Join(logs, []byte("")), nil
+}
+
+// BuildID returns the ID of the current pipeline, e.g. 1234
+func (g *gitlabConfigProvider) BuildID() string {
+	return getEnv("CI_PIPELINE_ID", "n/a")
+}
+
+// ChangeSets returns the commits of the current merge request or the commits pushed with the current pipeline.
+func (g *gitlabConfigProvider) ChangeSets() []ChangeSet {
+	var commits []gitlabCommit
+	prNumber := 0
+	if g.IsPullRequest() {
+		prNumber, _ = strconv.Atoi(getEnv("CI_MERGE_REQUEST_IID", ""))
+		URL := g.projectAPIURL() + "/merge_requests/" + strconv.Itoa(prNumber) + "/commits"
+		if err := g.getJSON(URL, &commits); err != nil {
+			log.Entry().WithError(err).Debug("could not fetch merge request commits")
+			return []ChangeSet{}
+		}
+	} else {
+		before := getEnv("CI_COMMIT_BEFORE_SHA", gitlabEmptySHA)
+		if before == gitlabEmptySHA {
+			log.Entry().Debug("no previous commit available, unable to determine change sets")
+			return []ChangeSet{}
+		}
+		URL := g.projectAPIURL() + "/repository/compare?from=" + before + "&to=" + g.CommitSHA()
+		var compare gitlabCompare
+		if err := g.getJSON(URL, &compare); err != nil {
+			log.Entry().WithError(err).Debug("could not fetch compared commits")
+			return []ChangeSet{}
+		}
+		commits = compare.Commits
+	}
+
+	changeSets := make([]ChangeSet, 0, len(commits))
+	for _, commit := range commits {
+		changeSets = append(changeSets, ChangeSet{
+			CommitId:  commit.ID,
+			Timestamp: commit.CommittedDate,
+			PrNumber:  prNumber,
+		})
+	}
+	return changeSets
+}
+
+// PipelineStartTime returns the pipeline start time in UTC
+func (g *gitlabConfigProvider) PipelineStartTime() time.Time {
+	createdAt := getEnv("CI_PIPELINE_CREATED_AT", "")
+	timeStamp, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		log.Entry().WithError(err).Errorf("could not parse pipeline creation time %q", createdAt)
+		return time.Time{}.UTC()
+	}
+	return timeStamp.UTC()
+}
+
+// StageName returns the name of the stage the current job belongs to, e.g. build
+func (g *gitlabConfigProvider) StageName() string {
+	return getEnv("CI_JOB_STAGE", "n/a")
+}
+
+// BuildReason returns the reason of the pipeline trigger.
+// BuildReasons are unified with AzureDevOps build reasons, see
+// https://docs.microsoft.com/en-us/azure/devops/pipelines/build/variables?view=azure-devops&tabs=yaml#build-variables-devops-services
+func (g *gitlabConfigProvider) BuildReason() string {
+	switch getEnv("CI_PIPELINE_SOURCE", "") {
+	case "web", "api", "chat":
+		return BuildReasonManual
+	case "schedule":
+		return BuildReasonSchedule
+	case "merge_request_event", "external_pull_request_event":
+		return BuildReasonPullRequest
+	case "trigger", "pipeline", "parent_pipeline":
+		return BuildReasonResourceTrigger
+	case "push":
+		return BuildReasonIndividualCI
+	default:
+		return BuildReasonUnknown
+	}
+}
+
+// Branch returns the source branch name, e.g. main
+func (g *gitlabConfigProvider) Branch() string {
+	if g.IsPullRequest() {
+		return getEnv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "n/a")
+	}
+	return getEnv("CI_COMMIT_REF_NAME", "n/a")
+}
+
+// GitReference returns the git reference, e.g. refs/heads/main, refs/tags/v1.0.0 or refs/merge-requests/1/head
+func (g *gitlabConfigProvider) GitReference() string {
+	if g.IsPullRequest() {
+		return "refs/merge-requests/" + getEnv("CI_MERGE_REQUEST_IID", "") + "/head"
+	}
+	if tag := getEnv("CI_COMMIT_TAG", ""); len(tag) > 0 {
+		return "refs/tags/" + tag
+	}
+	ref := getEnv("CI_COMMIT_REF_NAME", "n/a")
+	if ref == "n/a" {
+		return ref
+	}
+	return "refs/heads/" + ref
+}
+
+// BuildURL returns the URL of the current pipeline, e.g. https://gitlab.com/foo/bar/-/pipelines/1234
+func (g *gitlabConfigProvider) BuildURL() string {
+	return getEnv("CI_PIPELINE_URL", "n/a")
+}
+
+// JobURL returns the URL of the project's pipelines, e.g. https://gitlab.com/foo/bar/-/pipelines
+func (g *gitlabConfigProvider) JobURL() string {
+	return g.RepoURL() + "/-/pipelines"
+}
+
+// JobName returns the path of the project, e.g. foo/bar
+func (g *gitlabConfigProvider) JobName() string {
+	return getEnv("CI_PROJECT_PATH", "n/a")
+}
+
+// CommitSHA returns the commit SHA the pipeline runs for, e.g. ffac537e6cbbf934b08745a378932722df287a53
+func (g *gitlabConfigProvider) CommitSHA() string {
+	return getEnv("CI_COMMIT_SHA", "n/a")
+}
+
+// RepoURL returns the URL of the project, e.g. https://gitlab.com/foo/bar
+func (g *gitlabConfigProvider) RepoURL() string {
+	return getEnv("CI_PROJECT_URL", "n/a")
+}
+
+// PullRequestConfig returns the merge request configuration
+func (g *gitlabConfigProvider) PullRequestConfig() PullRequestConfig {
+	return PullRequestConfig{
+		Branch: getEnv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "n/a"),
+		Base:   getEnv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME", "n/a"),
+		Key:    getEnv("CI_MERGE_REQUEST_IID", "n/a"),
+	}
+}
+
+// IsPullRequest indicates whether the current pipeline is a merge request pipeline
+func (g *gitlabConfigProvider) IsPullRequest() bool {
+	return envVarIsTrue("CI_MERGE_REQUEST_IID")
+}
+
+func isGitLab() bool {
+	envVars := []string{"GITLAB_CI"}
+	return envVarsAreSet(envVars)
+}
+
+// projectAPIURL returns the API URL of the current project, e.g. https://gitlab.com/api/v4/projects/42
+func (g *gitlabConfigProvider) projectAPIURL() string {
+	return getEnv("CI_API_V4_URL", "n/a") + "/projects/" + getEnv("CI_PROJECT_ID", "n/a")
+}
+
+func (g *gitlabConfigProvider) getJSON(URL string, target interface{}) error {
+	response, err := g.client.GetRequest(URL, g.header, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to GET %s", URL)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("response code is %v for %s", response.StatusCode, URL)
+	}
+	return piperHttp.ParseHTTPResponseBodyJSON(response, target)
+}
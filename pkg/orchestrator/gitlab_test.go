@@ -0,0 +1,257 @@
+//go:build unit
+// +build unit
+
+package orchestrator
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/jarcoal/httpmock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitLab(t *testing.T) {
+	t.Run("GitLab - BranchBuild", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("GITLAB_CI", "true")
+		os.Setenv("CI_COMMIT_REF_NAME", "feat/test-gitlab")
+		os.Setenv("CI_COMMIT_SHA", "abcdef42713")
+		os.Setenv("CI_PROJECT_URL", "https://gitlab.com/foo/bar")
+		os.Setenv("CI_PROJECT_PATH", "foo/bar")
+		os.Setenv("CI_PIPELINE_URL", "https://gitlab.com/foo/bar/-/pipelines/42")
+		os.Setenv("CI_PIPELINE_ID", "42")
+		os.Setenv("CI_JOB_STAGE", "build")
+		os.Setenv("CI_SERVER_VERSION", "16.4.1")
+
+		assert.Equal(t, GitLab, DetectOrchestrator())
+		assert.Equal(t, "GitLab", DetectOrchestrator().String())
+
+		p := newGitlabConfigProvider()
+
+		assert.False(t, p.IsPullRequest())
+		assert.Equal(t, "feat/test-gitlab", p.Branch())
+		assert.Equal(t, "refs/heads/feat/test-gitlab", p.GitReference())
+		assert.Equal(t, "https://gitlab.com/foo/bar/-/pipelines/42", p.BuildURL())
+		assert.Equal(t, "https://gitlab.com/foo/bar/-/pipelines", p.JobURL())
+		assert.Equal(t, "foo/bar", p.JobName())
+		assert.Equal(t, "42", p.BuildID())
+		assert.Equal(t, "abcdef42713", p.CommitSHA())
+		assert.Equal(t, "https://gitlab.com/foo/bar", p.RepoURL())
+		assert.Equal(t, "build", p.StageName())
+		assert.Equal(t, "GitLab", p.OrchestratorType())
+		assert.Equal(t, "16.4.1", p.OrchestratorVersion())
+	})
+
+	t.Run("GitLab - TagBuild", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("CI_COMMIT_REF_NAME", "v1.0.0")
+		os.Setenv("CI_COMMIT_TAG", "v1.0.0")
+
+		p := newGitlabConfigProvider()
+
+		assert.Equal(t, "refs/tags/v1.0.0", p.GitReference())
+	})
+
+	t.Run("MR", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("CI_COMMIT_REF_NAME", "feat/test-gitlab")
+		os.Setenv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "feat/test-gitlab")
+		os.Setenv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME", "main")
+		os.Setenv("CI_MERGE_REQUEST_IID", "42")
+
+		p := newGitlabConfigProvider()
+		c := p.PullRequestConfig()
+
+		assert.True(t, p.IsPullRequest())
+		assert.Equal(t, "feat/test-gitlab", p.Branch())
+		assert.Equal(t, "refs/merge-requests/42/head", p.GitReference())
+		assert.Equal(t, "feat/test-gitlab", c.Branch)
+		assert.Equal(t, "main", c.Base)
+		assert.Equal(t, "42", c.Key)
+	})
+
+	t.Run("GitLab - false", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+
+		os.Setenv("GITLAB_CI", "false")
+
+		assert.Equal(t, Orchestrator(Unknown), DetectOrchestrator())
+	})
+}
+
+func TestGitlabConfigProvider_GetBuildStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		jobStatus string
+		want      string
+	}{
+		{"BuildStatusSuccess", "success", BuildStatusSuccess},
+		{"BuildStatusAborted", "canceled", BuildStatusAborted},
+		{"BuildStatusInProgress", "running", BuildStatusInProgress},
+		{"BuildStatusFailure", "failed", BuildStatusFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer resetEnv(os.Environ())
+			os.Clearenv()
+			os.Setenv("CI_JOB_STATUS", tt.jobStatus)
+
+			p := newGitlabConfigProvider()
+			assert.Equalf(t, tt.want, p.BuildStatus(), "BuildStatus()")
+		})
+	}
+}
+
+func TestGitlabConfigProvider_GetBuildReason(t *testing.T) {
+	tests := []struct {
+		name           string
+		pipelineSource string
+		want           string
+	}{
+		{"BuildReasonManual", "web", BuildReasonManual},
+		{"BuildReasonSchedule", "schedule", BuildReasonSchedule},
+		{"BuildReasonPullRequest", "merge_request_event", BuildReasonPullRequest},
+		{"BuildReasonResourceTrigger", "parent_pipeline", BuildReasonResourceTrigger},
+		{"BuildReasonIndividualCI", "push", BuildReasonIndividualCI},
+		{"BuildReasonUnknown", "qwerty", BuildReasonUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer resetEnv(os.Environ())
+			os.Clearenv()
+			os.Setenv("CI_PIPELINE_SOURCE", tt.pipelineSource)
+
+			p := newGitlabConfigProvider()
+			assert.Equalf(t, tt.want, p.BuildReason(), "BuildReason()")
+		})
+	}
+}
+
+func TestGitlabConfigProvider_GetPipelineStartTime(t *testing.T) {
+	tests := []struct {
+		name      string
+		createdAt string
+		want      time.Time
+	}{
+		{"Retrieve correct time", "2023-08-11T07:28:24Z", time.Date(2023, time.August, 11, 7, 28, 24, 0, time.UTC)},
+		{"Malformed time", "2023-08/11 07:28:24", time.Time{}.UTC()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer resetEnv(os.Environ())
+			os.Clearenv()
+			os.Setenv("CI_PIPELINE_CREATED_AT", tt.createdAt)
+
+			p := newGitlabConfigProvider()
+			assert.Equalf(t, tt.want, p.PipelineStartTime(), "PipelineStartTime()")
+		})
+	}
+}
+
+func TestGitlabConfigProvider_API(t *testing.T) {
+	setupEnv := func() {
+		os.Clearenv()
+		os.Setenv("CI_API_V4_URL", "https://gitlab.com/api/v4")
+		os.Setenv("CI_PROJECT_ID", "7")
+		os.Setenv("CI_PIPELINE_ID", "42")
+		os.Setenv("CI_COMMIT_SHA", "bbb")
+	}
+	newProvider := func() *gitlabConfigProvider {
+		p := newGitlabConfigProvider()
+		p.client.SetOptions(piperhttp.ClientOptions{
+			MaxRequestDuration:  5 * time.Second,
+			UseDefaultTransport: true, // need to use default transport for http mock
+			MaxRetries:          -1,
+		})
+		return p
+	}
+
+	t.Run("FullLogs", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		setupEnv()
+		os.Setenv("CI_JOB_TOKEN", "job-token")
+
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder(http.MethodGet, "https://gitlab.com/api/v4/projects/7/pipelines/42/jobs?per_page=100",
+			func(req *http.Request) (*http.Response, error) {
+				// the job token is used without further configuration of the provider
+				if req.Header.Get("JOB-TOKEN") != "job-token" {
+					return httpmock.NewStringResponse(401, ""), nil
+				}
+				return httpmock.NewStringResponse(200, `[{"id": 2, "name": "test"}, {"id": 1, "name": "build"}]`), nil
+			})
+		httpmock.RegisterResponder(http.MethodGet, "https://gitlab.com/api/v4/projects/7/jobs/1/trace",
+			httpmock.NewStringResponder(200, "build log\n"))
+		httpmock.RegisterResponder(http.MethodGet, "https://gitlab.com/api/v4/projects/7/jobs/2/trace",
+			httpmock.NewStringResponder(200, "test log\n"))
+
+		logs, err := newProvider().FullLogs()
+		assert.NoError(t, err)
+		assert.Equal(t, "build log\ntest log\n", string(logs))
+	})
+
+	t.Run("Configure - job token is not logged", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		setupEnv()
+		os.Setenv("CI_JOB_TOKEN", "job-token")
+		var buffer bytes.Buffer
+		outWriter := log.Entry().Logger.Out
+		log.Entry().Logger.SetOutput(&buffer)
+		defer func() { log.Entry().Logger.SetOutput(outWriter) }()
+		level := logrus.GetLevel()
+		logrus.SetLevel(logrus.DebugLevel)
+		defer logrus.SetLevel(level)
+
+		p := newGitlabConfigProvider()
+		log.Entry().Debugf("header: %v", p.header.Get("JOB-TOKEN"))
+
+		assert.Equal(t, "job-token", p.header.Get("JOB-TOKEN"))
+		assert.NotContains(t, buffer.String(), "job-token")
+	})
+
+	t.Run("ChangeSets - branch", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		setupEnv()
+		os.Setenv("CI_COMMIT_BEFORE_SHA", "aaa")
+
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder(http.MethodGet, "https://gitlab.com/api/v4/projects/7/repository/compare?from=aaa&to=bbb",
+			httpmock.NewStringResponder(200, `{"commits": [{"id": "bbb", "committed_date": "2023-08-11T07:28:24Z"}]}`))
+
+		assert.Equal(t, []ChangeSet{{CommitId: "bbb", Timestamp: "2023-08-11T07:28:24Z"}}, newProvider().ChangeSets())
+	})
+
+	t.Run("ChangeSets - MR", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		setupEnv()
+		os.Setenv("CI_MERGE_REQUEST_IID", "3")
+
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder(http.MethodGet, "https://gitlab.com/api/v4/projects/7/merge_requests/3/commits",
+			httpmock.NewStringResponder(200, `[{"id": "bbb", "committed_date": "2023-08-11T07:28:24Z"}]`))
+
+		assert.Equal(t, []ChangeSet{{CommitId: "bbb", Timestamp: "2023-08-11T07:28:24Z", PrNumber: 3}}, newProvider().ChangeSets())
+	})
+
+	t.Run("ChangeSets - first pipeline", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		setupEnv()
+		os.Setenv("CI_COMMIT_BEFORE_SHA", gitlabEmptySHA)
+
+		assert.Empty(t, newProvider().ChangeSets())
+	})
+}
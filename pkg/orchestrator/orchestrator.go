@@ -14,6 +14,8 @@ const (
 	AzureDevOps
 	GitHubActions
 	Jenkins
+	GitLab
+	Tekton
 )
 
 const (
@@ -66,6 +68,7 @@ type (
 		JenkinsToken    string
 		AzureToken      string
 		GitHubToken     string
+		GitLabToken     string
 	}
 
 	PullRequestConfig struct {
@@ -91,9 +94,13 @@ func GetOrchestratorConfigProvider(opts *Options) (ConfigProvider, error) {
 			provider = newGithubActionsConfigProvider()
 		case Jenkins:
 			provider = newJenkinsConfigProvider()
+		case GitLab:
+			provider = newGitlabConfigProvider()
+		case Tekton:
+			provider = newTektonConfigProvider()
 		default:
 			provider = newUnknownOrchestratorConfigProvider()
-			err = errors.New("unable to detect a supported orchestrator (Azure DevOps, GitHub Actions, Jenkins)")
+			err = errors.New("unable to detect a supported orchestrator (Azure DevOps, GitHub Actions, Jenkins, GitLab, Tekton)")
 		}
 	})
 	if err != nil {
@@ -121,13 +128,17 @@ func DetectOrchestrator() Orchestrator {
 		return GitHubActions
 	} else if isJenkins() {
 		return Jenkins
+	} else if isGitLab() {
+		return GitLab
+	} else if isTekton() {
+		return Tekton
 	} else {
 		return Unknown
 	}
 }
 
 func (o Orchestrator) String() string {
-	return [...]string{"Unknown", "AzureDevOps", "GitHubActions", "Jenkins"}[o]
+	return [...]string{"Unknown", "AzureDevOps", "GitHubActions", "Jenkins", "GitLab", "Tekton"}[o]
 }
 
 // ResetConfigProvider is intended to be used only for unit tests because some of these tests
@@ -136,4 +147,7 @@ func (o Orchestrator) String() string {
 func ResetConfigProvider() {
 	provider = nil
 	providerOnce = sync.Once{}
+	downwardAPIFiles.Lock()
+	downwardAPIFiles.entries = map[string]map[string]string{}
+	downwardAPIFiles.Unlock()
 }
@@ -0,0 +1,281 @@
+package orchestrator
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+)
+
+const (
+	tektonLabelPipelineRun  = "tekton.dev/pipelineRun"
+	tektonLabelTaskRun      = "tekton.dev/taskRun"
+	tektonLabelPipeline     = "tekton.dev/pipeline"
+	tektonLabelPipelineTask = "tekton.dev/pipelineTask"
+	tektonLabelTask         = "tekton.dev/task"
+
+	tektonAnnotationRelease = "pipeline.tekton.dev/release"
+
+	// annotations set by Pipelines-as-Code, see https://pipelinesascode.com/docs/guide/running/
+	tektonAnnotationSHA         = "pipelinesascode.tekton.dev/sha"
+	tektonAnnotationRepoURL     = "pipelinesascode.tekton.dev/repo-url"
+	tektonAnnotationBranch      = "pipelinesascode.tekton.dev/source-branch"
+	tektonAnnotationBaseBranch  = "pipelinesascode.tekton.dev/base-branch"
+	tektonAnnotationPullRequest = "pipelinesascode.tekton.dev/pull-request"
+	tektonAnnotationEventType   = "pipelinesascode.tekton.dev/event-type"
+)
+
+// tektonPodInfoPath is the default mount path of the downward API volume exposing pod labels and annotations
+var tektonPodInfoPath = "/etc/podinfo"
+
+// downwardAPIMaxLineSize is the maximum size of an entry in a downward API file, annotations may contain large values like
+// the last applied configuration of kubectl
+const downwardAPIMaxLineSize = 1024 * 1024
+
+// downwardAPIFiles caches the parsed downward API files per path since they do not change during the lifetime of the pod
+var downwardAPIFiles = struct {
+	sync.Mutex
+	entries map[string]map[string]string
+}{entries: map[string]map[string]string{}}
+
+type tektonConfigProvider struct {
+	labels      map[string]string
+	annotations map[string]string
+}
+
+func newTektonConfigProvider() *tektonConfigProvider {
+	podInfoPath := getEnv("TEKTON_PODINFO_PATH", tektonPodInfoPath)
+	return &tektonConfigProvider{
+		labels:      readDownwardAPIFile(filepath.Join(podInfoPath, "labels")),
+		annotations: readDownwardAPIFile(filepath.Join(podInfoPath, "annotations")),
+	}
+}
+
+// Configure is a no-op for Tekton, all information is taken from the environment and the downward API files.
+func (t *tektonConfigProvider) Configure(_ *Options) error {
+	log.Entry().Debug("Successfully initialized Tekton config provider")
+	return nil
+}
+
+// OrchestratorVersion returns the Tekton Pipelines release, e.g. v0.50.0
+func (t *tektonConfigProvider) OrchestratorVersion() string {
+	return t.lookup("TEKTON_VERSION", t.annotations, tektonAnnotationRelease)
+}
+
+// OrchestratorType returns the orchestrator type Tekton
+func (t *tektonConfigProvider) OrchestratorType() string {
+	return "Tekton"
+}
+
+// BuildStatus returns the aggregated status of the pipeline tasks.
+// It is only available in finally tasks, e.g. by passing $(tasks.status) as TEKTON_TASKS_STATUS,
+// otherwise the pipeline run is considered to be in progress.
+func (t *tektonConfigProvider) BuildStatus() string {
+	switch getEnv("TEKTON_TASKS_STATUS", "None") {
+	case "Succeeded", "Completed":
+		return BuildStatusSuccess
+	case "None":
+		return BuildStatusInProgress
+	default:
+		return BuildStatusFailure
+	}
+}
+
+func (t *tektonConfigProvider) FullLogs() ([]byte, error) {
+	log.Entry().Debug("FullLogs() for Tekton is not supported")
+	return []byte{}, nil
+}
+
+// BuildID returns the name of the current pipeline run, e.g. build-pipeline-run-x7k2p
+func (t *tektonConfigProvider) BuildID() string {
+	if pipelineRun := t.lookup("TEKTON_PIPELINE_RUN", t.labels, tektonLabelPipelineRun); pipelineRun != "n/a" {
+		return pipelineRun
+	}
+	return t.lookup("TEKTON_TASK_RUN", t.labels, tektonLabelTaskRun)
+}
+
+func (t *tektonConfigProvider) ChangeSets() []ChangeSet {
+	log.Entry().Debug("ChangeSets for Tekton not implemented")
+	return []ChangeSet{}
+}
+
+// PipelineStartTime returns the pipeline start time in UTC.
+// Tekton does not expose the start time to the pod, it needs to be passed as TEKTON_PIPELINE_RUN_START_TIME in RFC3339 format.
+func (t *tektonConfigProvider) PipelineStartTime() time.Time {
+	startTime := getEnv("TEKTON_PIPELINE_RUN_START_TIME", "")
+	timeStamp, err := time.Parse(time.RFC3339, startTime)
+	if err != nil {
+		log.Entry().WithError(err).Debugf("could not parse pipeline start time %q", startTime)
+		return time.Time{}.UTC()
+	}
+	return timeStamp.UTC()
+}
+
+// StageName returns the name of the pipeline task, e.g. build
+func (t *tektonConfigProvider) StageName() string {
+	if pipelineTask := t.lookup("TEKTON_PIPELINE_TASK", t.labels, tektonLabelPipelineTask); pipelineTask != "n/a" {
+		return pipelineTask
+	}
+	return t.lookup("TEKTON_TASK", t.labels, tektonLabelTask)
+}
+
+// BuildReason returns the reason of the pipeline run trigger, which is only available with Pipelines-as-Code.
+// BuildReasons are unified with AzureDevOps build reasons, see
+// https://docs.microsoft.com/en-us/azure/devops/pipelines/build/variables?view=azure-devops&tabs=yaml#build-variables-devops-services
+func (t *tektonConfigProvider) BuildReason() string {
+	switch t.lookup("TEKTON_EVENT_TYPE", t.annotations, tektonAnnotationEventType) {
+	case "pull_request", "Merge_Request", "pull_request_labeled":
+		return BuildReasonPullRequest
+	case "push", "Push":
+		return BuildReasonIndividualCI
+	case "incoming":
+		return BuildReasonResourceTrigger
+	default:
+		return BuildReasonUnknown
+	}
+}
+
+// Branch returns the source branch name, e.g. main
+func (t *tektonConfigProvider) Branch() string {
+	return strings.TrimPrefix(t.lookup("TEKTON_BRANCH", t.annotations, tektonAnnotationBranch), "refs/heads/")
+}
+
+// GitReference returns the git reference, e.g. refs/heads/main
+func (t *tektonConfigProvider) GitReference() string {
+	ref := t.lookup("TEKTON_BRANCH", t.annotations, tektonAnnotationBranch)
+	if ref == "n/a" || strings.HasPrefix(ref, "refs/") {
+		return ref
+	}
+	return "refs/heads/" + ref
+}
+
+// BuildURL returns the URL of the pipeline run in the Tekton Dashboard,
+// e.g. https://dashboard.example.com/#/namespaces/foo/pipelineruns/build-pipeline-run-x7k2p
+func (t *tektonConfigProvider) BuildURL() string {
+	dashboardURL := getEnv("TEKTON_DASHBOARD_URL", "")
+	if len(dashboardURL) == 0 {
+		return "n/a"
+	}
+	resource := "pipelineruns/"
+	if t.lookup("TEKTON_PIPELINE_RUN", t.labels, tektonLabelPipelineRun) == "n/a" {
+		resource = "taskruns/"
+	}
+	return strings.TrimSuffix(dashboardURL, "/") + "/#/namespaces/" + t.namespace() + "/" + resource + t.BuildID()
+}
+
+// JobURL returns the URL of the pipeline runs of the current pipeline in the Tekton Dashboard,
+// e.g. https://dashboard.example.com/#/namespaces/foo/pipelineruns?pipelineName=build-pipeline
+func (t *tektonConfigProvider) JobURL() string {
+	dashboardURL := getEnv("TEKTON_DASHBOARD_URL", "")
+	if len(dashboardURL) == 0 {
+		return "n/a"
+	}
+	return strings.TrimSuffix(dashboardURL, "/") + "/#/namespaces/" + t.namespace() + "/pipelineruns?pipelineName=" + t.JobName()
+}
+
+// JobName returns the name of the pipeline, e.g. build-pipeline
+func (t *tektonConfigProvider) JobName() string {
+	return t.lookup("TEKTON_PIPELINE", t.labels, tektonLabelPipeline)
+}
+
+// CommitSHA returns the commit SHA the pipeline runs for, e.g. ffac537e6cbbf934b08745a378932722df287a53
+func (t *tektonConfigProvider) CommitSHA() string {
+	return t.lookup("TEKTON_COMMIT_SHA", t.annotations, tektonAnnotationSHA)
+}
+
+// RepoURL returns the URL of the repository, e.g. https://github.com/SAP/jenkins-library
+func (t *tektonConfigProvider) RepoURL() string {
+	return t.lookup("TEKTON_REPO_URL", t.annotations, tektonAnnotationRepoURL)
+}
+
+// PullRequestConfig returns the pull request configuration
+func (t *tektonConfigProvider) PullRequestConfig() PullRequestConfig {
+	return PullRequestConfig{
+		Branch: t.Branch(),
+		Base:   strings.TrimPrefix(t.lookup("TEKTON_BASE_BRANCH", t.annotations, tektonAnnotationBaseBranch), "refs/heads/"),
+		Key:    t.lookup("TEKTON_PULL_REQUEST", t.annotations, tektonAnnotationPullRequest),
+	}
+}
+
+// IsPullRequest indicates whether the current pipeline run is triggered by a pull request
+func (t *tektonConfigProvider) IsPullRequest() bool {
+	return t.lookup("TEKTON_PULL_REQUEST", t.annotations, tektonAnnotationPullRequest) != "n/a"
+}
+
+func isTekton() bool {
+	envVars := []string{"TEKTON_PIPELINE_RUN", "TEKTON_TASK_RUN"}
+	if envVarsAreSet(envVars) {
+		return true
+	}
+	labels := readDownwardAPIFile(filepath.Join(getEnv("TEKTON_PODINFO_PATH", tektonPodInfoPath), "labels"))
+	_, ok := labels[tektonLabelTaskRun]
+	return ok
+}
+
+// namespace returns the namespace of the current pod
+func (t *tektonConfigProvider) namespace() string {
+	if namespace := getEnv("TEKTON_NAMESPACE", ""); len(namespace) > 0 {
+		return namespace
+	}
+	namespace, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
+	if err != nil {
+		return "n/a"
+	}
+	return strings.TrimSpace(string(namespace))
+}
+
+// lookup returns the value of the environment variable if set, otherwise the value of the given pod label or annotation
+func (t *tektonConfigProvider) lookup(envVar string, podInfo map[string]string, key string) string {
+	if value := getEnv(envVar, ""); len(value) > 0 {
+		return value
+	}
+	if value, ok := podInfo[key]; ok && len(value) > 0 {
+		return value
+	}
+	return "n/a"
+}
+
+// readDownwardAPIFile parses a labels or annotations file mounted via the Kubernetes downward API.
+// Each line contains one entry in the format key="value". The result is cached per path.
+func readDownwardAPIFile(path string) map[string]string {
+	downwardAPIFiles.Lock()
+	defer downwardAPIFiles.Unlock()
+	if entries, ok := downwardAPIFiles.entries[path]; ok {
+		return entries
+	}
+	entries := parseDownwardAPIFile(path)
+	downwardAPIFiles.entries[path] = entries
+	return entries
+}
+
+func parseDownwardAPIFile(path string) map[string]string {
+	entries := map[string]string{}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		log.Entry().Debugf("could not read downward API file %v: %v", path, err)
+		return entries
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), downwardAPIMaxLineSize)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), "=")
+		if !found {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		entries[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		log.Entry().Warnf("could not parse downward API file %v completely: %v", path, err)
+	}
+	return entries
+}
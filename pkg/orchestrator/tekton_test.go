@@ -0,0 +1,144 @@
+//go:build unit
+// +build unit
+
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTekton(t *testing.T) {
+	podInfo := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(podInfo, "labels"), []byte(`app.kubernetes.io/managed-by="tekton-pipelines"
+tekton.dev/pipeline="build-pipeline"
+tekton.dev/pipelineRun="build-pipeline-run-x7k2p"
+tekton.dev/pipelineTask="build"
+tekton.dev/task="piper-build"
+tekton.dev/taskRun="build-pipeline-run-x7k2p-build"`), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(podInfo, "annotations"), []byte(`pipeline.tekton.dev/release="v0.50.0"
+pipelinesascode.tekton.dev/sha="abcdef42713"
+pipelinesascode.tekton.dev/repo-url="https://github.com/foo/bar"
+pipelinesascode.tekton.dev/source-branch="refs/heads/feat/test-tekton"
+pipelinesascode.tekton.dev/base-branch="main"
+pipelinesascode.tekton.dev/pull-request="42"
+pipelinesascode.tekton.dev/event-type="pull_request"`), 0o644))
+
+	t.Run("Tekton - downward API", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("TEKTON_PODINFO_PATH", podInfo)
+		os.Setenv("TEKTON_DASHBOARD_URL", "https://dashboard.example.com/")
+		os.Setenv("TEKTON_NAMESPACE", "foo")
+
+		assert.Equal(t, Tekton, DetectOrchestrator())
+		assert.Equal(t, "Tekton", DetectOrchestrator().String())
+
+		p := newTektonConfigProvider()
+		c := p.PullRequestConfig()
+
+		assert.Equal(t, "Tekton", p.OrchestratorType())
+		assert.Equal(t, "v0.50.0", p.OrchestratorVersion())
+		assert.Equal(t, "build-pipeline-run-x7k2p", p.BuildID())
+		assert.Equal(t, "build", p.StageName())
+		assert.Equal(t, "build-pipeline", p.JobName())
+		assert.Equal(t, "https://dashboard.example.com/#/namespaces/foo/pipelineruns/build-pipeline-run-x7k2p", p.BuildURL())
+		assert.Equal(t, "https://dashboard.example.com/#/namespaces/foo/pipelineruns?pipelineName=build-pipeline", p.JobURL())
+		assert.Equal(t, "abcdef42713", p.CommitSHA())
+		assert.Equal(t, "https://github.com/foo/bar", p.RepoURL())
+		assert.Equal(t, "feat/test-tekton", p.Branch())
+		assert.Equal(t, "refs/heads/feat/test-tekton", p.GitReference())
+		assert.Equal(t, BuildReasonPullRequest, p.BuildReason())
+		assert.True(t, p.IsPullRequest())
+		assert.Equal(t, "feat/test-tekton", c.Branch)
+		assert.Equal(t, "main", c.Base)
+		assert.Equal(t, "42", c.Key)
+	})
+
+	t.Run("Tekton - env", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("TEKTON_PODINFO_PATH", t.TempDir())
+		os.Setenv("TEKTON_TASK_RUN", "piper-build-run-4hx9s")
+		os.Setenv("TEKTON_TASK", "piper-build")
+		os.Setenv("TEKTON_PIPELINE_RUN_START_TIME", "2023-08-11T07:28:24Z")
+		os.Setenv("TEKTON_DASHBOARD_URL", "https://dashboard.example.com")
+		os.Setenv("TEKTON_NAMESPACE", "foo")
+
+		assert.Equal(t, Tekton, DetectOrchestrator())
+
+		p := newTektonConfigProvider()
+
+		assert.Equal(t, "piper-build-run-4hx9s", p.BuildID())
+		assert.Equal(t, "piper-build", p.StageName())
+		assert.Equal(t, "n/a", p.JobName())
+		assert.Equal(t, "https://dashboard.example.com/#/namespaces/foo/taskruns/piper-build-run-4hx9s", p.BuildURL())
+		assert.Equal(t, time.Date(2023, time.August, 11, 7, 28, 24, 0, time.UTC), p.PipelineStartTime())
+		assert.False(t, p.IsPullRequest())
+		assert.Equal(t, "n/a", p.GitReference())
+	})
+
+	t.Run("Tekton - false", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("TEKTON_PODINFO_PATH", t.TempDir())
+
+		assert.Equal(t, Orchestrator(Unknown), DetectOrchestrator())
+	})
+}
+
+func TestTektonConfigProvider_GetBuildStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		tasksStatus string
+		want        string
+	}{
+		{"BuildStatusSuccess", "Succeeded", BuildStatusSuccess},
+		{"BuildStatusSuccess", "Completed", BuildStatusSuccess},
+		{"BuildStatusInProgress", "None", BuildStatusInProgress},
+		{"BuildStatusFailure", "Failed", BuildStatusFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer resetEnv(os.Environ())
+			os.Clearenv()
+			os.Setenv("TEKTON_TASKS_STATUS", tt.tasksStatus)
+
+			p := &tektonConfigProvider{}
+			assert.Equalf(t, tt.want, p.BuildStatus(), "BuildStatus()")
+		})
+	}
+}
+
+func Test_readDownwardAPIFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "annotations")
+	assert.NoError(t, os.WriteFile(path, []byte("foo=\"bar\"\nkey=\"with \\\"quotes\\\"\"\ninvalid"), 0o644))
+
+	assert.Equal(t, map[string]string{"foo": "bar", "key": `with "quotes"`}, readDownwardAPIFile(path))
+	assert.Empty(t, readDownwardAPIFile(filepath.Join(t.TempDir(), "missing")))
+
+	t.Run("cached", func(t *testing.T) {
+		assert.NoError(t, os.Remove(path))
+		assert.Equal(t, map[string]string{"foo": "bar", "key": `with "quotes"`}, readDownwardAPIFile(path))
+	})
+
+	t.Run("long entries", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "annotations")
+		longValue := strings.Repeat("x", 100*1024)
+		assert.NoError(t, os.WriteFile(path, []byte("long=\""+longValue+"\"\nfoo=\"bar\""), 0o644))
+
+		assert.Equal(t, map[string]string{"long": longValue, "foo": "bar"}, readDownwardAPIFile(path))
+	})
+
+	t.Run("too long entry", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "annotations")
+		assert.NoError(t, os.WriteFile(path, []byte("foo=\"bar\"\nlong=\""+strings.Repeat("x", downwardAPIMaxLineSize)+"\""), 0o644))
+
+		assert.Equal(t, map[string]string{"foo": "bar"}, readDownwardAPIFile(path))
+	})
+}
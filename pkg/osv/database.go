@@ -0,0 +1,301 @@
+package osv
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/package-url/packageurl-go"
+	"github.com/pkg/errors"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/sbom"
+)
+
+// Database is an in-memory vulnerability database loaded from OSV files,
+// e.g. the ecosystem exports available at https://osv-vulnerabilities.storage.googleapis.com/<ecosystem>/all.zip
+type Database struct {
+	vulnerabilities map[string]*Vulnerability
+	// packages indexes the ids of the vulnerabilities by the affected packages
+	packages     map[string][]string
+	lastModified string
+}
+
+// Finding is a vulnerability affecting a component
+type Finding struct {
+	PackageURL    string
+	Package       Package
+	Version       string
+	Vulnerability *Vulnerability
+	FixedVersions []string
+	Score         float64
+	Severity      string
+	// Locations are the SBOMs containing the component
+	Locations []string
+	// Assessment is set if the vulnerability has been assessed as not relevant for the component
+	Assessment *format.Assessment
+}
+
+// ScanResult contains the findings of a scan
+type ScanResult struct {
+	Findings []Finding
+	// Components is the number of distinct components which have been scanned
+	Components int
+	// Unsupported is the number of components which could not be scanned since their ecosystem is not supported
+	Unsupported int
+}
+
+// NewDatabase creates an empty database
+func NewDatabase() *Database {
+	return &Database{vulnerabilities: map[string]*Vulnerability{}, packages: map[string][]string{}}
+}
+
+// Size returns the number of vulnerabilities in the database
+func (d *Database) Size() int {
+	return len(d.vulnerabilities)
+}
+
+// LastModified returns the latest modification timestamp of the vulnerabilities, i.e. the age of the database snapshot
+func (d *Database) LastModified() string {
+	return d.lastModified
+}
+
+// Add adds a vulnerability to the database, a vulnerability with the same id is replaced if it has been modified later.
+// Withdrawn vulnerabilities are ignored.
+func (d *Database) Add(vulnerability Vulnerability) {
+	if len(vulnerability.ID) == 0 || len(vulnerability.Withdrawn) > 0 {
+		return
+	}
+	if existing, ok := d.vulnerabilities[vulnerability.ID]; ok && existing.Modified >= vulnerability.Modified {
+		return
+	}
+	d.vulnerabilities[vulnerability.ID] = &vulnerability
+	for _, affected := range vulnerability.Affected {
+		key := packageKey(affected.Package)
+		if !slices.Contains(d.packages[key], vulnerability.ID) {
+			d.packages[key] = append(d.packages[key], vulnerability.ID)
+		}
+	}
+	if vulnerability.Modified > d.lastModified {
+		d.lastModified = vulnerability.Modified
+	}
+}
+
+// Load adds the vulnerabilities contained in a file to the database.
+// Supported are OSV JSON files containing a single vulnerability or a list of vulnerabilities
+// as well as zip archives of such files like the OSV ecosystem exports.
+func (d *Database) Load(name string, content []byte) error {
+	if strings.EqualFold(path.Ext(name), ".zip") {
+		return d.loadZip(name, content)
+	}
+	return d.loadJSON(name, content)
+}
+
+func (d *Database) loadZip(name string, content []byte) error {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return errors.Wrapf(err, "failed to open vulnerability database %v", name)
+	}
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || !strings.EqualFold(path.Ext(file.Name), ".json") {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return errors.Wrapf(err, "failed to open %v in vulnerability database %v", file.Name, name)
+		}
+		entry, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %v in vulnerability database %v", file.Name, name)
+		}
+		if err := d.loadJSON(fmt.Sprintf("%v in %v", file.Name, name), entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Database) loadJSON(name string, content []byte) error {
+	vulnerabilities := []Vulnerability{}
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &vulnerabilities); err != nil {
+			return errors.Wrapf(err, "failed to parse vulnerabilities %v", name)
+		}
+	} else {
+		vulnerability := Vulnerability{}
+		if err := json.Unmarshal(content, &vulnerability); err != nil {
+			return errors.Wrapf(err, "failed to parse vulnerability %v", name)
+		}
+		vulnerabilities = append(vulnerabilities, vulnerability)
+	}
+	for _, vulnerability := range vulnerabilities {
+		d.Add(vulnerability)
+	}
+	return nil
+}
+
+// Match returns the vulnerabilities affecting the component with the given package URL.
+// The second return value is false if the ecosystem of the component is not supported.
+func (d *Database) Match(purl string) ([]Finding, bool) {
+	pkg, version, supported := PackageFromPurl(purl)
+	if !supported || len(version) == 0 {
+		return nil, supported
+	}
+	findings := []Finding{}
+	for _, id := range d.packages[packageKey(pkg)] {
+		vulnerability := d.vulnerabilities[id]
+		for _, affected := range vulnerability.Affected {
+			if packageKey(affected.Package) != packageKey(pkg) || !matchesEcosystem(affected.Package.Ecosystem, pkg.Ecosystem) {
+				continue
+			}
+			if !affected.isAffected(version) {
+				continue
+			}
+			score, severity := vulnerability.Score()
+			findings = append(findings, Finding{
+				PackageURL:    purl,
+				Package:       pkg,
+				Version:       version,
+				Vulnerability: vulnerability,
+				FixedVersions: affected.FixedVersions(),
+				Score:         score,
+				Severity:      severity,
+			})
+			break
+		}
+	}
+	return findings, true
+}
+
+// Scan matches the components of the given SBOMs against the database.
+// Components contained in several SBOMs are reported once, the findings are sorted by severity.
+func (d *Database) Scan(boms map[string]*cdx.BOM) ScanResult {
+	result := ScanResult{Findings: []Finding{}}
+	findings := map[string][]int{}
+	files := make([]string, 0, len(boms))
+	for file := range boms {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		for _, component := range sbom.Components(boms[file]) {
+			if len(component.PackageURL) == 0 {
+				continue
+			}
+			if indices, scanned := findings[component.PackageURL]; scanned {
+				for _, i := range indices {
+					if !slices.Contains(result.Findings[i].Locations, file) {
+						result.Findings[i].Locations = append(result.Findings[i].Locations, file)
+					}
+				}
+				continue
+			}
+			matches, supported := d.Match(component.PackageURL)
+			result.Components++
+			if !supported {
+				result.Unsupported++
+			}
+			indices := []int{}
+			for _, match := range matches {
+				match.Locations = []string{file}
+				indices = append(indices, len(result.Findings))
+				result.Findings = append(result.Findings, match)
+			}
+			findings[component.PackageURL] = indices
+		}
+	}
+
+	sort.SliceStable(result.Findings, func(i, j int) bool {
+		a, b := result.Findings[i], result.Findings[j]
+		if severityRank(a.Severity) != severityRank(b.Severity) {
+			return severityRank(a.Severity) > severityRank(b.Severity)
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Vulnerability.ID != b.Vulnerability.ID {
+			return a.Vulnerability.ID < b.Vulnerability.ID
+		}
+		return a.PackageURL < b.PackageURL
+	})
+	return result
+}
+
+// ApplyAssessments marks the findings assessed as not relevant and returns their number.
+// Assessments refer to the id or an alias of the vulnerability and optionally restrict it to packages.
+func (r *ScanResult) ApplyAssessments(assessments []format.Assessment) int {
+	assessed := 0
+	for i := range r.Findings {
+		for j := range assessments {
+			if assessments[j].Status != format.NotRelevant || !r.Findings[i].matchesAssessment(assessments[j]) {
+				continue
+			}
+			r.Findings[i].Assessment = &assessments[j]
+			assessed++
+			break
+		}
+	}
+	return assessed
+}
+
+// Active returns the findings which have not been assessed as not relevant
+func (r *ScanResult) Active() []Finding {
+	active := []Finding{}
+	for _, finding := range r.Findings {
+		if finding.Assessment == nil {
+			active = append(active, finding)
+		}
+	}
+	return active
+}
+
+func (f Finding) matchesAssessment(assessment format.Assessment) bool {
+	ids := append([]string{f.Vulnerability.ID}, f.Vulnerability.Aliases...)
+	matchesID := false
+	for _, id := range ids {
+		if strings.EqualFold(id, assessment.Vulnerability) {
+			matchesID = true
+			break
+		}
+	}
+	if !matchesID {
+		return false
+	}
+	if len(assessment.Purls) == 0 {
+		return true
+	}
+	component, err := packageurl.FromString(f.PackageURL)
+	if err != nil {
+		return false
+	}
+	for _, purl := range assessment.Purls {
+		assessed, err := purl.ToPackageUrl()
+		if err != nil {
+			continue
+		}
+		// the assessment applies to all versions if it does not specify one
+		if assessed.Type == component.Type && assessed.Namespace == component.Namespace && assessed.Name == component.Name &&
+			(len(assessed.Version) == 0 || assessed.Version == component.Version) {
+			return true
+		}
+	}
+	return false
+}
+
+// packageKey identifies a package independent of the release of its ecosystem, PyPI names are normalized
+func packageKey(pkg Package) string {
+	name := pkg.Name
+	if baseEcosystem(pkg.Ecosystem) == "PyPI" {
+		name = strings.ToLower(strings.NewReplacer("_", "-", ".", "-").Replace(name))
+	}
+	return strings.ToLower(baseEcosystem(pkg.Ecosystem)) + "|" + name
+}
@@ -0,0 +1,160 @@
+//go:build unit
+// +build unit
+
+package osv
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+)
+
+const testLog4Shell = `{
+  "id": "GHSA-jfh8-c2jp-5v3q",
+  "modified": "2024-03-01T12:00:00Z",
+  "aliases": ["CVE-2021-44228"],
+  "summary": "Remote code injection in Log4j",
+  "severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H"}],
+  "affected": [{
+    "package": {"ecosystem": "Maven", "name": "org.apache.logging.log4j:log4j-core"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "2.13.0"}, {"fixed": "2.15.0"}]}]
+  }]
+}`
+
+const testOpenSSL = `[{
+  "id": "DSA-5532-1",
+  "modified": "2024-02-01T12:00:00Z",
+  "aliases": ["CVE-2023-5363"],
+  "affected": [{
+    "package": {"ecosystem": "Debian:12", "name": "openssl"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "3.0.11-1~deb12u2"}]}]
+  }, {
+    "package": {"ecosystem": "Debian:11", "name": "openssl"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "1.1.1w-0+deb11u1"}]}]
+  }]
+}, {
+  "id": "DSA-0000-1",
+  "modified": "2024-02-01T12:00:00Z",
+  "withdrawn": "2024-02-02T12:00:00Z",
+  "affected": [{
+    "package": {"ecosystem": "Debian:12", "name": "openssl"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}]}]
+  }]
+}]`
+
+func newTestDatabase(t *testing.T) *Database {
+	archive := new(bytes.Buffer)
+	writer := zip.NewWriter(archive)
+	entry, err := writer.Create("GHSA-jfh8-c2jp-5v3q.json")
+	assert.NoError(t, err)
+	_, err = entry.Write([]byte(testLog4Shell))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	database := NewDatabase()
+	assert.NoError(t, database.Load("Maven/all.zip", archive.Bytes()))
+	assert.NoError(t, database.Load("debian.json", []byte(testOpenSSL)))
+	return database
+}
+
+func TestDatabaseLoad(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		database := newTestDatabase(t)
+		// withdrawn vulnerabilities are ignored
+		assert.Equal(t, 2, database.Size())
+		assert.Equal(t, "2024-03-01T12:00:00Z", database.LastModified())
+	})
+
+	t.Run("newer entry replaces older one", func(t *testing.T) {
+		database := newTestDatabase(t)
+		assert.NoError(t, database.Load("update.json", []byte(`{"id": "DSA-5532-1", "modified": "2024-04-01T12:00:00Z", "summary": "updated"}`)))
+		assert.NoError(t, database.Load("outdated.json", []byte(`{"id": "DSA-5532-1", "modified": "2023-04-01T12:00:00Z", "summary": "outdated"}`)))
+		assert.Equal(t, "updated", database.vulnerabilities["DSA-5532-1"].Summary)
+		assert.Equal(t, "2024-04-01T12:00:00Z", database.LastModified())
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		database := NewDatabase()
+		assert.ErrorContains(t, database.Load("broken.json", []byte(`{"id": 1}`)), "failed to parse vulnerability broken.json")
+		assert.ErrorContains(t, database.Load("broken.zip", []byte(`no zip`)), "failed to open vulnerability database broken.zip")
+	})
+}
+
+func TestDatabaseMatch(t *testing.T) {
+	t.Parallel()
+	database := newTestDatabase(t)
+
+	findings, supported := database.Match("pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1")
+	assert.True(t, supported)
+	if assert.Len(t, findings, 1) {
+		assert.Equal(t, "GHSA-jfh8-c2jp-5v3q", findings[0].Vulnerability.ID)
+		assert.Equal(t, 10.0, findings[0].Score)
+		assert.Equal(t, format.SeverityCritical, findings[0].Severity)
+		assert.Equal(t, []string{"2.15.0"}, findings[0].FixedVersions)
+	}
+
+	findings, _ = database.Match("pkg:maven/org.apache.logging.log4j/log4j-core@2.17.1")
+	assert.Empty(t, findings)
+
+	findings, _ = database.Match("pkg:deb/debian/libssl3@3.0.11-1~deb12u1?distro=debian-12.2&upstream=openssl")
+	if assert.Len(t, findings, 1) {
+		assert.Equal(t, "DSA-5532-1", findings[0].Vulnerability.ID)
+		assert.Equal(t, []string{"3.0.11-1~deb12u2"}, findings[0].FixedVersions)
+	}
+
+	// the version is fixed in Debian 11
+	findings, _ = database.Match("pkg:deb/debian/libssl1.1@1.1.1w-0+deb11u1?distro=debian-11&upstream=openssl")
+	assert.Empty(t, findings)
+
+	_, supported = database.Match("pkg:rpm/redhat/openssl@3.0.7")
+	assert.False(t, supported)
+}
+
+func TestDatabaseScan(t *testing.T) {
+	t.Parallel()
+	database := newTestDatabase(t)
+	log4j := cdx.Component{Name: "log4j-core", Version: "2.14.1", PackageURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}
+	boms := map[string]*cdx.BOM{
+		"bom-docker-0.xml": {Components: &[]cdx.Component{
+			{Name: "libssl3", Version: "3.0.11-1~deb12u1", PackageURL: "pkg:deb/debian/libssl3@3.0.11-1~deb12u1?distro=debian-12&upstream=openssl"},
+			log4j,
+			{Name: "openssl", Version: "3.0.7", PackageURL: "pkg:rpm/redhat/openssl@3.0.7"},
+			{Name: "no-purl", Version: "1.0.0"},
+		}},
+		"bom-docker-1.xml": {Components: &[]cdx.Component{log4j}},
+	}
+
+	result := database.Scan(boms)
+
+	assert.Equal(t, 3, result.Components)
+	assert.Equal(t, 1, result.Unsupported)
+	if assert.Len(t, result.Findings, 2) {
+		// sorted by severity
+		assert.Equal(t, "GHSA-jfh8-c2jp-5v3q", result.Findings[0].Vulnerability.ID)
+		assert.Equal(t, []string{"bom-docker-0.xml", "bom-docker-1.xml"}, result.Findings[0].Locations)
+		assert.Equal(t, "DSA-5532-1", result.Findings[1].Vulnerability.ID)
+		assert.Equal(t, []string{"bom-docker-0.xml"}, result.Findings[1].Locations)
+	}
+
+	t.Run("assessments", func(t *testing.T) {
+		result := database.Scan(boms)
+		assessed := result.ApplyAssessments([]format.Assessment{
+			{Vulnerability: "CVE-2021-44228", Status: format.NotRelevant, Analysis: format.NotUsed, Purls: []format.Purl{{Purl: "pkg:maven/org.apache.logging.log4j/log4j-core"}}},
+			{Vulnerability: "CVE-2023-5363", Status: format.Relevant},
+			{Vulnerability: "DSA-5532-1", Status: format.NotRelevant, Purls: []format.Purl{{Purl: "pkg:deb/debian/libssl3@3.0.0"}}},
+		})
+		assert.Equal(t, 1, assessed)
+		assert.Equal(t, format.NotUsed, result.Findings[0].Assessment.Analysis)
+		active := result.Active()
+		if assert.Len(t, active, 1) {
+			assert.Equal(t, "DSA-5532-1", active[0].Vulnerability.ID)
+		}
+	})
+}
@@ -0,0 +1,188 @@
+package osv
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// Vulnerability is an entry of an OSV vulnerability database, see https://ossf.github.io/osv-schema/
+type Vulnerability struct {
+	ID               string                 `json:"id"`
+	Modified         string                 `json:"modified,omitempty"`
+	Published        string                 `json:"published,omitempty"`
+	Withdrawn        string                 `json:"withdrawn,omitempty"`
+	Aliases          []string               `json:"aliases,omitempty"`
+	Summary          string                 `json:"summary,omitempty"`
+	Details          string                 `json:"details,omitempty"`
+	Severity         []Severity             `json:"severity,omitempty"`
+	Affected         []Affected             `json:"affected,omitempty"`
+	References       []Reference            `json:"references,omitempty"`
+	DatabaseSpecific map[string]interface{} `json:"database_specific,omitempty"`
+}
+
+// Severity is a severity score of a vulnerability, e.g. a CVSS vector
+type Severity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// Affected describes the affected versions of a package
+type Affected struct {
+	Package           Package                `json:"package"`
+	Severity          []Severity             `json:"severity,omitempty"`
+	Ranges            []Range                `json:"ranges,omitempty"`
+	Versions          []string               `json:"versions,omitempty"`
+	EcosystemSpecific map[string]interface{} `json:"ecosystem_specific,omitempty"`
+	DatabaseSpecific  map[string]interface{} `json:"database_specific,omitempty"`
+}
+
+// Package identifies a package within an ecosystem
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Purl      string `json:"purl,omitempty"`
+}
+
+// Range is a range of affected versions defined by a sequence of events
+type Range struct {
+	Type   string  `json:"type"`
+	Repo   string  `json:"repo,omitempty"`
+	Events []Event `json:"events"`
+}
+
+// Event introduces or ends a range of affected versions
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// Reference is a link to further information about a vulnerability
+type Reference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// Link returns the link to the vulnerability, preferring its advisory
+func (v Vulnerability) Link() string {
+	for _, reference := range v.References {
+		if reference.Type == "ADVISORY" {
+			return reference.URL
+		}
+	}
+	return fmt.Sprintf("https://osv.dev/vulnerability/%v", v.ID)
+}
+
+// FixedVersions returns the versions fixing the vulnerability in the given package
+func (a Affected) FixedVersions() []string {
+	fixed := []string{}
+	for _, r := range a.Ranges {
+		for _, event := range r.Events {
+			if len(event.Fixed) > 0 {
+				fixed = append(fixed, event.Fixed)
+			}
+		}
+	}
+	return fixed
+}
+
+// purlEcosystems maps package URL types to the corresponding OSV ecosystems
+var purlEcosystems = map[string]string{
+	packageurl.TypeCargo:    "crates.io",
+	packageurl.TypeComposer: "Packagist",
+	packageurl.TypeGem:      "RubyGems",
+	packageurl.TypeGolang:   "Go",
+	packageurl.TypeHex:      "Hex",
+	packageurl.TypeMaven:    "Maven",
+	packageurl.TypeNPM:      "npm",
+	packageurl.TypeNuget:    "NuGet",
+	packageurl.TypePyPi:     "PyPI",
+	"pub":                   "Pub",
+}
+
+// PackageFromPurl returns the OSV package and the version of a package URL, e.g. as contained in SBOMs created by Syft.
+// For Linux distribution packages the ecosystem contains the release of the distribution if known, e.g. Debian:12.
+// The last return value is false if the ecosystem is not supported.
+func PackageFromPurl(purl string) (Package, string, bool) {
+	packageURL, err := packageurl.FromString(purl)
+	if err != nil {
+		return Package{}, "", false
+	}
+	qualifiers := packageURL.Qualifiers.Map()
+	name := packageURL.Name
+	if len(packageURL.Namespace) > 0 {
+		name = packageURL.Namespace + "/" + packageURL.Name
+	}
+
+	switch packageURL.Type {
+	case packageurl.TypeMaven:
+		name = packageURL.Namespace + ":" + packageURL.Name
+	case packageurl.TypePyPi:
+		// see https://peps.python.org/pep-0503/#normalized-names
+		name = strings.ToLower(strings.NewReplacer("_", "-", ".", "-").Replace(packageURL.Name))
+	case packageurl.TypeDebian, "apk":
+		return distributionPackage(packageURL, qualifiers)
+	}
+	ecosystem, ok := purlEcosystems[packageURL.Type]
+	return Package{Ecosystem: ecosystem, Name: name, Purl: purl}, packageURL.Version, ok
+}
+
+// distributionPackage returns the OSV package of Debian, Ubuntu and Alpine packages.
+// The vulnerabilities are reported for the source packages, e.g. openssl instead of libssl3.
+func distributionPackage(packageURL packageurl.PackageURL, qualifiers map[string]string) (Package, string, bool) {
+	name := packageURL.Name
+	if upstream := qualifiers["upstream"]; len(upstream) > 0 {
+		// Syft adds the version of the source package if it differs, e.g. upstream=openssl@3.0.11-1
+		name, _, _ = strings.Cut(upstream, "@")
+	}
+	distro, release, _ := strings.Cut(qualifiers["distro"], "-")
+	if len(distro) == 0 {
+		distro = packageURL.Namespace
+	}
+
+	ecosystem := ""
+	switch strings.ToLower(distro) {
+	case "debian":
+		ecosystem = "Debian"
+		// OSV uses the major release only, e.g. Debian:12
+		release, _, _ = strings.Cut(release, ".")
+	case "ubuntu":
+		ecosystem = "Ubuntu"
+	case "alpine":
+		ecosystem = "Alpine"
+		// OSV uses the minor release prefixed with v, e.g. Alpine:v3.18
+		if parts := strings.Split(release, "."); len(parts) > 1 {
+			release = fmt.Sprintf("v%v.%v", parts[0], parts[1])
+		}
+	default:
+		return Package{}, "", false
+	}
+	if len(release) > 0 {
+		ecosystem += ":" + release
+	}
+	return Package{Ecosystem: ecosystem, Name: name, Purl: packageURL.ToString()}, packageURL.Version, true
+}
+
+// baseEcosystem returns the ecosystem without release, e.g. Debian for Debian:12
+func baseEcosystem(ecosystem string) string {
+	base, _, _ := strings.Cut(ecosystem, ":")
+	return base
+}
+
+// matchesEcosystem returns true if the ecosystem of an affected package applies to the ecosystem of a component.
+// If one of them does not specify a release, only the base ecosystems are compared.
+func matchesEcosystem(affected, component string) bool {
+	if !strings.EqualFold(baseEcosystem(affected), baseEcosystem(component)) {
+		return false
+	}
+	_, affectedRelease, affectedHasRelease := strings.Cut(affected, ":")
+	_, componentRelease, componentHasRelease := strings.Cut(component, ":")
+	if !affectedHasRelease || !componentHasRelease {
+		return true
+	}
+	// Ubuntu releases may carry suffixes like Ubuntu:22.04:LTS
+	return affectedRelease == componentRelease || strings.HasPrefix(affectedRelease, componentRelease+":")
+}
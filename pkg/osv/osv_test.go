@@ -0,0 +1,60 @@
+//go:build unit
+// +build unit
+
+package osv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackageFromPurl(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		purl      string
+		pkg       Package
+		version   string
+		supported bool
+	}{
+		{purl: "pkg:npm/%40babel/core@7.0.0", pkg: Package{Ecosystem: "npm", Name: "@babel/core"}, version: "7.0.0", supported: true},
+		{purl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", pkg: Package{Ecosystem: "Maven", Name: "org.apache.logging.log4j:log4j-core"}, version: "2.14.1", supported: true},
+		{purl: "pkg:pypi/Typing_Extensions@4.0.0", pkg: Package{Ecosystem: "PyPI", Name: "typing-extensions"}, version: "4.0.0", supported: true},
+		{purl: "pkg:golang/golang.org/x/net@v0.7.0", pkg: Package{Ecosystem: "Go", Name: "golang.org/x/net"}, version: "v0.7.0", supported: true},
+		{purl: "pkg:deb/debian/libssl3@3.0.11-1~deb12u1?arch=amd64&distro=debian-12&upstream=openssl", pkg: Package{Ecosystem: "Debian:12", Name: "openssl"}, version: "3.0.11-1~deb12u1", supported: true},
+		{purl: "pkg:deb/ubuntu/libc6@2.35-0ubuntu3?distro=ubuntu-22.04&upstream=glibc%402.35-0ubuntu3", pkg: Package{Ecosystem: "Ubuntu:22.04", Name: "glibc"}, version: "2.35-0ubuntu3", supported: true},
+		{purl: "pkg:apk/alpine/busybox@1.36.1-r2?arch=x86_64&distro=alpine-3.18.4", pkg: Package{Ecosystem: "Alpine:v3.18", Name: "busybox"}, version: "1.36.1-r2", supported: true},
+		{purl: "pkg:deb/debian/bash@5.2.15-2", pkg: Package{Ecosystem: "Debian", Name: "bash"}, version: "5.2.15-2", supported: true},
+		{purl: "pkg:rpm/redhat/openssl@3.0.7", supported: false},
+		{purl: "not a purl", supported: false},
+	}
+
+	for _, test := range tt {
+		t.Run(test.purl, func(t *testing.T) {
+			pkg, version, supported := PackageFromPurl(test.purl)
+			assert.Equal(t, test.supported, supported)
+			if test.supported {
+				assert.Equal(t, test.pkg.Ecosystem, pkg.Ecosystem)
+				assert.Equal(t, test.pkg.Name, pkg.Name)
+				assert.Equal(t, test.version, version)
+			}
+		})
+	}
+}
+
+func TestMatchesEcosystem(t *testing.T) {
+	t.Parallel()
+	assert.True(t, matchesEcosystem("Debian:12", "Debian:12"))
+	assert.True(t, matchesEcosystem("Debian", "Debian:12"))
+	assert.True(t, matchesEcosystem("Debian:12", "Debian"))
+	assert.False(t, matchesEcosystem("Debian:11", "Debian:12"))
+	assert.True(t, matchesEcosystem("Ubuntu:22.04:LTS", "Ubuntu:22.04"))
+	assert.False(t, matchesEcosystem("npm", "PyPI"))
+}
+
+func TestVulnerabilityLink(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "https://osv.dev/vulnerability/GHSA-1", Vulnerability{ID: "GHSA-1"}.Link())
+	assert.Equal(t, "https://github.com/advisories/GHSA-1", Vulnerability{ID: "GHSA-1", References: []Reference{{Type: "WEB", URL: "https://example.org"}, {Type: "ADVISORY", URL: "https://github.com/advisories/GHSA-1"}}}.Link())
+}
@@ -0,0 +1,188 @@
+package osv
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+)
+
+// ToolName is the name of the scanner used in reports
+const ToolName = "OSV offline scan"
+
+// CountBySeverity returns the number of findings per unified severity
+func CountBySeverity(findings []Finding) map[string]int {
+	counts := map[string]int{}
+	for _, finding := range findings {
+		counts[finding.Severity]++
+	}
+	return counts
+}
+
+// CreateScanReport creates the report of a scan, the detail table lists all findings including the assessed ones
+func CreateScanReport(result ScanResult, database *Database, stepName string, targets []string, failOnSeverity string) reporting.ScanReport {
+	active := result.Active()
+	counts := CountBySeverity(active)
+	report := reporting.ScanReport{
+		StepName:    stepName,
+		ReportTitle: "Vulnerability Report",
+		Subheaders: []reporting.Subheader{
+			{Description: "Vulnerability database", Details: fmt.Sprintf("%v vulnerabilities, last modified %v", database.Size(), database.LastModified())},
+		},
+		Overview: []reporting.OverviewRow{
+			{Description: "Components scanned", Details: fmt.Sprint(result.Components)},
+			{Description: "Components of unsupported ecosystems", Details: fmt.Sprint(result.Unsupported)},
+			{Description: "Vulnerabilities", Details: fmt.Sprint(len(active))},
+		},
+		ReportTime: time.Now(),
+		DetailTable: reporting.ScanDetailTable{
+			Headers:       []string{"Severity", "Vulnerability", "Score", "Package", "Version", "Fixed in", "Assessment"},
+			NoRowsMessage: "No vulnerabilities detected",
+			WithCounter:   true,
+			CounterHeader: "Entry #",
+		},
+		SuccessfulScan: true,
+		Findings:       counts,
+		SarifTool:      ToolName,
+	}
+	for _, target := range targets {
+		report.Subheaders = append(report.Subheaders, reporting.Subheader{Description: "SBOM", Details: target})
+	}
+	for _, severity := range format.Severities {
+		row := reporting.OverviewRow{Description: fmt.Sprintf("%v%v vulnerabilities", strings.ToUpper(severity[:1]), severity[1:]), Details: fmt.Sprint(counts[severity])}
+		if counts[severity] > 0 && IsAtLeast(severity, failOnSeverity) {
+			row.Style = reporting.Red
+			report.SuccessfulScan = false
+		}
+		report.Overview = append(report.Overview, row)
+	}
+	if assessed := len(result.Findings) - len(active); assessed > 0 {
+		report.Overview = append(report.Overview, reporting.OverviewRow{Description: "Vulnerabilities assessed as not relevant", Details: fmt.Sprint(assessed)})
+	}
+
+	for _, finding := range result.Findings {
+		style := reporting.ColumnStyle(0)
+		if finding.Assessment == nil && IsAtLeast(finding.Severity, failOnSeverity) {
+			style = reporting.Red
+		}
+		score := ""
+		if finding.Score > 0 {
+			score = fmt.Sprint(finding.Score)
+		}
+		assessment := ""
+		if finding.Assessment != nil {
+			assessment = finding.Assessment.Analysis.Description()
+		}
+		row := reporting.ScanRow{}
+		row.AddColumn(finding.Severity, style)
+		row.AddColumn(finding.Vulnerability.ID, 0)
+		row.AddColumn(score, 0)
+		row.AddColumn(finding.Package.Name, 0)
+		row.AddColumn(finding.Version, 0)
+		row.AddColumn(strings.Join(finding.FixedVersions, ", "), 0)
+		row.AddColumn(assessment, 0)
+		report.DetailTable.Rows = append(report.DetailTable.Rows, row)
+	}
+	return report
+}
+
+// ToSarif converts the findings of a scan into a SARIF document, one rule is created per vulnerability
+func ToSarif(result ScanResult, database *Database) format.SARIF {
+	run := format.Runs{
+		Tool: format.Tool{Driver: format.Driver{
+			Name:           ToolName,
+			Version:        database.LastModified(),
+			InformationUri: "https://osv.dev",
+			Rules:          []format.SarifRule{},
+		}},
+		Results: []format.Results{},
+	}
+	ruleIndices := map[string]int{}
+	for _, finding := range result.Findings {
+		vulnerability := finding.Vulnerability
+		ruleIndex, known := ruleIndices[vulnerability.ID]
+		if !known {
+			ruleIndex = len(run.Tool.Driver.Rules)
+			ruleIndices[vulnerability.ID] = ruleIndex
+			rule := format.SarifRule{
+				ID:               vulnerability.ID,
+				Name:             vulnerability.ID,
+				ShortDescription: &format.Message{Text: vulnerability.title()},
+				FullDescription:  &format.Message{Text: vulnerability.description()},
+				HelpURI:          vulnerability.Link(),
+				Help:             &format.Help{Text: vulnerability.description(), Markdown: vulnerability.description()},
+				Properties:       &format.SarifRuleProperties{Tags: append([]string{"SECURITY_VULNERABILITY"}, vulnerability.Aliases...)},
+			}
+			if finding.Score > 0 {
+				rule.Properties.SecuritySeverity = fmt.Sprint(finding.Score)
+			}
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+		}
+
+		auditState := "new"
+		if finding.Assessment != nil {
+			auditState = string(format.NotRelevant)
+		}
+		sarifResult := format.Results{
+			RuleID:         vulnerability.ID,
+			RuleIndex:      ruleIndex,
+			Level:          severityToLevel(finding.Severity),
+			Message:        &format.Message{Text: fmt.Sprintf("%v %v is affected by %v", finding.Package.Name, finding.Version, vulnerability.ID)},
+			AnalysisTarget: &format.ArtifactLocation{URI: finding.PackageURL},
+			PartialFingerprints: format.PartialFingerprints{
+				PackageURLPlusCVEHash: base64.URLEncoding.EncodeToString([]byte(fmt.Sprintf("%v+%v", finding.PackageURL, vulnerability.ID))),
+			},
+			Properties: &format.SarifProperties{
+				Audited:               finding.Assessment != nil,
+				ToolSeverity:          finding.Severity,
+				ToolSeverityIndex:     severityRank(finding.Severity),
+				UnifiedAuditState:     auditState,
+				UnifiedSeverity:       finding.Severity,
+				UnifiedCriticality:    float32(finding.Score),
+				AuditRequirement:      format.AUDIT_REQUIREMENT_GROUP_1_DESC,
+				AuditRequirementIndex: format.AUDIT_REQUIREMENT_GROUP_1_INDEX,
+			},
+		}
+		if finding.Assessment != nil {
+			sarifResult.Properties.ToolAuditMessage = finding.Assessment.Analysis.Description()
+		}
+		for _, location := range finding.Locations {
+			sarifResult.Locations = append(sarifResult.Locations, format.Location{PhysicalLocation: format.PhysicalLocation{ArtifactLocation: format.ArtifactLocation{URI: location}}})
+		}
+		run.Results = append(run.Results, sarifResult)
+	}
+
+	return format.SARIF{
+		Schema:  "https://docs.oasis-open.org/sarif/sarif/v2.1.0/cos02/schemas/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []format.Runs{run},
+	}
+}
+
+func (v Vulnerability) title() string {
+	if len(v.Summary) > 0 {
+		return v.Summary
+	}
+	return v.ID
+}
+
+func (v Vulnerability) description() string {
+	if len(v.Details) > 0 {
+		return v.Details
+	}
+	return v.title()
+}
+
+func severityToLevel(severity string) string {
+	switch severity {
+	case format.SeverityCritical, format.SeverityHigh:
+		return "error"
+	case format.SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
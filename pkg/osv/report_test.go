@@ -0,0 +1,86 @@
+//go:build unit
+// +build unit
+
+package osv
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+)
+
+func newTestScanResult(t *testing.T) (ScanResult, *Database) {
+	database := newTestDatabase(t)
+	result := database.Scan(map[string]*cdx.BOM{
+		"bom-docker-0.xml": {Components: &[]cdx.Component{
+			{Name: "libssl3", Version: "3.0.11-1~deb12u1", PackageURL: "pkg:deb/debian/libssl3@3.0.11-1~deb12u1?distro=debian-12&upstream=openssl"},
+			{Name: "log4j-core", Version: "2.14.1", PackageURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"},
+		}},
+	})
+	result.ApplyAssessments([]format.Assessment{{Vulnerability: "DSA-5532-1", Status: format.NotRelevant, Analysis: format.NotPresent}})
+	return result, database
+}
+
+func TestCreateScanReport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("severe vulnerability", func(t *testing.T) {
+		result, database := newTestScanResult(t)
+
+		report := CreateScanReport(result, database, "osvExecuteScan", []string{"bom-docker-0.xml"}, format.SeverityHigh)
+
+		assert.False(t, report.SuccessfulScan)
+		assert.Equal(t, "osvExecuteScan", report.StepName)
+		assert.Equal(t, "2 vulnerabilities, last modified 2024-03-01T12:00:00Z", report.Subheaders[0].Details)
+		assert.Equal(t, "bom-docker-0.xml", report.Subheaders[1].Details)
+		assert.Equal(t, "Vulnerabilities", report.Overview[2].Description)
+		assert.Equal(t, "1", report.Overview[2].Details)
+		assert.Equal(t, "Critical vulnerabilities", report.Overview[3].Description)
+		assert.Equal(t, "1", report.Overview[3].Details)
+		assert.Equal(t, "Vulnerabilities assessed as not relevant", report.Overview[len(report.Overview)-1].Description)
+		assert.Equal(t, map[string]int{format.SeverityCritical: 1}, report.Findings)
+		assert.Equal(t, ToolName, report.SarifTool)
+		assert.Len(t, report.DetailTable.Rows, 2)
+	})
+
+	t.Run("no severe vulnerability", func(t *testing.T) {
+		result, database := newTestScanResult(t)
+		result.Findings = result.Findings[1:]
+
+		report := CreateScanReport(result, database, "osvExecuteScan", []string{"bom-docker-0.xml"}, format.SeverityHigh)
+
+		assert.True(t, report.SuccessfulScan)
+	})
+}
+
+func TestToSarif(t *testing.T) {
+	t.Parallel()
+	result, database := newTestScanResult(t)
+
+	sarif := ToSarif(result, database)
+
+	assert.Equal(t, "2.1.0", sarif.Version)
+	run := sarif.Runs[0]
+	assert.Equal(t, ToolName, run.Tool.Driver.Name)
+	if assert.Len(t, run.Tool.Driver.Rules, 2) {
+		assert.Equal(t, "GHSA-jfh8-c2jp-5v3q", run.Tool.Driver.Rules[0].ID)
+		assert.Equal(t, "Remote code injection in Log4j", run.Tool.Driver.Rules[0].ShortDescription.Text)
+		assert.Equal(t, "10", run.Tool.Driver.Rules[0].Properties.SecuritySeverity)
+		assert.Contains(t, run.Tool.Driver.Rules[0].Properties.Tags, "CVE-2021-44228")
+	}
+	if assert.Len(t, run.Results, 2) {
+		assert.Equal(t, "error", run.Results[0].Level)
+		assert.Equal(t, "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", run.Results[0].AnalysisTarget.URI)
+		assert.Equal(t, "bom-docker-0.xml", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, "new", run.Results[0].Properties.UnifiedAuditState)
+		assert.Equal(t, format.SeverityCritical, format.SarifResultSeverity(run, run.Results[0]))
+
+		assert.Equal(t, 1, run.Results[1].RuleIndex)
+		assert.True(t, run.Results[1].Properties.Audited)
+		assert.Equal(t, "notRelevant", run.Results[1].Properties.UnifiedAuditState)
+		assert.True(t, format.IsSarifResultNotRelevant(run.Results[1]))
+	}
+}
@@ -0,0 +1,154 @@
+package osv
+
+import (
+	"math"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+)
+
+// cvss3Weights contains the weights of the CVSS v3 base metrics, see https://www.first.org/cvss/v3.1/specification-document#7-4-Metric-Values
+var cvss3Weights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"PR": {"N": 0.85, "L": 0.62, "H": 0.27},
+	"UI": {"N": 0.85, "R": 0.62},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+// Score returns the CVSS v3 base score and the unified severity of the vulnerability.
+// If no CVSS v3 vector is available, the severity is taken from the database specific information (e.g. of GitHub advisories)
+// or from the severity of the distribution (e.g. Ubuntu), in this case the score is 0.
+// Vulnerabilities without any severity information are rated as medium.
+func (v Vulnerability) Score() (float64, string) {
+	severities := append([]Severity{}, v.Severity...)
+	for _, affected := range v.Affected {
+		severities = append(severities, affected.Severity...)
+	}
+	for _, severity := range severities {
+		if severity.Type != "CVSS_V3" {
+			continue
+		}
+		if score, ok := CVSS3BaseScore(severity.Score); ok {
+			return score, severityFromScore(score)
+		}
+	}
+
+	if severity, ok := v.DatabaseSpecific["severity"].(string); ok {
+		if unified := normalizeSeverity(severity); len(unified) > 0 {
+			return 0, unified
+		}
+	}
+	for _, severity := range severities {
+		if unified := normalizeSeverity(severity.Score); len(unified) > 0 {
+			return 0, unified
+		}
+	}
+	return 0, format.SeverityMedium
+}
+
+// CVSS3BaseScore calculates the base score of a CVSS v3.0 or v3.1 vector like CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H
+func CVSS3BaseScore(vector string) (float64, bool) {
+	if !strings.HasPrefix(vector, "CVSS:3.") {
+		return 0, false
+	}
+	metrics := map[string]string{}
+	for _, metric := range strings.Split(vector, "/")[1:] {
+		name, value, _ := strings.Cut(metric, ":")
+		metrics[name] = value
+	}
+	weights := map[string]float64{}
+	for name, values := range cvss3Weights {
+		weight, ok := values[metrics[name]]
+		if !ok {
+			return 0, false
+		}
+		weights[name] = weight
+	}
+	scopeChanged := false
+	switch metrics["S"] {
+	case "C":
+		scopeChanged = true
+		// the privileges required weigh more if the scope changes
+		switch metrics["PR"] {
+		case "L":
+			weights["PR"] = 0.68
+		case "H":
+			weights["PR"] = 0.5
+		}
+	case "U":
+	default:
+		return 0, false
+	}
+
+	iss := 1 - (1-weights["C"])*(1-weights["I"])*(1-weights["A"])
+	impact := 6.42 * iss
+	if scopeChanged {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	}
+	if impact <= 0 {
+		return 0, true
+	}
+	exploitability := 8.22 * weights["AV"] * weights["AC"] * weights["PR"] * weights["UI"]
+	if scopeChanged {
+		return roundUp(math.Min(1.08*(impact+exploitability), 10)), true
+	}
+	return roundUp(math.Min(impact+exploitability, 10)), true
+}
+
+// roundUp returns the smallest number with one decimal place which is equal or higher than the input,
+// see https://www.first.org/cvss/v3.1/specification-document#Appendix-A---Floating-Point-Rounding
+func roundUp(value float64) float64 {
+	integer := int(math.Round(value * 100000))
+	if integer%10000 == 0 {
+		return float64(integer) / 100000
+	}
+	return float64(integer/10000+1) / 10
+}
+
+// severityFromScore maps a CVSS score to a severity following the CVSS v3 rating
+func severityFromScore(score float64) string {
+	switch {
+	case score >= 9.0:
+		return format.SeverityCritical
+	case score >= 7.0:
+		return format.SeverityHigh
+	case score >= 4.0:
+		return format.SeverityMedium
+	case score > 0:
+		return format.SeverityLow
+	}
+	return format.SeverityInfo
+}
+
+// normalizeSeverity maps textual severities of the different databases to the unified severities
+func normalizeSeverity(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return format.SeverityCritical
+	case "high", "important":
+		return format.SeverityHigh
+	case "medium", "moderate":
+		return format.SeverityMedium
+	case "low", "negligible":
+		return format.SeverityLow
+	}
+	return ""
+}
+
+// severityRank returns the rank of a unified severity, the most severe having the highest rank
+func severityRank(severity string) int {
+	for i, s := range format.Severities {
+		if s == severity {
+			return len(format.Severities) - i
+		}
+	}
+	return 0
+}
+
+// IsAtLeast returns true if the unified severity is at least as severe as the threshold
+func IsAtLeast(severity, threshold string) bool {
+	return severityRank(threshold) > 0 && severityRank(severity) >= severityRank(threshold)
+}
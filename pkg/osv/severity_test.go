@@ -0,0 +1,84 @@
+//go:build unit
+// +build unit
+
+package osv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+)
+
+func TestCVSS3BaseScore(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		vector string
+		score  float64
+		valid  bool
+	}{
+		{vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", score: 9.8, valid: true},
+		// log4shell
+		{vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H", score: 10.0, valid: true},
+		{vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N", score: 6.1, valid: true},
+		{vector: "CVSS:3.0/AV:L/AC:H/PR:H/UI:R/S:U/C:L/I:N/A:N", score: 1.8, valid: true},
+		{vector: "CVSS:3.1/AV:N/AC:L/PR:L/UI:N/S:C/C:H/I:N/A:N", score: 7.7, valid: true},
+		{vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N", score: 0, valid: true},
+		{vector: "CVSS:3.1/AV:N/AC:L", valid: false},
+		{vector: "AV:N/AC:L/Au:N/C:P/I:P/A:P", valid: false},
+	}
+
+	for _, test := range tt {
+		t.Run(test.vector, func(t *testing.T) {
+			score, valid := CVSS3BaseScore(test.vector)
+			assert.Equal(t, test.valid, valid)
+			assert.Equal(t, test.score, score)
+		})
+	}
+}
+
+func TestVulnerabilityScore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cvss vector", func(t *testing.T) {
+		vulnerability := Vulnerability{Severity: []Severity{{Type: "CVSS_V2", Score: "AV:N/AC:L/Au:N/C:P/I:P/A:P"}, {Type: "CVSS_V3", Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N"}}}
+		score, severity := vulnerability.Score()
+		assert.Equal(t, 6.1, score)
+		assert.Equal(t, format.SeverityMedium, severity)
+	})
+
+	t.Run("cvss vector of affected package", func(t *testing.T) {
+		vulnerability := Vulnerability{Affected: []Affected{{Severity: []Severity{{Type: "CVSS_V3", Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}}}}}
+		score, severity := vulnerability.Score()
+		assert.Equal(t, 9.8, score)
+		assert.Equal(t, format.SeverityCritical, severity)
+	})
+
+	t.Run("github advisory severity", func(t *testing.T) {
+		vulnerability := Vulnerability{DatabaseSpecific: map[string]interface{}{"severity": "MODERATE"}}
+		score, severity := vulnerability.Score()
+		assert.Equal(t, 0.0, score)
+		assert.Equal(t, format.SeverityMedium, severity)
+	})
+
+	t.Run("ubuntu severity", func(t *testing.T) {
+		vulnerability := Vulnerability{Severity: []Severity{{Type: "Ubuntu", Score: "high"}}}
+		_, severity := vulnerability.Score()
+		assert.Equal(t, format.SeverityHigh, severity)
+	})
+
+	t.Run("no severity", func(t *testing.T) {
+		_, severity := Vulnerability{}.Score()
+		assert.Equal(t, format.SeverityMedium, severity)
+	})
+}
+
+func TestIsAtLeast(t *testing.T) {
+	t.Parallel()
+	assert.True(t, IsAtLeast(format.SeverityCritical, format.SeverityHigh))
+	assert.True(t, IsAtLeast(format.SeverityHigh, format.SeverityHigh))
+	assert.False(t, IsAtLeast(format.SeverityMedium, format.SeverityHigh))
+	assert.False(t, IsAtLeast(format.SeverityCritical, "none"))
+}
@@ -0,0 +1,217 @@
+package osv
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/SAP/jenkins-library/pkg/versioning"
+)
+
+// isAffected returns true if the version is listed as affected or contained in one of the affected ranges.
+// Ranges of type GIT cannot be evaluated without the repository and are ignored.
+func (a Affected) isAffected(version string) bool {
+	for _, affectedVersion := range a.Versions {
+		if affectedVersion == version {
+			return true
+		}
+	}
+	for _, r := range a.Ranges {
+		if r.Type == "GIT" {
+			continue
+		}
+		if r.contains(a.Package.Ecosystem, version) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains evaluates the events of the range as described in https://ossf.github.io/osv-schema/#evaluation
+func (r Range) contains(ecosystem, version string) bool {
+	compare := versionComparator(ecosystem, r.Type)
+	events := append([]Event{}, r.Events...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return compareEventVersions(compare, events[i].version(), events[j].version()) < 0
+	})
+
+	affected := false
+	for _, event := range events {
+		switch {
+		case len(event.Introduced) > 0:
+			if event.Introduced == "0" || compare(version, event.Introduced) >= 0 {
+				affected = true
+			}
+		case len(event.Fixed) > 0:
+			if compare(version, event.Fixed) >= 0 {
+				affected = false
+			}
+		case len(event.LastAffected) > 0:
+			if compare(version, event.LastAffected) > 0 {
+				affected = false
+			}
+		case len(event.Limit) > 0:
+			if event.Limit != "*" && compare(version, event.Limit) >= 0 {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
+
+func (e Event) version() string {
+	for _, version := range []string{e.Introduced, e.Fixed, e.LastAffected, e.Limit} {
+		if len(version) > 0 {
+			return version
+		}
+	}
+	return ""
+}
+
+// compareEventVersions compares the versions of two events, where 0 is lower and * is higher than all versions
+func compareEventVersions(compare func(a, b string) int, a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "0" || b == "*":
+		return -1
+	case b == "0" || a == "*":
+		return 1
+	}
+	return compare(a, b)
+}
+
+// versionComparator returns the comparison of versions for the ecosystem and range type
+func versionComparator(ecosystem, rangeType string) func(a, b string) int {
+	if rangeType == "SEMVER" {
+		return compareSemanticVersions
+	}
+	switch baseEcosystem(ecosystem) {
+	case "Debian", "Ubuntu":
+		return compareDebianVersions
+	case "Go", "npm", "crates.io", "Hex", "Pub", "NuGet":
+		return compareSemanticVersions
+	}
+	return versioning.CompareGenericVersions
+}
+
+// compareSemanticVersions compares versions following https://semver.org/#spec-item-11, a leading v is ignored.
+// Versions which are not semantic versions, e.g. Go pseudo versions of incomplete modules, are compared like generic versions.
+func compareSemanticVersions(a, b string) int {
+	if result, err := versioning.CompareSemanticVersions(strings.TrimPrefix(a, "v"), strings.TrimPrefix(b, "v")); err == nil {
+		return result
+	}
+	return versioning.CompareGenericVersions(a, b)
+}
+
+// compareDebianVersions compares versions like dpkg does, see https://www.debian.org/doc/debian-policy/ch-controlfields.html#version
+func compareDebianVersions(a, b string) int {
+	aEpoch, aVersion := debianEpoch(a)
+	bEpoch, bVersion := debianEpoch(b)
+	if result := compareNumbers(aEpoch, bEpoch); result != 0 {
+		return result
+	}
+	aUpstream, aRevision := debianRevision(aVersion)
+	bUpstream, bRevision := debianRevision(bVersion)
+	if result := compareDebianParts(aUpstream, bUpstream); result != 0 {
+		return result
+	}
+	return compareDebianParts(aRevision, bRevision)
+}
+
+func debianEpoch(version string) (string, string) {
+	if epoch, rest, found := strings.Cut(version, ":"); found && isNumeric(epoch) {
+		return epoch, rest
+	}
+	return "0", version
+}
+
+func debianRevision(version string) (string, string) {
+	if i := strings.LastIndex(version, "-"); i >= 0 {
+		return version[:i], version[i+1:]
+	}
+	return version, "0"
+}
+
+// compareDebianParts alternately compares non-digit and digit parts, the non-digit parts with ~ sorting before everything
+func compareDebianParts(a, b string) int {
+	for len(a) > 0 || len(b) > 0 {
+		var aText, bText string
+		aText, a = splitPrefix(a, func(r rune) bool { return !unicode.IsDigit(r) })
+		bText, b = splitPrefix(b, func(r rune) bool { return !unicode.IsDigit(r) })
+		if result := compareDebianText(aText, bText); result != 0 {
+			return result
+		}
+		var aNumber, bNumber string
+		aNumber, a = splitPrefix(a, unicode.IsDigit)
+		bNumber, b = splitPrefix(b, unicode.IsDigit)
+		if result := compareNumbers(aNumber, bNumber); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+func compareDebianText(a, b string) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		aOrder, bOrder := 0, 0
+		if i < len(a) {
+			aOrder = debianCharOrder(a[i])
+		}
+		if i < len(b) {
+			bOrder = debianCharOrder(b[i])
+		}
+		if aOrder != bOrder {
+			if aOrder < bOrder {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// debianCharOrder returns the sort order of a character, letters sort before non-letters and ~ before the end of the part
+func debianCharOrder(c byte) int {
+	switch {
+	case c == '~':
+		return -1
+	case unicode.IsLetter(rune(c)):
+		return int(c)
+	}
+	return int(c) + 256
+}
+
+func splitPrefix(s string, matches func(rune) bool) (string, string) {
+	for i, r := range s {
+		if !matches(r) {
+			return s[:i], s[i:]
+		}
+	}
+	return s, ""
+}
+
+// compareNumbers compares numbers of arbitrary length, an empty string counts as 0
+func compareNumbers(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func isNumeric(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+//go:build unit
+// +build unit
+
+package osv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name    string
+		compare func(a, b string) int
+		lower   string
+		higher  string
+	}{
+		{name: "semver", compare: compareSemanticVersions, lower: "1.2.3", higher: "1.10.0"},
+		{name: "semver pre-release", compare: compareSemanticVersions, lower: "1.0.0-rc.1", higher: "1.0.0"},
+		{name: "semver pre-release identifiers", compare: compareSemanticVersions, lower: "1.0.0-alpha.1", higher: "1.0.0-alpha.beta"},
+		{name: "semver v prefix", compare: compareSemanticVersions, lower: "v0.7.0", higher: "0.17.0"},
+		{name: "debian revision", compare: compareDebianVersions, lower: "3.0.11-1", higher: "3.0.11-2"},
+		{name: "debian tilde", compare: compareDebianVersions, lower: "3.0.11-1~deb12u1", higher: "3.0.11-1"},
+		{name: "debian epoch", compare: compareDebianVersions, lower: "9.0-1", higher: "1:1.0-1"},
+		{name: "debian letters", compare: compareDebianVersions, lower: "1.0a", higher: "1.0+"},
+		{name: "debian numbers", compare: compareDebianVersions, lower: "2.9", higher: "2.10"},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, -1, test.compare(test.lower, test.higher))
+			assert.Equal(t, 1, test.compare(test.higher, test.lower))
+			assert.Equal(t, 0, test.compare(test.lower, test.lower))
+		})
+	}
+}
+
+func TestAffectedIsAffected(t *testing.T) {
+	t.Parallel()
+
+	affected := Affected{
+		Package: Package{Ecosystem: "Maven", Name: "org.apache.logging.log4j:log4j-core"},
+		Ranges: []Range{{Type: "ECOSYSTEM", Events: []Event{
+			// the events are not necessarily ordered
+			{Introduced: "2.13.0"}, {Fixed: "2.15.0"},
+			{Introduced: "0"}, {Fixed: "2.3.1"},
+		}}},
+		Versions: []string{"2.12.1"},
+	}
+
+	assert.True(t, affected.isAffected("2.0"))
+	assert.False(t, affected.isAffected("2.3.1"))
+	assert.False(t, affected.isAffected("2.12.0"))
+	assert.True(t, affected.isAffected("2.12.1"))
+	assert.True(t, affected.isAffected("2.14.1"))
+	assert.False(t, affected.isAffected("2.15.0"))
+
+	t.Run("last affected", func(t *testing.T) {
+		affected := Affected{
+			Package: Package{Ecosystem: "Debian:12", Name: "openssl"},
+			Ranges:  []Range{{Type: "ECOSYSTEM", Events: []Event{{Introduced: "0"}, {LastAffected: "3.0.11-1~deb12u1"}}}},
+		}
+		assert.True(t, affected.isAffected("3.0.11-1~deb12u1"))
+		assert.False(t, affected.isAffected("3.0.11-1"))
+	})
+
+	t.Run("git ranges ignored", func(t *testing.T) {
+		affected := Affected{Ranges: []Range{{Type: "GIT", Events: []Event{{Introduced: "0"}}}}}
+		assert.False(t, affected.isAffected("1.0.0"))
+	})
+}
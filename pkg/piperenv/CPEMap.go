@@ -24,6 +24,9 @@ func (c *CPEMap) LoadFromDisk(path string) error {
 	if err != nil {
 		return err
 	}
+	for k, v := range *c {
+		validateValue("reading", k, v)
+	}
 	return nil
 }
 
@@ -35,6 +38,7 @@ func (c CPEMap) WriteToDisk(rootDirectory string) error {
 	}
 
 	for k, v := range c {
+		validateValue("writing", k, v)
 		entryPath := path.Join(rootDirectory, k)
 		err := os.MkdirAll(filepath.Dir(entryPath), 0777)
 		if err != nil {
@@ -42,7 +46,11 @@ func (c CPEMap) WriteToDisk(rootDirectory string) error {
 		}
 		// if v is a string no json marshalling is needed
 		if vString, ok := v.(string); ok {
-			err := os.WriteFile(entryPath, []byte(vString), 0666)
+			content, err := encryptValue([]byte(vString))
+			if err != nil {
+				return err
+			}
+			err = os.WriteFile(entryPath, content, 0666)
 			if err != nil {
 				return err
 			}
@@ -53,8 +61,12 @@ func (c CPEMap) WriteToDisk(rootDirectory string) error {
 		if err != nil {
 			return err
 		}
+		content, err := encryptValue(jsonVal)
+		if err != nil {
+			return err
+		}
 
-		err = os.WriteFile(fmt.Sprintf("%s.json", entryPath), jsonVal, 0666)
+		err = os.WriteFile(fmt.Sprintf("%s.json", entryPath), content, 0666)
 		if err != nil {
 			return err
 		}
@@ -117,6 +129,10 @@ func readFileContent(fullPath string) (string, interface{}, bool, error) {
 	if err != nil {
 		return "", nil, toBeEmptied, err
 	}
+	fileContent, err = decryptValue(fileContent)
+	if err != nil {
+		return "", nil, toBeEmptied, fmt.Errorf("failed to read %v: %w", fullPath, err)
+	}
 	fileName := filepath.Base(fullPath)
 
 	if strings.HasSuffix(fullPath, ".json") {
@@ -0,0 +1,72 @@
+package piperenv
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// encryptedValuePrefix marks files of the pipeline environment which are encrypted at rest.
+// The prefix is followed by the name of the key provider and the base64 encoded cipher text:
+// PIPER-ENCRYPTED:<provider>:<cipher text>
+const encryptedValuePrefix = "PIPER-ENCRYPTED:"
+
+// KeyProvider encrypts and decrypts the values of the pipeline environment stored on disk
+type KeyProvider interface {
+	// Name identifies the provider in the files written to disk
+	Name() string
+	Encrypt(plainText []byte) ([]byte, error)
+	Decrypt(cipherText []byte) ([]byte, error)
+}
+
+var keyProvider KeyProvider
+
+// SetKeyProvider defines the provider used to keep the pipeline environment encrypted at rest.
+// Once a provider is set, values are encrypted when written to disk and encrypted values are decrypted when read from disk.
+// Passing nil switches back to plain text.
+func SetKeyProvider(provider KeyProvider) {
+	keyProvider = provider
+}
+
+// IsEncrypted checks if the content of a pipeline environment file is encrypted
+func IsEncrypted(content []byte) bool {
+	return bytes.HasPrefix(content, []byte(encryptedValuePrefix))
+}
+
+func encryptValue(content []byte) ([]byte, error) {
+	// empty values are used to reset a parameter and do not contain anything worth protecting
+	if keyProvider == nil || len(content) == 0 {
+		return content, nil
+	}
+	cipherText, err := keyProvider.Encrypt(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt value with key provider '%v': %w", keyProvider.Name(), err)
+	}
+	return []byte(encryptedValuePrefix + keyProvider.Name() + ":" + base64.StdEncoding.EncodeToString(cipherText)), nil
+}
+
+func decryptValue(content []byte) ([]byte, error) {
+	if !IsEncrypted(content) {
+		return content, nil
+	}
+	providerName, encodedCipherText, found := strings.Cut(strings.TrimPrefix(string(content), encryptedValuePrefix), ":")
+	if !found {
+		return nil, fmt.Errorf("invalid format of encrypted value: key provider is missing")
+	}
+	if keyProvider == nil {
+		return nil, fmt.Errorf("value is encrypted with key provider '%v' but no key provider is configured", providerName)
+	}
+	if providerName != keyProvider.Name() {
+		return nil, fmt.Errorf("value is encrypted with key provider '%v' but key provider '%v' is configured", providerName, keyProvider.Name())
+	}
+	cipherText, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedCipherText))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encrypted value: %w", err)
+	}
+	plainText, err := keyProvider.Decrypt(cipherText)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value with key provider '%v': %w", providerName, err)
+	}
+	return plainText, nil
+}
@@ -0,0 +1,126 @@
+//go:build unit
+// +build unit
+
+package piperenv
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type keyProviderMock struct {
+	name string
+}
+
+func (k *keyProviderMock) Name() string {
+	return k.name
+}
+
+func (k *keyProviderMock) Encrypt(plainText []byte) ([]byte, error) {
+	return []byte("encrypted(" + string(plainText) + ")"), nil
+}
+
+func (k *keyProviderMock) Decrypt(cipherText []byte) ([]byte, error) {
+	if !strings.HasPrefix(string(cipherText), "encrypted(") {
+		return nil, fmt.Errorf("invalid cipher text")
+	}
+	return []byte(strings.TrimSuffix(strings.TrimPrefix(string(cipherText), "encrypted("), ")")), nil
+}
+
+func setKeyProvider(t *testing.T, provider KeyProvider) {
+	SetKeyProvider(provider)
+	t.Cleanup(func() { SetKeyProvider(nil) })
+}
+
+func TestEncryptedCPE(t *testing.T) {
+	t.Run("CPEMap is encrypted at rest", func(t *testing.T) {
+		setKeyProvider(t, &keyProviderMock{name: "mock"})
+		dir := t.TempDir()
+
+		err := CPEMap{"git/branch": "main", "custom/list": []string{"a", "b"}, "custom/empty": ""}.WriteToDisk(dir)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(path.Join(dir, "git/branch"))
+		require.NoError(t, err)
+		assert.Equal(t, "PIPER-ENCRYPTED:mock:ZW5jcnlwdGVkKG1haW4p", string(content))
+		assert.True(t, IsEncrypted(content))
+		content, err = os.ReadFile(path.Join(dir, "custom/empty"))
+		require.NoError(t, err)
+		assert.Empty(t, content)
+
+		cpe := CPEMap{}
+		require.NoError(t, cpe.LoadFromDisk(dir))
+		assert.Equal(t, "main", cpe["git/branch"])
+		assert.Equal(t, []interface{}{"a", "b"}, cpe["custom/list"])
+		assert.Equal(t, "", cpe["custom/empty"])
+	})
+
+	t.Run("resource parameters are encrypted at rest", func(t *testing.T) {
+		setKeyProvider(t, &keyProviderMock{name: "mock"})
+		dir := t.TempDir()
+
+		require.NoError(t, SetResourceParameter(dir, "commonPipelineEnvironment", "custom/number", 5))
+		require.NoError(t, SetParameter(path.Join(dir, "commonPipelineEnvironment"), "artifactVersion", "1.0.0"))
+
+		content, err := os.ReadFile(path.Join(dir, "commonPipelineEnvironment", "custom/number.json"))
+		require.NoError(t, err)
+		assert.True(t, IsEncrypted(content))
+		content, err = os.ReadFile(path.Join(dir, "commonPipelineEnvironment", "artifactVersion"))
+		require.NoError(t, err)
+		assert.True(t, IsEncrypted(content))
+		assert.Equal(t, "5", GetResourceParameter(dir, "commonPipelineEnvironment", "custom/number.json"))
+		assert.Equal(t, "1.0.0", GetParameter(path.Join(dir, "commonPipelineEnvironment"), "artifactVersion"))
+	})
+
+	t.Run("other resources are not encrypted", func(t *testing.T) {
+		setKeyProvider(t, &keyProviderMock{name: "mock"})
+		dir := t.TempDir()
+
+		require.NoError(t, SetResourceParameter(dir, "influx", "step_data/fields/build", true))
+		require.NoError(t, SetParameter(path.Join(dir, "influx"), "step_data/tags/buildTool", "maven"))
+
+		content, err := os.ReadFile(path.Join(dir, "influx", "step_data/fields/build.json"))
+		require.NoError(t, err)
+		assert.Equal(t, "true", string(content))
+		content, err = os.ReadFile(path.Join(dir, "influx", "step_data/tags/buildTool"))
+		require.NoError(t, err)
+		assert.Equal(t, "maven", string(content))
+	})
+
+	t.Run("plain text values are read with key provider", func(t *testing.T) {
+		setKeyProvider(t, &keyProviderMock{name: "mock"})
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(path.Join(dir, "number.json"), []byte("5"), 0644))
+
+		cpe := CPEMap{}
+		require.NoError(t, cpe.LoadFromDisk(dir))
+		assert.Equal(t, json.Number("5"), cpe["number"])
+	})
+
+	t.Run("encrypted values without key provider", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(path.Join(dir, "branch"), []byte("PIPER-ENCRYPTED:mock:ZW5jcnlwdGVkKG1haW4p"), 0644))
+
+		cpe := CPEMap{}
+		err := cpe.LoadFromDisk(dir)
+		assert.EqualError(t, err, fmt.Sprintf("failed to read %v: value is encrypted with key provider 'mock' but no key provider is configured", path.Join(dir, "branch")))
+		assert.Equal(t, "", GetParameter(dir, "branch"))
+	})
+
+	t.Run("encrypted values with other key provider", func(t *testing.T) {
+		setKeyProvider(t, &keyProviderMock{name: "other"})
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(path.Join(dir, "branch"), []byte("PIPER-ENCRYPTED:mock:ZW5jcnlwdGVkKG1haW4p"), 0644))
+
+		cpe := CPEMap{}
+		err := cpe.LoadFromDisk(dir)
+		assert.ErrorContains(t, err, "value is encrypted with key provider 'mock' but key provider 'other' is configured")
+	})
+}
@@ -16,6 +16,9 @@ import (
 
 // SetResourceParameter sets a resource parameter in the environment stored in the file system
 func SetResourceParameter(path, resourceName, paramName string, value interface{}) error {
+	if resourceName == CPEResourceName {
+		validateValue("writing", paramName, value)
+	}
 	var content []byte
 	paramPath := filepath.Join(path, resourceName, paramName)
 	switch typedValue := value.(type) {
@@ -29,7 +32,8 @@ func SetResourceParameter(path, resourceName, paramName string, value interface{
 			return errors.Wrapf(err, "failed to marshal resource parameter value %v", typedValue)
 		}
 	}
-	return writeToDisk(paramPath, content)
+	// only the commonPipelineEnvironment is encrypted at rest, other resources like influx are read by the Jenkins library directly
+	return writeToDisk(paramPath, content, resourceName == CPEResourceName)
 }
 
 // GetResourceParameter reads a resource parameter from the environment stored in the file system
@@ -37,13 +41,17 @@ func GetResourceParameter(path, resourceName, paramName string) string {
 	//TODO: align JSON un/marshalling, currently done in pkg/config/stepmeta.go#getParameterValue
 
 	paramPath := filepath.Join(path, resourceName, paramName)
-	return readFromDisk(paramPath)
+	value := readFromDisk(paramPath)
+	if resourceName == CPEResourceName {
+		validateRead(filepath.Join(path, resourceName), paramName, value)
+	}
+	return value
 }
 
 // SetParameter sets any parameter in the pipeline environment or another environment stored in the file system
 func SetParameter(path, name, value string) error {
 	paramPath := filepath.Join(path, name)
-	return writeToDisk(paramPath, []byte(value))
+	return writeToDisk(paramPath, []byte(value), filepath.Base(path) == CPEResourceName)
 }
 
 // GetParameter reads any parameter from the pipeline environment or another environment stored in the file system
@@ -52,7 +60,7 @@ func GetParameter(path, name string) string {
 	return readFromDisk(paramPath)
 }
 
-func writeToDisk(filename string, data []byte) error {
+func writeToDisk(filename string, data []byte, encrypt bool) error {
 
 	if _, err := os.Stat(filepath.Dir(filename)); os.IsNotExist(err) {
 		log.Entry().Debugf("Creating directory: %v", filepath.Dir(filename))
@@ -65,6 +73,12 @@ func writeToDisk(filename string, data []byte) error {
 	//ToDo: make sure to not overwrite file but rather add another file? Create error if already existing?
 	if len(data) > 0 {
 		log.Entry().Debugf("Writing file to disk: %v", filename)
+		if encrypt {
+			var err error
+			if data, err = encryptValue(data); err != nil {
+				return err
+			}
+		}
 		return os.WriteFile(filename, data, 0766)
 	}
 	return nil
@@ -74,9 +88,13 @@ func readFromDisk(filename string) string {
 	//ToDo: if multiple files exist, read from latest file
 	log.Entry().Debugf("Reading file from disk: %v", filename)
 	v, err := os.ReadFile(filename)
-	val := string(v)
 	if err != nil {
-		val = ""
+		return ""
+	}
+	v, err = decryptValue(v)
+	if err != nil {
+		log.Entry().WithError(err).Warnf("failed to read %v", filename)
+		return ""
 	}
-	return strings.TrimSpace(val)
+	return strings.TrimSpace(string(v))
 }
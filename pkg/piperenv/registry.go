@@ -0,0 +1,197 @@
+package piperenv
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+)
+
+// CPEResourceName is the name of the resource holding the common pipeline environment
+const CPEResourceName = "commonPipelineEnvironment"
+
+// RegistryEntry describes a key of the common pipeline environment
+type RegistryEntry struct {
+	Type      string   // type as defined in the step metadata, e.g. string, []string, map[string]interface{}
+	WrittenBy []string // steps which write the key as output resource
+	ReadBy    []string // steps which read the key as input parameter
+}
+
+// Registry contains all keys of the common pipeline environment known from the step metadata
+type Registry map[string]RegistryEntry
+
+// RegistryFinding describes a key of the common pipeline environment which does not match the registry
+type RegistryFinding struct {
+	Key     string
+	Unknown bool // the key is not part of the registry
+	Message string
+}
+
+var registry Registry
+
+// SetRegistry defines the registry used to validate values written to and read from the common pipeline environment.
+// Mismatches are logged as warnings, passing nil disables the validation.
+func SetRegistry(r Registry) {
+	registry = r
+}
+
+// Validate checks if the value matches the type registered for the key
+func (r Registry) Validate(key string, value interface{}) error {
+	entry, ok := r[key]
+	if !ok {
+		return fmt.Errorf("unknown key")
+	}
+	return checkValueType(entry.Type, value)
+}
+
+// ValidateCPE checks all values of the common pipeline environment against the registry, findings are sorted by key
+func (r Registry) ValidateCPE(cpe CPEMap) []RegistryFinding {
+	findings := []RegistryFinding{}
+	for key, value := range cpe {
+		if _, ok := r[key]; !ok {
+			findings = append(findings, RegistryFinding{Key: key, Unknown: true, Message: "unknown key"})
+			continue
+		}
+		if err := r.Validate(key, value); err != nil {
+			findings = append(findings, RegistryFinding{Key: key, Message: err.Error()})
+		}
+	}
+	sort.Slice(findings, func(i, j int) bool { return findings[i].Key < findings[j].Key })
+	return findings
+}
+
+// validateValue checks a value which is read ("reading") or written ("writing") against the registry
+func validateValue(action, key string, value interface{}) {
+	if registry == nil {
+		return
+	}
+	if _, ok := registry[key]; !ok {
+		log.Entry().Debugf("commonPipelineEnvironment: %v unknown key '%v'", action, key)
+		return
+	}
+	if err := registry.Validate(key, value); err != nil {
+		log.Entry().Warnf("commonPipelineEnvironment: %v key '%v': %v", action, key, err)
+	}
+}
+
+// validateRead checks the value read from the file paramName (with '.json' suffix for non-string values) in dir
+func validateRead(dir, paramName, value string) {
+	if registry == nil {
+		return
+	}
+	key := strings.TrimSuffix(paramName, ".json")
+	entry, ok := registry[key]
+	if !ok {
+		return
+	}
+	if len(value) == 0 {
+		// a value stored with the wrong type is written to the file with or without '.json' suffix
+		otherFile := key + ".json"
+		if strings.HasSuffix(paramName, ".json") {
+			otherFile = key
+		}
+		if _, err := os.Stat(filepath.Join(dir, otherFile)); err == nil {
+			log.Entry().Warnf("commonPipelineEnvironment: reading key '%v': expected type %v but value is stored in '%v'", key, entry.Type, otherFile)
+		}
+		return
+	}
+	var typedValue interface{} = value
+	if strings.HasSuffix(paramName, ".json") {
+		if err := decodeJSON([]byte(value), &typedValue); err != nil {
+			log.Entry().Warnf("commonPipelineEnvironment: reading key '%v': invalid JSON value", key)
+			return
+		}
+	}
+	if err := checkValueType(entry.Type, typedValue); err != nil {
+		log.Entry().Warnf("commonPipelineEnvironment: reading key '%v': %v", key, err)
+	}
+}
+
+func decodeJSON(content []byte, value *interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	return decoder.Decode(value)
+}
+
+func checkValueType(expectedType string, value interface{}) error {
+	// empty values are used to remove a value from the environment
+	if value == nil || value == "" {
+		return nil
+	}
+	if _, ok := value.(string); ok {
+		if expectedType == "string" {
+			return nil
+		}
+		return fmt.Errorf("wrong type string, expected %v", expectedType)
+	}
+
+	// normalize typed values like []string in the same way they are read from disk
+	jsonValue, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("value cannot be serialized: %w", err)
+	}
+	var normalized interface{}
+	if err := decodeJSON(jsonValue, &normalized); err != nil {
+		return fmt.Errorf("value cannot be serialized: %w", err)
+	}
+	if normalized == nil {
+		return nil
+	}
+
+	valid := true
+	switch expectedType {
+	case "string":
+		valid = false
+	case "bool":
+		_, valid = normalized.(bool)
+	case "int":
+		number, ok := normalized.(json.Number)
+		_, err := number.Int64()
+		valid = ok && err == nil
+	case "[]string":
+		valid = isListOf(normalized, func(v interface{}) bool { _, ok := v.(string); return ok })
+	case "map[string]interface{}":
+		_, valid = normalized.(map[string]interface{})
+	case "[]map[string]interface{}", "piperenv.Artifacts":
+		valid = isListOf(normalized, func(v interface{}) bool { _, ok := v.(map[string]interface{}); return ok })
+	}
+	if !valid {
+		return fmt.Errorf("wrong type %v, expected %v", jsonTypeName(normalized), expectedType)
+	}
+	return nil
+}
+
+func isListOf(value interface{}, check func(interface{}) bool) bool {
+	list, ok := value.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, item := range list {
+		if !check(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case bool:
+		return "bool"
+	case json.Number:
+		return "number"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "object"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,124 @@
+//go:build unit
+// +build unit
+
+package piperenv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+)
+
+var testRegistry = Registry{
+	"artifactVersion":       {Type: "string", WrittenBy: []string{"artifactPrepareVersion"}},
+	"container/imageNames":  {Type: "[]string", WrittenBy: []string{"kanikoExecute"}},
+	"custom/artifacts":      {Type: "piperenv.Artifacts", WrittenBy: []string{"golangBuild"}},
+	"custom/isOptimized":    {Type: "bool", ReadBy: []string{"mavenBuild"}},
+	"custom/count":          {Type: "int"},
+	"custom/buildSettings":  {Type: "map[string]interface{}"},
+	"custom/unknownTypeKey": {Type: "someType"},
+}
+
+func TestRegistryValidate(t *testing.T) {
+	tests := []struct {
+		key   string
+		value interface{}
+		err   string
+	}{
+		{key: "artifactVersion", value: "1.0.0"},
+		{key: "artifactVersion", value: []string{"1.0.0"}, err: "wrong type list, expected string"},
+		{key: "container/imageNames", value: []string{"a", "b"}},
+		{key: "container/imageNames", value: []interface{}{"a"}},
+		{key: "container/imageNames", value: []string(nil)},
+		{key: "container/imageNames", value: ""},
+		{key: "container/imageNames", value: "a", err: "wrong type string, expected []string"},
+		{key: "container/imageNames", value: []int{1}, err: "wrong type list, expected []string"},
+		{key: "custom/artifacts", value: Artifacts{{Name: "app"}}},
+		{key: "custom/artifacts", value: map[string]interface{}{}, err: "wrong type object, expected piperenv.Artifacts"},
+		{key: "custom/isOptimized", value: true},
+		{key: "custom/isOptimized", value: "true", err: "wrong type string, expected bool"},
+		{key: "custom/count", value: 5},
+		{key: "custom/count", value: 5.5, err: "wrong type number, expected int"},
+		{key: "custom/buildSettings", value: map[string]interface{}{"a": 1}},
+		{key: "custom/unknownTypeKey", value: 1},
+		{key: "notRegistered", value: "a", err: "unknown key"},
+	}
+	for _, tc := range tests {
+		err := testRegistry.Validate(tc.key, tc.value)
+		if len(tc.err) == 0 {
+			assert.NoError(t, err, "key %v, value %v", tc.key, tc.value)
+		} else {
+			assert.EqualError(t, err, tc.err, "key %v, value %v", tc.key, tc.value)
+		}
+	}
+}
+
+func TestRegistryValidateCPE(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, CPEMap{
+		"artifactVersion":      "1.0.0",
+		"container/imageNames": "image",
+		"custom/isOptimized":   true,
+		"custom/myKey":         "value",
+	}.WriteToDisk(dir))
+
+	cpe := CPEMap{}
+	require.NoError(t, cpe.LoadFromDisk(dir))
+
+	assert.Equal(t, []RegistryFinding{
+		{Key: "container/imageNames", Message: "wrong type string, expected []string"},
+		{Key: "custom/myKey", Unknown: true, Message: "unknown key"},
+	}, testRegistry.ValidateCPE(cpe))
+}
+
+func TestRegistryRuntimeValidation(t *testing.T) {
+	SetRegistry(testRegistry)
+	defer SetRegistry(nil)
+	_, hook := test.NewNullLogger()
+	log.RegisterHook(hook)
+
+	t.Run("write with wrong type", func(t *testing.T) {
+		hook.Reset()
+		dir := t.TempDir()
+
+		require.NoError(t, SetResourceParameter(dir, CPEResourceName, "container/imageNames", "image"))
+
+		require.Len(t, hook.Entries, 1)
+		assert.Equal(t, "commonPipelineEnvironment: writing key 'container/imageNames': wrong type string, expected []string", hook.LastEntry().Message)
+	})
+
+	t.Run("read value stored with wrong type", func(t *testing.T) {
+		hook.Reset()
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, CPEResourceName, "container"), 0o777))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, CPEResourceName, "container", "imageNames"), []byte("image"), 0o666))
+
+		assert.Equal(t, "", GetResourceParameter(dir, CPEResourceName, "container/imageNames.json"))
+
+		require.Len(t, hook.Entries, 1)
+		assert.Equal(t, "commonPipelineEnvironment: reading key 'container/imageNames': expected type []string but value is stored in 'container/imageNames'", hook.LastEntry().Message)
+	})
+
+	t.Run("read valid value", func(t *testing.T) {
+		hook.Reset()
+		dir := t.TempDir()
+		require.NoError(t, SetResourceParameter(dir, CPEResourceName, "container/imageNames", []string{"image"}))
+
+		assert.Equal(t, `["image"]`, GetResourceParameter(dir, CPEResourceName, "container/imageNames.json"))
+		assert.Empty(t, hook.Entries)
+	})
+
+	t.Run("other resources are not validated", func(t *testing.T) {
+		hook.Reset()
+		dir := t.TempDir()
+
+		require.NoError(t, SetResourceParameter(dir, "otherResource", "container/imageNames", "image"))
+		assert.Empty(t, hook.Entries)
+	})
+}
@@ -1,6 +1,13 @@
 package protecode
 
-import "strconv"
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+)
 
 const (
 	vulnerabilitySeverityThreshold = 7.0
@@ -39,3 +46,79 @@ func isSevere(vulnerability Vulnerability) bool {
 	}
 	return false
 }
+
+// ApplyComponentAssessments triages the vulnerabilities of the components an assessment with package URLs applies to.
+// Components are matched by the type, namespace and name of the package URL and, if the package URL specifies one, by version.
+// It returns the number of triaged vulnerabilities.
+func ApplyComponentAssessments(result *Result, assessments []format.Assessment, source string) int {
+	triaged := 0
+	for i := range result.Components {
+		component := &result.Components[i]
+		for j := range component.Vulns {
+			vulnerability := &component.Vulns[j]
+			for _, assessment := range assessments {
+				if assessment.Status != format.NotRelevant || !strings.EqualFold(assessment.Vulnerability, vulnerability.Vuln.Cve) ||
+					!component.matchesAssessment(assessment) {
+					continue
+				}
+				vulnerability.Triage = append(vulnerability.Triage, Triage{
+					VulnID:      vulnerability.Vuln.Cve,
+					Component:   component.Lib,
+					Version:     component.Version,
+					Description: fmt.Sprintf("assessed as not relevant in %v", source),
+				})
+				triaged++
+				break
+			}
+		}
+	}
+	return triaged
+}
+
+// osPackageTypes are the package URL types of operating system packages, their namespace is the distribution
+var osPackageTypes = []string{"deb", "apk", "rpm"}
+
+// packageTypesByCodetype maps the code types Protecode reports for components to the package URL types of the ecosystem
+var packageTypesByCodetype = map[string][]string{
+	"java":       {"maven"},
+	"javascript": {"npm"},
+	"go":         {"golang"},
+	"python":     {"pypi"},
+	".net":       {"nuget"},
+	"ruby":       {"gem"},
+	"php":        {"composer"},
+	"rust":       {"cargo"},
+}
+
+func (c Component) matchesAssessment(assessment format.Assessment) bool {
+	for _, purl := range assessment.Purls {
+		assessed, err := purl.ToPackageUrl()
+		if err != nil {
+			continue
+		}
+		// the assessment applies to all versions if it does not specify one
+		if c.matchesPackage(assessed.Type, assessed.Namespace, assessed.Name) && (len(assessed.Version) == 0 || assessed.Version == c.Version) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Component) matchesPackage(packageType, namespace, name string) bool {
+	switch {
+	case packageType == "generic":
+		// generic package URLs do not belong to an ecosystem
+		return len(namespace) == 0 && strings.EqualFold(c.Lib, name)
+	case len(c.Distro) > 0:
+		return slices.Contains(osPackageTypes, packageType) && (len(namespace) == 0 || strings.EqualFold(namespace, c.Distro)) &&
+			strings.EqualFold(c.Lib, name)
+	case slices.Contains(packageTypesByCodetype[strings.ToLower(c.Codetype)], packageType):
+		if len(namespace) == 0 {
+			return strings.EqualFold(c.Lib, name)
+		}
+		// Protecode reports the namespace as part of the name, e.g. @angular/core or golang.org/x/net
+		return strings.EqualFold(c.Lib, namespace+"/"+name) || strings.EqualFold(c.Lib, namespace+":"+name)
+	default:
+		return false
+	}
+}
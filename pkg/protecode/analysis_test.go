@@ -6,6 +6,7 @@ package protecode
 import (
 	"testing"
 
+	"github.com/SAP/jenkins-library/pkg/format"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -128,3 +129,41 @@ func TestHasSevereVulnerabilities(t *testing.T) {
 		assert.False(t, HasSevereVulnerabilities(data, ""))
 	})
 }
+
+func TestApplyComponentAssessments(t *testing.T) {
+	severe := func(cve string) Vulnerability {
+		return Vulnerability{Exact: true, Vuln: Vuln{Cve: cve, Cvss3Score: "9.0"}}
+	}
+	result := Result{Components: []Component{
+		{Lib: "curl", Version: "7.35.0", Codetype: "Native", Distro: "ubuntu", Vulns: []Vulnerability{severe("CVE-2023-1"), severe("CVE-2023-2")}},
+		{Lib: "curl", Version: "7.50.0", Codetype: "Native", Vulns: []Vulnerability{severe("CVE-2023-1"), severe("CVE-2023-3")}},
+		{Lib: "bash", Version: "4.3.11", Codetype: "Native", Distro: "ubuntu", Vulns: []Vulnerability{severe("CVE-2023-1"), severe("CVE-2023-3")}},
+		{Lib: "@angular/core", Version: "16.0.0", Codetype: "JavaScript", Vulns: []Vulnerability{severe("CVE-2023-4"), severe("CVE-2023-5")}},
+		{Lib: "core", Version: "1.0.0", Codetype: "Go", Vulns: []Vulnerability{severe("CVE-2023-4")}},
+	}}
+	assessments := []format.Assessment{
+		{Vulnerability: "CVE-2023-1", Status: format.NotRelevant, Purls: []format.Purl{{Purl: "pkg:deb/ubuntu/curl@7.35.0"}}},
+		// applies to all versions
+		{Vulnerability: "CVE-2023-3", Status: format.NotRelevant, Purls: []format.Purl{{Purl: "pkg:generic/curl"}}},
+		{Vulnerability: "CVE-2023-2", Status: format.Relevant, Purls: []format.Purl{{Purl: "pkg:deb/ubuntu/curl@7.35.0"}}},
+		// packages of other distributions or ecosystems with the same name are not matched
+		{Vulnerability: "CVE-2023-3", Status: format.NotRelevant, Purls: []format.Purl{{Purl: "pkg:deb/debian/bash"}, {Purl: "pkg:npm/bash"}}},
+		{Vulnerability: "CVE-2023-4", Status: format.NotRelevant, Purls: []format.Purl{{Purl: "pkg:npm/%40angular/core"}}},
+		{Vulnerability: "CVE-2023-5", Status: format.NotRelevant, Purls: []format.Purl{{Purl: "pkg:npm/core"}, {Purl: "pkg:npm/%40other/core"}}},
+	}
+
+	triaged := ApplyComponentAssessments(&result, assessments, "vex.json")
+
+	assert.Equal(t, 3, triaged)
+	assert.Equal(t, []Triage{{VulnID: "CVE-2023-1", Component: "curl", Version: "7.35.0", Description: "assessed as not relevant in vex.json"}}, result.Components[0].Vulns[0].Triage)
+	assert.Empty(t, result.Components[0].Vulns[1].Triage)
+	assert.Empty(t, result.Components[1].Vulns[0].Triage)
+	assert.Len(t, result.Components[1].Vulns[1].Triage, 1)
+	// vulnerabilities of other components are still reported
+	assert.Empty(t, result.Components[2].Vulns[0].Triage)
+	assert.Empty(t, result.Components[2].Vulns[1].Triage)
+	assert.Len(t, result.Components[3].Vulns[0].Triage, 1)
+	assert.Empty(t, result.Components[3].Vulns[1].Triage)
+	assert.Empty(t, result.Components[4].Vulns[0].Triage)
+	assert.True(t, HasSevereVulnerabilities(result, ""))
+}
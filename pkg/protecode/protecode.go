@@ -46,7 +46,11 @@ type Result struct {
 
 // Component the protecode component information
 type Component struct {
-	Vulns []Vulnerability `json:"vulns,omitempty"`
+	Lib      string          `json:"lib,omitempty"`
+	Version  string          `json:"version,omitempty"`
+	Codetype string          `json:"codetype,omitempty"`
+	Distro   string          `json:"distro,omitempty"`
+	Vulns    []Vulnerability `json:"vulns,omitempty"`
 }
 
 // Vulnerability the protecode vulnerability information
@@ -0,0 +1,315 @@
+package releasenotes
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+
+	gitUtils "github.com/SAP/jenkins-library/pkg/git"
+	"github.com/SAP/jenkins-library/pkg/sbom"
+	"github.com/SAP/jenkins-library/pkg/versioning"
+)
+
+const (
+	// GroupByType groups the changes by the type of the Conventional Commits
+	GroupByType = "type"
+	// GroupByLabel groups the changes by the labels of their pull requests
+	GroupByLabel = "label"
+
+	otherChangesTitle = "Other Changes"
+)
+
+type typeSection struct {
+	commitType string
+	title      string
+}
+
+var typeSections = []typeSection{
+	{commitType: "feat", title: "Features"},
+	{commitType: "fix", title: "Bug Fixes"},
+	{commitType: "perf", title: "Performance Improvements"},
+}
+
+// pullRequestReference matches the reference GitHub adds to the subject of squash merged pull requests, e.g. "fix: handle timeout (#123)"
+var pullRequestReference = regexp.MustCompile(`\s*\(#(\d+)\)$`)
+
+// pullRequestMerge matches the subject of the merge commits GitHub creates for pull requests, e.g. "Merge pull request #123 from owner/branch"
+var pullRequestMerge = regexp.MustCompile(`^Merge pull request #(\d+) `)
+
+// Options define how the release notes are composed
+type Options struct {
+	// GroupBy is either GroupByType (default) or GroupByLabel
+	GroupBy string
+	// Labels define the sections and their order in case of GroupByLabel, by default a section is created per label
+	Labels []string
+	// ExcludeLabels define labels of pull requests which are not contained in the release notes
+	ExcludeLabels []string
+	// PullRequestLabels provides the labels per pull request number, required for GroupByLabel
+	PullRequestLabels map[int][]string
+}
+
+// Change is a single change of the release, based on a commit
+type Change struct {
+	Hash                string
+	Type                string
+	Scope               string
+	Description         string
+	Breaking            bool
+	BreakingDescription string
+	PullRequest         int
+	Labels              []string
+	Author              string
+}
+
+// Section is a group of changes with a common type or label
+type Section struct {
+	Title   string
+	Changes []Change
+}
+
+// Notes contains the information available for the release notes template
+type Notes struct {
+	Version         string
+	PreviousTag     string
+	Date            time.Time
+	BreakingChanges []Change
+	Sections        []Section
+	Dependencies    *sbom.Diff
+}
+
+// Commits returns the commits reachable from the revision to but not from the revision from.
+// In case from is empty, all commits reachable from the revision to are returned.
+func Commits(repository *git.Repository, from, to string) ([]*object.Commit, error) {
+	var commitIter object.CommitIter
+	var err error
+	if len(from) > 0 {
+		commitIter, err = gitUtils.LogRange(repository, from, to)
+	} else {
+		var hash *plumbing.Hash
+		hash, err = repository.ResolveRevision(plumbing.Revision(to))
+		if err == nil {
+			commitIter, err = repository.Log(&git.LogOptions{From: *hash})
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve commits between '%v' and '%v'", from, to)
+	}
+	commits := []*object.Commit{}
+	err = commitIter.ForEach(func(commit *object.Commit) error {
+		commits = append(commits, commit)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve commits")
+	}
+	return commits, nil
+}
+
+// PreviousTag returns the tag with the highest semantic version lower than the version, an empty string is returned in case no such tag exists
+func PreviousTag(repository *git.Repository, tagPrefix, version string) (string, error) {
+	tags, err := repository.Tags()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to retrieve tags")
+	}
+	previousTag, previousVersion := "", ""
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		tag := ref.Name().Short()
+		tagVersion := strings.TrimPrefix(tag, tagPrefix)
+		if !strings.HasPrefix(tag, tagPrefix) || !versioning.IsSemanticVersion(tagVersion) {
+			return nil
+		}
+		if result, err := versioning.CompareSemanticVersions(tagVersion, version); err == nil && result >= 0 {
+			return nil
+		}
+		if len(previousTag) > 0 {
+			if result, _ := versioning.CompareSemanticVersions(tagVersion, previousVersion); result <= 0 {
+				return nil
+			}
+		}
+		previousTag, previousVersion = tag, tagVersion
+		return nil
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to retrieve tags")
+	}
+	return previousTag, nil
+}
+
+// Changes converts the commits into changes, merge commits are not contained themselves.
+// Commits merged via a pull request are assigned to this pull request, squash merged pull requests are identified by the reference in the subject.
+// Commits not following the Conventional Commits specification are contained with the type "other".
+func Changes(commits []*object.Commit, opts Options) []Change {
+	mergedPullRequests := mergedPullRequests(commits)
+	changes := []Change{}
+	for _, commit := range commits {
+		if commit.NumParents() > 1 || strings.HasPrefix(commit.Message, "Merge ") {
+			continue
+		}
+		subject := strings.TrimSpace(strings.SplitN(strings.TrimSpace(commit.Message), "\n", 2)[0])
+		if len(subject) == 0 {
+			continue
+		}
+		change := Change{Hash: commit.Hash.String(), Type: "other", Description: subject, Author: commit.Author.Name}
+		if conventionalCommit, ok := versioning.ParseConventionalCommit(commit.Hash.String(), commit.Message); ok {
+			change.Type = conventionalCommit.Type
+			change.Scope = conventionalCommit.Scope
+			change.Description = conventionalCommit.Description
+			change.Breaking = conventionalCommit.Breaking
+			change.BreakingDescription = conventionalCommit.BreakingDescription
+		}
+		if reference := pullRequestReference.FindStringSubmatch(change.Description); reference != nil {
+			change.PullRequest, _ = strconv.Atoi(reference[1])
+			change.Description = strings.TrimSuffix(change.Description, reference[0])
+			change.BreakingDescription = pullRequestReference.ReplaceAllString(change.BreakingDescription, "")
+		} else {
+			change.PullRequest = mergedPullRequests[commit.Hash]
+		}
+		if change.PullRequest > 0 {
+			change.Labels = opts.PullRequestLabels[change.PullRequest]
+		}
+		if slices.ContainsFunc(change.Labels, func(label string) bool { return slices.Contains(opts.ExcludeLabels, label) }) {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// mergedPullRequests returns the number of the pull request per commit which was merged via a pull request merge commit.
+// Only the commits of the given range are considered, in case of nested merges the latest pull request wins.
+func mergedPullRequests(commits []*object.Commit) map[plumbing.Hash]int {
+	byHash := map[plumbing.Hash]*object.Commit{}
+	for _, commit := range commits {
+		byHash[commit.Hash] = commit
+	}
+	// reachable returns the commits of the range reachable from the given commit
+	reachable := func(from plumbing.Hash) map[plumbing.Hash]bool {
+		result := map[plumbing.Hash]bool{}
+		pending := []plumbing.Hash{from}
+		for len(pending) > 0 {
+			hash := pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+			commit, inRange := byHash[hash]
+			if !inRange || result[hash] {
+				continue
+			}
+			result[hash] = true
+			pending = append(pending, commit.ParentHashes...)
+		}
+		return result
+	}
+
+	pullRequests := map[plumbing.Hash]int{}
+	// commits are ordered from the latest to the oldest
+	for _, commit := range commits {
+		if len(commit.ParentHashes) < 2 {
+			continue
+		}
+		merge := pullRequestMerge.FindStringSubmatch(commit.Message)
+		if merge == nil {
+			continue
+		}
+		pullRequest, _ := strconv.Atoi(merge[1])
+		// the pull request contains the commits of the merged branch which were not yet part of the target branch
+		target := reachable(commit.ParentHashes[0])
+		for hash := range reachable(commit.ParentHashes[1]) {
+			if _, assigned := pullRequests[hash]; !assigned && !target[hash] {
+				pullRequests[hash] = pullRequest
+			}
+		}
+	}
+	return pullRequests
+}
+
+// Compose groups the changes into the sections of the release notes
+func Compose(version string, changes []Change, opts Options) (Notes, error) {
+	notes := Notes{Version: version, BreakingChanges: []Change{}}
+	for _, change := range changes {
+		if change.Breaking {
+			notes.BreakingChanges = append(notes.BreakingChanges, change)
+		}
+	}
+
+	switch opts.GroupBy {
+	case GroupByType, "":
+		notes.Sections = groupByType(changes)
+	case GroupByLabel:
+		notes.Sections = groupByLabel(changes, opts.Labels)
+	default:
+		return Notes{}, fmt.Errorf("grouping '%v' not supported, supported: [%v %v]", opts.GroupBy, GroupByType, GroupByLabel)
+	}
+	return notes, nil
+}
+
+func groupByType(changes []Change) []Section {
+	sections := []Section{}
+	other := Section{Title: otherChangesTitle}
+	for _, sectionType := range typeSections {
+		section := Section{Title: sectionType.title}
+		for _, change := range changes {
+			if change.Type == sectionType.commitType {
+				section.Changes = append(section.Changes, change)
+			}
+		}
+		if len(section.Changes) > 0 {
+			sections = append(sections, section)
+		}
+	}
+	for _, change := range changes {
+		if !slices.ContainsFunc(typeSections, func(s typeSection) bool { return s.commitType == change.Type }) {
+			other.Changes = append(other.Changes, change)
+		}
+	}
+	if len(other.Changes) > 0 {
+		sections = append(sections, other)
+	}
+	return sections
+}
+
+func groupByLabel(changes []Change, labels []string) []Section {
+	if len(labels) == 0 {
+		for _, change := range changes {
+			for _, label := range change.Labels {
+				if !slices.Contains(labels, label) {
+					labels = append(labels, label)
+				}
+			}
+		}
+		sort.Strings(labels)
+	}
+
+	sections := make([]Section, len(labels))
+	other := Section{Title: otherChangesTitle}
+	for i, label := range labels {
+		sections[i].Title = label
+	}
+	for _, change := range changes {
+		// a change is only listed once, in the section of its first label
+		index := slices.IndexFunc(labels, func(label string) bool { return slices.Contains(change.Labels, label) })
+		if index < 0 {
+			other.Changes = append(other.Changes, change)
+			continue
+		}
+		sections[index].Changes = append(sections[index].Changes, change)
+	}
+
+	result := []Section{}
+	for _, section := range sections {
+		if len(section.Changes) > 0 {
+			result = append(result, section)
+		}
+	}
+	if len(other.Changes) > 0 {
+		result = append(result, other)
+	}
+	return result
+}
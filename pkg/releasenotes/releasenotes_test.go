@@ -0,0 +1,169 @@
+//go:build unit
+// +build unit
+
+package releasenotes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommits(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	assert.NoError(t, err)
+	worktree, err := repo.Worktree()
+	assert.NoError(t, err)
+	commit := func(message string) plumbing.Hash {
+		hash, err := worktree.Commit(message, &git.CommitOptions{AllowEmptyCommits: true, Author: &object.Signature{Name: "Test", When: time.Now()}})
+		assert.NoError(t, err)
+		return hash
+	}
+
+	_, err = repo.CreateTag("v1.0.0", commit("feat: initial implementation"), nil)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.1.0-rc.1", commit("feat: new feature"), nil)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.1.0", commit("fix: handle timeout"), nil)
+	assert.NoError(t, err)
+	commit("chore: update dependencies")
+
+	t.Run("commits since tag", func(t *testing.T) {
+		commits, err := Commits(repo, "v1.0.0", "HEAD")
+
+		assert.NoError(t, err)
+		if assert.Len(t, commits, 3) {
+			assert.Equal(t, "chore: update dependencies", commits[0].Message)
+		}
+	})
+
+	t.Run("all commits", func(t *testing.T) {
+		commits, err := Commits(repo, "", "v1.1.0")
+
+		assert.NoError(t, err)
+		assert.Len(t, commits, 3)
+	})
+
+	t.Run("previous tag", func(t *testing.T) {
+		tag, err := PreviousTag(repo, "v", "1.2.0")
+		assert.NoError(t, err)
+		assert.Equal(t, "v1.1.0", tag)
+
+		tag, err = PreviousTag(repo, "v", "1.1.0")
+		assert.NoError(t, err)
+		assert.Equal(t, "v1.1.0-rc.1", tag)
+
+		tag, err = PreviousTag(repo, "v", "1.0.0")
+		assert.NoError(t, err)
+		assert.Empty(t, tag)
+	})
+
+	t.Run("error case - unknown revision", func(t *testing.T) {
+		_, err := Commits(repo, "v0.9.0", "HEAD")
+
+		assert.ErrorContains(t, err, "failed to retrieve commits between 'v0.9.0' and 'HEAD'")
+	})
+}
+
+func TestChanges(t *testing.T) {
+	t.Parallel()
+
+	newCommit := func(message string, data ...byte) *object.Commit {
+		return &object.Commit{Hash: plumbing.ComputeHash(plumbing.CommitObject, data), Message: message, Author: object.Signature{Name: "Test"}}
+	}
+	commits := []*object.Commit{
+		newCommit("feat(api): add endpoint (#12)", 1),
+		newCommit("fix: handle timeout (#13)\n\nBREAKING CHANGE: timeout is now mandatory", 2),
+		newCommit("Merge pull request #14 from feature", 3),
+		newCommit("Update README.md (#15)", 4),
+		newCommit("chore: internal cleanup (#16)", 5),
+	}
+	opts := Options{
+		ExcludeLabels:     []string{"internal"},
+		PullRequestLabels: map[int][]string{12: {"enhancement"}, 13: {"bug", "enhancement"}, 16: {"internal"}},
+	}
+
+	changes := Changes(commits, opts)
+
+	assert.Equal(t, []Change{
+		{Hash: commits[0].Hash.String(), Type: "feat", Scope: "api", Description: "add endpoint", PullRequest: 12, Labels: []string{"enhancement"}, Author: "Test"},
+		{Hash: commits[1].Hash.String(), Type: "fix", Description: "handle timeout", Breaking: true, BreakingDescription: "timeout is now mandatory", PullRequest: 13, Labels: []string{"bug", "enhancement"}, Author: "Test"},
+		{Hash: commits[3].Hash.String(), Type: "other", Description: "Update README.md", PullRequest: 15, Author: "Test"},
+	}, changes)
+
+	t.Run("merged pull requests", func(t *testing.T) {
+		base := newCommit("feat: previous release", 10)
+		onTarget := newCommit("fix: handle timeout (#20)", 11)
+		onTarget.ParentHashes = []plumbing.Hash{base.Hash}
+		first := newCommit("feat: add endpoint", 12)
+		first.ParentHashes = []plumbing.Hash{base.Hash}
+		second := newCommit("test: cover endpoint", 13)
+		second.ParentHashes = []plumbing.Hash{first.Hash}
+		merge := newCommit("Merge pull request #21 from owner/feature\n\nAdd endpoint", 14)
+		merge.ParentHashes = []plumbing.Hash{onTarget.Hash, second.Hash}
+		internal := newCommit("chore: internal cleanup", 15)
+		internal.ParentHashes = []plumbing.Hash{merge.Hash}
+		internalMerge := newCommit("Merge pull request #22 from owner/cleanup\n\nCleanup", 16)
+		internalMerge.ParentHashes = []plumbing.Hash{merge.Hash, internal.Hash}
+		// the previous release is not part of the range
+		mergeCommits := []*object.Commit{internalMerge, internal, merge, second, first, onTarget}
+		opts := Options{
+			ExcludeLabels:     []string{"internal"},
+			PullRequestLabels: map[int][]string{20: {"bug"}, 21: {"enhancement"}, 22: {"internal"}},
+		}
+
+		changes := Changes(mergeCommits, opts)
+
+		assert.Equal(t, []Change{
+			{Hash: second.Hash.String(), Type: "test", Description: "cover endpoint", PullRequest: 21, Labels: []string{"enhancement"}, Author: "Test"},
+			{Hash: first.Hash.String(), Type: "feat", Description: "add endpoint", PullRequest: 21, Labels: []string{"enhancement"}, Author: "Test"},
+			{Hash: onTarget.Hash.String(), Type: "fix", Description: "handle timeout", PullRequest: 20, Labels: []string{"bug"}, Author: "Test"},
+		}, changes)
+	})
+
+	t.Run("group by type", func(t *testing.T) {
+		notes, err := Compose("1.3.0", changes, Options{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1.3.0", notes.Version)
+		assert.Equal(t, []Change{changes[1]}, notes.BreakingChanges)
+		assert.Equal(t, []Section{
+			{Title: "Features", Changes: []Change{changes[0]}},
+			{Title: "Bug Fixes", Changes: []Change{changes[1]}},
+			{Title: "Other Changes", Changes: []Change{changes[2]}},
+		}, notes.Sections)
+	})
+
+	t.Run("group by label", func(t *testing.T) {
+		notes, err := Compose("1.3.0", changes, Options{GroupBy: GroupByLabel})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Section{
+			{Title: "bug", Changes: []Change{changes[1]}},
+			{Title: "enhancement", Changes: []Change{changes[0]}},
+			{Title: "Other Changes", Changes: []Change{changes[2]}},
+		}, notes.Sections)
+	})
+
+	t.Run("group by configured labels", func(t *testing.T) {
+		notes, err := Compose("1.3.0", changes, Options{GroupBy: GroupByLabel, Labels: []string{"enhancement", "bug"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Section{
+			{Title: "enhancement", Changes: []Change{changes[0], changes[1]}},
+			{Title: "Other Changes", Changes: []Change{changes[2]}},
+		}, notes.Sections)
+	})
+
+	t.Run("error case - unknown grouping", func(t *testing.T) {
+		_, err := Compose("1.3.0", changes, Options{GroupBy: "author"})
+
+		assert.EqualError(t, err, "grouping 'author' not supported, supported: [type label]")
+	})
+}
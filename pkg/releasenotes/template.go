@@ -0,0 +1,60 @@
+package releasenotes
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+	"github.com/pkg/errors"
+)
+
+// DefaultTemplate renders the release notes as markdown
+const DefaultTemplate = `
+{{- define "change" }}{{ if .Scope }}**{{ .Scope }}:** {{ end }}{{ .Description }}{{ if .PullRequest }} (#{{ .PullRequest }}){{ end }} ({{ trunc 7 .Hash }}){{ end }}
+{{- if .BreakingChanges }}
+### BREAKING CHANGES
+{{ range .BreakingChanges }}
+* {{ if .Scope }}**{{ .Scope }}:** {{ end }}{{ .BreakingDescription }} ({{ trunc 7 .Hash }})
+{{- end }}
+{{ end }}
+{{- range .Sections }}
+### {{ .Title }}
+{{ range .Changes }}
+* {{ template "change" . }}
+{{- end }}
+{{ end }}
+{{- with .Dependencies }}{{ if .HasChanges }}
+### Dependency Changes
+{{ range .Added }}
+* added ` + "`{{ .Name }}`" + ` {{ .Version }}
+{{- end }}
+{{- range .Removed }}
+* removed ` + "`{{ .Name }}`" + ` {{ .PreviousVersion }}
+{{- end }}
+{{- range .Upgraded }}
+* upgraded ` + "`{{ .Name }}`" + ` from {{ .PreviousVersion }} to {{ .Version }}
+{{- end }}
+{{- range .Downgraded }}
+* downgraded ` + "`{{ .Name }}`" + ` from {{ .PreviousVersion }} to {{ .Version }}
+{{- end }}
+{{- range .LicenseChanges }}
+* changed license of ` + "`{{ .Name }}`" + ` {{ .Version }} from {{ join ", " .PreviousLicenses }} to {{ join ", " .Licenses }}
+{{- end }}
+{{ end }}{{ end }}`
+
+// Render renders the release notes using a Go template, the DefaultTemplate is used in case no template is provided.
+// In addition to the Go template functions, the functions of https://masterminds.github.io/sprig/ are available.
+func Render(notes Notes, releaseNotesTemplate string) (string, error) {
+	if len(releaseNotesTemplate) == 0 {
+		releaseNotesTemplate = DefaultTemplate
+	}
+	tmpl, err := template.New("releaseNotes").Funcs(sprig.HermeticTxtFuncMap()).Parse(releaseNotesTemplate)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse release notes template")
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, notes); err != nil {
+		return "", errors.Wrap(err, "failed to render release notes")
+	}
+	return buffer.String(), nil
+}
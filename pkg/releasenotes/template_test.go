@@ -0,0 +1,84 @@
+//go:build unit
+// +build unit
+
+package releasenotes
+
+import (
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/sbom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	notes := Notes{
+		Version: "1.3.0",
+		BreakingChanges: []Change{
+			{Hash: "abcdef0123456789", Type: "fix", Breaking: true, BreakingDescription: "timeout is now mandatory"},
+		},
+		Sections: []Section{
+			{Title: "Features", Changes: []Change{{Hash: "0123456789abcdef", Type: "feat", Scope: "api", Description: "add endpoint", PullRequest: 12}}},
+			{Title: "Bug Fixes", Changes: []Change{{Hash: "abcdef0123456789", Type: "fix", Description: "handle timeout"}}},
+		},
+		Dependencies: &sbom.Diff{
+			Added:          []sbom.ComponentChange{{Name: "golang.org/x/net", Version: "0.20.0"}},
+			Removed:        []sbom.ComponentChange{{Name: "github.com/pkg/errors", PreviousVersion: "0.9.1"}},
+			Upgraded:       []sbom.ComponentChange{{Name: "github.com/spf13/cobra", PreviousVersion: "1.7.0", Version: "1.8.0"}},
+			LicenseChanges: []sbom.ComponentChange{{Name: "example", Version: "2.0.0", PreviousLicenses: []string{"MIT"}, Licenses: []string{"Apache-2.0"}}},
+		},
+	}
+
+	t.Run("default template", func(t *testing.T) {
+		releaseNotes, err := Render(notes, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, `
+### BREAKING CHANGES
+
+* timeout is now mandatory (abcdef0)
+
+### Features
+
+* **api:** add endpoint (#12) (0123456)
+
+### Bug Fixes
+
+* handle timeout (abcdef0)
+
+### Dependency Changes
+
+* added `+"`golang.org/x/net`"+` 0.20.0
+* removed `+"`github.com/pkg/errors`"+` 0.9.1
+* upgraded `+"`github.com/spf13/cobra`"+` from 1.7.0 to 1.8.0
+* changed license of `+"`example`"+` 2.0.0 from MIT to Apache-2.0
+`, releaseNotes)
+	})
+
+	t.Run("default template - no changes", func(t *testing.T) {
+		releaseNotes, err := Render(Notes{Version: "1.3.0", Dependencies: &sbom.Diff{}}, "")
+
+		assert.NoError(t, err)
+		assert.Empty(t, releaseNotes)
+	})
+
+	t.Run("custom template", func(t *testing.T) {
+		releaseNotes, err := Render(notes, `# {{ .Version }}{{ range .Sections }} - {{ .Title | upper }}: {{ len .Changes }}{{ end }}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "# 1.3.0 - FEATURES: 1 - BUG FIXES: 1", releaseNotes)
+	})
+
+	t.Run("error case - invalid template", func(t *testing.T) {
+		_, err := Render(notes, `{{ .Version`)
+
+		assert.ErrorContains(t, err, "failed to parse release notes template")
+	})
+
+	t.Run("error case - unknown field", func(t *testing.T) {
+		_, err := Render(notes, `{{ .Unknown }}`)
+
+		assert.ErrorContains(t, err, "failed to render release notes")
+	})
+}
@@ -27,6 +27,10 @@ type ScanReport struct {
 	ReportTime     time.Time       `json:"reportTime"`
 	DetailTable    ScanDetailTable `json:"detailTable"`
 	SuccessfulScan bool            `json:"successfulScan"`
+	// Findings contains the number of relevant findings per severity (critical, high, medium, low, info) if the step determines them
+	Findings map[string]int `json:"findings,omitempty"`
+	// SarifTool is the tool name of the SARIF file written by the step, which contains the same findings
+	SarifTool string `json:"sarifTool,omitempty"`
 }
 
 // ScanDetailTable defines a table containing scan result details
@@ -106,11 +110,7 @@ func (s ScanReport) ToTxt() string {
 	return string(txt)
 }
 
-const reportHTMLTemplate = `<!DOCTYPE html>
-<html>
-<head>
-	<title>{{.Title}}</title>
-	<style type="text/css">
+const reportHTMLStyle = `	<style type="text/css">
 	body {
 		font-family: Arial, Verdana;
 	}
@@ -168,9 +168,9 @@ const reportHTMLTemplate = `<!DOCTYPE html>
 		padding: 5px;
 	}
 	</style>
-</head>
-<body>
-	<h1>{{.Title}}</h1>
+`
+
+const reportHTMLBodyTemplate = `{{define "scanReport"}}	<h1>{{.Title}}</h1>
 	<h2>
 		<span>
 		{{range $s := .Subheaders}}
@@ -205,13 +205,20 @@ const reportHTMLTemplate = `<!DOCTYPE html>
 	<tr><td colspan="{{columnCount .DetailTable}}">{{.DetailTable.NoRowsMessage}}</td></tr>
 	{{- end}}
 	</table>
-</body>
+{{end}}`
+
+const reportHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head>
+	<title>{{.Title}}</title>
+` + reportHTMLStyle + `</head>
+<body>
+{{template "scanReport" .}}</body>
 </html>
 `
 
-// ToHTML creates a HTML version of the report
-func (s *ScanReport) ToHTML() ([]byte, error) {
-	funcMap := template.FuncMap{
+func reportHTMLFuncMap() template.FuncMap {
+	return template.FuncMap{
 		"inc": func(i int) int {
 			return i + 1
 		},
@@ -222,8 +229,12 @@ func (s *ScanReport) ToHTML() ([]byte, error) {
 		"drawCell":        drawCell,
 		"drawOverviewRow": drawOverviewRow,
 	}
+}
+
+// ToHTML creates a HTML version of the report
+func (s *ScanReport) ToHTML() ([]byte, error) {
 	report := []byte{}
-	tmpl, err := template.New("report").Funcs(funcMap).Parse(reportHTMLTemplate)
+	tmpl, err := template.New("report").Funcs(reportHTMLFuncMap()).Parse(reportHTMLTemplate + reportHTMLBodyTemplate)
 	if err != nil {
 		return report, errors.Wrap(err, "failed to create HTML report template")
 	}
@@ -0,0 +1,158 @@
+package reporting
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// ScanSummary combines the reports of several scans, e.g. to provide one dashboard for all scanning steps of a pipeline
+type ScanSummary struct {
+	Title        string
+	PipelineLink string
+	Reports      []ScanReport
+}
+
+const summaryHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head>
+	<title>{{.Title}}</title>
+` + reportHTMLStyle + `</head>
+<body>
+	<h1>{{.Title}}</h1>
+	{{if .PipelineLink}}<p>As listed results might be incomplete, it is crucial that you check the detailed <a href="{{.PipelineLink}}">pipeline</a> status.</p>{{end}}
+	<table>
+	<tr>
+		<th>Scan</th>
+		<th>Result</th>
+		<th>Overview</th>
+		<th>Snapshot taken</th>
+	</tr>
+	{{range $i, $r := .Reports}}
+	<tr>
+		<td><a href="#scan-{{inc $i}}">{{$r.Title}}</a></td>
+		{{if $r.SuccessfulScan}}<td class="green-cell">successful</td>{{else}}<td class="red-cell">failed</td>{{end}}
+		<td>
+		{{- range $o := $r.Overview}}
+		{{drawOverviewRow $o}}<br />
+		{{- end}}
+		</td>
+		<td>{{reportTime $r.ReportTime}}</td>
+	</tr>
+	{{else}}
+	<tr><td colspan="4">No scan reports available</td></tr>
+	{{- end}}
+	</table>
+	{{range $i, $r := .Reports}}
+	<hr />
+	<div id="scan-{{inc $i}}">
+{{template "scanReport" $r}}	</div>
+	{{- end}}
+</body>
+</html>
+`
+
+// ToHTML creates a HTML dashboard containing an overview of all scans followed by the individual reports
+func (s ScanSummary) ToHTML() ([]byte, error) {
+	report := []byte{}
+	tmpl, err := template.New("summary").Funcs(reportHTMLFuncMap()).Parse(summaryHTMLTemplate + reportHTMLBodyTemplate)
+	if err != nil {
+		return report, errors.Wrap(err, "failed to create HTML summary template")
+	}
+	buf := new(bytes.Buffer)
+	err = tmpl.Execute(buf, s)
+	if err != nil {
+		return report, errors.Wrap(err, "failed to execute HTML summary template")
+	}
+	return buf.Bytes(), nil
+}
+
+// JUnitTestSuites is the root element of a JUnit XML report
+type JUnitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr,omitempty"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	TestSuites []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite contains the test cases of one scan
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase represents one check of a scan
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+}
+
+// JUnitFailure describes why a check failed
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ToJUnit creates a JUnit XML version of the summary.
+// Each scan is represented by a test suite containing the overall scan result and one test case per overview row.
+// Overview rows highlighted in red are reported as failed test cases.
+func (s ScanSummary) ToJUnit() ([]byte, error) {
+	suites := JUnitTestSuites{Name: s.Title, TestSuites: []JUnitTestSuite{}}
+	for _, report := range s.Reports {
+		suite := JUnitTestSuite{Name: report.Title(), TestCases: []JUnitTestCase{}}
+		if !report.ReportTime.IsZero() {
+			suite.Timestamp = report.ReportTime.UTC().Format("2006-01-02T15:04:05")
+		}
+		className := report.StepName
+		if len(className) == 0 {
+			className = report.Title()
+		}
+
+		result := JUnitTestCase{Name: "Scan result", ClassName: className}
+		if !report.SuccessfulScan {
+			result.Failure = &JUnitFailure{Message: fmt.Sprintf("%v was not successful", report.Title()), Text: overviewText(report.Overview)}
+		}
+		suite.TestCases = append(suite.TestCases, result)
+
+		for _, row := range report.Overview {
+			testCase := JUnitTestCase{Name: row.Description, ClassName: className}
+			if row.Style == Red {
+				testCase.Failure = &JUnitFailure{Message: drawOverviewRow(row), Text: row.Details}
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+
+		for _, testCase := range suite.TestCases {
+			if testCase.Failure != nil {
+				suite.Failures++
+			}
+		}
+		suite.Tests = len(suite.TestCases)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.TestSuites = append(suites.TestSuites, suite)
+	}
+
+	content, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal JUnit report")
+	}
+	return append([]byte(xml.Header), content...), nil
+}
+
+func overviewText(rows []OverviewRow) string {
+	lines := []string{}
+	for _, row := range rows {
+		lines = append(lines, drawOverviewRow(row))
+	}
+	return strings.Join(lines, "\n")
+}
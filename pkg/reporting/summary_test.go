@@ -0,0 +1,97 @@
+//go:build unit
+// +build unit
+
+package reporting
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var summaryReports = []ScanReport{
+	{
+		StepName:    "checkmarxExecuteScan",
+		ReportTitle: "Checkmarx SAST Report",
+		Overview: []OverviewRow{
+			{Description: "High issues: 2 (threshold 0)", Style: Red},
+			{Description: "Medium issues: 1"},
+		},
+		ReportTime: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		DetailTable: ScanDetailTable{
+			Headers: []string{"KPI", "Count"},
+			Rows:    []ScanRow{{Columns: []ScanCell{{Content: "High issues"}, {Content: "2"}}}},
+		},
+	},
+	{
+		ReportTitle:    "Whitesource Security Vulnerability Report",
+		Overview:       []OverviewRow{{Description: "Total number of vulnerabilities", Details: "0"}},
+		SuccessfulScan: true,
+	},
+}
+
+func TestScanSummaryToHTML(t *testing.T) {
+	t.Run("with reports", func(t *testing.T) {
+		summary := ScanSummary{Title: "Scan Summary", PipelineLink: "https://jenkins.example.org/job/1", Reports: summaryReports}
+
+		res, err := summary.ToHTML()
+		result := string(res)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "<h1>Scan Summary</h1>")
+		assert.Contains(t, result, `<a href="https://jenkins.example.org/job/1">pipeline</a>`)
+		assert.Contains(t, result, `<td><a href="#scan-1">Checkmarx SAST Report</a></td>
+		<td class="red-cell">failed</td>`)
+		assert.Contains(t, result, `<td><a href="#scan-2">Whitesource Security Vulnerability Report</a></td>
+		<td class="green-cell">successful</td>`)
+		assert.Contains(t, result, "High issues: 2 (threshold 0)<br />")
+		assert.Contains(t, result, "Total number of vulnerabilities: 0<br />")
+		// details of the individual reports
+		assert.Contains(t, result, `<div id="scan-1">`)
+		assert.Contains(t, result, "<h1>Checkmarx SAST Report</h1>")
+		assert.Contains(t, result, "<th>KPI</th>")
+		assert.Contains(t, result, "<td>High issues</td>")
+		assert.Contains(t, result, "<h1>Whitesource Security Vulnerability Report</h1>")
+	})
+
+	t.Run("without reports", func(t *testing.T) {
+		summary := ScanSummary{Title: "Scan Summary"}
+
+		res, err := summary.ToHTML()
+		assert.NoError(t, err)
+		assert.Contains(t, string(res), `<td colspan="4">No scan reports available</td>`)
+		assert.NotContains(t, string(res), "pipeline</a>")
+	})
+}
+
+func TestScanSummaryToJUnit(t *testing.T) {
+	summary := ScanSummary{Title: "Scan Summary", Reports: summaryReports}
+
+	res, err := summary.ToJUnit()
+	assert.NoError(t, err)
+	assert.Contains(t, string(res), `<?xml version="1.0" encoding="UTF-8"?>`)
+
+	suites := JUnitTestSuites{}
+	assert.NoError(t, xml.Unmarshal(res, &suites))
+	assert.Equal(t, 5, suites.Tests)
+	assert.Equal(t, 2, suites.Failures)
+	if assert.Len(t, suites.TestSuites, 2) {
+		checkmarx := suites.TestSuites[0]
+		assert.Equal(t, "Checkmarx SAST Report", checkmarx.Name)
+		assert.Equal(t, "2021-01-01T00:00:00", checkmarx.Timestamp)
+		assert.Equal(t, 3, checkmarx.Tests)
+		assert.Equal(t, 2, checkmarx.Failures)
+		assert.Equal(t, "Scan result", checkmarx.TestCases[0].Name)
+		assert.Equal(t, "checkmarxExecuteScan", checkmarx.TestCases[0].ClassName)
+		assert.Equal(t, "Checkmarx SAST Report was not successful", checkmarx.TestCases[0].Failure.Message)
+		assert.Equal(t, "High issues: 2 (threshold 0)", checkmarx.TestCases[1].Failure.Message)
+		assert.Nil(t, checkmarx.TestCases[2].Failure)
+
+		whitesource := suites.TestSuites[1]
+		assert.Empty(t, whitesource.Timestamp)
+		assert.Equal(t, 2, whitesource.Tests)
+		assert.Equal(t, 0, whitesource.Failures)
+		assert.Equal(t, "Whitesource Security Vulnerability Report", whitesource.TestCases[1].ClassName)
+	}
+}
@@ -0,0 +1,146 @@
+package sbom
+
+import (
+	"slices"
+	"sort"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/SAP/jenkins-library/pkg/versioning"
+)
+
+// Diff contains the changes of the components between two SBOMs, e.g. the SBOMs of two releases
+type Diff struct {
+	Added          []ComponentChange `json:"added"`
+	Removed        []ComponentChange `json:"removed"`
+	Upgraded       []ComponentChange `json:"upgraded"`
+	Downgraded     []ComponentChange `json:"downgraded"`
+	LicenseChanges []ComponentChange `json:"licenseChanges"`
+}
+
+// ComponentChange describes the change of one component
+type ComponentChange struct {
+	Name             string   `json:"name"`
+	PackageURL       string   `json:"purl,omitempty"`
+	PreviousVersion  string   `json:"previousVersion,omitempty"`
+	Version          string   `json:"version,omitempty"`
+	PreviousLicenses []string `json:"previousLicenses,omitempty"`
+	Licenses         []string `json:"licenses,omitempty"`
+}
+
+// HasChanges returns true if any component has been added, removed or changed
+func (d Diff) HasChanges() bool {
+	return len(d.Added)+len(d.Removed)+len(d.Upgraded)+len(d.Downgraded)+len(d.LicenseChanges) > 0
+}
+
+// Compare determines the components added, removed, up- or downgraded and the components with changed licenses.
+// Components are matched by type, namespace and name of their package URL (or by group and name) independent of their version.
+// If a package is contained in several versions, a version change is only reported if exactly one version has been replaced.
+func Compare(previous, current *cdx.BOM) Diff {
+	diff := Diff{
+		Added:          []ComponentChange{},
+		Removed:        []ComponentChange{},
+		Upgraded:       []ComponentChange{},
+		Downgraded:     []ComponentChange{},
+		LicenseChanges: []ComponentChange{},
+	}
+	previousPackages := packagesByKey(previous)
+	currentPackages := packagesByKey(current)
+
+	keys := []string{}
+	for key := range previousPackages {
+		keys = append(keys, key)
+	}
+	for key := range currentPackages {
+		if _, ok := previousPackages[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		previousVersions := previousPackages[key]
+		currentVersions := currentPackages[key]
+
+		removed := []cdx.Component{}
+		for _, version := range sortedVersions(previousVersions) {
+			if currentComponent, ok := currentVersions[version]; ok {
+				diff.addLicenseChange(key, previousVersions[version], currentComponent)
+			} else {
+				removed = append(removed, previousVersions[version])
+			}
+		}
+		added := []cdx.Component{}
+		for _, version := range sortedVersions(currentVersions) {
+			if _, ok := previousVersions[version]; !ok {
+				added = append(added, currentVersions[version])
+			}
+		}
+
+		if len(removed) == 1 && len(added) == 1 {
+			change := newComponentChange(key, &removed[0], &added[0])
+			if versioning.CompareGenericVersions(removed[0].Version, added[0].Version) > 0 {
+				diff.Downgraded = append(diff.Downgraded, change)
+			} else {
+				diff.Upgraded = append(diff.Upgraded, change)
+			}
+			diff.addLicenseChange(key, removed[0], added[0])
+			continue
+		}
+		for i := range removed {
+			diff.Removed = append(diff.Removed, newComponentChange(key, &removed[i], nil))
+		}
+		for i := range added {
+			diff.Added = append(diff.Added, newComponentChange(key, nil, &added[i]))
+		}
+	}
+	return diff
+}
+
+func (d *Diff) addLicenseChange(key string, previous, current cdx.Component) {
+	if !slices.Equal(Licenses(previous), Licenses(current)) {
+		d.LicenseChanges = append(d.LicenseChanges, newComponentChange(key, &previous, &current))
+	}
+}
+
+func newComponentChange(key string, previous, current *cdx.Component) ComponentChange {
+	change := ComponentChange{Name: key}
+	if previous != nil {
+		change.Name = packageName(*previous)
+		change.PackageURL = previous.PackageURL
+		change.PreviousVersion = previous.Version
+		change.PreviousLicenses = Licenses(*previous)
+	}
+	if current != nil {
+		change.Name = packageName(*current)
+		change.PackageURL = current.PackageURL
+		change.Version = current.Version
+		change.Licenses = Licenses(*current)
+	}
+	return change
+}
+
+// packagesByKey groups the components by their package key and version
+func packagesByKey(bom *cdx.BOM) map[string]map[string]cdx.Component {
+	packages := map[string]map[string]cdx.Component{}
+	for _, component := range Components(bom) {
+		key := packageKey(component)
+		if _, ok := packages[key]; !ok {
+			packages[key] = map[string]cdx.Component{}
+		}
+		if _, ok := packages[key][component.Version]; !ok {
+			packages[key][component.Version] = component
+		}
+	}
+	return packages
+}
+
+func sortedVersions(components map[string]cdx.Component) []string {
+	versions := []string{}
+	for version := range components {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versioning.CompareGenericVersions(versions[i], versions[j]) < 0
+	})
+	return versions
+}
@@ -0,0 +1,96 @@
+//go:build unit
+// +build unit
+
+package sbom
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/stretchr/testify/assert"
+)
+
+func license(id string) *cdx.Licenses {
+	return &cdx.Licenses{{License: &cdx.License{ID: id}}}
+}
+
+func TestCompare(t *testing.T) {
+	previous := &cdx.BOM{
+		Metadata: &cdx.Metadata{Component: &cdx.Component{Name: "product", Version: "1.0.0"}},
+		Components: &[]cdx.Component{
+			{Group: "org.apache.commons", Name: "commons-text", Version: "1.9", PackageURL: "pkg:maven/org.apache.commons/commons-text@1.9?type=jar", Licenses: license("Apache-2.0")},
+			{Name: "lodash", Version: "4.17.21", PackageURL: "pkg:npm/lodash@4.17.21", Licenses: license("MIT")},
+			{Name: "left-pad", Version: "1.3.0", PackageURL: "pkg:npm/left-pad@1.3.0"},
+			{Name: "minimist", Version: "1.2.6", PackageURL: "pkg:npm/minimist@1.2.6"},
+			{Name: "internal-lib", Version: "2.0.0", Licenses: license("Proprietary")},
+			{Name: "semver", Version: "5.7.1", PackageURL: "pkg:npm/semver@5.7.1"},
+		},
+	}
+	current := &cdx.BOM{
+		Metadata: &cdx.Metadata{Component: &cdx.Component{Name: "product", Version: "2.0.0"}},
+		Components: &[]cdx.Component{
+			// a different tool creates purls with different qualifiers
+			{Group: "org.apache.commons", Name: "commons-text", Version: "1.10.0", PackageURL: "pkg:maven/org.apache.commons/commons-text@1.10.0", Licenses: license("Apache-2.0")},
+			{Name: "lodash", Version: "4.17.21", PackageURL: "pkg:npm/lodash@4.17.21", Licenses: license("MIT OR CC0-1.0")},
+			{Name: "minimist", Version: "1.2.5", PackageURL: "pkg:npm/minimist@1.2.5"},
+			{Name: "internal-lib", Version: "2.1.0", Licenses: license("Apache-2.0")},
+			{Name: "semver", Version: "5.7.1", PackageURL: "pkg:npm/semver@5.7.1"},
+			{Name: "semver", Version: "7.5.2", PackageURL: "pkg:npm/semver@7.5.2", Components: &[]cdx.Component{
+				{Name: "lru-cache", Version: "6.0.0", PackageURL: "pkg:npm/lru-cache@6.0.0"},
+			}},
+		},
+	}
+
+	diff := Compare(previous, current)
+
+	assert.True(t, diff.HasChanges())
+	assert.Equal(t, []ComponentChange{
+		{Name: "lru-cache", PackageURL: "pkg:npm/lru-cache@6.0.0", Version: "6.0.0", Licenses: []string{}},
+		{Name: "semver", PackageURL: "pkg:npm/semver@7.5.2", Version: "7.5.2", Licenses: []string{}},
+	}, diff.Added)
+	assert.Equal(t, []ComponentChange{
+		{Name: "left-pad", PackageURL: "pkg:npm/left-pad@1.3.0", PreviousVersion: "1.3.0", PreviousLicenses: []string{}},
+	}, diff.Removed)
+	assert.Equal(t, []ComponentChange{
+		{Name: "internal-lib", PreviousVersion: "2.0.0", Version: "2.1.0", PreviousLicenses: []string{"Proprietary"}, Licenses: []string{"Apache-2.0"}},
+		{Name: "org.apache.commons/commons-text", PackageURL: "pkg:maven/org.apache.commons/commons-text@1.10.0", PreviousVersion: "1.9", Version: "1.10.0", PreviousLicenses: []string{"Apache-2.0"}, Licenses: []string{"Apache-2.0"}},
+	}, diff.Upgraded)
+	assert.Equal(t, []ComponentChange{
+		{Name: "minimist", PackageURL: "pkg:npm/minimist@1.2.5", PreviousVersion: "1.2.6", Version: "1.2.5", PreviousLicenses: []string{}, Licenses: []string{}},
+	}, diff.Downgraded)
+	assert.Equal(t, []ComponentChange{
+		{Name: "internal-lib", PreviousVersion: "2.0.0", Version: "2.1.0", PreviousLicenses: []string{"Proprietary"}, Licenses: []string{"Apache-2.0"}},
+		{Name: "lodash", PackageURL: "pkg:npm/lodash@4.17.21", PreviousVersion: "4.17.21", Version: "4.17.21", PreviousLicenses: []string{"MIT"}, Licenses: []string{"MIT OR CC0-1.0"}},
+	}, diff.LicenseChanges)
+
+	t.Run("no changes", func(t *testing.T) {
+		assert.False(t, Compare(current, current).HasChanges())
+	})
+}
+
+func TestCreateDiffReport(t *testing.T) {
+	diff := Diff{
+		Added:          []ComponentChange{{Name: "lru-cache", Version: "6.0.0", Licenses: []string{"ISC"}}},
+		Upgraded:       []ComponentChange{{Name: "commons-text", PreviousVersion: "1.9", Version: "1.10.0"}},
+		LicenseChanges: []ComponentChange{{Name: "lodash", PreviousVersion: "4.17.21", Version: "4.17.21", PreviousLicenses: []string{"MIT"}, Licenses: []string{"MIT", "CC0-1.0"}}},
+	}
+
+	report := CreateDiffReport(diff, "sbomMerge", "previous/bom.xml")
+
+	assert.Equal(t, "sbomMerge", report.StepName)
+	assert.Equal(t, "previous/bom.xml", report.Subheaders[0].Details)
+	assert.Equal(t, reporting.OverviewRow{Description: "Components with changed licenses", Details: "1", Style: reporting.Yellow}, report.Overview[4])
+	if assert.Len(t, report.DetailTable.Rows, 3) {
+		assert.Equal(t, []reporting.ScanCell{
+			{Content: "added", Style: reporting.Green},
+			{Content: "lru-cache"},
+			{Content: ""},
+			{Content: "6.0.0"},
+			{Content: ""},
+			{Content: "ISC"},
+		}, report.DetailTable.Rows[0].Columns)
+		assert.Equal(t, "upgraded", report.DetailTable.Rows[1].Columns[0].Content)
+		assert.Equal(t, "MIT, CC0-1.0", report.DetailTable.Rows[2].Columns[5].Content)
+	}
+}
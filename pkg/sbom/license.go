@@ -0,0 +1,339 @@
+package sbom
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/package-url/packageurl-go"
+)
+
+// LicenseDecision is the result of evaluating a license against a license policy
+type LicenseDecision string
+
+// Possible license decisions, ordered from the most to the least permissive one
+const (
+	LicenseAllow  LicenseDecision = "allow"
+	LicenseReview LicenseDecision = "review"
+	LicenseDeny   LicenseDecision = "deny"
+)
+
+func (d LicenseDecision) rank() int {
+	switch d {
+	case LicenseAllow:
+		return 0
+	case LicenseReview:
+		return 1
+	}
+	return 2
+}
+
+// LicensePolicy defines which licenses are allowed, denied or need to be reviewed.
+// The entries are SPDX license identifiers or license names which are compared case-insensitively, `*` can be used as wildcard.
+type LicensePolicy struct {
+	Allow  []string `json:"allow,omitempty"`
+	Review []string `json:"review,omitempty"`
+	Deny   []string `json:"deny,omitempty"`
+	// Default is the decision for licenses not listed in the policy, licenses need to be reviewed by default
+	Default LicenseDecision `json:"default,omitempty"`
+	// MissingLicense is the decision for components without license information, they need to be reviewed by default
+	MissingLicense LicenseDecision `json:"missingLicense,omitempty"`
+	// Exceptions lists components which are allowed independent of their licenses
+	Exceptions []LicenseException `json:"exceptions,omitempty"`
+}
+
+// LicenseException allows the components matching the package URL, the version is optional
+type LicenseException struct {
+	Purl   string `json:"purl"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// LicenseFinding is the result of the license check of one component
+type LicenseFinding struct {
+	Component cdx.Component
+	// License contains the licenses of the component, several licenses are combined with AND
+	License  string
+	Decision LicenseDecision
+	Reason   string
+	// Direct is true if the component is a direct dependency of the SBOM's metadata component, it is nil if the SBOM has no dependency graph
+	Direct *bool
+}
+
+// Validate checks the decisions configured in the policy and sets the defaults
+func (p *LicensePolicy) Validate() error {
+	if len(p.Default) == 0 {
+		p.Default = LicenseReview
+	}
+	if len(p.MissingLicense) == 0 {
+		p.MissingLicense = LicenseReview
+	}
+	for _, decision := range []LicenseDecision{p.Default, p.MissingLicense} {
+		if decision != LicenseAllow && decision != LicenseReview && decision != LicenseDeny {
+			return fmt.Errorf("invalid license decision '%v', supported decisions are %v, %v and %v", decision, LicenseAllow, LicenseReview, LicenseDeny)
+		}
+	}
+	for _, exception := range p.Exceptions {
+		if _, err := packageurl.FromString(exception.Purl); err != nil {
+			return fmt.Errorf("invalid package URL '%v' in license exceptions: %w", exception.Purl, err)
+		}
+	}
+	return nil
+}
+
+// Evaluate returns the decision for an SPDX license expression.
+// For alternatives (OR) the most permissive decision applies since the license can be chosen, for combinations (AND) the most restrictive one.
+func (p LicensePolicy) Evaluate(expression string) (LicenseDecision, error) {
+	parser := expressionParser{tokens: tokenizeExpression(expression), policy: p}
+	if len(parser.tokens) == 0 {
+		return p.MissingLicense, nil
+	}
+	decision, err := parser.parseOr()
+	if err != nil {
+		return LicenseDeny, fmt.Errorf("invalid license expression '%v': %w", expression, err)
+	}
+	if parser.position < len(parser.tokens) {
+		return LicenseDeny, fmt.Errorf("invalid license expression '%v': unexpected '%v'", expression, parser.tokens[parser.position])
+	}
+	return decision, nil
+}
+
+// license returns the decision for a single license.
+// Entries without wildcard take precedence, otherwise deny entries take precedence over review and allow entries.
+func (p LicensePolicy) license(license string) LicenseDecision {
+	if decision, ok := p.exactLicense(license); ok {
+		return decision
+	}
+	for _, entry := range p.entries() {
+		for _, pattern := range entry.patterns {
+			if matched, _ := path.Match(strings.ToLower(pattern), strings.ToLower(license)); matched {
+				return entry.decision
+			}
+		}
+	}
+	return p.Default
+}
+
+func (p LicensePolicy) exactLicense(license string) (LicenseDecision, bool) {
+	for _, entry := range p.entries() {
+		for _, pattern := range entry.patterns {
+			if strings.EqualFold(pattern, license) {
+				return entry.decision, true
+			}
+		}
+	}
+	return "", false
+}
+
+type policyEntry struct {
+	decision LicenseDecision
+	patterns []string
+}
+
+func (p LicensePolicy) entries() []policyEntry {
+	return []policyEntry{{LicenseDeny, p.Deny}, {LicenseReview, p.Review}, {LicenseAllow, p.Allow}}
+}
+
+func (p LicensePolicy) exception(component cdx.Component) *LicenseException {
+	purl, err := packageurl.FromString(component.PackageURL)
+	if err != nil {
+		return nil
+	}
+	for i, exception := range p.Exceptions {
+		exceptionPurl, err := packageurl.FromString(exception.Purl)
+		if err != nil {
+			continue
+		}
+		if exceptionPurl.Type == purl.Type && exceptionPurl.Namespace == purl.Namespace && exceptionPurl.Name == purl.Name &&
+			(len(exceptionPurl.Version) == 0 || exceptionPurl.Version == purl.Version) {
+			return &p.Exceptions[i]
+		}
+	}
+	return nil
+}
+
+// CheckLicenses evaluates the licenses of all components of the SBOM against the policy
+func CheckLicenses(bom *cdx.BOM, policy LicensePolicy) []LicenseFinding {
+	direct := directDependencies(bom)
+	findings := []LicenseFinding{}
+	for _, component := range Components(bom) {
+		finding := LicenseFinding{Component: component, License: LicenseExpression(component)}
+		if direct != nil {
+			isDirect := direct[component.BOMRef]
+			finding.Direct = &isDirect
+		}
+		if exception := policy.exception(component); exception != nil {
+			finding.Decision = LicenseAllow
+			finding.Reason = "exception"
+			if len(exception.Reason) > 0 {
+				finding.Reason = fmt.Sprintf("exception: %v", exception.Reason)
+			}
+		} else if len(finding.License) == 0 {
+			finding.Decision = policy.MissingLicense
+			finding.Reason = "no license information"
+		} else {
+			finding.Decision, finding.Reason = policy.evaluateComponent(component)
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// evaluateComponent combines the decisions of all licenses of a component with AND
+func (p LicensePolicy) evaluateComponent(component cdx.Component) (LicenseDecision, string) {
+	result := LicenseAllow
+	reasons := []string{}
+	for _, choice := range *component.Licenses {
+		decision := LicenseAllow
+		switch {
+		case len(choice.Expression) > 0:
+			var err error
+			if decision, err = p.Evaluate(choice.Expression); err != nil {
+				decision = LicenseReview
+				reasons = append(reasons, err.Error())
+			}
+		case choice.License != nil && len(choice.License.ID) > 0:
+			decision = p.license(choice.License.ID)
+		case choice.License != nil && len(choice.License.Name) > 0:
+			// some tools write SPDX expressions into the name
+			var err error
+			if decision, err = p.Evaluate(choice.License.Name); err != nil {
+				decision = p.license(choice.License.Name)
+			}
+		}
+		if decision.rank() > result.rank() {
+			result = decision
+		}
+	}
+	return result, strings.Join(reasons, ", ")
+}
+
+// LicenseExpression combines the licenses of a component into one SPDX expression, e.g. for reporting
+func LicenseExpression(component cdx.Component) string {
+	licenses := Licenses(component)
+	if len(licenses) == 1 {
+		return licenses[0]
+	}
+	expressions := []string{}
+	for _, license := range licenses {
+		if strings.Contains(license, " ") && !(strings.HasPrefix(license, "(") && strings.HasSuffix(license, ")")) {
+			license = fmt.Sprintf("(%v)", license)
+		}
+		expressions = append(expressions, license)
+	}
+	return strings.Join(expressions, " AND ")
+}
+
+// directDependencies returns the references of the components the metadata component depends on
+func directDependencies(bom *cdx.BOM) map[string]bool {
+	if bom == nil || bom.Dependencies == nil || bom.Metadata == nil || bom.Metadata.Component == nil || len(bom.Metadata.Component.BOMRef) == 0 {
+		return nil
+	}
+	direct := map[string]bool{}
+	for _, dependency := range *bom.Dependencies {
+		if dependency.Ref != bom.Metadata.Component.BOMRef || dependency.Dependencies == nil {
+			continue
+		}
+		for _, dependsOn := range *dependency.Dependencies {
+			direct[dependsOn.Ref] = true
+		}
+	}
+	return direct
+}
+
+func tokenizeExpression(expression string) []string {
+	expression = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression)
+	return strings.Fields(expression)
+}
+
+// expressionParser evaluates SPDX license expressions, see https://spdx.github.io/spdx-spec/v2.3/SPDX-license-expressions/
+type expressionParser struct {
+	tokens   []string
+	position int
+	policy   LicensePolicy
+}
+
+func (e *expressionParser) parseOr() (LicenseDecision, error) {
+	decision, err := e.parseAnd()
+	if err != nil {
+		return decision, err
+	}
+	for e.accept("OR") {
+		alternative, err := e.parseAnd()
+		if err != nil {
+			return alternative, err
+		}
+		if alternative.rank() < decision.rank() {
+			decision = alternative
+		}
+	}
+	return decision, nil
+}
+
+func (e *expressionParser) parseAnd() (LicenseDecision, error) {
+	decision, err := e.parseLicense()
+	if err != nil {
+		return decision, err
+	}
+	for e.accept("AND") {
+		other, err := e.parseLicense()
+		if err != nil {
+			return other, err
+		}
+		if other.rank() > decision.rank() {
+			decision = other
+		}
+	}
+	return decision, nil
+}
+
+func (e *expressionParser) parseLicense() (LicenseDecision, error) {
+	if e.position >= len(e.tokens) {
+		return LicenseDeny, fmt.Errorf("unexpected end")
+	}
+	if e.accept("(") {
+		decision, err := e.parseOr()
+		if err != nil {
+			return decision, err
+		}
+		if !e.accept(")") {
+			return LicenseDeny, fmt.Errorf("missing ')'")
+		}
+		return decision, nil
+	}
+
+	license := e.tokens[e.position]
+	if isOperator(license) {
+		return LicenseDeny, fmt.Errorf("unexpected '%v'", license)
+	}
+	e.position++
+	if e.accept("WITH") {
+		if e.position >= len(e.tokens) || isOperator(e.tokens[e.position]) {
+			return LicenseDeny, fmt.Errorf("missing license exception")
+		}
+		exception := e.tokens[e.position]
+		e.position++
+		// a policy entry for the license including the exception takes precedence
+		if decision, ok := e.policy.exactLicense(fmt.Sprintf("%v WITH %v", license, exception)); ok {
+			return decision, nil
+		}
+	}
+	return e.policy.license(license), nil
+}
+
+func (e *expressionParser) accept(token string) bool {
+	if e.position < len(e.tokens) && strings.EqualFold(e.tokens[e.position], token) {
+		e.position++
+		return true
+	}
+	return false
+}
+
+func isOperator(token string) bool {
+	for _, operator := range []string{"AND", "OR", "WITH", "(", ")"} {
+		if strings.EqualFold(token, operator) {
+			return true
+		}
+	}
+	return false
+}
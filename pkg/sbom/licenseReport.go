@@ -0,0 +1,126 @@
+package sbom
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/reporting"
+)
+
+// Title returns the title of the policy violation, e.g. for a GitHub issue
+func (f LicenseFinding) Title() string {
+	license := f.License
+	if len(license) == 0 {
+		license = "no license"
+	}
+	return fmt.Sprintf("Policy Violation %v %v", license, packageName(f.Component))
+}
+
+// ToMarkdown returns the policy violation in markdown format
+func (f LicenseFinding) ToMarkdown() ([]byte, error) {
+	policyReport := reporting.PolicyViolationReport{
+		ArtifactID:  f.Component.Name,
+		Description: f.description(),
+		Group:       f.Component.Group,
+		Version:     f.Component.Version,
+		PackageURL:  f.Component.PackageURL,
+	}
+	if f.Direct != nil {
+		policyReport.DirectDependency = fmt.Sprint(*f.Direct)
+	}
+	return policyReport.ToMarkdown()
+}
+
+// ToTxt returns the textual representation of the policy violation
+func (f LicenseFinding) ToTxt() string {
+	return fmt.Sprintf(`Policy Violation
+Package: %v
+Installed Version: %v
+Package URL: %v
+Description: %v`,
+		packageName(f.Component),
+		f.Component.Version,
+		f.Component.PackageURL,
+		f.description(),
+	)
+}
+
+func (f LicenseFinding) description() string {
+	if len(f.License) == 0 {
+		return fmt.Sprintf("The component has no license information, license policy decision: %v.", f.Decision)
+	}
+	description := fmt.Sprintf("The license `%v` of the component violates the license policy, license policy decision: %v.", f.License, f.Decision)
+	if len(f.Reason) > 0 {
+		description += fmt.Sprintf(" %v", f.Reason)
+	}
+	return description
+}
+
+// CountLicenseDecisions returns the number of findings per license decision
+func CountLicenseDecisions(findings []LicenseFinding) map[LicenseDecision]int {
+	counts := map[LicenseDecision]int{}
+	for _, finding := range findings {
+		counts[finding.Decision]++
+	}
+	return counts
+}
+
+// CreateLicenseReport creates a report of the license check, the detail table lists the components which are not allowed
+func CreateLicenseReport(findings []LicenseFinding, stepName string, sbomFiles []string, failOnReview bool) reporting.ScanReport {
+	counts := CountLicenseDecisions(findings)
+	report := reporting.ScanReport{
+		StepName:    stepName,
+		ReportTitle: "License Compliance Report",
+		Overview: []reporting.OverviewRow{
+			{Description: "Components checked", Details: fmt.Sprint(len(findings))},
+			{Description: "Denied licenses", Details: fmt.Sprint(counts[LicenseDeny])},
+			{Description: "Licenses to be reviewed", Details: fmt.Sprint(counts[LicenseReview])},
+			{Description: "Allowed licenses", Details: fmt.Sprint(counts[LicenseAllow])},
+		},
+		ReportTime: time.Now(),
+		DetailTable: reporting.ScanDetailTable{
+			Headers:       []string{"Decision", "Component", "Version", "License", "Dependency", "Remark"},
+			NoRowsMessage: "No license policy violations",
+			WithCounter:   true,
+			CounterHeader: "Entry #",
+		},
+		SuccessfulScan: counts[LicenseDeny] == 0 && (!failOnReview || counts[LicenseReview] == 0),
+	}
+	for _, file := range sbomFiles {
+		report.Subheaders = append(report.Subheaders, reporting.Subheader{Description: "SBOM", Details: file})
+	}
+	if counts[LicenseDeny] > 0 {
+		report.Overview[1].Style = reporting.Red
+	}
+	if counts[LicenseReview] > 0 {
+		report.Overview[2].Style = reporting.Yellow
+	}
+
+	for _, decision := range []LicenseDecision{LicenseDeny, LicenseReview} {
+		style := reporting.ColumnStyle(reporting.Red)
+		if decision == LicenseReview {
+			style = reporting.Yellow
+		}
+		for _, finding := range findings {
+			if finding.Decision != decision {
+				continue
+			}
+			dependency := ""
+			if finding.Direct != nil {
+				dependency = "indirect"
+				if *finding.Direct {
+					dependency = "direct"
+				}
+			}
+			row := reporting.ScanRow{}
+			row.AddColumn(decision, style)
+			row.AddColumn(packageName(finding.Component), 0)
+			row.AddColumn(finding.Component.Version, 0)
+			row.AddColumn(finding.License, 0)
+			row.AddColumn(dependency, 0)
+			row.AddColumn(finding.Reason, 0)
+			report.DetailTable.Rows = append(report.DetailTable.Rows, row)
+		}
+	}
+	return report
+}
@@ -0,0 +1,175 @@
+//go:build unit
+// +build unit
+
+package sbom
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/stretchr/testify/assert"
+)
+
+var testLicensePolicy = LicensePolicy{
+	Allow:  []string{"MIT", "Apache-2.0", "BSD-*", "GPL-2.0-only WITH Classpath-exception-2.0"},
+	Review: []string{"LGPL-*", "MPL-2.0"},
+	Deny:   []string{"GPL-*", "AGPL-3.0-only"},
+	Exceptions: []LicenseException{
+		{Purl: "pkg:npm/gpl-tool", Reason: "build tool, not shipped"},
+	},
+}
+
+func TestLicensePolicyValidate(t *testing.T) {
+	policy := LicensePolicy{}
+	assert.NoError(t, policy.Validate())
+	assert.Equal(t, LicenseReview, policy.Default)
+	assert.Equal(t, LicenseReview, policy.MissingLicense)
+
+	policy = LicensePolicy{Default: "ignore"}
+	assert.EqualError(t, policy.Validate(), "invalid license decision 'ignore', supported decisions are allow, review and deny")
+
+	policy = LicensePolicy{Exceptions: []LicenseException{{Purl: "lodash"}}}
+	assert.ErrorContains(t, policy.Validate(), "invalid package URL 'lodash' in license exceptions")
+}
+
+func TestLicensePolicyEvaluate(t *testing.T) {
+	policy := testLicensePolicy
+	assert.NoError(t, policy.Validate())
+
+	tests := []struct {
+		expression string
+		expected   LicenseDecision
+	}{
+		{"MIT", LicenseAllow},
+		{"mit", LicenseAllow},
+		{"BSD-3-Clause", LicenseAllow},
+		{"LGPL-2.1-only", LicenseReview},
+		{"GPL-3.0-only", LicenseDeny},
+		{"Unlicense", LicenseReview},
+		{"MIT OR GPL-3.0-only", LicenseAllow},
+		{"MIT AND GPL-3.0-only", LicenseDeny},
+		{"MIT and MPL-2.0", LicenseReview},
+		{"(MIT OR GPL-3.0-only) AND LGPL-3.0-only", LicenseReview},
+		{"GPL-3.0-only OR (Apache-2.0 AND BSD-2-Clause)", LicenseAllow},
+		{"GPL-2.0-only WITH Classpath-exception-2.0", LicenseAllow},
+		{"GPL-3.0-only WITH GCC-exception-3.1", LicenseDeny},
+		{"", LicenseReview},
+	}
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			decision, err := policy.Evaluate(test.expression)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, decision)
+		})
+	}
+
+	for _, expression := range []string{"MIT OR", "(MIT", "MIT)", "AND MIT", "MIT WITH", "Apache License 2.0"} {
+		t.Run(expression, func(t *testing.T) {
+			_, err := policy.Evaluate(expression)
+			assert.ErrorContains(t, err, "invalid license expression")
+		})
+	}
+}
+
+func TestCheckLicenses(t *testing.T) {
+	policy := testLicensePolicy
+	assert.NoError(t, policy.Validate())
+	bom := &cdx.BOM{
+		Metadata: &cdx.Metadata{Component: &cdx.Component{BOMRef: "app", Name: "app"}},
+		Components: &[]cdx.Component{
+			{BOMRef: "lodash", Name: "lodash", Version: "4.17.21", PackageURL: "pkg:npm/lodash@4.17.21", Licenses: &cdx.Licenses{{License: &cdx.License{ID: "MIT"}}}},
+			{BOMRef: "readline", Name: "readline", Version: "8.1", PackageURL: "pkg:npm/readline@8.1", Licenses: &cdx.Licenses{{Expression: "GPL-3.0-or-later"}}},
+			{BOMRef: "gpl-tool", Name: "gpl-tool", Version: "1.0.0", PackageURL: "pkg:npm/gpl-tool@1.0.0", Licenses: &cdx.Licenses{{License: &cdx.License{ID: "GPL-3.0-only"}}}},
+			{BOMRef: "dual", Name: "dual", Version: "1.0.0", Licenses: &cdx.Licenses{{License: &cdx.License{ID: "MIT"}}, {License: &cdx.License{ID: "MPL-2.0"}}}},
+			{BOMRef: "named", Name: "named", Version: "1.0.0", Licenses: &cdx.Licenses{{License: &cdx.License{Name: "(MIT OR GPL-2.0-only)"}}, {License: &cdx.License{Name: "Apache License 2.0"}}}},
+			{BOMRef: "unknown", Name: "unknown", Version: "1.0.0"},
+		},
+		Dependencies: &[]cdx.Dependency{
+			{Ref: "app", Dependencies: &[]cdx.Dependency{{Ref: "lodash"}, {Ref: "readline"}}},
+			{Ref: "readline", Dependencies: &[]cdx.Dependency{{Ref: "gpl-tool"}}},
+		},
+	}
+
+	findings := CheckLicenses(bom, policy)
+
+	if assert.Len(t, findings, 6) {
+		assert.Equal(t, LicenseAllow, findings[0].Decision)
+		assert.True(t, *findings[0].Direct)
+
+		assert.Equal(t, LicenseDeny, findings[1].Decision)
+		assert.Equal(t, "GPL-3.0-or-later", findings[1].License)
+
+		assert.Equal(t, LicenseAllow, findings[2].Decision)
+		assert.Equal(t, "exception: build tool, not shipped", findings[2].Reason)
+		assert.False(t, *findings[2].Direct)
+
+		assert.Equal(t, LicenseReview, findings[3].Decision)
+		assert.Equal(t, "MIT AND MPL-2.0", findings[3].License)
+
+		// license names which are no SPDX expressions are compared as they are, not listed licenses need to be reviewed
+		assert.Equal(t, LicenseReview, findings[4].Decision)
+		assert.Equal(t, "(MIT OR GPL-2.0-only) AND (Apache License 2.0)", findings[4].License)
+
+		assert.Equal(t, LicenseReview, findings[5].Decision)
+		assert.Equal(t, "no license information", findings[5].Reason)
+	}
+
+	t.Run("without dependency graph", func(t *testing.T) {
+		findings := CheckLicenses(&cdx.BOM{Components: &[]cdx.Component{{Name: "lodash"}}}, policy)
+
+		assert.Nil(t, findings[0].Direct)
+	})
+}
+
+func TestCreateLicenseReport(t *testing.T) {
+	direct := true
+	findings := []LicenseFinding{
+		{Component: cdx.Component{Name: "lodash", Version: "4.17.21"}, License: "MIT", Decision: LicenseAllow},
+		{Component: cdx.Component{Name: "unknown", Version: "1.0.0"}, Decision: LicenseReview, Reason: "no license information"},
+		{Component: cdx.Component{Group: "org.example", Name: "readline", Version: "8.1"}, License: "GPL-3.0-only", Decision: LicenseDeny, Direct: &direct},
+	}
+
+	report := CreateLicenseReport(findings, "sbomLicenseCheck", []string{"bom-npm.xml"}, false)
+
+	assert.False(t, report.SuccessfulScan)
+	assert.Equal(t, []reporting.Subheader{{Description: "SBOM", Details: "bom-npm.xml"}}, report.Subheaders)
+	assert.Equal(t, reporting.OverviewRow{Description: "Denied licenses", Details: "1", Style: reporting.Red}, report.Overview[1])
+	if assert.Len(t, report.DetailTable.Rows, 2) {
+		assert.Equal(t, []reporting.ScanCell{
+			{Content: "deny", Style: reporting.Red},
+			{Content: "org.example/readline"},
+			{Content: "8.1"},
+			{Content: "GPL-3.0-only"},
+			{Content: "direct"},
+			{Content: ""},
+		}, report.DetailTable.Rows[0].Columns)
+		assert.Equal(t, "review", report.DetailTable.Rows[1].Columns[0].Content)
+	}
+
+	t.Run("fail on review", func(t *testing.T) {
+		report := CreateLicenseReport(findings[:2], "sbomLicenseCheck", nil, true)
+		assert.False(t, report.SuccessfulScan)
+
+		report = CreateLicenseReport(findings[:2], "sbomLicenseCheck", nil, false)
+		assert.True(t, report.SuccessfulScan)
+	})
+}
+
+func TestLicenseFindingMarkdown(t *testing.T) {
+	direct := false
+	finding := LicenseFinding{
+		Component: cdx.Component{Group: "org.example", Name: "readline", Version: "8.1", PackageURL: "pkg:maven/org.example/readline@8.1"},
+		License:   "GPL-3.0-only",
+		Decision:  LicenseDeny,
+		Direct:    &direct,
+	}
+
+	assert.Equal(t, "Policy Violation GPL-3.0-only org.example/readline", finding.Title())
+	markdown, err := finding.ToMarkdown()
+	assert.NoError(t, err)
+	assert.Contains(t, string(markdown), "# Policy Violation - pkg:maven/org.example/readline@8.1")
+	assert.Contains(t, string(markdown), "The license `GPL-3.0-only` of the component violates the license policy, license policy decision: deny.")
+	assert.Contains(t, string(markdown), "**Dependency:** indirect")
+	assert.Contains(t, finding.ToTxt(), "Package URL: pkg:maven/org.example/readline@8.1")
+}
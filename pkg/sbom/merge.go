@@ -0,0 +1,173 @@
+package sbom
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/uuid"
+)
+
+// Merge aggregates the SBOMs of the parts of a product (e.g. the modules of an MTA and its container images) into one SBOM.
+// The metadata component of each SBOM becomes a component the product depends on. Components are deduplicated by their
+// package URL (or group, name and version if no package URL is available) and the dependency graphs are combined.
+func Merge(product cdx.Component, boms []*cdx.BOM, timestamp time.Time) *cdx.BOM {
+	if len(product.Type) == 0 {
+		product.Type = cdx.ComponentTypeApplication
+	}
+	if len(product.BOMRef) == 0 {
+		product.BOMRef = productRef(product)
+	}
+	product.Components = nil
+
+	m := merger{
+		components:   []cdx.Component{},
+		refsByID:     map[string]string{},
+		indexByRef:   map[string]int{},
+		dependencies: map[string][]string{},
+	}
+	m.usedRefs = map[string]bool{product.BOMRef: true}
+	for index, bom := range boms {
+		if bom == nil {
+			continue
+		}
+		refs := map[string]string{}
+		if bom.Metadata != nil && bom.Metadata.Component != nil {
+			ref := m.addComponent(*bom.Metadata.Component, index, refs)
+			m.addDependency(product.BOMRef, ref)
+		}
+		if bom.Components != nil {
+			for _, component := range *bom.Components {
+				m.addComponent(component, index, refs)
+			}
+		}
+		if bom.Dependencies != nil {
+			for _, dependency := range *bom.Dependencies {
+				ref, ok := refs[dependency.Ref]
+				if !ok {
+					continue
+				}
+				m.addDependency(ref)
+				if dependency.Dependencies == nil {
+					continue
+				}
+				for _, dependsOn := range *dependency.Dependencies {
+					if dependsOnRef, ok := refs[dependsOn.Ref]; ok {
+						m.addDependency(ref, dependsOnRef)
+					}
+				}
+			}
+		}
+	}
+
+	dependencies := []cdx.Dependency{}
+	for _, ref := range m.dependencyOrder {
+		dependency := cdx.Dependency{Ref: ref}
+		if len(m.dependencies[ref]) > 0 {
+			dependsOn := []cdx.Dependency{}
+			for _, dependsOnRef := range m.dependencies[ref] {
+				dependsOn = append(dependsOn, cdx.Dependency{Ref: dependsOnRef})
+			}
+			dependency.Dependencies = &dependsOn
+		}
+		dependencies = append(dependencies, dependency)
+	}
+
+	merged := cdx.NewBOM()
+	merged.SerialNumber = fmt.Sprintf("urn:uuid:%v", uuid.New())
+	merged.Metadata = &cdx.Metadata{
+		Timestamp: timestamp.UTC().Format(time.RFC3339),
+		Component: &product,
+	}
+	merged.Components = &m.components
+	merged.Dependencies = &dependencies
+	return merged
+}
+
+type merger struct {
+	components []cdx.Component
+	// refsByID maps the identity of a component to its reference in the merged SBOM
+	refsByID   map[string]string
+	indexByRef map[string]int
+	usedRefs   map[string]bool
+
+	dependencies    map[string][]string
+	dependencyOrder []string
+}
+
+// addComponent adds a component including its nested components and returns its reference in the merged SBOM.
+// refs collects the mapping of the references used in the source SBOM to the references of the merged SBOM.
+func (m *merger) addComponent(component cdx.Component, bomIndex int, refs map[string]string) string {
+	id := componentID(component)
+	ref, known := m.refsByID[id]
+	if known {
+		existing := &m.components[m.indexByRef[ref]]
+		if existing.Licenses == nil {
+			existing.Licenses = component.Licenses
+		}
+		if existing.Hashes == nil {
+			existing.Hashes = component.Hashes
+		}
+	} else {
+		ref = m.newRef(component, bomIndex)
+		m.refsByID[id] = ref
+		merged := component
+		merged.BOMRef = ref
+		merged.Components = nil
+		m.indexByRef[ref] = len(m.components)
+		m.components = append(m.components, merged)
+	}
+	if len(component.BOMRef) > 0 {
+		refs[component.BOMRef] = ref
+	}
+
+	if component.Components != nil {
+		for _, nested := range *component.Components {
+			m.addDependency(ref, m.addComponent(nested, bomIndex, refs))
+		}
+	}
+	return ref
+}
+
+// newRef prefers the package URL as reference since it is stable across SBOMs created by different tools
+func (m *merger) newRef(component cdx.Component, bomIndex int) string {
+	ref := component.PackageURL
+	if len(ref) == 0 {
+		ref = component.BOMRef
+	}
+	if len(ref) == 0 {
+		ref = componentID(component)
+	}
+	for suffix := 1; m.usedRefs[ref]; suffix++ {
+		// the same reference is used for different components in different SBOMs
+		ref = fmt.Sprintf("%v-%v-%v", componentID(component), bomIndex, suffix)
+	}
+	m.usedRefs[ref] = true
+	return ref
+}
+
+// addDependency registers a node of the dependency graph and optionally the nodes it depends on
+func (m *merger) addDependency(ref string, dependsOn ...string) {
+	if _, ok := m.dependencies[ref]; !ok {
+		m.dependencies[ref] = []string{}
+		m.dependencyOrder = append(m.dependencyOrder, ref)
+	}
+	for _, dependsOnRef := range dependsOn {
+		if dependsOnRef == ref || slices.Contains(m.dependencies[ref], dependsOnRef) {
+			continue
+		}
+		m.dependencies[ref] = append(m.dependencies[ref], dependsOnRef)
+		m.addDependency(dependsOnRef)
+	}
+}
+
+func productRef(product cdx.Component) string {
+	if len(product.PackageURL) > 0 {
+		return product.PackageURL
+	}
+	if len(product.Version) > 0 {
+		return fmt.Sprintf("%v@%v", packageName(product), product.Version)
+	}
+	return packageName(product)
+}
@@ -0,0 +1,79 @@
+//go:build unit
+// +build unit
+
+package sbom
+
+import (
+	"testing"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	maven, err := Decode([]byte(mavenSBOM))
+	assert.NoError(t, err)
+	npm, err := Decode([]byte(npmSBOM))
+	assert.NoError(t, err)
+	image := &cdx.BOM{
+		Metadata: &cdx.Metadata{Component: &cdx.Component{Type: cdx.ComponentTypeContainer, BOMRef: "image", Name: "registry/app", Version: "1.0.0"}},
+		Components: &[]cdx.Component{
+			// contained in the npm SBOM as well
+			{Type: cdx.ComponentTypeLibrary, BOMRef: "syft-1", Name: "lodash", Version: "4.17.21", PackageURL: "pkg:npm/lodash@4.17.21"},
+			{Type: cdx.ComponentTypeLibrary, BOMRef: "openssl", Name: "openssl", Version: "3.0.2", PackageURL: "pkg:deb/ubuntu/openssl@3.0.2",
+				Components: &[]cdx.Component{{Type: cdx.ComponentTypeLibrary, Name: "libssl", Version: "3.0.2"}}},
+			// same reference as a component of the npm SBOM
+			{Type: cdx.ComponentTypeLibrary, BOMRef: "internal", Name: "busybox", Version: "1.36.1"},
+		},
+	}
+
+	merged := Merge(cdx.Component{Group: "com.sap", Name: "product", Version: "1.0.0"}, []*cdx.BOM{maven, npm, image}, timestamp)
+
+	assert.Contains(t, merged.SerialNumber, "urn:uuid:")
+	assert.Equal(t, "2024-01-02T03:04:05Z", merged.Metadata.Timestamp)
+	assert.Equal(t, cdx.Component{Type: cdx.ComponentTypeApplication, BOMRef: "com.sap/product@1.0.0", Group: "com.sap", Name: "product", Version: "1.0.0"}, *merged.Metadata.Component)
+
+	refs := []string{}
+	for _, component := range *merged.Components {
+		refs = append(refs, component.BOMRef)
+		assert.Nil(t, component.Components)
+	}
+	assert.Equal(t, []string{
+		"pkg:maven/com.sap/backend@1.0.0?type=jar",
+		"pkg:maven/org.apache.commons/commons-text@1.9?type=jar",
+		"pkg:maven/org.apache.commons/commons-lang3@3.11?type=jar",
+		"pkg:npm/ui@1.0.0",
+		"pkg:npm/lodash@4.17.21",
+		"internal",
+		"image",
+		"pkg:deb/ubuntu/openssl@3.0.2",
+		"libssl@3.0.2",
+		"busybox@1.36.1-2-1",
+	}, refs)
+
+	assert.Equal(t, []cdx.Dependency{
+		{Ref: "com.sap/product@1.0.0", Dependencies: &[]cdx.Dependency{{Ref: "pkg:maven/com.sap/backend@1.0.0?type=jar"}, {Ref: "pkg:npm/ui@1.0.0"}, {Ref: "image"}}},
+		{Ref: "pkg:maven/com.sap/backend@1.0.0?type=jar", Dependencies: &[]cdx.Dependency{{Ref: "pkg:maven/org.apache.commons/commons-text@1.9?type=jar"}}},
+		{Ref: "pkg:maven/org.apache.commons/commons-text@1.9?type=jar", Dependencies: &[]cdx.Dependency{{Ref: "pkg:maven/org.apache.commons/commons-lang3@3.11?type=jar"}}},
+		{Ref: "pkg:maven/org.apache.commons/commons-lang3@3.11?type=jar"},
+		{Ref: "pkg:npm/ui@1.0.0", Dependencies: &[]cdx.Dependency{{Ref: "pkg:npm/lodash@4.17.21"}, {Ref: "internal"}}},
+		{Ref: "pkg:npm/lodash@4.17.21"},
+		{Ref: "internal"},
+		{Ref: "image"},
+		{Ref: "pkg:deb/ubuntu/openssl@3.0.2", Dependencies: &[]cdx.Dependency{{Ref: "libssl@3.0.2"}}},
+		{Ref: "libssl@3.0.2"},
+	}, *merged.Dependencies)
+}
+
+func TestMergeLicenses(t *testing.T) {
+	withoutLicense := &cdx.BOM{Components: &[]cdx.Component{{Name: "lodash", Version: "4.17.21", PackageURL: "pkg:npm/lodash@4.17.21"}}}
+	npm, err := Decode([]byte(npmSBOM))
+	assert.NoError(t, err)
+
+	merged := Merge(cdx.Component{Name: "product"}, []*cdx.BOM{withoutLicense, npm}, time.Now())
+
+	assert.Equal(t, "product", merged.Metadata.Component.BOMRef)
+	assert.Equal(t, []string{"MIT"}, Licenses((*merged.Components)[0]))
+}
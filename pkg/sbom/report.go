@@ -0,0 +1,57 @@
+package sbom
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/reporting"
+)
+
+// CreateDiffReport creates a report listing the component changes compared to a previous SBOM
+func CreateDiffReport(diff Diff, stepName, previousSBOM string) reporting.ScanReport {
+	report := reporting.ScanReport{
+		StepName:    stepName,
+		ReportTitle: "SBOM Comparison Report",
+		Subheaders: []reporting.Subheader{
+			{Description: "Previous SBOM", Details: previousSBOM},
+		},
+		Overview: []reporting.OverviewRow{
+			{Description: "Added components", Details: fmt.Sprint(len(diff.Added))},
+			{Description: "Removed components", Details: fmt.Sprint(len(diff.Removed))},
+			{Description: "Upgraded components", Details: fmt.Sprint(len(diff.Upgraded))},
+			{Description: "Downgraded components", Details: fmt.Sprint(len(diff.Downgraded))},
+			{Description: "Components with changed licenses", Details: fmt.Sprint(len(diff.LicenseChanges))},
+		},
+		ReportTime: time.Now(),
+		DetailTable: reporting.ScanDetailTable{
+			Headers:       []string{"Change", "Component", "Previous version", "Version", "Previous licenses", "Licenses"},
+			NoRowsMessage: "No component changes",
+			WithCounter:   true,
+			CounterHeader: "Entry #",
+		},
+		SuccessfulScan: true,
+	}
+	if len(diff.LicenseChanges) > 0 {
+		report.Overview[4].Style = reporting.Yellow
+	}
+
+	addRows := func(change string, changes []ComponentChange, style reporting.ColumnStyle) {
+		for _, c := range changes {
+			row := reporting.ScanRow{}
+			row.AddColumn(change, style)
+			row.AddColumn(c.Name, 0)
+			row.AddColumn(c.PreviousVersion, 0)
+			row.AddColumn(c.Version, 0)
+			row.AddColumn(strings.Join(c.PreviousLicenses, ", "), 0)
+			row.AddColumn(strings.Join(c.Licenses, ", "), 0)
+			report.DetailTable.Rows = append(report.DetailTable.Rows, row)
+		}
+	}
+	addRows("added", diff.Added, reporting.Green)
+	addRows("removed", diff.Removed, reporting.Grey)
+	addRows("upgraded", diff.Upgraded, 0)
+	addRows("downgraded", diff.Downgraded, reporting.Yellow)
+	addRows("license changed", diff.LicenseChanges, reporting.Yellow)
+	return report
+}
@@ -0,0 +1,121 @@
+package sbom
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/package-url/packageurl-go"
+	"github.com/pkg/errors"
+)
+
+type fileReader interface {
+	FileRead(path string) ([]byte, error)
+}
+
+// Decode parses a CycloneDX document in XML or JSON format
+func Decode(content []byte) (*cdx.BOM, error) {
+	fileFormat := cdx.BOMFileFormatXML
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '{' {
+		fileFormat = cdx.BOMFileFormatJSON
+	}
+	bom := cdx.BOM{}
+	if err := cdx.NewBOMDecoder(bytes.NewReader(content), fileFormat).Decode(&bom); err != nil {
+		return nil, err
+	}
+	return &bom, nil
+}
+
+// Encode serializes a CycloneDX document, the format is derived from the file extension of the given path (XML by default)
+func Encode(bom *cdx.BOM, path string) ([]byte, error) {
+	fileFormat := cdx.BOMFileFormatXML
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		fileFormat = cdx.BOMFileFormatJSON
+	}
+	buffer := new(bytes.Buffer)
+	encoder := cdx.NewBOMEncoder(buffer, fileFormat)
+	encoder.SetPretty(true)
+	if err := encoder.Encode(bom); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// ReadFile reads a CycloneDX document from the given file
+func ReadFile(path string, utils fileReader) (*cdx.BOM, error) {
+	content, err := utils.FileRead(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read SBOM %v", path)
+	}
+	bom, err := Decode(content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse SBOM %v", path)
+	}
+	return bom, nil
+}
+
+// Components returns all components of the SBOM including nested ones, the metadata component is not contained
+func Components(bom *cdx.BOM) []cdx.Component {
+	if bom == nil {
+		return []cdx.Component{}
+	}
+	return flattenComponents(bom.Components)
+}
+
+func flattenComponents(components *[]cdx.Component) []cdx.Component {
+	flat := []cdx.Component{}
+	if components == nil {
+		return flat
+	}
+	for _, component := range *components {
+		flat = append(flat, component)
+		flat = append(flat, flattenComponents(component.Components)...)
+	}
+	return flat
+}
+
+// Licenses returns the sorted license identifiers, names or expressions of a component
+func Licenses(component cdx.Component) []string {
+	licenses := []string{}
+	if component.Licenses == nil {
+		return licenses
+	}
+	for _, choice := range *component.Licenses {
+		switch {
+		case len(choice.Expression) > 0:
+			licenses = append(licenses, choice.Expression)
+		case choice.License != nil && len(choice.License.ID) > 0:
+			licenses = append(licenses, choice.License.ID)
+		case choice.License != nil && len(choice.License.Name) > 0:
+			licenses = append(licenses, choice.License.Name)
+		}
+	}
+	sort.Strings(licenses)
+	return licenses
+}
+
+// componentID identifies a component in a specific version
+func componentID(component cdx.Component) string {
+	if len(component.PackageURL) > 0 {
+		return component.PackageURL
+	}
+	return fmt.Sprintf("%v@%v", packageName(component), component.Version)
+}
+
+// packageKey identifies a component independent of its version
+func packageKey(component cdx.Component) string {
+	if packageURL, err := packageurl.FromString(component.PackageURL); err == nil {
+		return fmt.Sprintf("pkg:%v/%v", packageURL.Type, strings.TrimPrefix(fmt.Sprintf("%v/%v", packageURL.Namespace, packageURL.Name), "/"))
+	}
+	return packageName(component)
+}
+
+func packageName(component cdx.Component) string {
+	if len(component.Group) > 0 {
+		return fmt.Sprintf("%v/%v", component.Group, component.Name)
+	}
+	return component.Name
+}
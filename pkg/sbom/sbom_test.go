@@ -0,0 +1,144 @@
+//go:build unit
+// +build unit
+
+package sbom
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const mavenSBOM = `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.4" version="1">
+  <metadata>
+    <component type="library" bom-ref="pkg:maven/com.sap/backend@1.0.0?type=jar">
+      <group>com.sap</group>
+      <name>backend</name>
+      <version>1.0.0</version>
+      <purl>pkg:maven/com.sap/backend@1.0.0?type=jar</purl>
+    </component>
+  </metadata>
+  <components>
+    <component type="library" bom-ref="pkg:maven/org.apache.commons/commons-text@1.9?type=jar">
+      <group>org.apache.commons</group>
+      <name>commons-text</name>
+      <version>1.9</version>
+      <licenses>
+        <license>
+          <id>Apache-2.0</id>
+        </license>
+      </licenses>
+      <purl>pkg:maven/org.apache.commons/commons-text@1.9?type=jar</purl>
+    </component>
+    <component type="library" bom-ref="pkg:maven/org.apache.commons/commons-lang3@3.11?type=jar">
+      <group>org.apache.commons</group>
+      <name>commons-lang3</name>
+      <version>3.11</version>
+      <purl>pkg:maven/org.apache.commons/commons-lang3@3.11?type=jar</purl>
+    </component>
+  </components>
+  <dependencies>
+    <dependency ref="pkg:maven/com.sap/backend@1.0.0?type=jar">
+      <dependency ref="pkg:maven/org.apache.commons/commons-text@1.9?type=jar"/>
+    </dependency>
+    <dependency ref="pkg:maven/org.apache.commons/commons-text@1.9?type=jar">
+      <dependency ref="pkg:maven/org.apache.commons/commons-lang3@3.11?type=jar"/>
+    </dependency>
+  </dependencies>
+</bom>`
+
+const npmSBOM = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "version": 1,
+  "metadata": {
+    "component": {"type": "application", "bom-ref": "ui", "name": "ui", "version": "1.0.0", "purl": "pkg:npm/ui@1.0.0"}
+  },
+  "components": [
+    {"type": "library", "bom-ref": "lodash@4.17.21", "name": "lodash", "version": "4.17.21", "purl": "pkg:npm/lodash@4.17.21", "licenses": [{"license": {"id": "MIT"}}]},
+    {"type": "library", "bom-ref": "internal", "name": "internal-lib", "version": "2.0.0"}
+  ],
+  "dependencies": [
+    {"ref": "ui", "dependsOn": ["lodash@4.17.21", "internal"]}
+  ]
+}`
+
+func TestDecode(t *testing.T) {
+	t.Run("XML", func(t *testing.T) {
+		bom, err := Decode([]byte(mavenSBOM))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "backend", bom.Metadata.Component.Name)
+		assert.Len(t, *bom.Components, 2)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		bom, err := Decode([]byte(npmSBOM))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ui", bom.Metadata.Component.Name)
+		assert.Len(t, *bom.Dependencies, 1)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Decode([]byte("<bom"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestEncode(t *testing.T) {
+	bom, err := Decode([]byte(npmSBOM))
+	assert.NoError(t, err)
+
+	for _, path := range []string{"bom.xml", "bom.json"} {
+		content, err := Encode(bom, path)
+		assert.NoError(t, err)
+
+		decoded, err := Decode(content)
+		assert.NoError(t, err)
+		assert.Equal(t, *bom.Components, *decoded.Components)
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	utils := &mock.FilesMock{}
+	utils.AddFile("bom-maven.xml", []byte(mavenSBOM))
+	utils.AddFile("bom-invalid.xml", []byte("<bom"))
+
+	bom, err := ReadFile("bom-maven.xml", utils)
+	assert.NoError(t, err)
+	assert.Equal(t, "backend", bom.Metadata.Component.Name)
+
+	_, err = ReadFile("bom-invalid.xml", utils)
+	assert.ErrorContains(t, err, "failed to parse SBOM bom-invalid.xml")
+
+	_, err = ReadFile("bom-missing.xml", utils)
+	assert.ErrorContains(t, err, "failed to read SBOM bom-missing.xml")
+}
+
+func TestLicenses(t *testing.T) {
+	component := cdx.Component{Licenses: &cdx.Licenses{
+		{License: &cdx.License{Name: "Custom License"}},
+		{Expression: "MIT OR Apache-2.0"},
+		{License: &cdx.License{ID: "BSD-3-Clause"}},
+	}}
+
+	assert.Equal(t, []string{"BSD-3-Clause", "Custom License", "MIT OR Apache-2.0"}, Licenses(component))
+	assert.Equal(t, []string{}, Licenses(cdx.Component{}))
+}
+
+func TestComponents(t *testing.T) {
+	bom := &cdx.BOM{Components: &[]cdx.Component{
+		{Name: "a", Components: &[]cdx.Component{{Name: "b"}}},
+		{Name: "c"},
+	}}
+
+	components := Components(bom)
+
+	assert.Equal(t, []string{"a", "b", "c"}, []string{components[0].Name, components[1].Name, components[2].Name})
+	assert.Empty(t, Components(nil))
+}
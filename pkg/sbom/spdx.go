@@ -0,0 +1,296 @@
+package sbom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+const spdxNoAssertion = "NOASSERTION"
+
+// SPDXDocument is an SPDX 2.3 document in JSON format, see https://spdx.github.io/spdx-spec/v2.3/
+type SPDXDocument struct {
+	SPDXVersion                string                     `json:"spdxVersion"`
+	DataLicense                string                     `json:"dataLicense"`
+	SPDXID                     string                     `json:"SPDXID"`
+	Name                       string                     `json:"name"`
+	DocumentNamespace          string                     `json:"documentNamespace"`
+	CreationInfo               SPDXCreationInfo           `json:"creationInfo"`
+	DocumentDescribes          []string                   `json:"documentDescribes,omitempty"`
+	Packages                   []SPDXPackage              `json:"packages"`
+	Relationships              []SPDXRelationship         `json:"relationships,omitempty"`
+	HasExtractedLicensingInfos []SPDXExtractedLicenseInfo `json:"hasExtractedLicensingInfos,omitempty"`
+}
+
+// SPDXCreationInfo describes when and by whom the document has been created
+type SPDXCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+// SPDXPackage describes one package
+type SPDXPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	Supplier         string            `json:"supplier,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	Checksums        []SPDXChecksum    `json:"checksums,omitempty"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	CopyrightText    string            `json:"copyrightText"`
+	Description      string            `json:"description,omitempty"`
+	ExternalRefs     []SPDXExternalRef `json:"externalRefs,omitempty"`
+	PrimaryPurpose   string            `json:"primaryPackagePurpose,omitempty"`
+}
+
+// SPDXChecksum is a checksum of a package
+type SPDXChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+// SPDXExternalRef refers to a package, e.g. via its package URL
+type SPDXExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+// SPDXRelationship describes the relationship between two elements
+type SPDXRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// SPDXExtractedLicenseInfo describes a license which is not on the SPDX license list
+type SPDXExtractedLicenseInfo struct {
+	LicenseID     string `json:"licenseId"`
+	ExtractedText string `json:"extractedText"`
+	Name          string `json:"name,omitempty"`
+}
+
+var (
+	spdxInvalidIDCharacters = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+	cdxSPDXHashAlgorithms   = map[cdx.HashAlgorithm]string{
+		cdx.HashAlgoMD5:      "MD5",
+		cdx.HashAlgoSHA1:     "SHA1",
+		cdx.HashAlgoSHA256:   "SHA256",
+		cdx.HashAlgoSHA384:   "SHA384",
+		cdx.HashAlgoSHA512:   "SHA512",
+		cdx.HashAlgoSHA3_256: "SHA3-256",
+		// not defined by cyclonedx-go v0.6.0
+		cdx.HashAlgorithm("SHA3-384"): "SHA3-384",
+		cdx.HashAlgoSHA3_512:          "SHA3-512",
+	}
+	cdxSPDXPurposes = map[cdx.ComponentType]string{
+		cdx.ComponentTypeApplication: "APPLICATION",
+		cdx.ComponentTypeContainer:   "CONTAINER",
+		cdx.ComponentTypeFile:        "FILE",
+		cdx.ComponentTypeFramework:   "FRAMEWORK",
+		cdx.ComponentTypeLibrary:     "LIBRARY",
+		cdx.ComponentTypeOS:          "OPERATING-SYSTEM",
+	}
+)
+
+// ToSPDX converts a CycloneDX SBOM into an SPDX 2.3 document.
+// The metadata component is described by the document, the dependency graph and nested components are converted into relationships.
+// Licenses which are given by name only are added as extracted licensing information.
+func ToSPDX(bom *cdx.BOM, namespace string, created time.Time) SPDXDocument {
+	document := SPDXDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              "sbom",
+		DocumentNamespace: namespace,
+		CreationInfo: SPDXCreationInfo{
+			Created:  created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: Project Piper"},
+		},
+		Packages:      []SPDXPackage{},
+		Relationships: []SPDXRelationship{},
+	}
+	converter := spdxConverter{document: &document, ids: map[string]string{}, usedIDs: map[string]bool{}, licenseRefs: map[string]string{}}
+
+	root := ""
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		document.Name = bom.Metadata.Component.Name
+		if len(bom.Metadata.Component.Version) > 0 {
+			document.Name = fmt.Sprintf("%v@%v", bom.Metadata.Component.Name, bom.Metadata.Component.Version)
+		}
+		root = converter.addPackage(*bom.Metadata.Component)
+		document.DocumentDescribes = []string{root}
+		converter.relate(document.SPDXID, "DESCRIBES", root)
+	}
+	if bom.Components != nil {
+		for _, component := range *bom.Components {
+			converter.addComponent(component)
+		}
+	}
+
+	rootHasDependencies := false
+	if bom.Dependencies != nil {
+		for _, dependency := range *bom.Dependencies {
+			id, ok := converter.ids[dependency.Ref]
+			if !ok || dependency.Dependencies == nil {
+				continue
+			}
+			for _, dependsOn := range *dependency.Dependencies {
+				if dependsOnID, ok := converter.ids[dependsOn.Ref]; ok {
+					converter.relate(id, "DEPENDS_ON", dependsOnID)
+					rootHasDependencies = rootHasDependencies || id == root
+				}
+			}
+		}
+	}
+	// without dependency information the described package at least contains all components
+	if len(root) > 0 && !rootHasDependencies && bom.Components != nil {
+		for _, component := range *bom.Components {
+			converter.relate(root, "CONTAINS", converter.ids[converter.key(component)])
+		}
+	}
+	return document
+}
+
+type spdxConverter struct {
+	document *SPDXDocument
+	// ids maps CycloneDX references to SPDX identifiers
+	ids         map[string]string
+	usedIDs     map[string]bool
+	licenseRefs map[string]string
+}
+
+func (c *spdxConverter) addComponent(component cdx.Component) string {
+	id := c.addPackage(component)
+	if component.Components != nil {
+		for _, nested := range *component.Components {
+			c.relate(id, "CONTAINS", c.addComponent(nested))
+		}
+	}
+	return id
+}
+
+func (c *spdxConverter) addPackage(component cdx.Component) string {
+	id := c.newID(component)
+	c.ids[c.key(component)] = id
+
+	pkg := SPDXPackage{
+		SPDXID:           id,
+		Name:             component.Name,
+		VersionInfo:      component.Version,
+		DownloadLocation: spdxNoAssertion,
+		LicenseConcluded: spdxNoAssertion,
+		LicenseDeclared:  c.licenseExpression(component),
+		CopyrightText:    spdxNoAssertion,
+		Description:      component.Description,
+		PrimaryPurpose:   cdxSPDXPurposes[component.Type],
+	}
+	if len(component.Group) > 0 {
+		pkg.Name = fmt.Sprintf("%v:%v", component.Group, component.Name)
+	}
+	if len(component.Copyright) > 0 {
+		pkg.CopyrightText = component.Copyright
+	}
+	if component.Supplier != nil && len(component.Supplier.Name) > 0 {
+		pkg.Supplier = fmt.Sprintf("Organization: %v", component.Supplier.Name)
+	} else if len(component.Publisher) > 0 {
+		pkg.Supplier = fmt.Sprintf("Organization: %v", component.Publisher)
+	}
+	if component.Hashes != nil {
+		for _, hash := range *component.Hashes {
+			if algorithm, ok := cdxSPDXHashAlgorithms[hash.Algorithm]; ok {
+				pkg.Checksums = append(pkg.Checksums, SPDXChecksum{Algorithm: algorithm, ChecksumValue: hash.Value})
+			}
+		}
+	}
+	if len(component.PackageURL) > 0 {
+		pkg.ExternalRefs = append(pkg.ExternalRefs, SPDXExternalRef{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: component.PackageURL})
+	}
+	if len(component.CPE) > 0 {
+		pkg.ExternalRefs = append(pkg.ExternalRefs, SPDXExternalRef{ReferenceCategory: "SECURITY", ReferenceType: "cpe23Type", ReferenceLocator: component.CPE})
+	}
+	c.document.Packages = append(c.document.Packages, pkg)
+	return id
+}
+
+// key identifies a component within the CycloneDX document
+func (c *spdxConverter) key(component cdx.Component) string {
+	if len(component.BOMRef) > 0 {
+		return component.BOMRef
+	}
+	return componentID(component)
+}
+
+func (c *spdxConverter) newID(component cdx.Component) string {
+	base := "SPDXRef-Package-" + strings.Trim(spdxInvalidIDCharacters.ReplaceAllString(fmt.Sprintf("%v-%v", packageName(component), component.Version), "-"), "-")
+	id := base
+	for suffix := 2; c.usedIDs[id]; suffix++ {
+		id = fmt.Sprintf("%v-%v", base, suffix)
+	}
+	c.usedIDs[id] = true
+	return id
+}
+
+func (c *spdxConverter) relate(element, relationshipType, related string) {
+	if len(element) == 0 || len(related) == 0 {
+		return
+	}
+	c.document.Relationships = append(c.document.Relationships, SPDXRelationship{SPDXElementID: element, RelationshipType: relationshipType, RelatedSPDXElement: related})
+}
+
+// licenseExpression combines the licenses of a component with AND, licenses given by name are referenced as LicenseRef
+func (c *spdxConverter) licenseExpression(component cdx.Component) string {
+	if component.Licenses == nil {
+		return spdxNoAssertion
+	}
+	expressions := []string{}
+	for _, choice := range *component.Licenses {
+		switch {
+		case len(choice.Expression) > 0:
+			expressions = append(expressions, choice.Expression)
+		case choice.License != nil && len(choice.License.ID) > 0:
+			expressions = append(expressions, choice.License.ID)
+		case choice.License != nil && len(choice.License.Name) > 0:
+			expressions = append(expressions, c.licenseRef(choice.License.Name))
+		}
+	}
+	switch len(expressions) {
+	case 0:
+		return spdxNoAssertion
+	case 1:
+		return expressions[0]
+	}
+	for i, expression := range expressions {
+		if strings.Contains(expression, " ") {
+			expressions[i] = fmt.Sprintf("(%v)", expression)
+		}
+	}
+	return strings.Join(expressions, " AND ")
+}
+
+func (c *spdxConverter) licenseRef(name string) string {
+	if ref, ok := c.licenseRefs[name]; ok {
+		return ref
+	}
+	ref := "LicenseRef-" + strings.Trim(spdxInvalidIDCharacters.ReplaceAllString(name, "-"), "-")
+	for suffix := 2; c.isLicenseRefUsed(ref); suffix++ {
+		ref = fmt.Sprintf("LicenseRef-%v-%v", strings.Trim(spdxInvalidIDCharacters.ReplaceAllString(name, "-"), "-"), suffix)
+	}
+	c.licenseRefs[name] = ref
+	c.document.HasExtractedLicensingInfos = append(c.document.HasExtractedLicensingInfos, SPDXExtractedLicenseInfo{LicenseID: ref, ExtractedText: name, Name: name})
+	return ref
+}
+
+func (c *spdxConverter) isLicenseRefUsed(ref string) bool {
+	for _, used := range c.licenseRefs {
+		if used == ref {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+//go:build unit
+// +build unit
+
+package sbom
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSPDX(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("with dependency graph", func(t *testing.T) {
+		bom, err := Decode([]byte(mavenSBOM))
+		assert.NoError(t, err)
+		(*bom.Components)[0].Hashes = &[]cdx.Hash{{Algorithm: cdx.HashAlgoSHA256, Value: "abc"}, {Algorithm: cdx.HashAlgoBlake3, Value: "def"}}
+
+		document := ToSPDX(bom, "https://spdx.org/spdxdocs/backend-1", created)
+
+		assert.Equal(t, "SPDX-2.3", document.SPDXVersion)
+		assert.Equal(t, "com.sap:backend", document.Packages[0].Name)
+		assert.Equal(t, "backend@1.0.0", document.Name)
+		assert.Equal(t, "https://spdx.org/spdxdocs/backend-1", document.DocumentNamespace)
+		assert.Equal(t, SPDXCreationInfo{Created: "2024-01-02T03:04:05Z", Creators: []string{"Tool: Project Piper"}}, document.CreationInfo)
+		assert.Equal(t, []string{"SPDXRef-Package-com.sap-backend-1.0.0"}, document.DocumentDescribes)
+		if assert.Len(t, document.Packages, 3) {
+			assert.Equal(t, SPDXPackage{
+				SPDXID:           "SPDXRef-Package-org.apache.commons-commons-text-1.9",
+				Name:             "org.apache.commons:commons-text",
+				VersionInfo:      "1.9",
+				DownloadLocation: "NOASSERTION",
+				Checksums:        []SPDXChecksum{{Algorithm: "SHA256", ChecksumValue: "abc"}},
+				LicenseConcluded: "NOASSERTION",
+				LicenseDeclared:  "Apache-2.0",
+				CopyrightText:    "NOASSERTION",
+				ExternalRefs:     []SPDXExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: "pkg:maven/org.apache.commons/commons-text@1.9?type=jar"}},
+				PrimaryPurpose:   "LIBRARY",
+			}, document.Packages[1])
+			assert.Equal(t, "NOASSERTION", document.Packages[2].LicenseDeclared)
+		}
+		assert.Equal(t, []SPDXRelationship{
+			{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: "SPDXRef-Package-com.sap-backend-1.0.0"},
+			{SPDXElementID: "SPDXRef-Package-com.sap-backend-1.0.0", RelationshipType: "DEPENDS_ON", RelatedSPDXElement: "SPDXRef-Package-org.apache.commons-commons-text-1.9"},
+			{SPDXElementID: "SPDXRef-Package-org.apache.commons-commons-text-1.9", RelationshipType: "DEPENDS_ON", RelatedSPDXElement: "SPDXRef-Package-org.apache.commons-commons-lang3-3.11"},
+		}, document.Relationships)
+	})
+
+	t.Run("without dependency graph", func(t *testing.T) {
+		bom := &cdx.BOM{
+			Metadata: &cdx.Metadata{Component: &cdx.Component{Type: cdx.ComponentTypeContainer, Name: "registry/app"}},
+			Components: &[]cdx.Component{
+				{Name: "openssl", Version: "3.0.2", Licenses: &cdx.Licenses{{License: &cdx.License{Name: "OpenSSL License"}}, {Expression: "MIT OR Apache-2.0"}},
+					Components: &[]cdx.Component{{Name: "libssl", Version: "3.0.2", Licenses: &cdx.Licenses{{License: &cdx.License{Name: "OpenSSL License"}}}}}},
+				{Name: "openssl", Version: "3.0.2"},
+			},
+		}
+
+		document := ToSPDX(bom, "https://spdx.org/spdxdocs/app-1", created)
+
+		assert.Equal(t, "registry/app", document.Name)
+		if assert.Len(t, document.Packages, 4) {
+			assert.Equal(t, "CONTAINER", document.Packages[0].PrimaryPurpose)
+			assert.Equal(t, "LicenseRef-OpenSSL-License AND (MIT OR Apache-2.0)", document.Packages[1].LicenseDeclared)
+			assert.Equal(t, "LicenseRef-OpenSSL-License", document.Packages[2].LicenseDeclared)
+			assert.Equal(t, "SPDXRef-Package-openssl-3.0.2-2", document.Packages[3].SPDXID)
+		}
+		assert.Equal(t, []SPDXExtractedLicenseInfo{{LicenseID: "LicenseRef-OpenSSL-License", ExtractedText: "OpenSSL License", Name: "OpenSSL License"}}, document.HasExtractedLicensingInfos)
+		assert.Equal(t, []SPDXRelationship{
+			{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: "SPDXRef-Package-registry-app"},
+			{SPDXElementID: "SPDXRef-Package-openssl-3.0.2", RelationshipType: "CONTAINS", RelatedSPDXElement: "SPDXRef-Package-libssl-3.0.2"},
+			{SPDXElementID: "SPDXRef-Package-registry-app", RelationshipType: "CONTAINS", RelatedSPDXElement: "SPDXRef-Package-openssl-3.0.2-2"},
+			{SPDXElementID: "SPDXRef-Package-registry-app", RelationshipType: "CONTAINS", RelatedSPDXElement: "SPDXRef-Package-openssl-3.0.2-2"},
+		}, document.Relationships)
+
+		content, err := json.Marshal(document)
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), `"spdxVersion":"SPDX-2.3"`)
+	})
+}
@@ -0,0 +1,109 @@
+package sonar
+
+import (
+	"strconv"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	sonargo "github.com/magicsong/sonargo/sonar"
+	"github.com/pkg/errors"
+)
+
+// EndpointHotspotsSearch API endpoint for https://sonarcloud.io/web_api/api/hotspots/search
+const EndpointHotspotsSearch = "hotspots/search"
+
+// HotspotStatusToReview is the status of security hotspots which have not been reviewed yet
+const HotspotStatusToReview = "TO_REVIEW"
+
+// HotspotsSearchOption contains the query parameters of the hotspots/search endpoint
+type HotspotsSearchOption struct {
+	ProjectKey  string `url:"projectKey,omitempty"`  // Description:"Project key"
+	Branch      string `url:"branch,omitempty"`      // Description:"Branch key"
+	PullRequest string `url:"pullRequest,omitempty"` // Description:"Pull request id"
+	Status      string `url:"status,omitempty"`      // Description:"Status of the hotspots",PossibleValues:"TO_REVIEW,REVIEWED"
+	P           string `url:"p,omitempty"`           // Description:"1-based page number"
+	Ps          string `url:"ps,omitempty"`          // Description:"Page size"
+}
+
+// Hotspot is a subset of the security hotspot returned by the hotspots/search endpoint
+type Hotspot struct {
+	Key                      string     `json:"key"`
+	Component                string     `json:"component"`
+	SecurityCategory         string     `json:"securityCategory"`
+	VulnerabilityProbability string     `json:"vulnerabilityProbability"`
+	Status                   string     `json:"status"`
+	Line                     int        `json:"line,omitempty"`
+	TextRange                *TextRange `json:"textRange,omitempty"`
+	Message                  string     `json:"message"`
+	RuleKey                  string     `json:"ruleKey"`
+}
+
+type hotspotsSearchPage struct {
+	Paging struct {
+		PageIndex int `json:"pageIndex"`
+		PageSize  int `json:"pageSize"`
+		Total     int `json:"total"`
+	} `json:"paging"`
+	Hotspots   []Hotspot        `json:"hotspots"`
+	Components []IssueComponent `json:"components"`
+}
+
+// HotspotService ...
+type HotspotService struct {
+	Project     string
+	Branch      string
+	PullRequest string
+	apiClient   *Requester
+}
+
+// GetOpenHotspots returns all security hotspots which need to be reviewed together with the components they refer to
+func (service *HotspotService) GetOpenHotspots() ([]Hotspot, []IssueComponent, error) {
+	hotspots := []Hotspot{}
+	components := []IssueComponent{}
+	for page := 1; ; page++ {
+		options := &HotspotsSearchOption{ProjectKey: service.Project, Status: HotspotStatusToReview, P: strconv.Itoa(page), Ps: strconv.Itoa(issuesPageSize)}
+		// if PR, ignore branch name and consider PR branch name. If not PR, consider branch name
+		if len(service.PullRequest) > 0 {
+			options.PullRequest = service.PullRequest
+		} else if len(service.Branch) > 0 {
+			options.Branch = service.Branch
+		}
+		request, err := service.apiClient.create("GET", EndpointHotspotsSearch, options)
+		if err != nil {
+			return nil, nil, err
+		}
+		// use custom HTTP client to send request
+		response, err := service.apiClient.send(request)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to fetch the security hotspots")
+		}
+		// reuse response verrification from sonargo
+		if err := sonargo.CheckResponse(response); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to fetch the security hotspots")
+		}
+		result := hotspotsSearchPage{}
+		if err := service.apiClient.decode(response, &result); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to decode the security hotspots")
+		}
+		hotspots = append(hotspots, result.Hotspots...)
+		components = append(components, result.Components...)
+
+		if len(result.Hotspots) == 0 || page*issuesPageSize >= result.Paging.Total {
+			break
+		}
+		if page*issuesPageSize >= issuesSearchLimit {
+			log.Entry().Warnf("only the first %v of %v security hotspots can be retrieved", issuesSearchLimit, result.Paging.Total)
+			break
+		}
+	}
+	return hotspots, components, nil
+}
+
+// NewHotspotService returns a new instance of a service for the security hotspots API endpoint.
+func NewHotspotService(host, token, project, branch, pullRequest string, client Sender) *HotspotService {
+	return &HotspotService{
+		Project:     project,
+		Branch:      branch,
+		PullRequest: pullRequest,
+		apiClient:   NewAPIClient(host, token, client),
+	}
+}
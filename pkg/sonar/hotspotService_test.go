@@ -0,0 +1,66 @@
+//go:build unit
+// +build unit
+
+package sonar
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+)
+
+func TestGetOpenHotspots(t *testing.T) {
+	testURL := "https://example.org"
+	t.Run("success", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		sender := &piperhttp.Client{}
+		sender.SetOptions(piperhttp.ClientOptions{MaxRetries: -1, UseDefaultTransport: true})
+		// add response handler
+		httpmock.RegisterResponder(http.MethodGet, testURL+"/api/"+EndpointHotspotsSearch+"", func(req *http.Request) (*http.Response, error) {
+			query := req.URL.Query()
+			assert.Equal(t, "SAP_jenkins-library", query.Get("projectKey"))
+			assert.Equal(t, "TO_REVIEW", query.Get("status"))
+			assert.Equal(t, "42", query.Get("pullRequest"))
+			assert.Empty(t, query.Get("branch"))
+			return httpmock.NewStringResponse(http.StatusOK, `{"paging": {"pageIndex": 1, "pageSize": 500, "total": 1}, "hotspots": [
+				{"key": "AXW3MmCVOYWf3_DBLGvO", "component": "SAP_jenkins-library:pkg/http/http.go", "securityCategory": "weak-cryptography", "vulnerabilityProbability": "MEDIUM", "status": "TO_REVIEW", "line": 42, "message": "Make sure this weak hash algorithm is not used in a sensitive context here.", "ruleKey": "go:S4790"}
+			], "components": [{"key": "SAP_jenkins-library:pkg/http/http.go", "path": "pkg/http/http.go"}]}`), nil
+		})
+		// create service instance
+		serviceUnderTest := NewHotspotService(testURL, mock.Anything, "SAP_jenkins-library", "feature", "42", sender)
+		// test
+		hotspots, components, err := serviceUnderTest.GetOpenHotspots()
+		// assert
+		assert.NoError(t, err)
+		if assert.Len(t, hotspots, 1) {
+			assert.Equal(t, "go:S4790", hotspots[0].RuleKey)
+			assert.Equal(t, "MEDIUM", hotspots[0].VulnerabilityProbability)
+			assert.Equal(t, 42, hotspots[0].Line)
+		}
+		assert.Equal(t, []IssueComponent{{Key: "SAP_jenkins-library:pkg/http/http.go", Path: "pkg/http/http.go"}}, components)
+		assert.Equal(t, 1, httpmock.GetTotalCallCount(), "unexpected number of requests")
+	})
+	t.Run("error", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		sender := &piperhttp.Client{}
+		sender.SetOptions(piperhttp.ClientOptions{MaxRetries: -1, UseDefaultTransport: true})
+		// add response handler
+		httpmock.RegisterResponder(http.MethodGet, testURL+"/api/"+EndpointHotspotsSearch+"", httpmock.NewStringResponder(http.StatusNotFound, responseIssueSearchError))
+		// create service instance
+		serviceUnderTest := NewHotspotService(testURL, mock.Anything, mock.Anything, mock.Anything, mock.Anything, sender)
+		// test
+		hotspots, _, err := serviceUnderTest.GetOpenHotspots()
+		// assert
+		assert.ErrorContains(t, err, "failed to fetch the security hotspots")
+		assert.Empty(t, hotspots)
+	})
+}
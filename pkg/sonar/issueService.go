@@ -3,6 +3,8 @@ package sonar
 import (
 	"net/http"
 	"net/http/httputil"
+	"strconv"
+	"strings"
 
 	"github.com/SAP/jenkins-library/pkg/log"
 	sonargo "github.com/magicsong/sonargo/sonar"
@@ -12,6 +14,51 @@ import (
 // EndpointIssuesSearch API endpoint for https://sonarcloud.io/web_api/api/issues/search
 const EndpointIssuesSearch = "issues/search"
 
+// issuesPageSize is the maximum page size supported by the issues/search endpoint
+const issuesPageSize = 500
+
+// issuesSearchLimit is the maximum number of issues which can be retrieved via the issues/search endpoint
+const issuesSearchLimit = 10000
+
+// Issue is a subset of the issue returned by the issues/search endpoint
+type Issue struct {
+	Key       string     `json:"key"`
+	Rule      string     `json:"rule"`
+	Severity  string     `json:"severity"`
+	Component string     `json:"component"`
+	Line      int        `json:"line,omitempty"`
+	Hash      string     `json:"hash,omitempty"`
+	TextRange *TextRange `json:"textRange,omitempty"`
+	Status    string     `json:"status"`
+	Message   string     `json:"message"`
+	Tags      []string   `json:"tags,omitempty"`
+	Type      string     `json:"type"`
+}
+
+// TextRange describes the location of an issue within a file
+type TextRange struct {
+	StartLine   int `json:"startLine"`
+	EndLine     int `json:"endLine"`
+	StartOffset int `json:"startOffset"`
+	EndOffset   int `json:"endOffset"`
+}
+
+// IssueComponent is a subset of the component an issue refers to
+type IssueComponent struct {
+	Key  string `json:"key"`
+	Path string `json:"path,omitempty"`
+}
+
+type issuesSearchPage struct {
+	Paging struct {
+		PageIndex int `json:"pageIndex"`
+		PageSize  int `json:"pageSize"`
+		Total     int `json:"total"`
+	} `json:"paging"`
+	Issues     []Issue          `json:"issues"`
+	Components []IssueComponent `json:"components"`
+}
+
 // IssueService ...
 type IssueService struct {
 	Organization string
@@ -50,10 +97,48 @@ func (service *IssueService) SearchIssues(options *IssuesSearchOption) (*sonargo
 	return result, response, nil
 }
 
-func (service *IssueService) getIssueCount(severity issueSeverity, categories *[]Severity) (int, error) {
+// GetOpenIssues returns all unresolved issues of the given types (e.g. VULNERABILITY) together with the components they refer to.
+// Issues of all types are returned if no type is given. The result is limited to the first 10000 issues by the API.
+func (service *IssueService) GetOpenIssues(issueTypes ...string) ([]Issue, []IssueComponent, error) {
+	issues := []Issue{}
+	components := []IssueComponent{}
+	for page := 1; ; page++ {
+		options := service.searchOptions()
+		options.Types = strings.Join(issueTypes, ",")
+		options.P = strconv.Itoa(page)
+		options.Ps = strconv.Itoa(issuesPageSize)
+		request, err := service.apiClient.create("GET", EndpointIssuesSearch, options)
+		if err != nil {
+			return nil, nil, err
+		}
+		response, err := service.apiClient.send(request)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to fetch the open issues")
+		}
+		if err := sonargo.CheckResponse(response); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to fetch the open issues")
+		}
+		result := issuesSearchPage{}
+		if err := service.apiClient.decode(response, &result); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to decode the open issues")
+		}
+		issues = append(issues, result.Issues...)
+		components = append(components, result.Components...)
+
+		if len(result.Issues) == 0 || page*issuesPageSize >= result.Paging.Total {
+			break
+		}
+		if page*issuesPageSize >= issuesSearchLimit {
+			log.Entry().Warnf("only the first %v of %v issues can be retrieved", issuesSearchLimit, result.Paging.Total)
+			break
+		}
+	}
+	return issues, components, nil
+}
+
+func (service *IssueService) searchOptions() *IssuesSearchOption {
 	options := &IssuesSearchOption{
 		ComponentKeys: service.Project,
-		Severities:    severity.ToString(),
 		Resolved:      "false",
 	}
 	if len(service.Organization) > 0 {
@@ -65,6 +150,12 @@ func (service *IssueService) getIssueCount(severity issueSeverity, categories *[
 	} else if len(service.Branch) > 0 {
 		options.Branch = service.Branch
 	}
+	return options
+}
+
+func (service *IssueService) getIssueCount(severity issueSeverity, categories *[]Severity) (int, error) {
+	options := service.searchOptions()
+	options.Severities = severity.ToString()
 	result, _, err := service.SearchIssues(options)
 	if err != nil {
 		return -1, errors.Wrapf(err, "failed to fetch the numer of '%s' issues", severity)
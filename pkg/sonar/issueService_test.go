@@ -5,6 +5,7 @@ package sonar
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
@@ -112,6 +113,65 @@ func TestIssueService(t *testing.T) {
 	})
 }
 
+func TestGetOpenIssues(t *testing.T) {
+	testURL := "https://example.org"
+	t.Run("success", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		sender := &piperhttp.Client{}
+		sender.SetOptions(piperhttp.ClientOptions{MaxRetries: -1, UseDefaultTransport: true})
+		// add response handler returning two pages
+		httpmock.RegisterResponder(http.MethodGet, testURL+"/api/"+EndpointIssuesSearch+"", func(req *http.Request) (*http.Response, error) {
+			query := req.URL.Query()
+			assert.Equal(t, "false", query.Get("resolved"))
+			assert.Equal(t, "VULNERABILITY", query.Get("types"))
+			assert.Equal(t, "500", query.Get("ps"))
+			assert.Equal(t, "feature", query.Get("branch"))
+			if query.Get("p") == "1" {
+				// first page of 501 issues in total
+				return httpmock.NewStringResponse(http.StatusOK, strings.ReplaceAll(responseIssueSearchCritical, `"total": 111`, `"total": 501`)), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, `{"paging": {"pageIndex": 2, "pageSize": 500, "total": 501}, "issues": [
+				{"key": "AXW3MmCVOYWf3_DBLGvM", "rule": "go:S1192", "severity": "MINOR", "component": "SAP_jenkins-library:cmd/piper.go", "line": 12, "message": "Define a constant", "type": "CODE_SMELL", "status": "OPEN"}
+			]}`), nil
+		})
+		// create service instance
+		serviceUnderTest := NewIssuesService(testURL, mock.Anything, "SAP_jenkins-library", "", "feature", "", sender)
+		// test
+		issues, components, err := serviceUnderTest.GetOpenIssues("VULNERABILITY")
+		// assert
+		assert.NoError(t, err)
+		if assert.Len(t, issues, 2) {
+			assert.Equal(t, "go:S3776", issues[0].Rule)
+			assert.Equal(t, "CRITICAL", issues[0].Severity)
+			assert.Equal(t, 647, issues[0].TextRange.StartLine)
+			assert.Equal(t, 5, issues[0].TextRange.StartOffset)
+			assert.Equal(t, "a154a51bdb1502a2ac057a348d08e7f6", issues[0].Hash)
+			assert.Equal(t, "go:S1192", issues[1].Rule)
+			assert.Nil(t, issues[1].TextRange)
+		}
+		assert.Contains(t, components, IssueComponent{Key: "SAP_jenkins-library:cmd/fortifyExecuteScan.go", Path: "cmd/fortifyExecuteScan.go"})
+		assert.Equal(t, 2, httpmock.GetTotalCallCount(), "unexpected number of requests")
+	})
+	t.Run("error", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		sender := &piperhttp.Client{}
+		sender.SetOptions(piperhttp.ClientOptions{MaxRetries: -1, UseDefaultTransport: true})
+		// add response handler
+		httpmock.RegisterResponder(http.MethodGet, testURL+"/api/"+EndpointIssuesSearch+"", httpmock.NewStringResponder(http.StatusNotFound, responseIssueSearchError))
+		// create service instance
+		serviceUnderTest := NewIssuesService(testURL, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, sender)
+		// test
+		issues, _, err := serviceUnderTest.GetOpenIssues()
+		// assert
+		assert.ErrorContains(t, err, "failed to fetch the open issues")
+		assert.Empty(t, issues)
+	})
+}
+
 const responseIssueSearchError = `{
   "errors": [
     {
@@ -0,0 +1,89 @@
+package sonar
+
+import (
+	"github.com/SAP/jenkins-library/pkg/log"
+	sonargo "github.com/magicsong/sonargo/sonar"
+	"github.com/pkg/errors"
+)
+
+// EndpointQualityGatesProjectStatus API endpoint for https://sonarcloud.io/web_api/api/qualitygates/project_status
+const EndpointQualityGatesProjectStatus = "qualitygates/project_status"
+
+// Quality gate status values
+const (
+	QualityGateStatusOK    = "OK"
+	QualityGateStatusError = "ERROR"
+	QualityGateStatusNone  = "NONE"
+)
+
+// QualityGateProjectStatusOption contains the query parameters of the qualitygates/project_status endpoint
+type QualityGateProjectStatusOption struct {
+	ProjectKey  string `url:"projectKey,omitempty"`  // Description:"Project key"
+	Branch      string `url:"branch,omitempty"`      // Description:"Branch key"
+	PullRequest string `url:"pullRequest,omitempty"` // Description:"Pull request id"
+}
+
+// QualityGateStatus is the status of the quality gate of the last analysis
+type QualityGateStatus struct {
+	Status     string                 `json:"status"`
+	Conditions []QualityGateCondition `json:"conditions"`
+}
+
+// QualityGateCondition is the result of one condition of the quality gate
+type QualityGateCondition struct {
+	Status         string `json:"status"`
+	MetricKey      string `json:"metricKey"`
+	Comparator     string `json:"comparator"`
+	ErrorThreshold string `json:"errorThreshold"`
+	ActualValue    string `json:"actualValue"`
+}
+
+// QualityGateService ...
+type QualityGateService struct {
+	Project     string
+	Branch      string
+	PullRequest string
+	apiClient   *Requester
+}
+
+// GetProjectStatus returns the status of the quality gate including the results of all conditions
+func (service *QualityGateService) GetProjectStatus() (*QualityGateStatus, error) {
+	options := &QualityGateProjectStatusOption{ProjectKey: service.Project}
+	// if PR, ignore branch name and consider PR branch name. If not PR, consider branch name
+	if len(service.PullRequest) > 0 {
+		options.PullRequest = service.PullRequest
+	} else if len(service.Branch) > 0 {
+		options.Branch = service.Branch
+	}
+	request, err := service.apiClient.create("GET", EndpointQualityGatesProjectStatus, options)
+	if err != nil {
+		return nil, err
+	}
+	// use custom HTTP client to send request
+	response, err := service.apiClient.send(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch the quality gate status")
+	}
+	// reuse response verrification from sonargo
+	if err := sonargo.CheckResponse(response); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch the quality gate status")
+	}
+	result := struct {
+		ProjectStatus QualityGateStatus `json:"projectStatus"`
+	}{}
+	if err := service.apiClient.decode(response, &result); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the quality gate status")
+	}
+	log.Entry().Debugf("quality gate status: %v", result.ProjectStatus.Status)
+	return &result.ProjectStatus, nil
+}
+
+// NewQualityGateService returns a new instance of a service for the quality gates API endpoint.
+func NewQualityGateService(host, token, project, branch, pullRequest string, client Sender) *QualityGateService {
+	return &QualityGateService{
+		Project:     project,
+		Branch:      branch,
+		PullRequest: pullRequest,
+		apiClient:   NewAPIClient(host, token, client),
+	}
+}
@@ -0,0 +1,94 @@
+//go:build unit
+// +build unit
+
+package sonar
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+)
+
+func TestQualityGateService(t *testing.T) {
+	testURL := "https://example.org"
+	t.Run("success", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		sender := &piperhttp.Client{}
+		sender.SetOptions(piperhttp.ClientOptions{MaxRetries: -1, UseDefaultTransport: true})
+		// add response handler
+		httpmock.RegisterResponder(http.MethodGet, testURL+"/api/"+EndpointQualityGatesProjectStatus+"", func(req *http.Request) (*http.Response, error) {
+			query := req.URL.Query()
+			assert.Equal(t, "SAP_jenkins-library", query.Get("projectKey"))
+			assert.Equal(t, "42", query.Get("pullRequest"))
+			assert.Empty(t, query.Get("branch"))
+			return httpmock.NewStringResponse(http.StatusOK, responseQualityGateStatus), nil
+		})
+		// create service instance
+		serviceUnderTest := NewQualityGateService(testURL, mock.Anything, "SAP_jenkins-library", "feature", "42", sender)
+		// test
+		status, err := serviceUnderTest.GetProjectStatus()
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, QualityGateStatusError, status.Status)
+		if assert.Len(t, status.Conditions, 2) {
+			assert.Equal(t, QualityGateCondition{Status: "ERROR", MetricKey: "new_coverage", Comparator: "LT", ErrorThreshold: "80", ActualValue: "71.4"}, status.Conditions[0])
+			assert.Equal(t, "OK", status.Conditions[1].Status)
+		}
+		assert.Equal(t, 1, httpmock.GetTotalCallCount(), "unexpected number of requests")
+	})
+	t.Run("error", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		sender := &piperhttp.Client{}
+		sender.SetOptions(piperhttp.ClientOptions{MaxRetries: -1, UseDefaultTransport: true})
+		// add response handler
+		httpmock.RegisterResponder(http.MethodGet, testURL+"/api/"+EndpointQualityGatesProjectStatus+"", httpmock.NewStringResponder(http.StatusNotFound, responseQualityGateStatusError))
+		// create service instance
+		serviceUnderTest := NewQualityGateService(testURL, mock.Anything, mock.Anything, mock.Anything, "", sender)
+		// test
+		status, err := serviceUnderTest.GetProjectStatus()
+		// assert
+		assert.ErrorContains(t, err, "failed to fetch the quality gate status")
+		assert.Nil(t, status)
+		assert.Equal(t, 1, httpmock.GetTotalCallCount(), "unexpected number of requests")
+	})
+}
+
+const responseQualityGateStatus = `{
+  "projectStatus": {
+    "status": "ERROR",
+    "conditions": [
+      {
+        "status": "ERROR",
+        "metricKey": "new_coverage",
+        "comparator": "LT",
+        "errorThreshold": "80",
+        "actualValue": "71.4"
+      },
+      {
+        "status": "OK",
+        "metricKey": "new_reliability_rating",
+        "comparator": "GT",
+        "errorThreshold": "1",
+        "actualValue": "1"
+      }
+    ],
+    "ignoredConditions": false
+  }
+}`
+
+const responseQualityGateStatusError = `{
+  "errors": [
+    {
+      "msg": "Project 'SAP_jenkins-library' not found"
+    }
+  ]
+}`
@@ -0,0 +1,149 @@
+package sonar
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/pkg/errors"
+)
+
+const sarifFileName = "sonarscan.sarif"
+
+// CreateScanReport creates a report containing the number of issues per severity and the result of each quality gate condition
+func CreateScanReport(data ReportData, dashboardURL string) reporting.ScanReport {
+	scanReport := reporting.ScanReport{
+		StepName:    "sonarExecuteScan",
+		ReportTitle: "SonarQube Report",
+		Subheaders: []reporting.Subheader{
+			{Description: "Project key", Details: data.ProjectKey},
+		},
+		Overview: []reporting.OverviewRow{
+			{Description: "Blocker issues", Details: fmt.Sprint(data.NumberOfIssues.Blocker)},
+			{Description: "Critical issues", Details: fmt.Sprint(data.NumberOfIssues.Critical)},
+			{Description: "Major issues", Details: fmt.Sprint(data.NumberOfIssues.Major)},
+			{Description: "Minor issues", Details: fmt.Sprint(data.NumberOfIssues.Minor)},
+			{Description: "Info issues", Details: fmt.Sprint(data.NumberOfIssues.Info)},
+		},
+		ReportTime: time.Now(),
+		DetailTable: reporting.ScanDetailTable{
+			Headers:       []string{"Metric", "Comparator", "Threshold", "Actual value", "Status"},
+			NoRowsMessage: "No quality gate conditions available",
+		},
+		SuccessfulScan: true,
+	}
+	if len(data.ChangeID) > 0 {
+		scanReport.Subheaders = append(scanReport.Subheaders, reporting.Subheader{Description: "Pull request", Details: data.ChangeID})
+	} else if len(data.BranchName) > 0 {
+		scanReport.Subheaders = append(scanReport.Subheaders, reporting.Subheader{Description: "Branch", Details: data.BranchName})
+	}
+	if len(dashboardURL) > 0 {
+		scanReport.Subheaders = append(scanReport.Subheaders, reporting.Subheader{Description: "Dashboard", Details: fmt.Sprintf(`<a href="%v" target="_blank">Link to project in SonarQube UI</a>`, dashboardURL)})
+	}
+	if data.Coverage != nil {
+		scanReport.Overview = append(scanReport.Overview, reporting.OverviewRow{Description: "Coverage", Details: fmt.Sprintf("%v%%", data.Coverage.Coverage)})
+	}
+
+	if data.QualityGate != nil {
+		row := reporting.OverviewRow{Description: "Quality gate", Details: data.QualityGate.Status}
+		switch data.QualityGate.Status {
+		case QualityGateStatusOK:
+			row.Style = reporting.Green
+		case QualityGateStatusError:
+			row.Style = reporting.Red
+			scanReport.SuccessfulScan = false
+		}
+		scanReport.Overview = append(scanReport.Overview, row)
+
+		for _, condition := range data.QualityGate.Conditions {
+			detailRow := reporting.ScanRow{}
+			detailRow.AddColumn(condition.MetricKey, 0)
+			detailRow.AddColumn(condition.Comparator, 0)
+			detailRow.AddColumn(condition.ErrorThreshold, 0)
+			detailRow.AddColumn(condition.ActualValue, 0)
+			if condition.Status == QualityGateStatusError {
+				detailRow.AddColumn(condition.Status, reporting.Red)
+				// failed conditions are shown in the overview as well
+				scanReport.Overview = append(scanReport.Overview, reporting.OverviewRow{
+					Description: fmt.Sprintf("Quality gate condition %v", condition.MetricKey),
+					Details:     fmt.Sprintf("%v (%v %v)", condition.ActualValue, comparatorText(condition.Comparator), condition.ErrorThreshold),
+					Style:       reporting.Red,
+				})
+			} else {
+				detailRow.AddColumn(condition.Status, reporting.Green)
+			}
+			scanReport.DetailTable.Rows = append(scanReport.DetailTable.Rows, detailRow)
+		}
+	}
+	return scanReport
+}
+
+// WriteScanReports writes the scan report as HTML file and as JSON file for step pipelineCreateScanSummary
+func WriteScanReports(data ReportData, scanReport reporting.ScanReport, reportPath string, utils piperutils.FileUtils) ([]piperutils.Path, error) {
+	reportPaths := []piperutils.Path{}
+
+	// ignore templating errors since template is in our hands and issues will be detected with the automated tests
+	htmlReport, _ := scanReport.ToHTML()
+	htmlReportPath := filepath.Join(reportPath, "sonarscan.html")
+	if err := utils.FileWrite(htmlReportPath, htmlReport, 0666); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return reportPaths, errors.Wrap(err, "failed to write html report")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "SonarQube Report", Target: htmlReportPath})
+
+	// JSON reports are used by step pipelineCreateSummary in order to e.g. prepare an issue creation in GitHub
+	// ignore JSON errors since structure is in our hands
+	jsonReport, _ := scanReport.ToJSON()
+	if exists, _ := utils.DirExists(reporting.StepReportDirectory); !exists {
+		if err := utils.MkdirAll(reporting.StepReportDirectory, 0777); err != nil {
+			return reportPaths, errors.Wrap(err, "failed to create step reporting directory")
+		}
+	}
+	if err := utils.FileWrite(filepath.Join(reporting.StepReportDirectory, fmt.Sprintf("sonarExecuteScan_%v.json", reportSha(data))), jsonReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write json report")
+	}
+	return reportPaths, nil
+}
+
+// WriteSarif writes the issues in SARIF format
+func WriteSarif(sarif format.SARIF, reportPath string, utils piperutils.FileUtils) ([]piperutils.Path, error) {
+	reportPaths := []piperutils.Path{}
+
+	sarifReport, err := json.Marshal(sarif)
+	if err != nil {
+		return reportPaths, errors.Wrap(err, "failed to marshal SARIF file")
+	}
+	sarifReportPath := filepath.Join(reportPath, sarifFileName)
+	if err := utils.FileWrite(sarifReportPath, sarifReport, 0666); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return reportPaths, errors.Wrap(err, "failed to write SARIF file")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "SonarQube SARIF file", Target: sarifReportPath})
+	return reportPaths, nil
+}
+
+func reportSha(data ReportData) string {
+	reportShaData := []byte(strings.Join([]string{data.ProjectKey, data.BranchName, data.ChangeID}, ","))
+	return fmt.Sprintf("%x", sha1.Sum(reportShaData))
+}
+
+func comparatorText(comparator string) string {
+	switch comparator {
+	case "LT":
+		return "must not be less than"
+	case "GT":
+		return "must not be greater than"
+	case "EQ":
+		return "must not be equal to"
+	case "NE":
+		return "must be equal to"
+	}
+	return comparator
+}
@@ -0,0 +1,105 @@
+//go:build unit
+// +build unit
+
+package sonar
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateScanReport(t *testing.T) {
+	data := ReportData{
+		ProjectKey:     "SAP_jenkins-library",
+		ChangeID:       "42",
+		BranchName:     "feature",
+		NumberOfIssues: Issues{Blocker: 1, Critical: 2},
+		Coverage:       &SonarCoverage{Coverage: 71.4},
+	}
+
+	t.Run("quality gate failed", func(t *testing.T) {
+		data.QualityGate = &QualityGateStatus{
+			Status: QualityGateStatusError,
+			Conditions: []QualityGateCondition{
+				{Status: "ERROR", MetricKey: "new_coverage", Comparator: "LT", ErrorThreshold: "80", ActualValue: "71.4"},
+				{Status: "OK", MetricKey: "new_reliability_rating", Comparator: "GT", ErrorThreshold: "1", ActualValue: "1"},
+			},
+		}
+
+		scanReport := CreateScanReport(data, "https://sonar.example.org/dashboard?id=SAP_jenkins-library")
+
+		assert.False(t, scanReport.SuccessfulScan)
+		assert.Equal(t, "sonarExecuteScan", scanReport.StepName)
+		assert.Contains(t, scanReport.Subheaders, reporting.Subheader{Description: "Pull request", Details: "42"})
+		assert.Equal(t, reporting.OverviewRow{Description: "Blocker issues", Details: "1"}, scanReport.Overview[0])
+		assert.Contains(t, scanReport.Overview, reporting.OverviewRow{Description: "Coverage", Details: "71.4%"})
+		assert.Contains(t, scanReport.Overview, reporting.OverviewRow{Description: "Quality gate", Details: "ERROR", Style: reporting.Red})
+		assert.Contains(t, scanReport.Overview, reporting.OverviewRow{Description: "Quality gate condition new_coverage", Details: "71.4 (must not be less than 80)", Style: reporting.Red})
+		assert.NotContains(t, fmt.Sprint(scanReport.Overview), "new_reliability_rating")
+		if assert.Len(t, scanReport.DetailTable.Rows, 2) {
+			assert.Equal(t, "new_coverage", scanReport.DetailTable.Rows[0].Columns[0].Content)
+			assert.Equal(t, reporting.ColumnStyle(reporting.Red), scanReport.DetailTable.Rows[0].Columns[4].Style)
+			assert.Equal(t, reporting.ColumnStyle(reporting.Green), scanReport.DetailTable.Rows[1].Columns[4].Style)
+		}
+	})
+
+	t.Run("quality gate passed", func(t *testing.T) {
+		data.ChangeID = ""
+		data.QualityGate = &QualityGateStatus{Status: QualityGateStatusOK}
+
+		scanReport := CreateScanReport(data, "")
+
+		assert.True(t, scanReport.SuccessfulScan)
+		assert.Contains(t, scanReport.Subheaders, reporting.Subheader{Description: "Branch", Details: "feature"})
+		assert.Len(t, scanReport.Subheaders, 2)
+		assert.Contains(t, scanReport.Overview, reporting.OverviewRow{Description: "Quality gate", Details: "OK", Style: reporting.Green})
+		assert.Empty(t, scanReport.DetailTable.Rows)
+	})
+
+	t.Run("without quality gate", func(t *testing.T) {
+		data.QualityGate = nil
+
+		scanReport := CreateScanReport(data, "")
+
+		assert.True(t, scanReport.SuccessfulScan)
+		assert.Len(t, scanReport.Overview, 6)
+	})
+}
+
+func TestWriteScanReports(t *testing.T) {
+	data := ReportData{ProjectKey: "SAP_jenkins-library", BranchName: "feature"}
+	utils := &mock.FilesMock{}
+
+	reportPaths, err := WriteScanReports(data, CreateScanReport(data, ""), "sonar", utils)
+
+	assert.NoError(t, err)
+	if assert.Len(t, reportPaths, 1) {
+		assert.Equal(t, filepath.Join("sonar", "sonarscan.html"), reportPaths[0].Target)
+	}
+	assert.True(t, utils.HasWrittenFile(filepath.Join("sonar", "sonarscan.html")))
+	assert.True(t, utils.HasWrittenFile(filepath.Join(reporting.StepReportDirectory, fmt.Sprintf("sonarExecuteScan_%v.json", reportSha(data)))))
+}
+
+func TestWriteSarif(t *testing.T) {
+	utils := &mock.FilesMock{}
+	sarif := IssuesToSarif([]Issue{{Key: "1", Rule: "go:S1192", Severity: "MINOR", Component: "project:main.go"}}, nil, nil, "")
+
+	reportPaths, err := WriteSarif(sarif, "sonar", utils)
+
+	assert.NoError(t, err)
+	if assert.Len(t, reportPaths, 1) {
+		assert.Equal(t, filepath.Join("sonar", "sonarscan.sarif"), reportPaths[0].Target)
+	}
+	content, err := utils.FileRead(filepath.Join("sonar", "sonarscan.sarif"))
+	assert.NoError(t, err)
+	written := format.SARIF{}
+	assert.NoError(t, json.Unmarshal(content, &written))
+	assert.Equal(t, "main.go", written.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+}
@@ -0,0 +1,175 @@
+package sonar
+
+import (
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/format"
+)
+
+var sonarSeverityIndex = map[string]int{"BLOCKER": 5, "CRITICAL": 4, "MAJOR": 3, "MINOR": 2, "INFO": 1}
+
+var hotspotProbabilityIndex = map[string]int{"HIGH": 3, "MEDIUM": 2, "LOW": 1}
+
+// IssuesToSarif converts the issues and security hotspots of a SonarQube project into a SARIF document.
+// The caller is responsible to pass only security related issues, i.e. issues of type VULNERABILITY.
+func IssuesToSarif(issues []Issue, hotspots []Hotspot, components []IssueComponent, serverURL string) format.SARIF {
+	converter := sarifConverter{
+		paths:       map[string]string{},
+		ruleIndices: map[string]int{},
+		serverURL:   strings.TrimSuffix(serverURL, "/"),
+		run: format.Runs{
+			Tool: format.Tool{Driver: format.Driver{
+				Name:           "SonarQube",
+				InformationUri: "https://www.sonarsource.com/products/sonarqube/",
+				Rules:          []format.SarifRule{},
+			}},
+			Results: []format.Results{},
+		},
+	}
+	for _, component := range components {
+		converter.paths[component.Key] = component.Path
+	}
+
+	for _, issue := range issues {
+		result := format.Results{
+			RuleID:    issue.Rule,
+			RuleIndex: converter.ruleIndex(issue.Rule, append([]string{strings.ToLower(issue.Type)}, issue.Tags...)),
+			Level:     sonarSeverityToLevel(issue.Severity),
+			Message:   &format.Message{Text: issue.Message},
+			Properties: &format.SarifProperties{
+				InstanceID:        issue.Key,
+				ToolSeverity:      issue.Severity,
+				ToolSeverityIndex: sonarSeverityIndex[issue.Severity],
+				ToolState:         issue.Status,
+				UnifiedSeverity:   sonarSeverityToUnifiedSeverity(issue.Severity),
+			},
+			Locations: []format.Location{converter.location(issue.Component, issue.TextRange, issue.Line)},
+		}
+		if len(issue.Hash) > 0 {
+			result.PartialFingerprints = format.PartialFingerprints{PrimaryLocationLineHash: issue.Hash}
+		}
+		converter.run.Results = append(converter.run.Results, result)
+	}
+
+	for _, hotspot := range hotspots {
+		tags := []string{"security_hotspot"}
+		if len(hotspot.SecurityCategory) > 0 {
+			tags = append(tags, hotspot.SecurityCategory)
+		}
+		result := format.Results{
+			RuleID:    hotspot.RuleKey,
+			RuleIndex: converter.ruleIndex(hotspot.RuleKey, tags),
+			Level:     hotspotProbabilityToLevel(hotspot.VulnerabilityProbability),
+			Message:   &format.Message{Text: hotspot.Message},
+			Properties: &format.SarifProperties{
+				InstanceID:        hotspot.Key,
+				ToolSeverity:      hotspot.VulnerabilityProbability,
+				ToolSeverityIndex: hotspotProbabilityIndex[hotspot.VulnerabilityProbability],
+				ToolState:         hotspot.Status,
+				UnifiedSeverity:   hotspotProbabilityToUnifiedSeverity(hotspot.VulnerabilityProbability),
+			},
+			Locations: []format.Location{converter.location(hotspot.Component, hotspot.TextRange, hotspot.Line)},
+		}
+		converter.run.Results = append(converter.run.Results, result)
+	}
+
+	return format.SARIF{
+		Schema:  "https://docs.oasis-open.org/sarif/sarif/v2.1.0/cos02/schemas/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []format.Runs{converter.run},
+	}
+}
+
+type sarifConverter struct {
+	paths       map[string]string
+	ruleIndices map[string]int
+	serverURL   string
+	run         format.Runs
+}
+
+// ruleIndex returns the index of the rule in the tool driver and adds the rule if it is not known yet
+func (converter *sarifConverter) ruleIndex(ruleKey string, tags []string) int {
+	if index, known := converter.ruleIndices[ruleKey]; known {
+		return index
+	}
+	index := len(converter.run.Tool.Driver.Rules)
+	converter.ruleIndices[ruleKey] = index
+	rule := format.SarifRule{
+		ID:         ruleKey,
+		Name:       ruleKey,
+		Properties: &format.SarifRuleProperties{Tags: tags},
+	}
+	if len(converter.serverURL) > 0 {
+		rule.HelpURI = converter.serverURL + "/coding_rules?open=" + ruleKey + "&rule_key=" + ruleKey
+	}
+	converter.run.Tool.Driver.Rules = append(converter.run.Tool.Driver.Rules, rule)
+	return index
+}
+
+func (converter *sarifConverter) location(component string, textRange *TextRange, line int) format.Location {
+	path, ok := converter.paths[component]
+	if !ok || len(path) == 0 {
+		// component keys are prefixed with the project key, e.g. my-project:src/main.go
+		path = component[strings.Index(component, ":")+1:]
+	}
+	location := format.Location{PhysicalLocation: format.PhysicalLocation{ArtifactLocation: format.ArtifactLocation{URI: path}}}
+	if textRange != nil {
+		location.PhysicalLocation.Region = format.Region{
+			StartLine:   textRange.StartLine,
+			StartColumn: textRange.StartOffset + 1,
+			EndLine:     textRange.EndLine,
+			EndColumn:   textRange.EndOffset + 1,
+		}
+	} else if line > 0 {
+		location.PhysicalLocation.Region = format.Region{StartLine: line}
+	}
+	return location
+}
+
+func sonarSeverityToLevel(severity string) string {
+	switch severity {
+	case "BLOCKER", "CRITICAL":
+		return "error"
+	case "MAJOR":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func sonarSeverityToUnifiedSeverity(severity string) string {
+	switch severity {
+	case "BLOCKER":
+		return format.SeverityCritical
+	case "CRITICAL":
+		return format.SeverityHigh
+	case "MAJOR":
+		return format.SeverityMedium
+	case "MINOR":
+		return format.SeverityLow
+	default:
+		return format.SeverityInfo
+	}
+}
+
+func hotspotProbabilityToLevel(probability string) string {
+	switch probability {
+	case "HIGH":
+		return "error"
+	case "MEDIUM":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func hotspotProbabilityToUnifiedSeverity(probability string) string {
+	switch probability {
+	case "HIGH":
+		return format.SeverityHigh
+	case "MEDIUM":
+		return format.SeverityMedium
+	default:
+		return format.SeverityLow
+	}
+}
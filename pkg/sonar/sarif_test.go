@@ -0,0 +1,110 @@
+//go:build unit
+// +build unit
+
+package sonar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssuesToSarif(t *testing.T) {
+	issues := []Issue{
+		{
+			Key:       "AXW3MmCVOYWf3_DBLGvL",
+			Rule:      "go:S3776",
+			Severity:  "CRITICAL",
+			Component: "SAP_jenkins-library:cmd/fortifyExecuteScan.go",
+			Hash:      "a154a51bdb1502a2ac057a348d08e7f6",
+			TextRange: &TextRange{StartLine: 647, EndLine: 647, StartOffset: 5, EndOffset: 26},
+			Status:    "OPEN",
+			Message:   "Refactor this method to reduce its Cognitive Complexity from 22 to the 15 allowed.",
+			Tags:      []string{"brain-overload"},
+			Type:      "VULNERABILITY",
+		},
+		{
+			Key:       "AXW3MmCVOYWf3_DBLGvM",
+			Rule:      "go:S3776",
+			Severity:  "BLOCKER",
+			Component: "SAP_jenkins-library:cmd/piper.go",
+			Line:      12,
+			Status:    "CONFIRMED",
+			Message:   "Refactor this method.",
+			Type:      "VULNERABILITY",
+		},
+		{
+			Key:       "AXW3MmCVOYWf3_DBLGvN",
+			Rule:      "go:S1192",
+			Severity:  "MINOR",
+			Component: "SAP_jenkins-library",
+			Status:    "OPEN",
+			Message:   "Define a constant.",
+			Type:      "VULNERABILITY",
+		},
+	}
+	hotspots := []Hotspot{
+		{
+			Key:                      "AXW3MmCVOYWf3_DBLGvO",
+			RuleKey:                  "go:S4790",
+			VulnerabilityProbability: "MEDIUM",
+			SecurityCategory:         "weak-cryptography",
+			Component:                "SAP_jenkins-library:cmd/fortifyExecuteScan.go",
+			Line:                     42,
+			Status:                   "TO_REVIEW",
+			Message:                  "Make sure this weak hash algorithm is not used in a sensitive context here.",
+		},
+	}
+	components := []IssueComponent{{Key: "SAP_jenkins-library:cmd/fortifyExecuteScan.go", Path: "cmd/fortifyExecuteScan.go"}}
+
+	sarif := IssuesToSarif(issues, hotspots, components, "https://sonar.example.org/")
+
+	assert.Equal(t, "2.1.0", sarif.Version)
+	if assert.Len(t, sarif.Runs, 1) {
+		run := sarif.Runs[0]
+		assert.Equal(t, "SonarQube", run.Tool.Driver.Name)
+		if assert.Len(t, run.Tool.Driver.Rules, 3) {
+			assert.Equal(t, "go:S3776", run.Tool.Driver.Rules[0].ID)
+			assert.Equal(t, "https://sonar.example.org/coding_rules?open=go:S3776&rule_key=go:S3776", run.Tool.Driver.Rules[0].HelpURI)
+			assert.Equal(t, []string{"vulnerability", "brain-overload"}, run.Tool.Driver.Rules[0].Properties.Tags)
+			assert.Equal(t, []string{"security_hotspot", "weak-cryptography"}, run.Tool.Driver.Rules[2].Properties.Tags)
+		}
+		if assert.Len(t, run.Results, 4) {
+			first := run.Results[0]
+			assert.Equal(t, "error", first.Level)
+			assert.Equal(t, 0, first.RuleIndex)
+			assert.Equal(t, "a154a51bdb1502a2ac057a348d08e7f6", first.PartialFingerprints.PrimaryLocationLineHash)
+			assert.Equal(t, "cmd/fortifyExecuteScan.go", first.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+			assert.Equal(t, 647, first.Locations[0].PhysicalLocation.Region.StartLine)
+			assert.Equal(t, 6, first.Locations[0].PhysicalLocation.Region.StartColumn)
+			assert.Equal(t, 27, first.Locations[0].PhysicalLocation.Region.EndColumn)
+			assert.Equal(t, "AXW3MmCVOYWf3_DBLGvL", first.Properties.InstanceID)
+			assert.Equal(t, "high", first.Properties.UnifiedSeverity)
+			assert.Equal(t, 4, first.Properties.ToolSeverityIndex)
+
+			second := run.Results[1]
+			assert.Equal(t, 0, second.RuleIndex)
+			assert.Equal(t, "critical", second.Properties.UnifiedSeverity)
+			assert.Equal(t, "CONFIRMED", second.Properties.ToolState)
+			assert.Equal(t, "cmd/piper.go", second.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+			assert.Equal(t, 12, second.Locations[0].PhysicalLocation.Region.StartLine)
+
+			third := run.Results[2]
+			assert.Equal(t, "note", third.Level)
+			assert.Equal(t, 1, third.RuleIndex)
+			assert.Equal(t, "low", third.Properties.UnifiedSeverity)
+			assert.Equal(t, "SAP_jenkins-library", third.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+
+			hotspot := run.Results[3]
+			assert.Equal(t, "go:S4790", hotspot.RuleID)
+			assert.Equal(t, 2, hotspot.RuleIndex)
+			assert.Equal(t, "warning", hotspot.Level)
+			assert.Equal(t, "medium", hotspot.Properties.UnifiedSeverity)
+			assert.Equal(t, "MEDIUM", hotspot.Properties.ToolSeverity)
+			assert.Equal(t, "TO_REVIEW", hotspot.Properties.ToolState)
+			assert.Equal(t, "AXW3MmCVOYWf3_DBLGvO", hotspot.Properties.InstanceID)
+			assert.Equal(t, "cmd/fortifyExecuteScan.go", hotspot.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+			assert.Equal(t, 42, hotspot.Locations[0].PhysicalLocation.Region.StartLine)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/pkg/errors"
 
@@ -107,6 +108,9 @@ func readCommonPipelineEnvironment(filePath string) string {
 		log.Entry().Debugf("Could not read %v file. %v", filePath, err)
 		contentFile = []byte("N/A")
 	}
+	if piperenv.IsEncrypted(contentFile) {
+		return piperenv.GetParameter(".pipeline/commonPipelineEnvironment", filePath)
+	}
 	return string(contentFile)
 }
 
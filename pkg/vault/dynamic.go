@@ -0,0 +1,87 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// DynamicSecret holds credentials issued by a dynamic secrets engine together with their lease
+type DynamicSecret struct {
+	LeaseID       string
+	LeaseDuration time.Duration
+	Renewable     bool
+	// Data contains the fields of the response, lists (e.g. the ca_chain of a PKI certificate) are joined by line breaks
+	Data map[string]string
+}
+
+// GetDynamicSecret requests credentials from a dynamic secrets engine, e.g. database/creds/<role>, aws/creds/<role> or pki/issue/<role>.
+// Without data the path is read, otherwise data is written to the path as it is required e.g. by the PKI engine (common_name, ttl, ...).
+// The returned lease should be revoked with RevokeLease as soon as the credentials are not needed anymore.
+func (c *Client) GetDynamicSecret(path string, data map[string]interface{}) (*DynamicSecret, error) {
+	path = sanitizePath(path)
+	var secret *api.Secret
+	var err error
+	if len(data) == 0 {
+		secret, err = c.logical.Read(path)
+	} else {
+		secret, err = c.logical.Write(path, data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to request dynamic secret '%s': %w", path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("failed to request dynamic secret '%s': empty response", path)
+	}
+
+	dynamicSecret := &DynamicSecret{
+		LeaseID:       secret.LeaseID,
+		LeaseDuration: time.Duration(secret.LeaseDuration) * time.Second,
+		Renewable:     secret.Renewable,
+		Data:          make(map[string]string, len(secret.Data)),
+	}
+	for field, value := range secret.Data {
+		dynamicSecret.Data[field] = dynamicSecretField(value)
+	}
+	return dynamicSecret, nil
+}
+
+// RevokeLease revokes the lease of a dynamic secret, the credentials become invalid immediately
+func (c *Client) RevokeLease(leaseID string) error {
+	if len(leaseID) == 0 {
+		return nil
+	}
+	_, err := c.logical.Write("sys/leases/revoke", map[string]interface{}{
+		"lease_id": leaseID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke lease '%s': %w", leaseID, err)
+	}
+	return nil
+}
+
+func dynamicSecretField(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, dynamicSecretField(item))
+		}
+		return strings.Join(items, "\n")
+	case map[string]interface{}:
+		content, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(content)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,170 @@
+package vault
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+)
+
+const defaultTransitMountPath = "transit"
+
+// TransitKeyProvider encrypts and decrypts data with a named key of the transit secrets engine using envelope encryption.
+// A single data key is requested from Vault per run and used to encrypt all values locally with AES-GCM. Only the data key
+// wrapped by the transit key is stored next to each value, i.e. the key material of the transit key never leaves Vault.
+type TransitKeyProvider struct {
+	client    *Client
+	mountPath string
+	keyName   string
+
+	mutex sync.Mutex
+	// wrappedDataKey and dataKey are used for encryption, created with the first encrypted value
+	wrappedDataKey string
+	dataKey        []byte
+	// dataKeys caches the data keys unwrapped by Vault for decryption
+	dataKeys map[string][]byte
+}
+
+// NewTransitKeyProvider creates a key provider for the given key. The key is either just the key name,
+// in which case the transit engine is expected at the default mount path "transit", or "<mountPath>/<keyName>".
+func (c *Client) NewTransitKeyProvider(key string) (*TransitKeyProvider, error) {
+	key = sanitizePath(key)
+	if len(key) == 0 {
+		return nil, fmt.Errorf("transit key name must not be empty")
+	}
+	mountPath, keyName := defaultTransitMountPath, key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		mountPath, keyName = key[:i], key[i+1:]
+	}
+	return &TransitKeyProvider{client: c, mountPath: mountPath, keyName: keyName, dataKeys: map[string][]byte{}}, nil
+}
+
+// Name returns the name of the key provider
+func (p *TransitKeyProvider) Name() string {
+	return "vaultTransit"
+}
+
+// Encrypt encrypts the plain text with the data key of the run. The result consists of the wrapped data key (vault:v<n>:...),
+// a line break and the random nonce followed by the cipher text.
+func (p *TransitKeyProvider) Encrypt(plainText []byte) ([]byte, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.dataKey == nil {
+		if err := p.createDataKey(); err != nil {
+			return nil, err
+		}
+	}
+	aead, err := newDataKeyCipher(p.dataKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plainText)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to init nonce: %w", err)
+	}
+	cipherText := append([]byte(p.wrappedDataKey+"\n"), aead.Seal(nonce, nonce, plainText, nil)...)
+	return cipherText, nil
+}
+
+// Decrypt decrypts cipher text created by Encrypt, Vault is only called once per data key
+func (p *TransitKeyProvider) Decrypt(cipherText []byte) ([]byte, error) {
+	i := bytes.IndexByte(cipherText, '\n')
+	if i < 0 {
+		return nil, fmt.Errorf("invalid ciphertext: wrapped data key is missing")
+	}
+	wrappedDataKey, cipherText := string(cipherText[:i]), cipherText[i+1:]
+
+	p.mutex.Lock()
+	dataKey, ok := p.dataKeys[wrappedDataKey]
+	if !ok {
+		var err error
+		if dataKey, err = p.unwrapDataKey(wrappedDataKey); err != nil {
+			p.mutex.Unlock()
+			return nil, err
+		}
+		p.dataKeys[wrappedDataKey] = dataKey
+	}
+	p.mutex.Unlock()
+
+	aead, err := newDataKeyCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(cipherText) < aead.NonceSize() {
+		return nil, fmt.Errorf("invalid ciphertext: too short")
+	}
+	nonce, cipherText := cipherText[:aead.NonceSize()], cipherText[aead.NonceSize():]
+	plainText, err := aead.Open(nil, nonce, cipherText, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: corrupted data")
+	}
+	return plainText, nil
+}
+
+// createDataKey requests a new data key from the datakey endpoint of the transit engine
+func (p *TransitKeyProvider) createDataKey() error {
+	dataKeyPath := path.Join(p.mountPath, "datakey", "plaintext", p.keyName)
+	secret, err := p.client.logical.Write(dataKeyPath, map[string]interface{}{"bits": 256})
+	if err != nil {
+		return fmt.Errorf("failed to create data key with transit key '%s': %w", dataKeyPath, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return fmt.Errorf("failed to create data key with transit key '%s': empty response", dataKeyPath)
+	}
+	wrappedDataKey, ok := secret.Data["ciphertext"].(string)
+	if !ok {
+		return fmt.Errorf("expected 'ciphertext' field in response of '%s' to be a string but got %T instead", dataKeyPath, secret.Data["ciphertext"])
+	}
+	encodedDataKey, ok := secret.Data["plaintext"].(string)
+	if !ok {
+		return fmt.Errorf("expected 'plaintext' field in response of '%s' to be a string but got %T instead", dataKeyPath, secret.Data["plaintext"])
+	}
+	dataKey, err := base64.StdEncoding.DecodeString(encodedDataKey)
+	if err != nil {
+		return fmt.Errorf("failed to decode data key: %w", err)
+	}
+	p.wrappedDataKey, p.dataKey = wrappedDataKey, dataKey
+	p.dataKeys[wrappedDataKey] = dataKey
+	return nil
+}
+
+// unwrapDataKey sends a wrapped data key to the decrypt endpoint of the transit engine
+func (p *TransitKeyProvider) unwrapDataKey(wrappedDataKey string) ([]byte, error) {
+	decryptPath := path.Join(p.mountPath, "decrypt", p.keyName)
+	secret, err := p.client.logical.Write(decryptPath, map[string]interface{}{
+		"ciphertext": wrappedDataKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data key with transit key '%s': %w", decryptPath, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("failed to decrypt data key with transit key '%s': empty response", decryptPath)
+	}
+	encodedDataKey, ok := secret.Data["plaintext"].(string)
+	if !ok {
+		return nil, fmt.Errorf("expected 'plaintext' field in response of '%s' to be a string but got %T instead", decryptPath, secret.Data["plaintext"])
+	}
+	dataKey, err := base64.StdEncoding.DecodeString(encodedDataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode data key: %w", err)
+	}
+	return dataKey, nil
+}
+
+func newDataKeyCipher(dataKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return aead, nil
+}
@@ -0,0 +1,86 @@
+//go:build unit
+// +build unit
+
+package vault
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/jenkins-library/pkg/vault/mocks"
+)
+
+func TestTransitKeyProvider(t *testing.T) {
+	// base64 encoded data key "0123456789abcdef0123456789abcdef"
+	dataKey := "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+	t.Run("encrypt and decrypt", func(t *testing.T) {
+		vaultMock := &mocks.VaultMock{}
+		client := Client{nil, vaultMock, &ClientConfig{}}
+		vaultMock.On("Write", "transit/datakey/plaintext/piper", map[string]interface{}{"bits": 256}).
+			Return(&api.Secret{Data: map[string]interface{}{"ciphertext": "vault:v1:abcd", "plaintext": dataKey}}, nil).Once()
+		vaultMock.On("Write", "transit/decrypt/piper", map[string]interface{}{"ciphertext": "vault:v1:abcd"}).
+			Return(&api.Secret{Data: map[string]interface{}{"plaintext": dataKey}}, nil).Once()
+
+		provider, err := client.NewTransitKeyProvider("piper")
+		assert.NoError(t, err)
+		assert.Equal(t, "vaultTransit", provider.Name())
+
+		// all values are encrypted with the same data key
+		first, err := provider.Encrypt([]byte("hello world"))
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(first), "vault:v1:abcd\n"))
+		assert.NotContains(t, string(first), "hello world")
+		second, err := provider.Encrypt([]byte("hello again"))
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(second), "vault:v1:abcd\n"))
+
+		// a later run unwraps the data key once
+		otherProvider, _ := client.NewTransitKeyProvider("piper")
+		plainText, err := otherProvider.Decrypt(first)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world", string(plainText))
+		plainText, err = otherProvider.Decrypt(second)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello again", string(plainText))
+
+		vaultMock.AssertExpectations(t)
+	})
+
+	t.Run("custom mount path", func(t *testing.T) {
+		vaultMock := &mocks.VaultMock{}
+		client := Client{nil, vaultMock, &ClientConfig{}}
+		vaultMock.On("Write", "team/transit/datakey/plaintext/piper", map[string]interface{}{"bits": 256}).
+			Return(&api.Secret{Data: map[string]interface{}{"ciphertext": "vault:v2:efgh", "plaintext": dataKey}}, nil)
+
+		provider, err := client.NewTransitKeyProvider("/team/transit/piper")
+		assert.NoError(t, err)
+
+		cipherText, err := provider.Encrypt([]byte("test"))
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(cipherText), "vault:v2:efgh\n"))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		vaultMock := &mocks.VaultMock{}
+		client := Client{nil, vaultMock, &ClientConfig{}}
+		vaultMock.On("Write", "transit/datakey/plaintext/piper", map[string]interface{}{"bits": 256}).Return(nil, fmt.Errorf("permission denied"))
+		vaultMock.On("Write", "transit/decrypt/piper", map[string]interface{}{"ciphertext": "vault:v1:abcd"}).
+			Return(&api.Secret{Data: map[string]interface{}{}}, nil)
+
+		_, err := client.NewTransitKeyProvider("")
+		assert.EqualError(t, err, "transit key name must not be empty")
+
+		provider, _ := client.NewTransitKeyProvider("piper")
+		_, err = provider.Encrypt([]byte("test"))
+		assert.EqualError(t, err, "failed to create data key with transit key 'transit/datakey/plaintext/piper': permission denied")
+		_, err = provider.Decrypt([]byte("vault:v1:abcd\n0123456789abcdef"))
+		assert.EqualError(t, err, "expected 'plaintext' field in response of 'transit/decrypt/piper' to be a string but got <nil> instead")
+		_, err = provider.Decrypt([]byte("vault:v1:abcd"))
+		assert.EqualError(t, err, "invalid ciphertext: wrapped data key is missing")
+	})
+}
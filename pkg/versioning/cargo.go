@@ -0,0 +1,189 @@
+package versioning
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/package-url/packageurl-go"
+)
+
+// Cargo defines an artifact using a Rust Cargo.toml manifest for versioning
+type Cargo struct {
+	path     string
+	utils    Utils
+	content  string
+	manifest cargoManifest
+}
+
+type cargoManifest struct {
+	Package struct {
+		Name    string      `toml:"name"`
+		Version interface{} `toml:"version"`
+	} `toml:"package"`
+	Workspace struct {
+		Package struct {
+			Version string `toml:"version"`
+		} `toml:"package"`
+	} `toml:"workspace"`
+}
+
+func (c *Cargo) init() error {
+	if c.utils == nil {
+		return fmt.Errorf("no file utils provided")
+	}
+	if len(c.path) == 0 {
+		c.path = "Cargo.toml"
+	}
+	if len(c.content) == 0 {
+		content, err := c.utils.FileRead(c.path)
+		if err != nil {
+			return fmt.Errorf("failed to read file '%v': %w", c.path, err)
+		}
+		if _, err := toml.Decode(string(content), &c.manifest); err != nil {
+			return fmt.Errorf("Cargo manifest content invalid '%v': %w", c.path, err)
+		}
+		c.content = string(content)
+	}
+	return nil
+}
+
+// versionTable returns the table containing the version, which is either the package itself or the workspace in case the version is inherited
+func (c *Cargo) versionTable() (string, string, error) {
+	if version, ok := c.manifest.Package.Version.(string); ok && len(version) > 0 {
+		return "package", version, nil
+	}
+	if len(c.manifest.Workspace.Package.Version) > 0 {
+		return "workspace.package", c.manifest.Workspace.Package.Version, nil
+	}
+	if c.manifest.Package.Version != nil {
+		return "", "", fmt.Errorf("version of '%v' is inherited from the workspace, please use the Cargo.toml of the workspace", c.path)
+	}
+	return "", "", fmt.Errorf("no version found in '%v'", c.path)
+}
+
+// VersioningScheme returns the relevant versioning scheme
+func (c *Cargo) VersioningScheme() string {
+	return "semver2"
+}
+
+// GetVersion returns the current version of the package defined in Cargo.toml
+func (c *Cargo) GetVersion() (string, error) {
+	if err := c.init(); err != nil {
+		return "", fmt.Errorf("failed to init Cargo versioning: %w", err)
+	}
+	_, version, err := c.versionTable()
+	return version, err
+}
+
+// SetVersion updates the version of the package defined in Cargo.toml as well as in the Cargo.lock next to it
+func (c *Cargo) SetVersion(version string) error {
+	if err := c.init(); err != nil {
+		return fmt.Errorf("failed to init Cargo versioning: %w", err)
+	}
+	table, previousVersion, err := c.versionTable()
+	if err != nil {
+		return err
+	}
+	content, err := setTomlString(c.content, table, "version", version)
+	if err != nil {
+		return fmt.Errorf("failed to update version in '%v': %w", c.path, err)
+	}
+	if err := c.utils.FileWrite(c.path, []byte(content), 0666); err != nil {
+		return fmt.Errorf("failed to write file '%v': %w", c.path, err)
+	}
+	packageName := ""
+	if table == "package" {
+		packageName = c.manifest.Package.Name
+	}
+	c.content, c.manifest = "", cargoManifest{}
+	return c.setLockFileVersion(packageName, previousVersion, version)
+}
+
+// setLockFileVersion updates the version of the local packages in the Cargo.lock, if it exists.
+// Without package name all local packages with the previous version are updated, since the names of the
+// members of a workspace which inherit its version are only defined in their manifests.
+func (c *Cargo) setLockFileVersion(packageName, previousVersion, version string) error {
+	lockPath := filepath.Join(filepath.Dir(c.path), "Cargo.lock")
+	if exists, _ := c.utils.FileExists(lockPath); !exists {
+		return nil
+	}
+	content, err := c.utils.FileRead(lockPath)
+	if err != nil {
+		return fmt.Errorf("failed to read file '%v': %w", lockPath, err)
+	}
+	lines := strings.Split(string(content), "\n")
+	updated := 0
+	for _, entry := range cargoLockPackages(lines) {
+		// packages of registries and git repositories have a source, local packages do not
+		if entry.hasSource || entry.versionLine < 0 || entry.version != previousVersion ||
+			(len(packageName) > 0 && entry.name != packageName) {
+			continue
+		}
+		match := tomlStringKeyValue.FindStringSubmatch(lines[entry.versionLine])
+		lines[entry.versionLine] = fmt.Sprintf("%v\"%v\"%v", match[1], version, match[4])
+		updated++
+	}
+	if updated == 0 {
+		return nil
+	}
+	if err := c.utils.FileWrite(lockPath, []byte(strings.Join(lines, "\n")), 0666); err != nil {
+		return fmt.Errorf("failed to write file '%v': %w", lockPath, err)
+	}
+	return nil
+}
+
+type cargoLockPackage struct {
+	name        string
+	version     string
+	versionLine int
+	hasSource   bool
+}
+
+// cargoLockPackages returns the [[package]] entries of a Cargo.lock together with the line of their version
+func cargoLockPackages(lines []string) []cargoLockPackage {
+	packages := []cargoLockPackage{}
+	var current *cargoLockPackage
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			current = nil
+			if trimmed == "[[package]]" {
+				packages = append(packages, cargoLockPackage{versionLine: -1})
+				current = &packages[len(packages)-1]
+			}
+			continue
+		}
+		match := tomlStringKeyValue.FindStringSubmatch(line)
+		if current == nil || match == nil {
+			continue
+		}
+		value := strings.Trim(match[3], `"'`)
+		switch match[2] {
+		case "name":
+			current.name = value
+		case "version":
+			current.version, current.versionLine = value, i
+		case "source":
+			current.hasSource = true
+		}
+	}
+	return packages
+}
+
+// GetCoordinates returns the coordinates of the package defined in Cargo.toml
+func (c *Cargo) GetCoordinates() (Coordinates, error) {
+	version, err := c.GetVersion()
+	if err != nil {
+		return Coordinates{}, err
+	}
+	result := Coordinates{
+		ArtifactID: c.manifest.Package.Name,
+		Version:    version,
+	}
+	if len(result.ArtifactID) > 0 {
+		result.PURL = packageurl.NewPackageURL(packageurl.TypeCargo, "", result.ArtifactID, version, nil, "").ToString()
+	}
+	return result, nil
+}
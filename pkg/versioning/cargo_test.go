@@ -0,0 +1,176 @@
+//go:build unit
+// +build unit
+
+package versioning
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const cargoManifestContent = `[package]
+name = "my-crate"
+version = "1.2.3" # keep in sync with the changelog
+edition = "2021"
+
+[dependencies]
+serde = { version = "1.0", features = ["derive"] }
+`
+
+func TestCargoGetVersion(t *testing.T) {
+	t.Run("success case", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("Cargo.toml", []byte(cargoManifestContent))
+
+		cargo := Cargo{utils: fileUtils}
+
+		version, err := cargo.GetVersion()
+		assert.NoError(t, err)
+		assert.Equal(t, "1.2.3", version)
+	})
+
+	t.Run("success case - workspace", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("Cargo.toml", []byte("[workspace]\nmembers = [\"a\", \"b\"]\n\n[workspace.package]\nversion = \"0.4.0\"\n"))
+
+		cargo := Cargo{utils: fileUtils}
+
+		version, err := cargo.GetVersion()
+		assert.NoError(t, err)
+		assert.Equal(t, "0.4.0", version)
+	})
+
+	t.Run("error case - version inherited from workspace", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("a/Cargo.toml", []byte("[package]\nname = \"a\"\nversion.workspace = true\n"))
+
+		cargo := Cargo{utils: fileUtils, path: "a/Cargo.toml"}
+
+		_, err := cargo.GetVersion()
+		assert.EqualError(t, err, "version of 'a/Cargo.toml' is inherited from the workspace, please use the Cargo.toml of the workspace")
+	})
+
+	t.Run("error case - manifest invalid", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("Cargo.toml", []byte("[package"))
+
+		cargo := Cargo{utils: fileUtils}
+
+		_, err := cargo.GetVersion()
+		assert.Contains(t, fmt.Sprint(err), "failed to init Cargo versioning: Cargo manifest content invalid 'Cargo.toml'")
+	})
+
+	t.Run("error case - no utils", func(t *testing.T) {
+		cargo := Cargo{}
+
+		_, err := cargo.GetVersion()
+		assert.EqualError(t, err, "failed to init Cargo versioning: no file utils provided")
+	})
+}
+
+const cargoLockContent = `# This file is automatically @generated by Cargo.
+# It is not intended for manual editing.
+version = 3
+
+[[package]]
+name = "my-crate"
+version = "1.2.3"
+dependencies = [
+ "my-crate-macros",
+ "other",
+]
+
+[[package]]
+name = "my-crate-macros"
+version = "1.2.3"
+
+[[package]]
+name = "other"
+version = "1.2.3"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "0123456789abcdef"
+`
+
+func TestCargoSetVersion(t *testing.T) {
+	t.Run("success case", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("Cargo.toml", []byte(cargoManifestContent))
+
+		cargo := Cargo{utils: fileUtils}
+
+		err := cargo.SetVersion("1.3.0")
+		assert.NoError(t, err)
+
+		content, err := fileUtils.FileRead("Cargo.toml")
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "version = \"1.3.0\" # keep in sync with the changelog\n")
+		assert.Contains(t, string(content), `serde = { version = "1.0", features = ["derive"] }`)
+
+		version, err := cargo.GetVersion()
+		assert.NoError(t, err)
+		assert.Equal(t, "1.3.0", version)
+	})
+
+	t.Run("success case - lock file", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("crate/Cargo.toml", []byte(cargoManifestContent))
+		fileUtils.AddFile("crate/Cargo.lock", []byte(cargoLockContent))
+
+		cargo := Cargo{path: "crate/Cargo.toml", utils: fileUtils}
+
+		err := cargo.SetVersion("1.3.0")
+		assert.NoError(t, err)
+
+		content, err := fileUtils.FileRead("crate/Cargo.lock")
+		assert.NoError(t, err)
+		assert.Equal(t, strings.Replace(cargoLockContent, "name = \"my-crate\"\nversion = \"1.2.3\"", "name = \"my-crate\"\nversion = \"1.3.0\"", 1), string(content))
+	})
+
+	t.Run("success case - workspace lock file", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("Cargo.toml", []byte(`[workspace]
+members = ["crates/*"]
+
+[workspace.package]
+version = "1.2.3"
+`))
+		fileUtils.AddFile("Cargo.lock", []byte(cargoLockContent))
+
+		cargo := Cargo{utils: fileUtils}
+
+		err := cargo.SetVersion("1.3.0")
+		assert.NoError(t, err)
+
+		content, err := fileUtils.FileRead("Cargo.lock")
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "name = \"my-crate\"\nversion = \"1.3.0\"\n")
+		assert.Contains(t, string(content), "name = \"my-crate-macros\"\nversion = \"1.3.0\"\n")
+		// dependencies of registries are not updated even with the same version
+		assert.Contains(t, string(content), "name = \"other\"\nversion = \"1.2.3\"\nsource = ")
+	})
+
+	t.Run("error case - write failed", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("Cargo.toml", []byte(cargoManifestContent))
+		fileUtils.FileWriteError = fmt.Errorf("write error")
+
+		cargo := Cargo{utils: fileUtils}
+
+		err := cargo.SetVersion("1.3.0")
+		assert.EqualError(t, err, "failed to write file 'Cargo.toml': write error")
+	})
+}
+
+func TestCargoGetCoordinates(t *testing.T) {
+	fileUtils := newVersioningMockUtils()
+	fileUtils.AddFile("Cargo.toml", []byte(cargoManifestContent))
+
+	cargo := Cargo{utils: fileUtils}
+
+	coordinates, err := cargo.GetCoordinates()
+	assert.NoError(t, err)
+	assert.Equal(t, Coordinates{ArtifactID: "my-crate", Version: "1.2.3", PURL: "pkg:cargo/my-crate@1.2.3"}, coordinates)
+}
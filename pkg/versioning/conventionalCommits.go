@@ -0,0 +1,205 @@
+package versioning
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ReleaseType defines which part of a semantic version is incremented by a release
+type ReleaseType int
+
+const (
+	// ReleaseNone indicates that no release relevant change is available
+	ReleaseNone ReleaseType = iota
+	// ReleasePatch increments the patch version
+	ReleasePatch
+	// ReleaseMinor increments the minor version
+	ReleaseMinor
+	// ReleaseMajor increments the major version
+	ReleaseMajor
+)
+
+func (r ReleaseType) String() string {
+	switch r {
+	case ReleasePatch:
+		return "patch"
+	case ReleaseMinor:
+		return "minor"
+	case ReleaseMajor:
+		return "major"
+	}
+	return "none"
+}
+
+// ConventionalCommit contains the information of a commit message following the Conventional Commits specification (https://www.conventionalcommits.org/)
+type ConventionalCommit struct {
+	Hash                string
+	Type                string
+	Scope               string
+	Description         string
+	Breaking            bool
+	BreakingDescription string
+}
+
+var (
+	conventionalCommitHeader = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?: +(.+)$`)
+	breakingChangeFooter     = regexp.MustCompile(`^BREAKING[ -]CHANGE: *(.+)$`)
+)
+
+// ParseConventionalCommit parses a commit message, false is returned in case the message does not follow the Conventional Commits specification
+func ParseConventionalCommit(hash, message string) (ConventionalCommit, bool) {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	header := conventionalCommitHeader.FindStringSubmatch(strings.TrimSpace(lines[0]))
+	if header == nil {
+		return ConventionalCommit{}, false
+	}
+
+	commit := ConventionalCommit{
+		Hash:        hash,
+		Type:        strings.ToLower(header[1]),
+		Scope:       strings.TrimSpace(header[2]),
+		Description: strings.TrimSpace(header[4]),
+		Breaking:    header[3] == "!",
+	}
+	for _, line := range lines[1:] {
+		if footer := breakingChangeFooter.FindStringSubmatch(strings.TrimSpace(line)); footer != nil {
+			commit.Breaking = true
+			commit.BreakingDescription = strings.TrimSpace(footer[1])
+			break
+		}
+	}
+	if commit.Breaking && len(commit.BreakingDescription) == 0 {
+		commit.BreakingDescription = commit.Description
+	}
+	return commit, true
+}
+
+// ReleaseType returns the release type required by the commit: breaking changes require a major, features a minor and fixes a patch release
+func (c ConventionalCommit) ReleaseType() ReleaseType {
+	switch {
+	case c.Breaking:
+		return ReleaseMajor
+	case c.Type == "feat":
+		return ReleaseMinor
+	case c.Type == "fix", c.Type == "perf":
+		return ReleasePatch
+	}
+	return ReleaseNone
+}
+
+// NextReleaseType returns the highest release type required by the commits
+func NextReleaseType(commits []ConventionalCommit) ReleaseType {
+	release := ReleaseNone
+	for _, commit := range commits {
+		if commitRelease := commit.ReleaseType(); commitRelease > release {
+			release = commitRelease
+		}
+	}
+	return release
+}
+
+// IncrementVersion increments the semantic version according to the release type.
+// Pre-release and build information is removed from the version in case it is incremented.
+func IncrementVersion(version string, release ReleaseType) (string, error) {
+	semanticVersion, err := parseSemanticVersion(version)
+	if err != nil {
+		return "", err
+	}
+	if release == ReleaseNone {
+		return version, nil
+	}
+	core := SemanticVersion{Major: semanticVersion.Major, Minor: semanticVersion.Minor, Patch: semanticVersion.Patch}
+	return core.Increment(release).String(), nil
+}
+
+// IsSemanticVersion checks if the version starts with <major>.<minor>.<patch>, optionally followed by pre-release or build information
+func IsSemanticVersion(version string) bool {
+	_, err := parseSemanticVersion(version)
+	return err == nil
+}
+
+// CompareSemanticVersions compares the precedence of two semantic versions.
+// A pre-release version has a lower precedence than the release version, build information is not considered.
+func CompareSemanticVersions(a, b string) (int, error) {
+	versionA, err := parseSemanticVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	versionB, err := parseSemanticVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	return versionA.Compare(versionB), nil
+}
+
+var changelogSections = []struct {
+	commitType string
+	title      string
+}{
+	{commitType: "feat", title: "Features"},
+	{commitType: "fix", title: "Bug Fixes"},
+	{commitType: "perf", title: "Performance Improvements"},
+}
+
+// Changelog creates the markdown changelog section of a release, commits which are not release relevant are not listed
+func Changelog(version string, date time.Time, commits []ConventionalCommit) string {
+	var changelog strings.Builder
+	fmt.Fprintf(&changelog, "## %v (%v)\n", version, date.Format("2006-01-02"))
+
+	breaking := []string{}
+	for _, commit := range commits {
+		if commit.Breaking {
+			breaking = append(breaking, changelogEntry(commit.Scope, commit.BreakingDescription, commit.Hash))
+		}
+	}
+	writeChangelogSection(&changelog, "BREAKING CHANGES", breaking)
+
+	for _, section := range changelogSections {
+		entries := []string{}
+		for _, commit := range commits {
+			if commit.Type == section.commitType {
+				entries = append(entries, changelogEntry(commit.Scope, commit.Description, commit.Hash))
+			}
+		}
+		writeChangelogSection(&changelog, section.title, entries)
+	}
+	return changelog.String()
+}
+
+// UpdateChangelog adds the changelog section of a release on top of an existing changelog, a leading title is kept
+func UpdateChangelog(changelog, section string) string {
+	if len(strings.TrimSpace(changelog)) == 0 {
+		return "# Changelog\n\n" + section
+	}
+	if strings.HasPrefix(changelog, "# ") {
+		title, remainder, _ := strings.Cut(changelog, "\n")
+		return fmt.Sprintf("%v\n\n%v\n%v", title, section, strings.TrimLeft(remainder, "\n"))
+	}
+	return fmt.Sprintf("%v\n%v", section, changelog)
+}
+
+func writeChangelogSection(changelog *strings.Builder, title string, entries []string) {
+	if len(entries) == 0 {
+		return
+	}
+	fmt.Fprintf(changelog, "\n### %v\n\n", title)
+	for _, entry := range entries {
+		fmt.Fprintf(changelog, "* %v\n", entry)
+	}
+}
+
+func changelogEntry(scope, description, hash string) string {
+	entry := description
+	if len(scope) > 0 {
+		entry = fmt.Sprintf("**%v:** %v", scope, description)
+	}
+	if len(hash) > 7 {
+		hash = hash[:7]
+	}
+	if len(hash) > 0 {
+		entry = fmt.Sprintf("%v (%v)", entry, hash)
+	}
+	return entry
+}
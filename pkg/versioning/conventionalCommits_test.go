@@ -0,0 +1,154 @@
+//go:build unit
+// +build unit
+
+package versioning
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		message  string
+		expected ConventionalCommit
+		valid    bool
+	}{
+		{message: "feat: add login", expected: ConventionalCommit{Type: "feat", Description: "add login"}, valid: true},
+		{message: "fix(parser): handle empty input\n\nsome details", expected: ConventionalCommit{Type: "fix", Scope: "parser", Description: "handle empty input"}, valid: true},
+		{message: "Feat!: drop Java 8", expected: ConventionalCommit{Type: "feat", Description: "drop Java 8", Breaking: true, BreakingDescription: "drop Java 8"}, valid: true},
+		{message: "refactor(api): rename fields\n\nBREAKING CHANGE: field id is now named key", expected: ConventionalCommit{Type: "refactor", Scope: "api", Description: "rename fields", Breaking: true, BreakingDescription: "field id is now named key"}, valid: true},
+		{message: "Merge branch 'main' into feature", valid: false},
+		{message: "feat:missing space", valid: false},
+	}
+
+	for _, test := range tt {
+		t.Run(test.message, func(t *testing.T) {
+			commit, valid := ParseConventionalCommit("", test.message)
+			assert.Equal(t, test.valid, valid)
+			assert.Equal(t, test.expected, commit)
+		})
+	}
+}
+
+func TestNextReleaseType(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ReleaseNone, NextReleaseType([]ConventionalCommit{{Type: "docs"}, {Type: "chore"}}))
+	assert.Equal(t, ReleasePatch, NextReleaseType([]ConventionalCommit{{Type: "docs"}, {Type: "perf"}}))
+	assert.Equal(t, ReleaseMinor, NextReleaseType([]ConventionalCommit{{Type: "fix"}, {Type: "feat"}}))
+	assert.Equal(t, ReleaseMajor, NextReleaseType([]ConventionalCommit{{Type: "chore", Breaking: true}, {Type: "feat"}}))
+	assert.Equal(t, "minor", ReleaseMinor.String())
+}
+
+func TestIncrementVersion(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		version  string
+		release  ReleaseType
+		expected string
+	}{
+		{version: "1.2.3", release: ReleaseNone, expected: "1.2.3"},
+		{version: "1.2.3", release: ReleasePatch, expected: "1.2.4"},
+		{version: "1.2.3", release: ReleaseMinor, expected: "1.3.0"},
+		{version: "1.2.3", release: ReleaseMajor, expected: "2.0.0"},
+		{version: "1.2.3-SNAPSHOT", release: ReleasePatch, expected: "1.2.4"},
+		{version: "0.9.1+build.7", release: ReleaseMinor, expected: "0.10.0"},
+	}
+
+	for _, test := range tt {
+		t.Run(test.version+" "+test.release.String(), func(t *testing.T) {
+			version, err := IncrementVersion(test.version, test.release)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, version)
+		})
+	}
+
+	t.Run("error - no semantic version", func(t *testing.T) {
+		_, err := IncrementVersion("1.2", ReleasePatch)
+		assert.EqualError(t, err, "version '1.2' is not a semantic version <major>.<minor>.<patch>")
+	})
+}
+
+func TestCompareSemanticVersions(t *testing.T) {
+	t.Parallel()
+
+	result, err := CompareSemanticVersions("1.10.0", "1.9.3")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result)
+	result, err = CompareSemanticVersions("1.2.3-rc.1", "1.2.3")
+	assert.NoError(t, err)
+	assert.Equal(t, -1, result)
+	result, err = CompareSemanticVersions("1.2.3-rc.10", "1.2.3-rc.9")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result)
+	result, err = CompareSemanticVersions("1.2.3+build.1", "1.2.3+build.2")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result)
+	result, err = CompareSemanticVersions("0.1.0", "1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, -1, result)
+	_, err = CompareSemanticVersions("latest", "1.0.0")
+	assert.Error(t, err)
+}
+
+func TestChangelog(t *testing.T) {
+	t.Parallel()
+
+	commits := []ConventionalCommit{
+		{Hash: "0123456789abcdef", Type: "feat", Scope: "api", Description: "add endpoint"},
+		{Hash: "abcdef0123456789", Type: "fix", Description: "handle timeout", Breaking: true, BreakingDescription: "timeout is now mandatory"},
+		{Hash: "fedcba9876543210", Type: "docs", Description: "update readme"},
+	}
+
+	changelog := Changelog("2.0.0", time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC), commits)
+
+	assert.Equal(t, `## 2.0.0 (2024-05-17)
+
+### BREAKING CHANGES
+
+* timeout is now mandatory (abcdef0)
+
+### Features
+
+* **api:** add endpoint (0123456)
+
+### Bug Fixes
+
+* handle timeout (abcdef0)
+`, changelog)
+}
+
+func TestUpdateChangelog(t *testing.T) {
+	t.Parallel()
+
+	section := "## 1.1.0 (2024-05-17)\n\n### Features\n\n* login\n"
+
+	t.Run("new changelog", func(t *testing.T) {
+		assert.Equal(t, "# Changelog\n\n"+section, UpdateChangelog("", section))
+	})
+
+	t.Run("existing changelog with title", func(t *testing.T) {
+		existing := "# Changelog\n\n## 1.0.0 (2024-05-01)\n"
+		assert.Equal(t, "# Changelog\n\n"+section+"\n## 1.0.0 (2024-05-01)\n", UpdateChangelog(existing, section))
+	})
+
+	t.Run("existing changelog without title", func(t *testing.T) {
+		existing := "## 1.0.0 (2024-05-01)\n"
+		assert.Equal(t, section+"\n## 1.0.0 (2024-05-01)\n", UpdateChangelog(existing, section))
+	})
+}
+
+func TestIsSemanticVersion(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsSemanticVersion("1.2.3"))
+	assert.True(t, IsSemanticVersion("1.2.3-20240517100000_0123456"))
+	assert.False(t, IsSemanticVersion("1.2"))
+	assert.False(t, IsSemanticVersion("release-1"))
+}
@@ -0,0 +1,253 @@
+package versioning
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// Dotnet defines an artifact using a .NET project file (*.csproj) or Directory.Build.props for versioning
+type Dotnet struct {
+	path       string
+	utils      Utils
+	content    string
+	properties dotnetProperties
+}
+
+type dotnetProject struct {
+	PropertyGroups []dotnetProperties `xml:"PropertyGroup"`
+}
+
+type dotnetProperties struct {
+	Version        string `xml:"Version"`
+	PackageVersion string `xml:"PackageVersion"`
+	VersionPrefix  string `xml:"VersionPrefix"`
+	VersionSuffix  string `xml:"VersionSuffix"`
+	PackageID      string `xml:"PackageId"`
+	AssemblyName   string `xml:"AssemblyName"`
+}
+
+func (d *Dotnet) init() error {
+	if d.utils == nil {
+		return fmt.Errorf("no file utils provided")
+	}
+	if len(d.path) == 0 {
+		if exists, _ := d.utils.FileExists("Directory.Build.props"); exists {
+			d.path = "Directory.Build.props"
+		} else {
+			projects, err := d.utils.Glob("*.csproj")
+			if len(projects) == 0 || err != nil {
+				return fmt.Errorf("failed to find a .NET project file")
+			}
+			// use first project which can be found
+			sort.Strings(projects)
+			d.path = projects[0]
+		}
+	}
+
+	if len(d.content) == 0 {
+		content, err := d.utils.FileRead(d.path)
+		if err != nil {
+			return fmt.Errorf("failed to read file '%v': %w", d.path, err)
+		}
+		project := dotnetProject{}
+		if err := xml.Unmarshal(content, &project); err != nil {
+			return fmt.Errorf(".NET project content invalid '%v': %w", d.path, err)
+		}
+		// the first value of a property wins, conditional property groups are not evaluated
+		d.properties = dotnetProperties{}
+		for _, group := range project.PropertyGroups {
+			d.properties.merge(group)
+		}
+		d.content = string(content)
+	}
+	return nil
+}
+
+func (p *dotnetProperties) merge(group dotnetProperties) {
+	values := []struct {
+		target *string
+		value  string
+	}{
+		{&p.Version, group.Version},
+		{&p.PackageVersion, group.PackageVersion},
+		{&p.VersionPrefix, group.VersionPrefix},
+		{&p.VersionSuffix, group.VersionSuffix},
+		{&p.PackageID, group.PackageID},
+		{&p.AssemblyName, group.AssemblyName},
+	}
+	for _, v := range values {
+		if len(*v.target) == 0 {
+			*v.target = strings.TrimSpace(v.value)
+		}
+	}
+}
+
+// VersioningScheme returns the relevant versioning scheme
+func (d *Dotnet) VersioningScheme() string {
+	return "semver2"
+}
+
+// GetVersion returns the version of the .NET project, taken from the properties Version, PackageVersion or VersionPrefix and VersionSuffix
+func (d *Dotnet) GetVersion() (string, error) {
+	if err := d.init(); err != nil {
+		return "", fmt.Errorf("failed to init .NET versioning: %w", err)
+	}
+	switch {
+	case len(d.properties.Version) > 0:
+		return d.properties.Version, nil
+	case len(d.properties.PackageVersion) > 0:
+		return d.properties.PackageVersion, nil
+	case len(d.properties.VersionPrefix) > 0:
+		if len(d.properties.VersionSuffix) > 0 {
+			return fmt.Sprintf("%v-%v", d.properties.VersionPrefix, d.properties.VersionSuffix), nil
+		}
+		return d.properties.VersionPrefix, nil
+	}
+	return "", fmt.Errorf("no version found in '%v'", d.path)
+}
+
+// SetVersion updates the version property of the .NET project which defines the version
+func (d *Dotnet) SetVersion(version string) error {
+	if err := d.init(); err != nil {
+		return fmt.Errorf("failed to init .NET versioning: %w", err)
+	}
+
+	content := d.content
+	var err error
+	switch {
+	case len(d.properties.Version) > 0:
+		content, err = setXMLElementText(content, "Version", version)
+	case len(d.properties.PackageVersion) > 0:
+		content, err = setXMLElementText(content, "PackageVersion", version)
+	case len(d.properties.VersionPrefix) > 0:
+		prefix, suffix, _ := strings.Cut(version, "-")
+		content, err = setXMLElementText(content, "VersionPrefix", prefix)
+		if err == nil && (len(suffix) > 0 || len(d.properties.VersionSuffix) > 0) {
+			content, err = setXMLElementText(content, "VersionSuffix", suffix)
+			if err != nil {
+				content, err = addXMLElementAfter(content, "VersionPrefix", "VersionSuffix", suffix)
+			}
+		}
+	default:
+		err = fmt.Errorf("no version found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update version in '%v': %w", d.path, err)
+	}
+
+	if err := d.utils.FileWrite(d.path, []byte(content), 0666); err != nil {
+		return fmt.Errorf("failed to write file '%v': %w", d.path, err)
+	}
+	d.content = ""
+	return nil
+}
+
+// GetCoordinates returns the coordinates of the .NET project, the package id defaults to the assembly name or the project file name
+func (d *Dotnet) GetCoordinates() (Coordinates, error) {
+	version, err := d.GetVersion()
+	if err != nil {
+		return Coordinates{}, err
+	}
+	result := Coordinates{
+		ArtifactID: d.properties.PackageID,
+		Version:    version,
+		Packaging:  "nupkg",
+	}
+	if len(result.ArtifactID) == 0 {
+		result.ArtifactID = d.properties.AssemblyName
+	}
+	if len(result.ArtifactID) == 0 && filepath.Ext(d.path) == ".csproj" {
+		result.ArtifactID = strings.TrimSuffix(filepath.Base(d.path), ".csproj")
+	}
+	if len(result.ArtifactID) > 0 {
+		result.PURL = packageurl.NewPackageURL(packageurl.TypeNuget, "", result.ArtifactID, version, nil, "").ToString()
+	}
+	return result, nil
+}
+
+// setXMLElementText replaces the text of the first property with the given name, the remaining document is kept untouched.
+// Only direct children of a PropertyGroup are considered, e.g. not the Version of a PackageReference.
+func setXMLElementText(content, element, text string) (string, error) {
+	property, err := findXMLProperty(content, element)
+	if err != nil {
+		return content, err
+	}
+	if property.textStart == property.end {
+		// empty element like <Version />
+		return fmt.Sprintf("%v<%v>%v</%v>%v", content[:property.start], element, text, element, content[property.end:]), nil
+	}
+	return content[:property.textStart] + text + content[property.textEnd:], nil
+}
+
+// addXMLElementAfter adds a new element after the first property with the given name using the same indentation
+func addXMLElementAfter(content, after, element, text string) (string, error) {
+	property, err := findXMLProperty(content, after)
+	if err != nil {
+		return content, err
+	}
+	lineStart := strings.LastIndex(content[:property.start], "\n") + 1
+	indentation := content[lineStart:property.start]
+	if len(strings.TrimLeft(indentation, " \t")) > 0 {
+		indentation = ""
+	}
+	return fmt.Sprintf("%v\n%v<%v>%v</%v>%v", content[:property.end], indentation, element, text, element, content[property.end:]), nil
+}
+
+// xmlProperty contains the offsets of a property element within a project file
+type xmlProperty struct {
+	start, textStart, textEnd, end int
+}
+
+// findXMLProperty returns the offsets of the first element with the given name which is a direct child of a PropertyGroup
+func findXMLProperty(content, element string) (xmlProperty, error) {
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	parents := []string{}
+	for {
+		offset := int(decoder.InputOffset())
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return xmlProperty{}, fmt.Errorf("element '%v' not found", element)
+		}
+		if err != nil {
+			return xmlProperty{}, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == element && len(parents) > 0 && parents[len(parents)-1] == "PropertyGroup" {
+				return readXMLProperty(decoder, offset)
+			}
+			parents = append(parents, t.Name.Local)
+		case xml.EndElement:
+			parents = parents[:len(parents)-1]
+		}
+	}
+}
+
+// readXMLProperty reads the content of the property whose start element has just been read
+func readXMLProperty(decoder *xml.Decoder, start int) (xmlProperty, error) {
+	property := xmlProperty{start: start, textStart: int(decoder.InputOffset())}
+	depth := 0
+	for {
+		textEnd := int(decoder.InputOffset())
+		token, err := decoder.Token()
+		if err != nil {
+			return xmlProperty{}, err
+		}
+		switch token.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			if depth == 0 {
+				property.textEnd, property.end = textEnd, int(decoder.InputOffset())
+				return property, nil
+			}
+			depth--
+		}
+	}
+}
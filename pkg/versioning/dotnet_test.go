@@ -0,0 +1,149 @@
+//go:build unit
+// +build unit
+
+package versioning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const dotnetProjectContent = `<Project Sdk="Microsoft.NET.Sdk">
+
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+    <Version>1.2.3</Version>
+  </PropertyGroup>
+
+</Project>
+`
+
+func TestDotnetGetVersion(t *testing.T) {
+	t.Run("success case - project file", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("MyApp.csproj", []byte(dotnetProjectContent))
+
+		dotnet := Dotnet{utils: fileUtils}
+
+		version, err := dotnet.GetVersion()
+		assert.NoError(t, err)
+		assert.Equal(t, "1.2.3", version)
+		assert.Equal(t, "MyApp.csproj", dotnet.path)
+	})
+
+	t.Run("success case - Directory.Build.props with prefix and suffix", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("MyApp.csproj", []byte(dotnetProjectContent))
+		fileUtils.AddFile("Directory.Build.props", []byte("<Project>\n  <PropertyGroup>\n    <VersionPrefix>2.0.0</VersionPrefix>\n    <VersionSuffix>beta.1</VersionSuffix>\n  </PropertyGroup>\n</Project>\n"))
+
+		dotnet := Dotnet{utils: fileUtils}
+
+		version, err := dotnet.GetVersion()
+		assert.NoError(t, err)
+		assert.Equal(t, "2.0.0-beta.1", version)
+		assert.Equal(t, "Directory.Build.props", dotnet.path)
+	})
+
+	t.Run("error case - no version", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("MyApp.csproj", []byte("<Project><PropertyGroup></PropertyGroup></Project>"))
+
+		dotnet := Dotnet{utils: fileUtils}
+
+		_, err := dotnet.GetVersion()
+		assert.EqualError(t, err, "no version found in 'MyApp.csproj'")
+	})
+
+	t.Run("error case - no project file", func(t *testing.T) {
+		dotnet := Dotnet{utils: newVersioningMockUtils()}
+
+		_, err := dotnet.GetVersion()
+		assert.EqualError(t, err, "failed to init .NET versioning: failed to find a .NET project file")
+	})
+}
+
+func TestDotnetSetVersion(t *testing.T) {
+	t.Run("success case - version", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("MyApp.csproj", []byte(dotnetProjectContent))
+
+		dotnet := Dotnet{utils: fileUtils, path: "MyApp.csproj"}
+
+		err := dotnet.SetVersion("1.3.0")
+		assert.NoError(t, err)
+
+		content, err := fileUtils.FileRead("MyApp.csproj")
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "    <TargetFramework>net8.0</TargetFramework>\n    <Version>1.3.0</Version>\n")
+	})
+
+	t.Run("success case - property with attributes after package reference", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("MyApp.csproj", []byte(`<Project Sdk="Microsoft.NET.Sdk">
+  <ItemGroup>
+    <PackageReference Include="Newtonsoft.Json">
+      <Version>13.0.1</Version>
+    </PackageReference>
+  </ItemGroup>
+  <PropertyGroup>
+    <Version Condition="'$(Version)' == ''">1.2.3</Version>
+  </PropertyGroup>
+</Project>
+`))
+
+		dotnet := Dotnet{utils: fileUtils, path: "MyApp.csproj"}
+
+		err := dotnet.SetVersion("1.3.0")
+		assert.NoError(t, err)
+
+		content, err := fileUtils.FileRead("MyApp.csproj")
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "      <Version>13.0.1</Version>\n")
+		assert.Contains(t, string(content), "    <Version Condition=\"'$(Version)' == ''\">1.3.0</Version>\n")
+	})
+
+	t.Run("success case - prefix and new suffix", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("Directory.Build.props", []byte("<Project>\n  <PropertyGroup>\n    <VersionPrefix>2.0.0</VersionPrefix>\n  </PropertyGroup>\n</Project>\n"))
+
+		dotnet := Dotnet{utils: fileUtils, path: "Directory.Build.props"}
+
+		err := dotnet.SetVersion("2.0.1-20240517100000")
+		assert.NoError(t, err)
+
+		content, err := fileUtils.FileRead("Directory.Build.props")
+		assert.NoError(t, err)
+		assert.Equal(t, "<Project>\n  <PropertyGroup>\n    <VersionPrefix>2.0.1</VersionPrefix>\n    <VersionSuffix>20240517100000</VersionSuffix>\n  </PropertyGroup>\n</Project>\n", string(content))
+
+		version, err := dotnet.GetVersion()
+		assert.NoError(t, err)
+		assert.Equal(t, "2.0.1-20240517100000", version)
+	})
+}
+
+func TestDotnetGetCoordinates(t *testing.T) {
+	t.Run("project file name", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("src/MyApp.csproj", []byte(dotnetProjectContent))
+
+		dotnet := Dotnet{utils: fileUtils, path: "src/MyApp.csproj"}
+
+		coordinates, err := dotnet.GetCoordinates()
+		assert.NoError(t, err)
+		assert.Equal(t, Coordinates{ArtifactID: "MyApp", Version: "1.2.3", Packaging: "nupkg", PURL: "pkg:nuget/MyApp@1.2.3"}, coordinates)
+	})
+
+	t.Run("package id", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("MyApp.csproj", []byte("<Project><PropertyGroup><PackageId>My.Company.App</PackageId><AssemblyName>App</AssemblyName><PackageVersion>3.1.0</PackageVersion></PropertyGroup></Project>"))
+
+		dotnet := Dotnet{utils: fileUtils, path: "MyApp.csproj"}
+
+		coordinates, err := dotnet.GetCoordinates()
+		assert.NoError(t, err)
+		assert.Equal(t, "My.Company.App", coordinates.ArtifactID)
+		assert.Equal(t, "3.1.0", coordinates.Version)
+		assert.Equal(t, "pkg:nuget/My.Company.App@3.1.0", coordinates.PURL)
+	})
+}
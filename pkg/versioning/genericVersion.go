@@ -0,0 +1,111 @@
+package versioning
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CompareGenericVersions compares versions by their numeric and alphabetic parts, e.g. for Maven, PyPI or Alpine.
+// Versions with alphabetic qualifiers like 1.0-rc1 or 1.0b1 are considered lower than the release 1.0,
+// apart from qualifiers marking a release or a post release like 1.0.Final, 1.0-sp1 or 1.0-r1. A leading v is ignored.
+// The result is -1 if a is lower than b, 1 if a is higher than b and 0 if both are equal.
+func CompareGenericVersions(a, b string) int {
+	aTokens, bTokens := versionTokens(a), versionTokens(b)
+	for i := 0; i < len(aTokens) || i < len(bTokens); i++ {
+		if i >= len(aTokens) || i >= len(bTokens) {
+			// trailing zeros are not significant, 1.0 equals 1.0.0
+			if i >= len(aTokens) && !isZero(bTokens[i]) {
+				return -qualifierOrder(bTokens[i])
+			}
+			if i >= len(bTokens) && !isZero(aTokens[i]) {
+				return qualifierOrder(aTokens[i])
+			}
+			continue
+		}
+		aNumeric, bNumeric := isNumeric(aTokens[i]), isNumeric(bTokens[i])
+		switch {
+		case aNumeric && bNumeric:
+			if result := compareNumbers(aTokens[i], bTokens[i]); result != 0 {
+				return result
+			}
+		case aNumeric:
+			// 1.0.1 is higher than 1.0-rc1
+			return 1
+		case bNumeric:
+			return -1
+		default:
+			if result := strings.Compare(strings.ToLower(aTokens[i]), strings.ToLower(bTokens[i])); result != 0 {
+				return result
+			}
+		}
+	}
+	return 0
+}
+
+// qualifierOrder returns whether a version extended by the given token is higher (1) or lower (-1) than the version without it
+func qualifierOrder(token string) int {
+	if isNumeric(token) {
+		return 1
+	}
+	switch strings.ToLower(token) {
+	case "final", "ga", "release", "sp", "post", "p", "r", "pl":
+		return 1
+	}
+	return -1
+}
+
+// versionTokens splits a version into its numeric and alphabetic parts, separators are dropped
+func versionTokens(version string) []string {
+	if len(version) > 1 && version[0] == 'v' && unicode.IsDigit(rune(version[1])) {
+		version = version[1:]
+	}
+	tokens := []string{}
+	current := []rune{}
+	for _, r := range version {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(current) > 0 {
+				tokens = append(tokens, string(current))
+			}
+			current = []rune{}
+			continue
+		}
+		if len(current) > 0 && unicode.IsDigit(r) != unicode.IsDigit(current[len(current)-1]) {
+			tokens = append(tokens, string(current))
+			current = []rune{}
+		}
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		tokens = append(tokens, string(current))
+	}
+	return tokens
+}
+
+// compareNumbers compares numbers of arbitrary length, an empty string counts as 0
+func compareNumbers(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func isZero(s string) bool {
+	return isNumeric(s) && len(strings.TrimLeft(s, "0")) == 0
+}
+
+func isNumeric(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+//go:build unit
+// +build unit
+
+package versioning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareGenericVersions(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		lower  string
+		higher string
+	}{
+		{name: "numbers", lower: "2.14.1", higher: "2.15.0"},
+		{name: "numbers by value", lower: "1.9", higher: "1.10.0"},
+		{name: "v prefix", lower: "1.99", higher: "v2.0.0"},
+		{name: "qualifier", lower: "2.0-rc1", higher: "2.0"},
+		{name: "qualifiers", lower: "1.0.0-alpha", higher: "1.0.0-beta"},
+		{name: "qualifier and number", lower: "1.0.beta", higher: "1.0.1"},
+		{name: "additional number", lower: "1.0", higher: "1.0.1"},
+		{name: "pep440 pre-release", lower: "1.0b2", higher: "1.0"},
+		{name: "release qualifier", lower: "5.3.0", higher: "5.3.0.Final-1"},
+		{name: "alpine revision", lower: "1.36.1-r2", higher: "1.36.1-r15"},
+	}
+
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, -1, CompareGenericVersions(test.lower, test.higher))
+			assert.Equal(t, 1, CompareGenericVersions(test.higher, test.lower))
+			assert.Equal(t, 0, CompareGenericVersions(test.lower, test.lower))
+		})
+	}
+
+	t.Run("equal versions", func(t *testing.T) {
+		// trailing zeros are not significant
+		assert.Equal(t, 0, CompareGenericVersions("1.0", "1.0.0"))
+		assert.Equal(t, 0, CompareGenericVersions("1.2.3", "v1.2.3"))
+	})
+}
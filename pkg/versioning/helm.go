@@ -2,7 +2,6 @@ package versioning
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/ghodss/yaml"
 	"helm.sh/helm/v3/pkg/chart"
@@ -67,7 +66,7 @@ func (h *HelmChart) SetVersion(version string) error {
 	h.metadata.Version = version
 	if h.updateAppVersion {
 		// k8s does not allow a plus sign in labels
-		h.metadata.AppVersion = strings.ReplaceAll(version, "+", "_")
+		h.metadata.AppVersion = KubernetesLabelVersion(version)
 	}
 
 	content, err := yaml.Marshal(h.metadata)
@@ -0,0 +1,32 @@
+package versioning
+
+import (
+	"regexp"
+	"strings"
+)
+
+// container image tags and Kubernetes label values share the same set of valid characters
+var invalidTagCharacters = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// ContainerImageTag derives a valid container image tag from a version.
+// Container image tags do not allow a plus sign, thus build metadata is separated by a dash, e.g. 1.2.3-rc.1+build.5 results in 1.2.3-rc.1-build.5
+func ContainerImageTag(version string) string {
+	tag := invalidTagCharacters.ReplaceAllString(strings.ReplaceAll(version, "+", "-"), "-")
+	// a tag must not start with a period or a dash and may contain a maximum of 128 characters
+	tag = strings.TrimLeft(tag, ".-")
+	if len(tag) > 128 {
+		tag = tag[:128]
+	}
+	return tag
+}
+
+// KubernetesLabelVersion derives a valid Kubernetes label value from a version, e.g. for the appVersion of a Helm chart.
+// Labels do not allow a plus sign, thus build metadata is separated by an underscore, e.g. 1.2.3+build.5 results in 1.2.3_build.5
+func KubernetesLabelVersion(version string) string {
+	label := invalidTagCharacters.ReplaceAllString(strings.ReplaceAll(version, "+", "_"), "_")
+	// a label value may contain a maximum of 63 characters and has to begin and end with an alphanumeric character
+	if len(label) > 63 {
+		label = label[:63]
+	}
+	return strings.Trim(label, "_.-")
+}
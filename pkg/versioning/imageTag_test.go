@@ -0,0 +1,30 @@
+//go:build unit
+// +build unit
+
+package versioning
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerImageTag(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "1.2.3", ContainerImageTag("1.2.3"))
+	assert.Equal(t, "1.2.3-rc.1-build.5", ContainerImageTag("1.2.3-rc.1+build.5"))
+	assert.Equal(t, "1.2.3-feature-x", ContainerImageTag("1.2.3-feature/x"))
+	assert.Equal(t, "latest", ContainerImageTag("-latest"))
+	assert.Len(t, ContainerImageTag(strings.Repeat("1", 200)), 128)
+}
+
+func TestKubernetesLabelVersion(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "1.2.3", KubernetesLabelVersion("1.2.3"))
+	assert.Equal(t, "1.2.3-rc.1_build.5", KubernetesLabelVersion("1.2.3-rc.1+build.5"))
+	assert.Equal(t, "1.2.3-20240517100000_0123456", KubernetesLabelVersion("1.2.3-20240517100000+0123456"))
+	assert.Equal(t, strings.Repeat("1", 63), KubernetesLabelVersion(strings.Repeat("1", 70)))
+}
@@ -0,0 +1,71 @@
+package versioning
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var mavenVersionPattern = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:[.-]?([A-Za-z0-9][A-Za-z0-9._-]*))?$`)
+
+var mavenQualifierToken = regexp.MustCompile(`^([a-z]+)(\d+)$`)
+
+// mavenQualifiers maps the qualifiers known by Maven to their canonical name, release qualifiers are removed
+var mavenQualifiers = map[string]string{
+	"alpha":     "alpha",
+	"beta":      "beta",
+	"milestone": "milestone",
+	"rc":        "rc",
+	"cr":        "rc",
+	"snapshot":  "SNAPSHOT",
+	"ga":        "",
+	"final":     "",
+	"release":   "",
+	"sp":        "sp",
+}
+
+// mavenQualifierAbbreviations are only considered in case they are directly followed by a number, e.g. 1.0-M1
+var mavenQualifierAbbreviations = map[string]string{
+	"a": "alpha",
+	"b": "beta",
+	"m": "milestone",
+}
+
+// NormalizeMavenVersion normalizes a Maven version into <major>.<minor>.<patch>[-<qualifier>] using the canonical qualifiers of Maven,
+// e.g. 2.0.M3 results in 2.0.0-milestone-3 and 1.2.RELEASE in 1.2.0
+func NormalizeMavenVersion(version string) (string, error) {
+	match := mavenVersionPattern.FindStringSubmatch(strings.TrimSpace(version))
+	if match == nil {
+		return "", fmt.Errorf("version '%v' is not a valid Maven version", version)
+	}
+	parts := []string{match[1], match[2], match[3]}
+	for i, part := range parts {
+		if len(part) == 0 {
+			parts[i] = "0"
+		}
+	}
+	result := strings.Join(parts, ".")
+
+	qualifier := []string{}
+	for _, token := range strings.FieldsFunc(strings.ToLower(match[4]), func(r rune) bool { return r == '.' || r == '-' }) {
+		name, number := token, ""
+		if tokenMatch := mavenQualifierToken.FindStringSubmatch(token); tokenMatch != nil {
+			name, number = tokenMatch[1], tokenMatch[2]
+			if abbreviation, ok := mavenQualifierAbbreviations[name]; ok {
+				name = abbreviation
+			}
+		}
+		if canonical, ok := mavenQualifiers[name]; ok {
+			name = canonical
+		}
+		for _, part := range []string{name, number} {
+			if len(part) > 0 {
+				qualifier = append(qualifier, part)
+			}
+		}
+	}
+	if len(qualifier) > 0 {
+		result += "-" + strings.Join(qualifier, "-")
+	}
+	return result, nil
+}
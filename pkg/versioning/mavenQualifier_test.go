@@ -0,0 +1,39 @@
+//go:build unit
+// +build unit
+
+package versioning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeMavenVersion(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		version  string
+		expected string
+	}{
+		{version: "1.2.3", expected: "1.2.3"},
+		{version: "1.0", expected: "1.0.0"},
+		{version: "1.2.RELEASE", expected: "1.2.0"},
+		{version: "3.1.4.Final", expected: "3.1.4"},
+		{version: "2.0-GA", expected: "2.0.0"},
+		{version: "2.0.M3", expected: "2.0.0-milestone-3"},
+		{version: "1.0.0.CR2", expected: "1.0.0-rc-2"},
+		{version: "1.0-a1", expected: "1.0.0-alpha-1"},
+		{version: "1.0-SNAPSHOT", expected: "1.0.0-SNAPSHOT"},
+		{version: "1.0.0-beta1-snapshot", expected: "1.0.0-beta-1-SNAPSHOT"},
+		{version: "1.2.3-20240517100000_0123456", expected: "1.2.3-20240517100000_0123456"},
+	}
+	for _, test := range tt {
+		version, err := NormalizeMavenVersion(test.version)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, version, test.version)
+	}
+
+	_, err := NormalizeMavenVersion("latest")
+	assert.EqualError(t, err, "version 'latest' is not a valid Maven version")
+}
@@ -0,0 +1,126 @@
+package versioning
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Module is an artifact within a repository containing several artifacts (monorepo)
+type Module struct {
+	// Name is the slash separated directory of the module relative to the repository root
+	Name            string
+	BuildTool       string
+	BuildDescriptor string
+	Artifact        Artifact
+}
+
+// Tag returns the tag of a module version, e.g. services/api/v1.2.3
+func (m Module) Tag(tagPrefix, version string) string {
+	return fmt.Sprintf("%v/%v%v", m.Name, tagPrefix, version)
+}
+
+var descriptorBuildTools = map[string]string{
+	"Cargo.toml":            "cargo",
+	"Chart.yaml":            "helm",
+	"Directory.Build.props": "dotnet",
+	"dub.json":              "dub",
+	"go.mod":                "golang",
+	"gradle.properties":     "gradle",
+	"mta.yaml":              "mta",
+	"package.json":          "npm",
+	"pom.xml":               "maven",
+	"pyproject.toml":        "pip",
+	"setup.py":              "pip",
+}
+
+// BuildToolForDescriptor returns the build tool handling the build descriptor
+func BuildToolForDescriptor(buildDescriptor string) (string, error) {
+	if buildTool, ok := descriptorBuildTools[filepath.Base(buildDescriptor)]; ok {
+		return buildTool, nil
+	}
+	if filepath.Ext(buildDescriptor) == ".csproj" {
+		return "dotnet", nil
+	}
+	return "", fmt.Errorf("build descriptor '%v' not supported", buildDescriptor)
+}
+
+// DiscoverModules returns the modules of all build descriptors matching the patterns.
+// Descriptors in the repository root belong to the main artifact and descriptors within node_modules are ignored.
+// In case a directory contains descriptors matching several patterns, the descriptor matching the first of these patterns is used,
+// e.g. the pom.xml for the patterns libs/*/pom.xml and **/package.json. Several descriptors matching the same pattern are rejected.
+func DiscoverModules(patterns []string, opts *Options, utils Utils) ([]Module, error) {
+	// the index of the first pattern matching the descriptor
+	descriptors := map[string]int{}
+	for i, pattern := range patterns {
+		matches, err := utils.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search build descriptors with pattern '%v': %w", pattern, err)
+		}
+		for _, match := range matches {
+			if _, ok := descriptors[filepath.ToSlash(match)]; !ok {
+				descriptors[filepath.ToSlash(match)] = i
+			}
+		}
+	}
+	sortedDescriptors := make([]string, 0, len(descriptors))
+	for descriptor := range descriptors {
+		sortedDescriptors = append(sortedDescriptors, descriptor)
+	}
+	sort.Strings(sortedDescriptors)
+
+	names := map[string]string{}
+	for _, descriptor := range sortedDescriptors {
+		name := path.Dir(descriptor)
+		if name == "." || strings.Contains("/"+descriptor, "/node_modules/") {
+			continue
+		}
+		other, ok := names[name]
+		switch {
+		case !ok || descriptors[descriptor] < descriptors[other]:
+			names[name] = descriptor
+		case descriptors[descriptor] == descriptors[other]:
+			return nil, fmt.Errorf("module '%v' has more than one build descriptor matching pattern '%v': '%v' and '%v'", name, patterns[descriptors[other]], other, descriptor)
+		}
+	}
+
+	moduleNames := make([]string, 0, len(names))
+	for name := range names {
+		moduleNames = append(moduleNames, name)
+	}
+	sort.Strings(moduleNames)
+
+	modules := []Module{}
+	for _, name := range moduleNames {
+		descriptor := names[name]
+		buildTool, err := BuildToolForDescriptor(descriptor)
+		if err != nil {
+			return nil, err
+		}
+		buildDescriptor := descriptor
+		if buildTool == "golang" {
+			// Go modules do not contain a version, it is maintained in a version file next to go.mod
+			if buildDescriptor, err = moduleVersionFile(name, utils); err != nil {
+				return nil, fmt.Errorf("failed to retrieve version of module '%v': %w", name, err)
+			}
+		}
+		artifact, err := GetArtifact(buildTool, buildDescriptor, opts, utils)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve artifact of module '%v': %w", name, err)
+		}
+		modules = append(modules, Module{Name: name, BuildTool: buildTool, BuildDescriptor: buildDescriptor, Artifact: artifact})
+	}
+	return modules, nil
+}
+
+func moduleVersionFile(name string, utils Utils) (string, error) {
+	candidates := []string{path.Join(name, "version.txt"), path.Join(name, "VERSION")}
+	for _, candidate := range candidates {
+		if exists, _ := utils.FileExists(candidate); exists {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no version file available, supported: %v", candidates)
+}
@@ -0,0 +1,102 @@
+//go:build unit
+// +build unit
+
+package versioning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildToolForDescriptor(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		descriptor string
+		buildTool  string
+	}{
+		{descriptor: "packages/ui/package.json", buildTool: "npm"},
+		{descriptor: "services/api/go.mod", buildTool: "golang"},
+		{descriptor: "libs/core/pom.xml", buildTool: "maven"},
+		{descriptor: "crates/parser/Cargo.toml", buildTool: "cargo"},
+		{descriptor: "tools/cli/pyproject.toml", buildTool: "pip"},
+		{descriptor: "src/App/App.csproj", buildTool: "dotnet"},
+	}
+	for _, test := range tt {
+		buildTool, err := BuildToolForDescriptor(test.descriptor)
+		assert.NoError(t, err)
+		assert.Equal(t, test.buildTool, buildTool, test.descriptor)
+	}
+
+	_, err := BuildToolForDescriptor("build.xml")
+	assert.EqualError(t, err, "build descriptor 'build.xml' not supported")
+}
+
+func TestDiscoverModules(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success case", func(t *testing.T) {
+		utils := newVersioningMockUtils()
+		utils.AddFile("package.json", []byte(`{"version": "1.0.0"}`))
+		utils.AddFile("packages/ui/package.json", []byte(`{"version": "1.0.0"}`))
+		utils.AddFile("packages/ui/node_modules/left-pad/package.json", []byte(`{"version": "1.3.0"}`))
+		utils.AddFile("services/api/go.mod", []byte("module github.com/my/repo/services/api\n"))
+		utils.AddFile("services/api/VERSION", []byte("0.3.0"))
+		utils.AddFile("crates/parser/Cargo.toml", []byte("[package]\nname = \"parser\"\nversion = \"0.1.0\"\n"))
+
+		modules, err := DiscoverModules([]string{"**/package.json", "services/*/go.mod", "crates/*/Cargo.toml"}, &Options{}, utils)
+
+		assert.NoError(t, err)
+		if assert.Len(t, modules, 3) {
+			assert.Equal(t, "crates/parser", modules[0].Name)
+			assert.Equal(t, "cargo", modules[0].BuildTool)
+			assert.IsType(t, &Cargo{}, modules[0].Artifact)
+
+			assert.Equal(t, "packages/ui", modules[1].Name)
+			assert.Equal(t, "npm", modules[1].BuildTool)
+			assert.Equal(t, "packages/ui/package.json", modules[1].BuildDescriptor)
+
+			assert.Equal(t, "services/api", modules[2].Name)
+			assert.Equal(t, "golang", modules[2].BuildTool)
+			assert.Equal(t, "services/api/VERSION", modules[2].BuildDescriptor)
+			assert.IsType(t, &Versionfile{}, modules[2].Artifact)
+		}
+		assert.Equal(t, "crates/parser/v0.2.0", modules[0].Tag("v", "0.2.0"))
+	})
+
+	t.Run("error case - Go module without version file", func(t *testing.T) {
+		utils := newVersioningMockUtils()
+		utils.AddFile("services/api/go.mod", []byte("module github.com/my/repo/services/api\n"))
+
+		_, err := DiscoverModules([]string{"services/*/go.mod"}, &Options{}, utils)
+
+		assert.EqualError(t, err, "failed to retrieve version of module 'services/api': no version file available, supported: [services/api/version.txt services/api/VERSION]")
+	})
+
+	t.Run("several descriptors - first pattern wins", func(t *testing.T) {
+		utils := newVersioningMockUtils()
+		utils.AddFile("tools/cli/setup.py", []byte("setup(version='1.0.0')"))
+		utils.AddFile("tools/cli/pyproject.toml", []byte("[project]\nversion = \"1.0.0\"\n"))
+		utils.AddFile("libs/core/pom.xml", []byte("<project><version>1.0.0</version></project>"))
+		utils.AddFile("libs/core/package.json", []byte(`{"version": "1.0.0"}`))
+
+		modules, err := DiscoverModules([]string{"tools/*/pyproject.toml", "libs/*/pom.xml", "**/*"}, &Options{}, utils)
+
+		assert.NoError(t, err)
+		if assert.Len(t, modules, 2) {
+			assert.Equal(t, "libs/core/pom.xml", modules[0].BuildDescriptor)
+			assert.Equal(t, "tools/cli/pyproject.toml", modules[1].BuildDescriptor)
+		}
+	})
+
+	t.Run("error case - several descriptors", func(t *testing.T) {
+		utils := newVersioningMockUtils()
+		utils.AddFile("tools/cli/setup.py", []byte("setup(version='1.0.0')"))
+		utils.AddFile("tools/cli/pyproject.toml", []byte("[project]\nversion = \"1.0.0\"\n"))
+
+		_, err := DiscoverModules([]string{"tools/*/*"}, &Options{}, utils)
+
+		assert.EqualError(t, err, "module 'tools/cli' has more than one build descriptor matching pattern 'tools/*/*': 'tools/cli/pyproject.toml' and 'tools/cli/setup.py'")
+	})
+}
@@ -0,0 +1,66 @@
+package versioning
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// pep440Pattern is the pattern of https://peps.python.org/pep-0440/#appendix-b-parsing-version-strings-with-regular-expressions
+var pep440Pattern = regexp.MustCompile(`(?i)^\s*v?(?:(?:(?P<epoch>[0-9]+)!)?(?P<release>[0-9]+(?:\.[0-9]+)*)(?P<pre>[-_\.]?(?P<pre_l>alpha|a|beta|b|preview|pre|c|rc)[-_\.]?(?P<pre_n>[0-9]+)?)?(?P<post>(?:-(?P<post_n1>[0-9]+))|(?:[-_\.]?(?P<post_l>post|rev|r)[-_\.]?(?P<post_n2>[0-9]+)?))?(?P<dev>[-_\.]?(?P<dev_l>dev)[-_\.]?(?P<dev_n>[0-9]+)?)?)(?:\+(?P<local>[a-z0-9]+(?:[-_\.][a-z0-9]+)*))?\s*$`)
+
+var pep440PreReleaseLabels = map[string]string{
+	"a":       "a",
+	"alpha":   "a",
+	"b":       "b",
+	"beta":    "b",
+	"c":       "rc",
+	"rc":      "rc",
+	"pre":     "rc",
+	"preview": "rc",
+}
+
+// NormalizePEP440 normalizes a Python version according to PEP 440, e.g. v1.0-Alpha1.post2 results in 1.0a1.post2
+func NormalizePEP440(version string) (string, error) {
+	match := pep440Pattern.FindStringSubmatch(version)
+	if match == nil {
+		return "", fmt.Errorf("version '%v' is not a valid PEP 440 version", version)
+	}
+	groups := map[string]string{}
+	for i, name := range pep440Pattern.SubexpNames() {
+		if len(name) > 0 {
+			groups[name] = strings.ToLower(match[i])
+		}
+	}
+
+	var result strings.Builder
+	if epoch := pep440Number(groups["epoch"]); epoch != "0" {
+		result.WriteString(epoch + "!")
+	}
+	release := strings.Split(groups["release"], ".")
+	for i, part := range release {
+		release[i] = pep440Number(part)
+	}
+	result.WriteString(strings.Join(release, "."))
+	if len(groups["pre"]) > 0 {
+		result.WriteString(pep440PreReleaseLabels[groups["pre_l"]] + pep440Number(groups["pre_n"]))
+	}
+	if len(groups["post"]) > 0 {
+		result.WriteString(".post" + pep440Number(groups["post_n1"]+groups["post_n2"]))
+	}
+	if len(groups["dev"]) > 0 {
+		result.WriteString(".dev" + pep440Number(groups["dev_n"]))
+	}
+	if len(groups["local"]) > 0 {
+		result.WriteString("+" + strings.NewReplacer("-", ".", "_", ".").Replace(groups["local"]))
+	}
+	return result.String(), nil
+}
+
+// pep440Number removes leading zeros of a number, an implicit number is 0
+func pep440Number(number string) string {
+	if number = strings.TrimLeft(number, "0"); len(number) == 0 {
+		return "0"
+	}
+	return number
+}
@@ -0,0 +1,40 @@
+//go:build unit
+// +build unit
+
+package versioning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePEP440(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		version  string
+		expected string
+	}{
+		{version: "1.2.3", expected: "1.2.3"},
+		{version: "v1.0", expected: "1.0"},
+		{version: "1.0-Alpha1", expected: "1.0a1"},
+		{version: "1.0.beta", expected: "1.0b0"},
+		{version: "1.0-preview.2", expected: "1.0rc2"},
+		{version: "1.0c1", expected: "1.0rc1"},
+		{version: "1.0-1", expected: "1.0.post1"},
+		{version: "1.0.rev", expected: "1.0.post0"},
+		{version: "1.0.0-dev_3", expected: "1.0.0.dev3"},
+		{version: "0!01.02.003rc04.post05.dev06", expected: "1.2.3rc4.post5.dev6"},
+		{version: "2!1.0", expected: "2!1.0"},
+		{version: "1.0+Ubuntu-1_2", expected: "1.0+ubuntu.1.2"},
+	}
+	for _, test := range tt {
+		version, err := NormalizePEP440(test.version)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, version, test.version)
+	}
+
+	_, err := NormalizePEP440("1.0-SNAPSHOT")
+	assert.EqualError(t, err, "version '1.0-SNAPSHOT' is not a valid PEP 440 version")
+}
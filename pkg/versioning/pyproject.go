@@ -0,0 +1,121 @@
+package versioning
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/package-url/packageurl-go"
+)
+
+// PyProject defines an artifact using a Python pyproject.toml (PEP 621 or Poetry) for versioning
+type PyProject struct {
+	path     string
+	utils    Utils
+	content  string
+	manifest pyprojectManifest
+}
+
+type pyprojectManifest struct {
+	Project struct {
+		Name    string   `toml:"name"`
+		Version string   `toml:"version"`
+		Dynamic []string `toml:"dynamic"`
+	} `toml:"project"`
+	Tool struct {
+		Poetry struct {
+			Name    string `toml:"name"`
+			Version string `toml:"version"`
+		} `toml:"poetry"`
+	} `toml:"tool"`
+}
+
+func (p *PyProject) init() error {
+	if p.utils == nil {
+		return fmt.Errorf("no file utils provided")
+	}
+	if len(p.path) == 0 {
+		p.path = "pyproject.toml"
+	}
+	if len(p.content) == 0 {
+		content, err := p.utils.FileRead(p.path)
+		if err != nil {
+			return fmt.Errorf("failed to read file '%v': %w", p.path, err)
+		}
+		if _, err := toml.Decode(string(content), &p.manifest); err != nil {
+			return fmt.Errorf("pyproject content invalid '%v': %w", p.path, err)
+		}
+		p.content = string(content)
+	}
+	return nil
+}
+
+// versionTable returns the table containing the version, the PEP 621 project table takes precedence over the Poetry table
+func (p *PyProject) versionTable() (string, string, error) {
+	if len(p.manifest.Project.Version) > 0 {
+		return "project", p.manifest.Project.Version, nil
+	}
+	if len(p.manifest.Tool.Poetry.Version) > 0 {
+		return "tool.poetry", p.manifest.Tool.Poetry.Version, nil
+	}
+	if slices.Contains(p.manifest.Project.Dynamic, "version") {
+		return "", "", fmt.Errorf("version of '%v' is dynamic and cannot be maintained in pyproject.toml", p.path)
+	}
+	return "", "", fmt.Errorf("no version found in '%v'", p.path)
+}
+
+// VersioningScheme returns the relevant versioning scheme
+func (p *PyProject) VersioningScheme() string {
+	return "pep440"
+}
+
+// GetVersion returns the current version of the project defined in pyproject.toml
+func (p *PyProject) GetVersion() (string, error) {
+	if err := p.init(); err != nil {
+		return "", fmt.Errorf("failed to init pyproject versioning: %w", err)
+	}
+	_, version, err := p.versionTable()
+	return version, err
+}
+
+// SetVersion updates the version of the project defined in pyproject.toml
+func (p *PyProject) SetVersion(version string) error {
+	if err := p.init(); err != nil {
+		return fmt.Errorf("failed to init pyproject versioning: %w", err)
+	}
+	table, _, err := p.versionTable()
+	if err != nil {
+		return err
+	}
+	content, err := setTomlString(p.content, table, "version", version)
+	if err != nil {
+		return fmt.Errorf("failed to update version in '%v': %w", p.path, err)
+	}
+	if err := p.utils.FileWrite(p.path, []byte(content), 0666); err != nil {
+		return fmt.Errorf("failed to write file '%v': %w", p.path, err)
+	}
+	p.content, p.manifest = "", pyprojectManifest{}
+	return nil
+}
+
+// GetCoordinates returns the coordinates of the project defined in pyproject.toml
+func (p *PyProject) GetCoordinates() (Coordinates, error) {
+	version, err := p.GetVersion()
+	if err != nil {
+		return Coordinates{}, err
+	}
+	result := Coordinates{
+		ArtifactID: p.manifest.Project.Name,
+		Version:    version,
+	}
+	if len(result.ArtifactID) == 0 {
+		result.ArtifactID = p.manifest.Tool.Poetry.Name
+	}
+	if len(result.ArtifactID) > 0 {
+		// PyPI names are case insensitive and do not distinguish between underscores and dashes
+		name := strings.ReplaceAll(strings.ToLower(result.ArtifactID), "_", "-")
+		result.PURL = packageurl.NewPackageURL(packageurl.TypePyPi, "", name, version, nil, "").ToString()
+	}
+	return result, nil
+}
@@ -0,0 +1,88 @@
+//go:build unit
+// +build unit
+
+package versioning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPyProjectGetVersion(t *testing.T) {
+	t.Run("success case - PEP 621", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("pyproject.toml", []byte("[project]\nname = \"my-package\"\nversion = \"1.2.3\"\n"))
+
+		pyproject := PyProject{utils: fileUtils}
+
+		version, err := pyproject.GetVersion()
+		assert.NoError(t, err)
+		assert.Equal(t, "1.2.3", version)
+	})
+
+	t.Run("success case - Poetry", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("pyproject.toml", []byte("[tool.poetry]\nname = \"my-package\"\nversion = '0.1.0'\n"))
+
+		pyproject := PyProject{utils: fileUtils}
+
+		version, err := pyproject.GetVersion()
+		assert.NoError(t, err)
+		assert.Equal(t, "0.1.0", version)
+	})
+
+	t.Run("error case - dynamic version", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("pyproject.toml", []byte("[project]\nname = \"my-package\"\ndynamic = [\"version\"]\n"))
+
+		pyproject := PyProject{utils: fileUtils}
+
+		_, err := pyproject.GetVersion()
+		assert.EqualError(t, err, "version of 'pyproject.toml' is dynamic and cannot be maintained in pyproject.toml")
+	})
+
+	t.Run("error case - file missing", func(t *testing.T) {
+		pyproject := PyProject{utils: newVersioningMockUtils()}
+
+		_, err := pyproject.GetVersion()
+		assert.ErrorContains(t, err, "failed to init pyproject versioning: failed to read file 'pyproject.toml'")
+	})
+}
+
+func TestPyProjectSetVersion(t *testing.T) {
+	t.Run("success case - Poetry", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("pyproject.toml", []byte("[tool.black]\nversion = \"23\"\n\n[tool.poetry]\nname = \"my-package\"\nversion = '0.1.0'\n"))
+
+		pyproject := PyProject{utils: fileUtils}
+
+		err := pyproject.SetVersion("0.2.0")
+		assert.NoError(t, err)
+
+		content, err := fileUtils.FileRead("pyproject.toml")
+		assert.NoError(t, err)
+		assert.Equal(t, "[tool.black]\nversion = \"23\"\n\n[tool.poetry]\nname = \"my-package\"\nversion = '0.2.0'\n", string(content))
+	})
+
+	t.Run("error case - no version", func(t *testing.T) {
+		fileUtils := newVersioningMockUtils()
+		fileUtils.AddFile("pyproject.toml", []byte("[build-system]\nrequires = [\"setuptools\"]\n"))
+
+		pyproject := PyProject{utils: fileUtils}
+
+		err := pyproject.SetVersion("0.2.0")
+		assert.EqualError(t, err, "no version found in 'pyproject.toml'")
+	})
+}
+
+func TestPyProjectGetCoordinates(t *testing.T) {
+	fileUtils := newVersioningMockUtils()
+	fileUtils.AddFile("pyproject.toml", []byte("[project]\nname = \"My_Package\"\nversion = \"1.2.3\"\n"))
+
+	pyproject := PyProject{utils: fileUtils}
+
+	coordinates, err := pyproject.GetCoordinates()
+	assert.NoError(t, err)
+	assert.Equal(t, Coordinates{ArtifactID: "My_Package", Version: "1.2.3", PURL: "pkg:pypi/my-package@1.2.3"}, coordinates)
+}
@@ -0,0 +1,225 @@
+package versioning
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PreReleaseChannels contains the supported pre-release channels in ascending order of their precedence
+var PreReleaseChannels = []string{"alpha", "beta", "rc"}
+
+// semanticVersionPattern is the pattern suggested by https://semver.org, a leading v is tolerated
+var semanticVersionPattern = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// lenientSemanticVersionPattern only requires <major>.<minor>.<patch>, pre-release and build information are not validated
+var lenientSemanticVersionPattern = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(?:-([^+]*))?(?:\+(.*))?$`)
+
+// SemanticVersion is a version following Semantic Versioning 2.0.0 (https://semver.org)
+type SemanticVersion struct {
+	Major      int
+	Minor      int
+	Patch      int
+	PreRelease []string
+	Build      []string
+}
+
+// ParseSemanticVersion parses a version strictly following Semantic Versioning 2.0.0
+func ParseSemanticVersion(version string) (SemanticVersion, error) {
+	match := semanticVersionPattern.FindStringSubmatch(strings.TrimSpace(version))
+	if match == nil {
+		return SemanticVersion{}, fmt.Errorf("version '%v' is not a valid semantic version 2.0.0", version)
+	}
+	return semanticVersionFromMatch(version, match)
+}
+
+// parseSemanticVersion parses a version starting with <major>.<minor>.<patch> whose pre-release and build information
+// does not necessarily comply with Semantic Versioning, e.g. cloud versions like 1.2.3-20240517100000_0123456
+func parseSemanticVersion(version string) (SemanticVersion, error) {
+	match := lenientSemanticVersionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return SemanticVersion{}, fmt.Errorf("version '%v' is not a semantic version <major>.<minor>.<patch>", version)
+	}
+	return semanticVersionFromMatch(version, match)
+}
+
+func semanticVersionFromMatch(version string, match []string) (SemanticVersion, error) {
+	result := SemanticVersion{}
+	for i, target := range []*int{&result.Major, &result.Minor, &result.Patch} {
+		value, err := strconv.Atoi(match[i+1])
+		if err != nil {
+			return SemanticVersion{}, fmt.Errorf("version '%v' is not a semantic version <major>.<minor>.<patch>: %w", version, err)
+		}
+		*target = value
+	}
+	if len(match[4]) > 0 {
+		result.PreRelease = strings.Split(match[4], ".")
+	}
+	if len(match[5]) > 0 {
+		result.Build = strings.Split(match[5], ".")
+	}
+	return result, nil
+}
+
+// String returns the version in the format <major>.<minor>.<patch>[-<pre-release>][+<build>]
+func (v SemanticVersion) String() string {
+	version := v.Core()
+	if len(v.PreRelease) > 0 {
+		version += "-" + strings.Join(v.PreRelease, ".")
+	}
+	if len(v.Build) > 0 {
+		version += "+" + strings.Join(v.Build, ".")
+	}
+	return version
+}
+
+// Core returns the version in the format <major>.<minor>.<patch>
+func (v SemanticVersion) Core() string {
+	return fmt.Sprintf("%v.%v.%v", v.Major, v.Minor, v.Patch)
+}
+
+// IsPreRelease checks if the version is a pre-release version
+func (v SemanticVersion) IsPreRelease() bool {
+	return len(v.PreRelease) > 0
+}
+
+// Compare compares the precedence of two versions, build metadata is not considered
+func (v SemanticVersion) Compare(other SemanticVersion) int {
+	for _, parts := range [][2]int{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if parts[0] != parts[1] {
+			if parts[0] < parts[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return comparePreRelease(v.PreRelease, other.PreRelease)
+}
+
+// Increment increments the version according to the release type, pre-release and build information is removed.
+// A pre-release version is released without increment in case it already contains the changes of the release type, e.g. 2.0.0-rc.1 is released as 2.0.0.
+func (v SemanticVersion) Increment(release ReleaseType) SemanticVersion {
+	result := SemanticVersion{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	switch release {
+	case ReleaseMajor:
+		if !v.IsPreRelease() || v.Minor != 0 || v.Patch != 0 {
+			result = SemanticVersion{Major: v.Major + 1}
+		}
+	case ReleaseMinor:
+		if !v.IsPreRelease() || v.Patch != 0 {
+			result = SemanticVersion{Major: v.Major, Minor: v.Minor + 1}
+		}
+	case ReleasePatch:
+		if !v.IsPreRelease() {
+			result.Patch++
+		}
+	default:
+		return v
+	}
+	return result
+}
+
+// NextPreRelease provides the next pre-release version of the channel, e.g. 1.2.3 results in 1.3.0-rc.1 for a minor release.
+// The counter of the channel is incremented in case the version already is a pre-release of the next version, e.g. 1.3.0-rc.1 results in 1.3.0-rc.2.
+func (v SemanticVersion) NextPreRelease(release ReleaseType, channel string) (SemanticVersion, error) {
+	rank := slices.Index(PreReleaseChannels, channel)
+	if rank < 0 {
+		return SemanticVersion{}, fmt.Errorf("pre-release channel '%v' not supported, supported: %v", channel, PreReleaseChannels)
+	}
+	if release == ReleaseNone {
+		return v, nil
+	}
+
+	next := v.Increment(release)
+	if !v.IsPreRelease() || next.Core() != v.Core() {
+		next.PreRelease = []string{channel, "1"}
+		return next, nil
+	}
+
+	currentChannel, counter := v.preReleaseChannel()
+	currentRank := slices.Index(PreReleaseChannels, currentChannel)
+	switch {
+	case currentRank < 0 || currentRank < rank:
+		next.PreRelease = []string{channel, "1"}
+	case currentRank == rank:
+		next.PreRelease = []string{channel, strconv.Itoa(counter + 1)}
+	default:
+		return SemanticVersion{}, fmt.Errorf("pre-release channel '%v' has a lower precedence than version '%v'", channel, v)
+	}
+	return next, nil
+}
+
+// preReleaseChannel returns the channel and the counter of a pre-release like rc.2, the counter is 0 in case it is missing
+func (v SemanticVersion) preReleaseChannel() (string, int) {
+	if !v.IsPreRelease() {
+		return "", 0
+	}
+	counter := 0
+	if len(v.PreRelease) > 1 {
+		counter, _ = strconv.Atoi(v.PreRelease[1])
+	}
+	return strings.ToLower(v.PreRelease[0]), counter
+}
+
+// NextVersion provides the version following the release type. With a pre-release channel, the next pre-release of this channel is provided.
+// Without a channel, a pre-release of one of the supported channels is promoted to its release version, other versions are incremented using IncrementVersion.
+func NextVersion(version string, release ReleaseType, channel string) (string, error) {
+	semanticVersion, err := ParseSemanticVersion(version)
+	if len(channel) > 0 {
+		if err != nil {
+			return "", err
+		}
+		next, err := semanticVersion.NextPreRelease(release, channel)
+		if err != nil {
+			return "", err
+		}
+		return next.String(), nil
+	}
+	if currentChannel, _ := semanticVersion.preReleaseChannel(); err == nil && slices.Contains(PreReleaseChannels, currentChannel) {
+		return semanticVersion.Increment(release).String(), nil
+	}
+	return IncrementVersion(version, release)
+}
+
+// comparePreRelease compares pre-release identifiers, numeric identifiers have a lower precedence than alphanumeric ones
+// and a version without pre-release has a higher precedence than a pre-release version
+func comparePreRelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		numberA, errA := strconv.Atoi(a[i])
+		numberB, errB := strconv.Atoi(b[i])
+		switch {
+		case errA == nil && errB == nil:
+			if numberA != numberB {
+				if numberA < numberB {
+					return -1
+				}
+				return 1
+			}
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			if result := strings.Compare(a[i], b[i]); result != 0 {
+				return result
+			}
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,121 @@
+//go:build unit
+// +build unit
+
+package versioning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSemanticVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success case", func(t *testing.T) {
+		version, err := ParseSemanticVersion("v1.2.3-rc.1+build.5")
+
+		assert.NoError(t, err)
+		assert.Equal(t, SemanticVersion{Major: 1, Minor: 2, Patch: 3, PreRelease: []string{"rc", "1"}, Build: []string{"build", "5"}}, version)
+		assert.Equal(t, "1.2.3-rc.1+build.5", version.String())
+		assert.Equal(t, "1.2.3", version.Core())
+		assert.True(t, version.IsPreRelease())
+	})
+
+	t.Run("error case", func(t *testing.T) {
+		for _, version := range []string{"1.2", "01.2.3", "1.2.3-rc.01", "1.2.3-rc_1", "1.2.3+", "latest"} {
+			_, err := ParseSemanticVersion(version)
+			assert.EqualError(t, err, "version '"+version+"' is not a valid semantic version 2.0.0")
+		}
+	})
+}
+
+func TestSemanticVersionCompare(t *testing.T) {
+	t.Parallel()
+
+	// precedence example of https://semver.org/#spec-item-11
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0"}
+	for i := 1; i < len(ordered); i++ {
+		lower, err := ParseSemanticVersion(ordered[i-1])
+		assert.NoError(t, err)
+		higher, err := ParseSemanticVersion(ordered[i])
+		assert.NoError(t, err)
+
+		assert.Equal(t, -1, lower.Compare(higher), "%v < %v", lower, higher)
+		assert.Equal(t, 1, higher.Compare(lower), "%v > %v", higher, lower)
+	}
+
+	a, _ := ParseSemanticVersion("1.0.0+build.1")
+	b, _ := ParseSemanticVersion("1.0.0+build.2")
+	assert.Equal(t, 0, a.Compare(b))
+}
+
+func TestSemanticVersionIncrement(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		version  string
+		release  ReleaseType
+		expected string
+	}{
+		{version: "1.2.3+build.5", release: ReleasePatch, expected: "1.2.4"},
+		{version: "1.2.3", release: ReleaseNone, expected: "1.2.3"},
+		{version: "1.3.0-rc.2", release: ReleasePatch, expected: "1.3.0"},
+		{version: "1.3.0-rc.2", release: ReleaseMinor, expected: "1.3.0"},
+		{version: "1.3.0-rc.2", release: ReleaseMajor, expected: "2.0.0"},
+		{version: "1.3.1-rc.1", release: ReleaseMinor, expected: "1.4.0"},
+		{version: "2.0.0-beta.1", release: ReleaseMajor, expected: "2.0.0"},
+	}
+	for _, test := range tt {
+		version, err := ParseSemanticVersion(test.version)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, version.Increment(test.release).String(), "%v %v", test.version, test.release)
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		version  string
+		release  ReleaseType
+		channel  string
+		expected string
+	}{
+		{name: "first pre-release", version: "1.2.3", release: ReleaseMinor, channel: "rc", expected: "1.3.0-rc.1"},
+		{name: "next pre-release", version: "1.3.0-rc.1", release: ReleasePatch, channel: "rc", expected: "1.3.0-rc.2"},
+		{name: "next pre-release with more than 9 releases", version: "1.3.0-rc.9", release: ReleaseMinor, channel: "rc", expected: "1.3.0-rc.10"},
+		{name: "higher channel", version: "1.3.0-beta.4", release: ReleasePatch, channel: "rc", expected: "1.3.0-rc.1"},
+		{name: "pre-release with breaking change", version: "1.3.0-rc.2", release: ReleaseMajor, channel: "rc", expected: "2.0.0-rc.1"},
+		{name: "pre-release without counter", version: "1.3.0-alpha", release: ReleasePatch, channel: "alpha", expected: "1.3.0-alpha.1"},
+		{name: "build metadata", version: "1.2.3+build.5", release: ReleasePatch, channel: "alpha", expected: "1.2.4-alpha.1"},
+		{name: "no release", version: "1.3.0-rc.1", release: ReleaseNone, channel: "rc", expected: "1.3.0-rc.1"},
+		{name: "promote pre-release", version: "1.3.0-rc.2", release: ReleasePatch, expected: "1.3.0"},
+		{name: "release", version: "1.2.3", release: ReleaseMinor, expected: "1.3.0"},
+		{name: "release of other pre-release", version: "1.2.3-SNAPSHOT", release: ReleasePatch, expected: "1.2.4"},
+		{name: "release of cloud version", version: "1.2.3-20240517100000_0123456", release: ReleaseMajor, expected: "2.0.0"},
+	}
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			version, err := NextVersion(test.version, test.release, test.channel)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, version)
+		})
+	}
+
+	t.Run("error case - lower channel", func(t *testing.T) {
+		_, err := NextVersion("1.3.0-rc.1", ReleasePatch, "beta")
+		assert.EqualError(t, err, "pre-release channel 'beta' has a lower precedence than version '1.3.0-rc.1'")
+	})
+
+	t.Run("error case - unknown channel", func(t *testing.T) {
+		_, err := NextVersion("1.2.3", ReleasePatch, "nightly")
+		assert.EqualError(t, err, "pre-release channel 'nightly' not supported, supported: [alpha beta rc]")
+	})
+
+	t.Run("error case - invalid version", func(t *testing.T) {
+		_, err := NextVersion("1.2.3-20240517100000_0123456", ReleasePatch, "rc")
+		assert.EqualError(t, err, "version '1.2.3-20240517100000_0123456' is not a valid semantic version 2.0.0")
+	})
+}
@@ -0,0 +1,50 @@
+package versioning
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var tomlStringKeyValue = regexp.MustCompile(`^(\s*([A-Za-z0-9_-]+)\s*=\s*)("[^"]*"|'[^']*')(.*)$`)
+
+// setTomlString replaces the string value of a key within a table of a TOML document.
+// The document is updated line by line in order to keep formatting and comments untouched.
+func setTomlString(content, table, key, value string) (string, error) {
+	lines := strings.Split(content, "\n")
+	currentTable := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			currentTable = tomlTableName(trimmed)
+			continue
+		}
+		if currentTable != table {
+			continue
+		}
+		match := tomlStringKeyValue.FindStringSubmatch(line)
+		if match == nil || match[2] != key {
+			continue
+		}
+		quote := match[3][:1]
+		lines[i] = fmt.Sprintf("%v%v%v%v%v", match[1], quote, value, quote, match[4])
+		return strings.Join(lines, "\n"), nil
+	}
+	return content, fmt.Errorf("no string value '%v' found in table [%v]", key, table)
+}
+
+// tomlTableName returns the name of a table header like [tool.poetry], array of tables are not considered
+func tomlTableName(header string) string {
+	if strings.HasPrefix(header, "[[") {
+		return header
+	}
+	name := strings.TrimPrefix(header, "[")
+	if end := strings.Index(name, "]"); end >= 0 {
+		name = name[:end]
+	}
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), `"'`)
+	}
+	return strings.Join(parts, ".")
+}
@@ -85,6 +85,11 @@ func GetArtifact(buildTool, buildDescriptorFilePath string, opts *Options, utils
 	}
 
 	switch buildTool {
+	case "cargo":
+		artifact = &Cargo{
+			path:  buildDescriptorFilePath,
+			utils: utils,
+		}
 	case "custom":
 		var err error
 		artifact, err = customArtifact(buildDescriptorFilePath, opts.VersionField, opts.VersionSection, opts.VersioningScheme)
@@ -99,6 +104,11 @@ func GetArtifact(buildTool, buildDescriptorFilePath string, opts *Options, utils
 			versionSource:    opts.VersionSource,
 			versioningScheme: opts.VersioningScheme,
 		}
+	case "dotnet":
+		artifact = &Dotnet{
+			path:  buildDescriptorFilePath,
+			utils: utils,
+		}
 	case "dub":
 		if len(buildDescriptorFilePath) == 0 {
 			buildDescriptorFilePath = "dub.json"
@@ -173,11 +183,18 @@ func GetArtifact(buildTool, buildDescriptorFilePath string, opts *Options, utils
 	case "pip":
 		if len(buildDescriptorFilePath) == 0 {
 			var err error
-			buildDescriptorFilePath, err = searchDescriptor([]string{"setup.py", "version.txt", "VERSION"}, fileExists)
+			buildDescriptorFilePath, err = searchDescriptor([]string{"setup.py", "version.txt", "VERSION", "pyproject.toml"}, fileExists)
 			if err != nil {
 				return artifact, err
 			}
 		}
+		if filepath.Base(buildDescriptorFilePath) == "pyproject.toml" {
+			artifact = &PyProject{
+				path:  buildDescriptorFilePath,
+				utils: utils,
+			}
+			break
+		}
 		artifact = &Pip{
 			path:       buildDescriptorFilePath,
 			fileExists: fileExists,
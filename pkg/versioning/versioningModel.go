@@ -1,6 +1,9 @@
 package versioning
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/Masterminds/sprig"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
@@ -22,12 +25,28 @@ const (
 	VersioningModelSemantic   string = "semantic"
 	VersioningModelMajorMinor string = "major-minor"
 	VersioningModelMajor      string = "major"
+	// VersioningModelSemVer2 normalizes the version according to Semantic Versioning 2.0.0 including pre-release and build metadata
+	VersioningModelSemVer2 string = "semver2"
+	// VersioningModelPreRelease keeps the pre-release of a semantic version but removes the build metadata, e.g. 1.2.3-rc.1+build.5 results in 1.2.3-rc.1
+	VersioningModelPreRelease string = "semantic-prerelease"
+	// VersioningModelPEP440 normalizes the version according to PEP 440
+	VersioningModelPEP440 string = "pep440"
+	// VersioningModelMaven normalizes the version using the canonical Maven qualifiers
+	VersioningModelMaven string = "maven"
 )
 
 func ApplyVersioningModel(model, projectVersion string) string {
 	var versioningScheme string
 
 	switch model {
+	case VersioningModelSemVer2, VersioningModelPreRelease, VersioningModelPEP440, VersioningModelMaven:
+		version, err := normalizeVersion(model, projectVersion)
+		if err != nil {
+			// e.g. cloud versions like 1.2.3-20240517100000_0123456 are no valid semantic versions 2.0.0
+			log.Entry().Warnf("unable to resolve project version with versioning model %v, using the full version %v: %v", model, projectVersion, err)
+			return projectVersion
+		}
+		return version
 	case VersioningModelFull:
 		versioningScheme = SchemeFullVersion
 	case VersioningModelSemantic:
@@ -46,3 +65,25 @@ func ApplyVersioningModel(model, projectVersion string) string {
 	}
 	return version
 }
+
+func normalizeVersion(model, projectVersion string) (string, error) {
+	switch model {
+	case VersioningModelSemVer2:
+		version, err := ParseSemanticVersion(projectVersion)
+		if err != nil {
+			return "", err
+		}
+		return version.String(), nil
+	case VersioningModelPreRelease:
+		// pre-release information of cloud versions like 1.2.3-20240517100000_0123456 is not necessarily compliant to semantic versioning
+		if !IsSemanticVersion(projectVersion) {
+			return "", fmt.Errorf("version '%v' is not a semantic version <major>.<minor>.<patch>", projectVersion)
+		}
+		return strings.SplitN(projectVersion, "+", 2)[0], nil
+	case VersioningModelPEP440:
+		return NormalizePEP440(projectVersion)
+	case VersioningModelMaven:
+		return NormalizeMavenVersion(projectVersion)
+	}
+	return "", fmt.Errorf("versioning model not supported: %s", model)
+}
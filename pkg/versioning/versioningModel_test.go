@@ -24,7 +24,15 @@ func Test_applyVersioningModel(t *testing.T) {
 		{"python - major-minor", args{VersioningModelMajorMinor, "2.2.3.20200101"}, "2.2"},
 		{"leading zero", args{VersioningModelMajor, "0.0.1"}, "0"},
 		{"trailing zero", args{VersioningModelMajorMinor, "2.0"}, "2.0"},
+		{"semver2", args{VersioningModelSemVer2, "v1.2.3-rc.1+build.5"}, "1.2.3-rc.1+build.5"},
+		{"semantic-prerelease", args{VersioningModelPreRelease, "1.2.3-rc.1+build.5"}, "1.2.3-rc.1"},
+		{"semantic-prerelease - cloud version", args{VersioningModelPreRelease, "1.2.3-20240517100000+0123456"}, "1.2.3-20240517100000"},
+		{"pep440", args{VersioningModelPEP440, "1.0-Alpha1"}, "1.0a1"},
+		{"maven", args{VersioningModelMaven, "2.0.M3"}, "2.0.0-milestone-3"},
 		{"invalid - unknown versioning model", args{"snapshot", "1.2.3-SNAPSHOT"}, ""},
+		{"invalid - no semver2 version", args{VersioningModelSemVer2, "1.2"}, "1.2"},
+		{"invalid - semver2 cloud version", args{VersioningModelSemVer2, "1.2.3-20240517100000_0123456"}, "1.2.3-20240517100000_0123456"},
+		{"invalid - no pep440 version", args{VersioningModelPEP440, "1.0-foo_bar"}, "1.0-foo_bar"},
 		{"invalid - incorrect version", args{VersioningModelMajor, ".2.3"}, ""},
 		{"invalid - version to short", args{VersioningModelSemantic, "1.2"}, "1.2.<no value>"},
 	}
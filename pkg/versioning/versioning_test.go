@@ -30,6 +30,16 @@ func (v *versioningMockUtils) DownloadFile(url, filename string, header http.Hea
 }
 
 func TestGetArtifact(t *testing.T) {
+	t.Run("cargo", func(t *testing.T) {
+		cargo, err := GetArtifact("cargo", "", &Options{}, nil)
+
+		assert.NoError(t, err)
+
+		_, ok := cargo.(*Cargo)
+		assert.True(t, ok)
+		assert.Equal(t, "semver2", cargo.VersioningScheme())
+	})
+
 	t.Run("custom", func(t *testing.T) {
 		custom, err := GetArtifact("custom", "test.ini", &Options{VersionField: "theversion", VersionSection: "test"}, nil)
 
@@ -56,6 +66,17 @@ func TestGetArtifact(t *testing.T) {
 		assert.Equal(t, "docker", docker.VersioningScheme())
 	})
 
+	t.Run("dotnet", func(t *testing.T) {
+		dotnet, err := GetArtifact("dotnet", "src/App/App.csproj", &Options{}, nil)
+
+		assert.NoError(t, err)
+
+		theType, ok := dotnet.(*Dotnet)
+		assert.True(t, ok)
+		assert.Equal(t, "src/App/App.csproj", theType.path)
+		assert.Equal(t, "semver2", dotnet.VersioningScheme())
+	})
+
 	t.Run("dub", func(t *testing.T) {
 		dub, err := GetArtifact("dub", "", &Options{VersionField: "theversion"}, nil)
 
@@ -228,11 +249,23 @@ func TestGetArtifact(t *testing.T) {
 		assert.Equal(t, "pep440", pip.VersioningScheme())
 	})
 
+	t.Run("pip - pyproject.toml", func(t *testing.T) {
+		fileExists = func(f string) (bool, error) { return f == "pyproject.toml", nil }
+		pip, err := GetArtifact("pip", "", &Options{}, nil)
+
+		assert.NoError(t, err)
+
+		theType, ok := pip.(*PyProject)
+		assert.True(t, ok)
+		assert.Equal(t, "pyproject.toml", theType.path)
+		assert.Equal(t, "pep440", pip.VersioningScheme())
+	})
+
 	t.Run("pip - error", func(t *testing.T) {
 		fileExists = func(string) (bool, error) { return false, nil }
 		_, err := GetArtifact("pip", "", &Options{}, nil)
 
-		assert.EqualError(t, err, "no build descriptor available, supported: [setup.py version.txt VERSION]")
+		assert.EqualError(t, err, "no build descriptor available, supported: [setup.py version.txt VERSION pyproject.toml]")
 	})
 
 	t.Run("sbt", func(t *testing.T) {
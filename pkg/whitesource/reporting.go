@@ -105,6 +105,12 @@ func CreateCustomVulnerabilityReport(productName string, scan *Scan, alerts *[]A
 	}
 	scanReport.DetailTable = detailTable
 
+	scanReport.Findings = map[string]int{}
+	for _, alert := range *alerts {
+		scanReport.Findings[format.SeverityFromScore(vulnerabilityScore(alert))]++
+	}
+	scanReport.SarifTool = scan.AgentName
+
 	return scanReport
 }
 
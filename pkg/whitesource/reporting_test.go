@@ -40,6 +40,7 @@ func TestCreateCustomVulnerabilityReport(t *testing.T) {
 
 		assert.Equal(t, "WhiteSource Security Vulnerability Report", scanReport.Title())
 		assert.Equal(t, 3, len(scanReport.DetailTable.Rows))
+		assert.Equal(t, map[string]int{"high": 2, "medium": 1}, scanReport.Findings)
 
 		// assert that library info is filled and sorting has been executed
 		assert.Equal(t, "vul2", scanReport.DetailTable.Rows[0].Columns[5].Content)
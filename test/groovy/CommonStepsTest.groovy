@@ -233,7 +233,17 @@ public class CommonStepsTest extends BasePiperTest{
         'tmsUpload',
         'tmsExport',
         'imagePushToRegistry',
-        'gcpPublishEvent'
+        'gcpPublishEvent',
+        'sarifMerge',
+        'securityQualityGate',
+        'vexCreate',
+        'sbomMerge',
+        'sbomLicenseCheck',
+        'provenanceCreate',
+        'sbomConvert',
+        'containerSignImage',
+        'containerVerifyImage',
+        'osvExecuteScan'
     ]
 
     @Test
@@ -48,6 +48,10 @@ class PiperPipelineStageBuildTest extends BasePiperTest {
             stepsCalled.add('buildExecute')
         })
 
+        helper.registerAllowedMethod('sbomConvert', [Map.class], {m ->
+            stepsCalled.add('sbomConvert')
+        })
+
         helper.registerAllowedMethod('pipelineStashFilesAfterBuild', [Map.class], {m ->
             stepsCalled.add('pipelineStashFilesAfterBuild')
         })
@@ -77,7 +81,7 @@ class PiperPipelineStageBuildTest extends BasePiperTest {
 
         assertThat(stepsCalled, hasItems('buildExecute', 'checksPublishResults', 'pipelineStashFilesAfterBuild', 'testsPublishResults'))
         assertThat(stepParameters.testsPublishResults.junit.updateResults, is(true))
-        assertThat(stepsCalled, not(anyOf(hasItem('mavenExecuteStaticCodeChecks'), hasItem('npmExecuteLint'))))
+        assertThat(stepsCalled, not(anyOf(hasItem('mavenExecuteStaticCodeChecks'), hasItem('npmExecuteLint'), hasItem('sbomConvert'))))
     }
 
     @Test
@@ -99,4 +103,15 @@ class PiperPipelineStageBuildTest extends BasePiperTest {
 
         assertThat(stepsCalled, hasItems('buildExecute', 'checksPublishResults', 'pipelineStashFilesAfterBuild', 'testsPublishResults', 'mavenExecuteStaticCodeChecks'))
     }
+
+    @Test
+    void testBuildWithSbomConvert() {
+
+        nullScript.commonPipelineEnvironment.configuration = [runStep: ['Build': [sbomConvert: true]]]
+
+        jsr.step.piperPipelineStageBuild(script: nullScript, juStabUtils: utils)
+
+        assertThat(stepsCalled, hasItems('buildExecute', 'sbomConvert', 'pipelineStashFilesAfterBuild'))
+        assertThat(stepsCalled.indexOf('sbomConvert'), is(stepsCalled.indexOf('buildExecute') + 1))
+    }
 }
@@ -13,6 +13,8 @@ import static com.sap.piper.Prerequisites.checkScript
 @Field STAGE_STEP_KEYS = [
     /** Starts build execution. This is always being executed.*/
     'buildExecute',
+    /** Converts the CycloneDX SBOMs created by the build into SPDX documents. It is active in case `createBOM` is enabled.*/
+    'sbomConvert',
     /**
      * Executes stashing of files after build execution.<br /
      * Build results are stashed with stash name `buildResult`.
@@ -52,6 +54,7 @@ void call(Map parameters = [:]) {
         .mixinGeneralConfig(script.commonPipelineEnvironment, GENERAL_CONFIG_KEYS)
         .mixinStageConfig(script.commonPipelineEnvironment, stageName, STEP_CONFIG_KEYS)
         .mixin(parameters, PARAMETER_KEYS)
+        .addIfEmpty('sbomConvert', script.commonPipelineEnvironment.configuration.runStep?.get(stageName)?.sbomConvert)
         .addIfEmpty('npmExecuteLint', script.commonPipelineEnvironment.configuration.runStep?.get(stageName)?.npmExecuteLint)
         .addIfEmpty('mavenExecuteStaticCodeChecks', script.commonPipelineEnvironment.configuration.runStep?.get(stageName)?.mavenExecuteStaticCodeChecks)
         .use()
@@ -60,6 +63,9 @@ void call(Map parameters = [:]) {
         durationMeasure(script: script, measurementName: 'build_duration') {
 
             buildExecute script: script
+            if (config.sbomConvert) {
+                sbomConvert script: script
+            }
             pipelineStashFilesAfterBuild script: script
 
             try {